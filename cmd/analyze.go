@@ -1,62 +1,446 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
 	"github.com/spf13/cobra"
 	"github.com/timanthonyalexander/demo-anticheat/pkg/analyzer"
 	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
+	"github.com/timanthonyalexander/demo-anticheat/pkg/steam"
 )
 
 var htmlOut bool
+var jsonlOut bool
+var colorMode string
+var heatmapOut bool
+var outputFormat string
+var outputPath string
+var verdictOut bool
+var promNamespace string
+var sprayPatternsPath string
+var minKillsForFlag int
+var flagOnLowerBound bool
+var playerFilter []string
+var roundRange string
+var tickRange string
+var tickRateOverride float64
+var fastTTD float64
+var ttdRamp string
+var rawSamplesPath string
+var maxSamples int
+var includeBots bool
+var debugLog bool
+var omitZero bool
+var onlyKeys []string
+var trustedPlayers []string
+var analyzeCodesFile string
+var resolveCmd string
+var downloadDir string
 
 const htmlEnvVar = "DEMOANTICHEAT_HTML"
 const htmlOutputFile = "index.html"
 
+// partialMarker is appended to the demo name of a report cut short by Ctrl-C.
+const partialMarker = " (partial, interrupted)"
+
 var analyzeCmd = &cobra.Command{
 	Use:   "analyze [demo-file]",
 	Short: "Analyze a CS2 demo file",
-	Args:  cobra.ExactArgs(1),
+	Long: `Analyzes a CS2 demo and reports per-player stats and cheat likelihoods.
+
+With --codes-file, analyzes the matches in a file of share codes instead, one
+per line ("-" reads stdin). Invalid lines are reported and skipped. Turning a
+share code into a replay URL needs the CS2 Game Coordinator, which this tool
+doesn't talk to, so --resolve-cmd names a command that does: it is run with
+the share code as its last argument and must print the demo URL. The demos
+are downloaded into --download-dir (ones already there are reused), analyzed
+side by side and reported as one merged lobby. A code that fails to resolve,
+download or parse is reported and skipped; the command only fails when no
+demo could be analyzed. --raw-samples and --heatmap apply to single demos
+only.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if analyzeCodesFile != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		demoPath := args[0]
+		var demoPath string
+		if analyzeCodesFile == "" {
+			demoPath = args[0]
+			if _, err := os.Stat(demoPath); os.IsNotExist(err) {
+				return fmt.Errorf("demo file not found: %s", demoPath)
+			}
+			if filepath.Ext(demoPath) != ".dem" {
+				return fmt.Errorf("file must have .dem extension: %s", demoPath)
+			}
+		}
+		switch colorMode {
+		case "auto", "always", "never":
+		default:
+			return fmt.Errorf("invalid --color %q: want auto, always or never", colorMode)
+		}
+		outputFormat = resolveFormat(outputFormat, jsonlOut, verdictOut)
+		reporter, err := newReporter(outputFormat)
+		if err != nil {
+			return err
+		}
+		if omitZero || len(onlyKeys) > 0 {
+			filtered := stats.NewFilteredReporter(reporter)
+			filtered.OmitZero(omitZero)
+			keys := make([]stats.Key, len(onlyKeys))
+			for i, k := range onlyKeys {
+				keys[i] = stats.Key(strings.TrimSpace(k))
+			}
+			filtered.OnlyKeys(keys)
+			reporter = filtered
+		}
 
-		if _, err := os.Stat(demoPath); os.IsNotExist(err) {
-			return fmt.Errorf("demo file not found: %s", demoPath)
+		// Keep stdout clean when it carries machine-readable output
+		var progress io.Writer = os.Stdout
+		if outputFormat != "text" && outputPath == "" {
+			progress = os.Stderr
 		}
-		if filepath.Ext(demoPath) != ".dem" {
-			return fmt.Errorf("file must have .dem extension: %s", demoPath)
+		if outputFormat == "verdict" {
+			progress = io.Discard
 		}
 
-		fmt.Printf("Analyzing demo file: %s\n", demoPath)
+		newAnalyzer, err := analyzerFactory(progress)
+		if err != nil {
+			return err
+		}
+		if analyzeCodesFile != "" {
+			return analyzeCodes(cmd, analyzeCodesFile, newAnalyzer, reporter, progress)
+		}
+		demoAnalyzer := newAnalyzer(demoPath)
 
-		demoAnalyzer := analyzer.NewAnalyzer(demoPath)
+		// Ctrl-C stops parsing and reports what was collected so far; a
+		// second Ctrl-C kills the process as usual.
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			stop()
+		}()
 
-		fmt.Println("Analysis in progress...")
-		results, err := demoAnalyzer.Analyze()
-		if err != nil {
+		results, err := demoAnalyzer.AnalyzeContext(ctx)
+		if err != nil && !(results.Partial && errors.Is(err, context.Canceled)) {
 			return fmt.Errorf("analysis failed: %v", err)
 		}
 
-		reporter := stats.NewTextReporter("CS2 Demo Analysis Results")
-
-		fmt.Println("Analysis complete!")
-		if err := reporter.Report(results.DemoStats, results.Categories, os.Stdout); err != nil {
+		if results.Partial {
+			fmt.Fprintf(progress, "Interrupted after %d ticks, writing partial results...\n", results.DemoStats.TickCount)
+			results.DemoStats.DemoName += partialMarker
+		} else {
+			fmt.Fprintln(progress, "Analysis complete!")
+		}
+		if err := writeReport(reporter, results, progress); err != nil {
 			return fmt.Errorf("error generating report: %v", err)
 		}
 
+		if rawSamplesPath != "" {
+			if err := writeRawSamples(results, progress); err != nil {
+				return fmt.Errorf("error writing raw samples: %v", err)
+			}
+		}
+
+		if heatmapOut {
+			fmt.Fprintln(progress)
+			if err := stats.NewHeatmapReporter().Report(results.DemoStats, results.Categories, progress); err != nil {
+				return fmt.Errorf("error generating heatmap: %v", err)
+			}
+		}
+
 		if shouldWriteHTML() {
-			if err := writeHTMLReport(results); err != nil {
+			if err := writeHTMLReport(results, progress); err != nil {
 				return fmt.Errorf("error generating html report: %v", err)
 			}
 		}
 
+		if results.Partial {
+			cmd.SilenceUsage = true
+			return errors.New("analysis interrupted: the report covers only the ticks parsed before Ctrl-C")
+		}
+		if outputFormat == "verdict" && len(stats.FlaggedPlayers(results.DemoStats)) > 0 {
+			cmd.SilenceUsage, cmd.SilenceErrors = true, true
+			return exitCodeError{code: exitFlagged}
+		}
 		return nil
 	},
 }
 
+// reportFormats lists the --format values in help order.
+var reportFormats = []string{"text", "json", "jsonl", "csv", "md", "html", "prom", "verdict"}
+
+// resolveFormat applies the shorthand flags to --format: the deprecated
+// --jsonl means --format jsonl, and --verdict, which wins over both, means
+// --format verdict.
+func resolveFormat(format string, jsonl, verdict bool) string {
+	if verdict {
+		return "verdict"
+	}
+	if jsonl {
+		return "jsonl"
+	}
+	return format
+}
+
+// newReporter maps a --format value to its reporter.
+func newReporter(format string) (stats.Reporter, error) {
+	switch format {
+	case "text":
+		textReporter := stats.NewTextReporter("CS2 Demo Analysis Results")
+		switch colorMode {
+		case "always":
+			textReporter.EnableColor(true)
+		case "never":
+			textReporter.EnableColor(false)
+		}
+		return textReporter, nil
+	case "json":
+		return stats.NewJSONReporter(), nil
+	case "jsonl":
+		return stats.NewJSONLinesReporter(), nil
+	case "csv":
+		return stats.NewCSVReporter(), nil
+	case "md":
+		return stats.NewMarkdownReporter(), nil
+	case "html":
+		return stats.NewHTMLReporter()
+	case "prom":
+		return stats.NewPrometheusReporter(promNamespace)
+	case "verdict":
+		return stats.NewVerdictReporter(), nil
+	}
+	return nil, fmt.Errorf("invalid --format %q: want one of %s", format, strings.Join(reportFormats, ", "))
+}
+
+// analyzerFactory checks the analysis flags and returns a constructor for
+// Analyzers configured by them, one per demo.
+func analyzerFactory(progress io.Writer) (func(demoPath string) *analyzer.Analyzer, error) {
+	var patterns map[common.EquipmentType][][2]float64
+	if sprayPatternsPath != "" {
+		var err error
+		if patterns, err = loadSprayPatterns(sprayPatternsPath); err != nil {
+			return nil, err
+		}
+	}
+	filter, err := parseSteamIDs(playerFilter)
+	if err != nil {
+		return nil, err
+	}
+	trusted, err := parseSteamIDs(trustedPlayers)
+	if err != nil {
+		return nil, err
+	}
+	var roundStart, roundEnd, tickStart, tickEnd int
+	if roundRange != "" {
+		if roundStart, roundEnd, err = parseRange(roundRange); err != nil {
+			return nil, fmt.Errorf("invalid --rounds: %v", err)
+		}
+	}
+	if tickRange != "" {
+		if tickStart, tickEnd, err = parseRange(tickRange); err != nil {
+			return nil, fmt.Errorf("invalid --ticks: %v", err)
+		}
+	}
+	if tickRateOverride < 0 {
+		return nil, fmt.Errorf("invalid --tick-rate %v: must be positive", tickRateOverride)
+	}
+	thresholds := stats.ReactionThresholds{FastMs: fastTTD}
+	if fastTTD < 0 {
+		return nil, fmt.Errorf("invalid --fast-ttd %v: must be positive", fastTTD)
+	}
+	if ttdRamp != "" {
+		clean, blatant, err := parseRange(ttdRamp)
+		if err != nil || clean <= blatant || blatant <= 0 {
+			return nil, fmt.Errorf("invalid --ttd-ramp %q: want <clean>-<blatant> in ms, clean above blatant", ttdRamp)
+		}
+		thresholds.ScoreCleanMs, thresholds.ScoreBlatantMs = float64(clean), float64(blatant)
+	}
+
+	return func(demoPath string) *analyzer.Analyzer {
+		a := analyzer.NewAnalyzer(demoPath)
+		a.SetLogger(stats.NewWriterLogger(progress, debugLog))
+		if patterns != nil {
+			a.SetSprayPatterns(patterns)
+		}
+		a.SetMinKillsForFlag(minKillsForFlag)
+		a.SetFlagOnLowerBound(flagOnLowerBound)
+		a.SetPlayerFilter(filter...)
+		a.SetAllowlist(trusted...)
+		if roundRange != "" {
+			a.SetRoundRange(roundStart, roundEnd)
+		}
+		if tickRange != "" {
+			a.SetTickRange(tickStart, tickEnd)
+		}
+		a.SetTickRateOverride(tickRateOverride)
+		a.SetReactionThresholds(thresholds)
+		if rawSamplesPath != "" {
+			a.EnableRawSamples()
+		}
+		a.SetMaxSamples(maxSamples)
+		a.SetIncludeBots(includeBots)
+		return a
+	}, nil
+}
+
+// analyzeCodes downloads the matches behind the share codes in path with
+// --resolve-cmd, analyzes them with newAnalyzer and reports them merged.
+// Invalid lines and codes that fail to download or parse are reported to
+// progress and skipped.
+func analyzeCodes(cmd *cobra.Command, path string, newAnalyzer func(string) *analyzer.Analyzer, reporter stats.Reporter, progress io.Writer) error {
+	if strings.TrimSpace(resolveCmd) == "" {
+		return errors.New("--codes-file needs --resolve-cmd: share codes only resolve to demo URLs through the CS2 Game Coordinator")
+	}
+	codes, invalid, err := readShareCodes(path)
+	if err != nil {
+		return err
+	}
+	for _, e := range invalid {
+		fmt.Fprintf(progress, "Skipping invalid share code: %v\n", e)
+	}
+	if len(codes) == 0 {
+		return fmt.Errorf("no valid share codes in %s", path)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	fmt.Fprintf(progress, "Downloading %d demos into %s...\n", len(codes), downloadDir)
+	downloaded, err := steam.DownloadMany(ctx, codes, downloadDir, steam.DownloadOptions{
+		Resolve: commandResolver(resolveCmd),
+		Logger:  stats.NewWriterLogger(progress, debugLog),
+	})
+	reportSkipped(progress, "Download failed", err)
+	paths := make([]string, 0, len(downloaded))
+	for _, code := range codes {
+		if p, ok := downloaded[code]; ok {
+			paths = append(paths, p)
+		}
+	}
+	if len(paths) == 0 {
+		return errors.New("no demo could be downloaded")
+	}
+
+	fmt.Fprintf(progress, "Analyzing %d demos...\n", len(paths))
+	byPath, merged, err := analyzer.AnalyzeManyWith(ctx, paths, runtime.GOMAXPROCS(0), newAnalyzer)
+	reportSkipped(progress, "Analysis failed", err)
+	if len(byPath) == 0 {
+		return errors.New("no demo could be analyzed")
+	}
+	results := analyzer.Results{DemoStats: merged}
+	for _, p := range paths {
+		if r, ok := byPath[p]; ok {
+			results.Categories = r.Categories
+			break
+		}
+	}
+	fmt.Fprintf(progress, "Analysis complete! %d of %d share codes analyzed.\n", len(byPath), len(codes)+len(invalid))
+
+	if err := writeReport(reporter, results, progress); err != nil {
+		return fmt.Errorf("error generating report: %v", err)
+	}
+	if shouldWriteHTML() {
+		if err := writeHTMLReport(results, progress); err != nil {
+			return fmt.Errorf("error generating html report: %v", err)
+		}
+	}
+	if outputFormat == "verdict" && len(stats.FlaggedPlayers(results.DemoStats)) > 0 {
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		return exitCodeError{code: exitFlagged}
+	}
+	return nil
+}
+
+// commandResolver resolves a share code by running command with the code
+// appended as its last argument; the trimmed stdout is the demo URL.
+func commandResolver(command string) func(ctx context.Context, shareCode string) (string, error) {
+	fields := strings.Fields(command)
+	return func(ctx context.Context, shareCode string) (string, error) {
+		out, err := exec.CommandContext(ctx, fields[0], append(fields[1:], shareCode)...).Output()
+		if err != nil {
+			return "", fmt.Errorf("resolve: %w", err)
+		}
+		url := strings.TrimSpace(string(out))
+		if url == "" {
+			return "", errors.New("resolve: command printed no URL")
+		}
+		return url, nil
+	}
+}
+
+// reportSkipped prints each error joined into err on its own line.
+func reportSkipped(progress io.Writer, what string, err error) {
+	if err == nil {
+		return
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, e := range errs {
+		fmt.Fprintf(progress, "%s, skipping: %v\n", what, e)
+	}
+}
+
+// writeReport writes the report to --out, creating parent directories, or
+// to stdout when no path is set.
+func writeReport(reporter stats.Reporter, results analyzer.Results, progress io.Writer) error {
+	if outputPath == "" {
+		return reporter.Report(results.DemoStats, results.Categories, os.Stdout)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	if err := reporter.Report(results.DemoStats, results.Categories, f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(progress, "Report written to: %s\n", outputPath)
+	return nil
+}
+
+// writeRawSamples writes Results.RawSamples as CSV to --raw-samples.
+func writeRawSamples(results analyzer.Results, progress io.Writer) error {
+	if err := os.MkdirAll(filepath.Dir(rawSamplesPath), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(rawSamplesPath)
+	if err != nil {
+		return err
+	}
+	reporter := stats.NewRawSampleReporter(results.RawSamples)
+	if err := reporter.Report(results.DemoStats, nil, f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(progress, "Raw samples written to: %s\n", rawSamplesPath)
+	return nil
+}
+
 func shouldWriteHTML() bool {
 	if htmlOut {
 		return true
@@ -72,7 +456,54 @@ func envTruthy(v string) bool {
 	return true
 }
 
-func writeHTMLReport(results analyzer.Results) error {
+func loadSprayPatterns(path string) (map[common.EquipmentType][][2]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open spray patterns: %w", err)
+	}
+	defer f.Close()
+
+	patterns, err := stats.LoadSprayPatterns(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return patterns, nil
+}
+
+func parseSteamIDs(values []string) ([]uint64, error) {
+	ids := make([]uint64, 0, len(values))
+	for _, v := range values {
+		id, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid steamid %q: %v", v, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseRange parses "N", "N-M" or "N-" (open-ended) into start and end;
+// an open end is returned as 0.
+func parseRange(v string) (int, int, error) {
+	startStr, endStr, isRange := strings.Cut(strings.TrimSpace(v), "-")
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%q: %v", v, err)
+	}
+	if !isRange {
+		return start, start, nil
+	}
+	if endStr == "" {
+		return start, 0, nil
+	}
+	end, err := strconv.Atoi(endStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%q: %v", v, err)
+	}
+	return start, end, nil
+}
+
+func writeHTMLReport(results analyzer.Results, progress io.Writer) error {
 	reporter, err := stats.NewHTMLReporter()
 	if err != nil {
 		return err
@@ -89,11 +520,38 @@ func writeHTMLReport(results analyzer.Results) error {
 	}
 
 	abs, _ := filepath.Abs(htmlOutputFile)
-	fmt.Printf("\nHTML report written to: %s\n", abs)
+	fmt.Fprintf(progress, "\nHTML report written to: %s\n", abs)
 	return nil
 }
 
 func init() {
 	rootCmd.AddCommand(analyzeCmd)
 	analyzeCmd.Flags().BoolVar(&htmlOut, "html", false, "Also write an HTML report to ./index.html")
+	analyzeCmd.Flags().StringVar(&colorMode, "color", "auto", "Color the terminal report: auto (TTY only), always or never")
+	analyzeCmd.Flags().BoolVar(&heatmapOut, "heatmap", false, "Also print a per-round suspicion grid")
+	analyzeCmd.Flags().StringVar(&outputFormat, "format", "text", "Report format: "+strings.Join(reportFormats, ", "))
+	analyzeCmd.Flags().StringVarP(&outputPath, "out", "o", "", "Write the report to this file instead of stdout")
+	analyzeCmd.Flags().BoolVar(&verdictOut, "verdict", false, "Print only a FLAGGED line per flagged player and exit 2 if there is any (same as --format verdict)")
+	analyzeCmd.Flags().StringVar(&promNamespace, "prom-namespace", "demo_anticheat", "Metric name prefix for --format prom")
+	analyzeCmd.Flags().BoolVar(&jsonlOut, "jsonl", false, "Write one JSON object per player to stdout instead of the terminal report")
+	_ = analyzeCmd.Flags().MarkDeprecated("jsonl", "use --format jsonl")
+	analyzeCmd.Flags().StringVar(&sprayPatternsPath, "spray-patterns", "", "JSON file of weapon spray patterns overriding the built-in ones")
+	analyzeCmd.Flags().IntVar(&minKillsForFlag, "min-kills", stats.DefaultMinKillsForFlag, "Players with fewer kills are never flagged (0 disables)")
+	analyzeCmd.Flags().BoolVar(&flagOnLowerBound, "strict-flag", false, "Only flag players whose likelihood's lower confidence bound reaches the threshold")
+	analyzeCmd.Flags().StringSliceVar(&playerFilter, "player", nil, "Only report these SteamID64s (repeatable or comma-separated); everyone is still scored")
+	analyzeCmd.Flags().StringSliceVar(&trustedPlayers, "trusted", nil, "Never flag these SteamID64s, e.g. known pros; their likelihood is still shown (repeatable or comma-separated)")
+	analyzeCmd.Flags().StringVar(&roundRange, "rounds", "", "Only analyze these rounds, e.g. 15-18, 12 or 20-")
+	analyzeCmd.Flags().StringVar(&tickRange, "ticks", "", "Only analyze this in-game tick range, e.g. 50000-80000")
+	analyzeCmd.Flags().Float64Var(&tickRateOverride, "tick-rate", 0, "Use this tick rate instead of the one the demo reports, for demos with a wrong header")
+	analyzeCmd.Flags().Float64Var(&fastTTD, "fast-ttd", 0, "Count time-to-damage at or under this many ms toward sub_100ms_ttd (default 100)")
+	analyzeCmd.Flags().StringVar(&ttdRamp, "ttd-ramp", "", "P10 time-to-damage in ms at which reaction_cheat_score is 0 and 1, as <clean>-<blatant> (default 400-100)")
+	analyzeCmd.Flags().StringVar(&rawSamplesPath, "raw-samples", "", "Also write the snap, reaction and recoil samples to this CSV file")
+	analyzeCmd.Flags().IntVar(&maxSamples, "max-samples", stats.DefaultMaxSamples, "Samples kept per player for each percentile and for --raw-samples; beyond it a random subset is kept (0 keeps all)")
+	analyzeCmd.Flags().BoolVar(&includeBots, "include-bots", false, "Analyze bots as players (practice and aim-trainer demos)")
+	analyzeCmd.Flags().BoolVar(&debugLog, "debug", false, "Also print collector diagnostics such as every recoil burst")
+	analyzeCmd.Flags().BoolVar(&omitZero, "omit-zero", false, "Leave metrics that are zero or empty out of the report")
+	analyzeCmd.Flags().StringVar(&analyzeCodesFile, "codes-file", "", "Download and analyze the matches in this file of share codes, one per line (- for stdin), instead of a demo")
+	analyzeCmd.Flags().StringVar(&resolveCmd, "resolve-cmd", "", "Command that prints the demo URL for the share code given as its last argument; required with --codes-file")
+	analyzeCmd.Flags().StringVar(&downloadDir, "download-dir", "demos", "Directory --codes-file demos are downloaded into")
+	analyzeCmd.Flags().StringSliceVar(&onlyKeys, "only-keys", nil, "Only report these metric keys, e.g. cheat_likelihood,hs_score (the verdict is always kept)")
 }
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNewReporter(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		jsonl    bool
+		verdict  bool
+		wantType string
+		wantErr  string
+	}{
+		{name: "default", format: "text", wantType: "*stats.TextReporter"},
+		{name: "json", format: "json", wantType: "*stats.JSONReporter"},
+		{name: "csv", format: "csv", wantType: "*stats.CSVReporter"},
+		{name: "markdown", format: "md", wantType: "*stats.MarkdownReporter"},
+		{name: "prometheus", format: "prom", wantType: "*stats.PrometheusReporter"},
+		{name: "deprecated --jsonl", format: "text", jsonl: true, wantType: "*stats.JSONLinesReporter"},
+		{name: "--jsonl overrides --format", format: "csv", jsonl: true, wantType: "*stats.JSONLinesReporter"},
+		{name: "--verdict wins over --jsonl", format: "text", jsonl: true, verdict: true, wantType: "*stats.VerdictReporter"},
+		{name: "invalid format", format: "xml", wantErr: `invalid --format "xml"`},
+		{name: "--jsonl rescues an invalid format", format: "xml", jsonl: true, wantType: "*stats.JSONLinesReporter"},
+	}
+	for _, tt := range tests {
+		reporter, err := newReporter(resolveFormat(tt.format, tt.jsonl, tt.verdict))
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: err = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), strings.Join(reportFormats, ", ")) {
+				t.Errorf("%s: error %q doesn't list the valid formats", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if got := fmt.Sprintf("%T", reporter); got != tt.wantType {
+			t.Errorf("%s: reporter = %s, want %s", tt.name, got, tt.wantType)
+		}
+	}
+}
+
+func TestCommandResolver(t *testing.T) {
+	resolve := commandResolver("printf https://replay.example/%s.dem")
+	url, err := resolve(context.Background(), "CSGO-abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://replay.example/CSGO-abc.dem"; url != want {
+		t.Errorf("url = %q, want %q", url, want)
+	}
+
+	if _, err := commandResolver("true")(context.Background(), "CSGO-abc"); err == nil {
+		t.Error("no error for a command that prints nothing")
+	}
+	if _, err := commandResolver("false")(context.Background(), "CSGO-abc"); err == nil {
+		t.Error("no error for a failing command")
+	}
+}
+
+func TestReportSkipped(t *testing.T) {
+	var buf bytes.Buffer
+	reportSkipped(&buf, "Download failed", errors.Join(errors.New("a: 404"), errors.New("b: timeout")))
+	want := "Download failed, skipping: a: 404\nDownload failed, skipping: b: timeout\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/timanthonyalexander/demo-anticheat/pkg/analyzer"
+	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [suspect-demo] [suspect-steamid] [benchmark-demo] [benchmark-steamid]",
+	Short: "Compare a suspect's metrics against a benchmark player",
+	Long: `Analyzes both demos and prints the suspect's metrics side by side with the
+benchmark player's, with the delta for each numeric metric. Rows where the
+suspect differs from the benchmark by more than 1.5x in the suspicious
+direction are marked with "!" — higher for most metrics, lower for timings
+(TTD, time-to-kill) and aim angles. Both players may come from the same demo.`,
+	Args:         cobra.ExactArgs(4),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		suspectID, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid suspect steamid %q: %v", args[1], err)
+		}
+		benchmarkID, err := strconv.ParseUint(args[3], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid benchmark steamid %q: %v", args[3], err)
+		}
+
+		suspect, err := analyzeForDiff(args[0])
+		if err != nil {
+			return err
+		}
+		benchmark := suspect
+		if args[2] != args[0] {
+			if benchmark, err = analyzeForDiff(args[2]); err != nil {
+				return err
+			}
+		}
+
+		return stats.DiffReport(suspect, benchmark, suspectID, benchmarkID, os.Stdout)
+	},
+}
+
+func analyzeForDiff(demoPath string) (*stats.DemoStats, error) {
+	if _, err := os.Stat(demoPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("demo file not found: %s", demoPath)
+	}
+	demoAnalyzer := analyzer.NewAnalyzer(demoPath)
+	demoAnalyzer.SetLogger(stats.NewWriterLogger(os.Stderr, false))
+	results, err := demoAnalyzer.Analyze()
+	if err != nil {
+		return nil, fmt.Errorf("analysis of %s failed: %v", demoPath, err)
+	}
+	return results.DemoStats, nil
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -13,10 +14,30 @@ var rootCmd = &cobra.Command{
 	Long:  `A CLI tool that analyzes CS2 demo files and generates statistics.`,
 }
 
+// Exit codes. They are part of the CLI's contract for scripts: 0 when the
+// command succeeded, 1 on any error, and 2 from `analyze --verdict` when a
+// player was flagged.
+const (
+	exitError   = 1
+	exitFlagged = 2
+)
+
+// exitCodeError makes Execute exit with code instead of exitError, printing
+// nothing.
+type exitCodeError struct {
+	code int
+}
+
+func (e exitCodeError) Error() string { return fmt.Sprintf("exit status %d", e.code) }
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		var exit exitCodeError
+		if errors.As(err, &exit) {
+			os.Exit(exit.code)
+		}
 		fmt.Println(err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 }
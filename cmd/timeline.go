@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/timanthonyalexander/demo-anticheat/pkg/analyzer"
+)
+
+var timelineCmd = &cobra.Command{
+	Use:   "timeline [steamid] [demo]...",
+	Short: "Follow one player's metrics across several demos",
+	Long: `Analyzes every demo in full and prints this player's row for each one,
+oldest first by file modification time, followed by the trend of each column
+per demo. Demos the player isn't in are listed at the end. A demo that fails
+to parse is reported and the others are still shown.`,
+	Args:         cobra.MinimumNArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		steamID, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid steamid %q: %v", args[0], err)
+		}
+
+		timeline, analyzeErr := analyzer.AnalyzePlayerAcrossDemos(args[1:], steamID)
+		if analyzeErr != nil {
+			fmt.Fprintln(os.Stderr, analyzeErr)
+		}
+		if len(timeline.Points) == 0 {
+			return fmt.Errorf("player %d is in none of the analyzed demos", steamID)
+		}
+
+		fmt.Printf("%s (%d), %d demos\n\n", timeline.Name, steamID, len(timeline.Points))
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, "Date\tMap\tKills\tHS %\tP95 snap\tMedian TTD\tCheat %\tFlagged\t")
+		for _, p := range timeline.Points {
+			flagged := ""
+			if p.Stats.Cheat.Flagged {
+				flagged = "yes"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%.1f\t%.3f\t%.0f\t%.1f\t%s\t\n",
+				p.Played.Format("2006-01-02"), p.Info.MapName, p.Kills, p.HeadshotPercentage,
+				p.Stats.Aim.P95Snap, p.Stats.Reaction.MedianTTD, p.Stats.Cheat.Likelihood, flagged)
+		}
+		fmt.Fprintf(tw, "trend/demo\t\t%+.1f\t%+.1f\t%+.3f\t%+.0f\t%+.1f\t\t\n",
+			timeline.Trend(func(p analyzer.TimelinePoint) float64 { return float64(p.Kills) }),
+			timeline.Trend(func(p analyzer.TimelinePoint) float64 { return p.HeadshotPercentage }),
+			timeline.Trend(func(p analyzer.TimelinePoint) float64 { return p.Stats.Aim.P95Snap }),
+			timeline.Trend(func(p analyzer.TimelinePoint) float64 { return p.Stats.Reaction.MedianTTD }),
+			timeline.Trend(func(p analyzer.TimelinePoint) float64 { return p.Stats.Cheat.Likelihood }))
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		for _, path := range timeline.Absent {
+			fmt.Printf("not in %s\n", path)
+		}
+		if analyzeErr != nil {
+			return exitCodeError{code: exitError}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(timelineCmd)
+}
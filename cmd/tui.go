@@ -0,0 +1,38 @@
+//go:build tui
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/timanthonyalexander/demo-anticheat/internal/tui"
+	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [report.json]",
+	Short: "Browse a saved JSON report interactively",
+	Long: `Loads a report written with --format json and opens a two-pane browser:
+players sorted by cheat likelihood on the left, every category of the
+selected player on the right. Only built with -tags tui.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("open report: %w", err)
+		}
+		ds, err := stats.LoadJSONReport(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+		return tui.Browse(ds, os.Stdin, os.Stdout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
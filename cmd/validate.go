@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/timanthonyalexander/demo-anticheat/pkg/analyzer"
+	"github.com/timanthonyalexander/demo-anticheat/pkg/steam"
+)
+
+var validateFrames int
+var validateContinue bool
+var codesFile string
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [demo-file...]",
+	Short: "Check that CS2 demo files are parseable without analyzing them",
+	Long: `Parses the header and the first frames of each demo and reports the map
+name and tick rate. No collectors run, so this is fast enough to sanity-check
+a batch before analyzing it. Stops at the first invalid demo unless
+--continue is given.
+
+With --codes-file, checks a file of match share codes instead, one per line
+("-" reads stdin). Every line is checked; invalid ones are reported with
+their line number. analyze --codes-file downloads and analyzes them.`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if codesFile != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if codesFile != "" {
+			return validateCodes(codesFile)
+		}
+		invalid := 0
+		for _, demoPath := range args {
+			result := analyzer.Validate(demoPath, validateFrames)
+			if !result.Valid() {
+				fmt.Printf("INVALID %s: %v\n", demoPath, result.Err)
+				invalid++
+				if !validateContinue {
+					return fmt.Errorf("invalid demo: %s", demoPath)
+				}
+				continue
+			}
+			fmt.Printf("OK      %s (map %s, %.0f tick, %d frames checked)\n",
+				demoPath, result.MapName, result.TickRate, result.FramesParsed)
+		}
+
+		if invalid > 0 {
+			return fmt.Errorf("%d of %d demos invalid", invalid, len(args))
+		}
+		return nil
+	},
+}
+
+// validateCodes checks the share codes in path, or stdin for "-".
+func validateCodes(path string) error {
+	codes, invalid, err := readShareCodes(path)
+	if err != nil {
+		return err
+	}
+	for _, code := range codes {
+		fmt.Printf("OK      %s\n", code)
+	}
+	for _, e := range invalid {
+		fmt.Printf("INVALID %v\n", e)
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("%d of %d share codes invalid", len(invalid), len(codes)+len(invalid))
+	}
+	return nil
+}
+
+// readShareCodes reads the share codes in path, or stdin for "-", with
+// steam.ReadShareCodes.
+func readShareCodes(path string) ([]string, []*steam.ShareCodeLineError, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	return steam.ReadShareCodes(r)
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().IntVar(&validateFrames, "frames", analyzer.DefaultValidateFrames, "Number of frames to parse per demo")
+	validateCmd.Flags().BoolVar(&validateContinue, "continue", false, "Keep validating after an invalid demo")
+	validateCmd.Flags().StringVar(&codesFile, "codes-file", "", "Check the share codes in this file, one per line (- for stdin), instead of demos")
+}
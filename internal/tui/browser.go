@@ -0,0 +1,341 @@
+//go:build tui
+
+// Package tui is the interactive results browser behind the `tui` command.
+// It is only built with `-tags tui`, so the core binary carries no
+// raw-terminal code, and it reads results only through the exported
+// pkg/stats API.
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
+
+	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
+)
+
+// browserListWidth is the width of the player list pane.
+const browserListWidth = 32
+
+// Likelihood bands, the same as the HTML and terminal reports use.
+const (
+	flagLikelihood = 50.0
+	warnLikelihood = 25.0
+)
+
+// Palette, a subset of the terminal report's.
+var (
+	colorFlag  = lipgloss.Color("#dc5a4a")
+	colorWarn  = lipgloss.Color("#d49a3a")
+	colorOk    = lipgloss.Color("#4f9d65")
+	colorText  = lipgloss.Color("#e6e7e9")
+	colorDim   = lipgloss.Color("#9aa0a8")
+	colorFaint = lipgloss.Color("#6a707a")
+	colorLine  = lipgloss.Color("#3a4048")
+)
+
+// styles holds the browser's lipgloss styles, bound to one renderer.
+type styles struct {
+	r *lipgloss.Renderer
+
+	headerName    lipgloss.Style
+	meta          lipgloss.Style
+	tableName     lipgloss.Style
+	plyrName      lipgloss.Style
+	plyrID        lipgloss.Style
+	categoryTitle lipgloss.Style
+	categoryNote  lipgloss.Style
+	metricLabel   lipgloss.Style
+	metricValue   lipgloss.Style
+	footer        lipgloss.Style
+	likeFlag      lipgloss.Style
+	likeWarn      lipgloss.Style
+	likeOk        lipgloss.Style
+}
+
+// newStyles builds the styles for a renderer targeting w. When isTTY is
+// false every style renders as plain text.
+func newStyles(w io.Writer, isTTY bool) *styles {
+	r := lipgloss.NewRenderer(w)
+	if !isTTY {
+		r.SetColorProfile(termenv.Ascii)
+	}
+	ns := r.NewStyle
+	return &styles{
+		r:             r,
+		headerName:    ns().Foreground(colorDim),
+		meta:          ns().Foreground(colorDim),
+		tableName:     ns().Foreground(colorText),
+		plyrName:      ns().Foreground(colorText).Bold(true),
+		plyrID:        ns().Foreground(colorFaint),
+		categoryTitle: ns().Foreground(colorFaint).Bold(true),
+		categoryNote:  ns().Foreground(colorFaint).Italic(true),
+		metricLabel:   ns().Foreground(colorDim),
+		metricValue:   ns().Foreground(colorText),
+		footer:        ns().Foreground(colorFaint),
+		likeFlag:      ns().Foreground(colorFlag).Bold(true),
+		likeWarn:      ns().Foreground(colorWarn).Bold(true),
+		likeOk:        ns().Foreground(colorOk).Bold(true),
+	}
+}
+
+// likelihoodStyle returns the style for a cheat likelihood of v percent.
+func (s *styles) likelihoodStyle(v float64) lipgloss.Style {
+	switch {
+	case v >= flagLikelihood:
+		return s.likeFlag
+	case v >= warnLikelihood:
+		return s.likeWarn
+	}
+	return s.likeOk
+}
+
+// browser is the state of the results browser: a player list sorted by
+// cheat likelihood and a scrollable detail pane for the selected player.
+type browser struct {
+	s       *styles
+	ds      *stats.DemoStats
+	players []stats.ReportPlayer
+
+	sel    int // selected player
+	scroll int // first visible detail line
+
+	width, height int
+}
+
+func newBrowser(ds *stats.DemoStats, s *styles, width, height int) *browser {
+	b := &browser{s: s, ds: ds, width: width, height: height}
+	b.players = stats.BuildReport(ds, nil).Players
+	// BuildReport orders by SteamID, which the stable sort keeps for ties
+	sort.SliceStable(b.players, func(i, j int) bool {
+		li, lj := b.likelihood(b.players[i]), b.likelihood(b.players[j])
+		if li != lj {
+			return li > lj
+		}
+		return b.players[i].Name < b.players[j].Name
+	})
+	return b
+}
+
+// likelihood returns p's cheat likelihood in percent, 0 when unscored.
+func (b *browser) likelihood(p stats.ReportPlayer) float64 {
+	m, _ := b.ds.Players[p.SteamID].GetMetric(stats.CatAntiCheat, stats.KeyCheatLikelihood)
+	return m.FloatValue
+}
+
+// key applies one keypress and reports whether the browser should keep
+// running.
+func (b *browser) key(k string) bool {
+	page := b.height - 4
+	if page < 1 {
+		page = 1
+	}
+	switch k {
+	case "q", "esc", "ctrl+c":
+		return false
+	case "up", "k":
+		b.selectPlayer(b.sel - 1)
+	case "down", "j":
+		b.selectPlayer(b.sel + 1)
+	case "home", "g":
+		b.selectPlayer(0)
+	case "end", "G":
+		b.selectPlayer(len(b.players) - 1)
+	case "pgdown", " ", "J":
+		b.scrollDetail(page)
+	case "pgup", "K":
+		b.scrollDetail(-page)
+	}
+	return true
+}
+
+func (b *browser) selectPlayer(i int) {
+	if i < 0 || i >= len(b.players) || i == b.sel {
+		return
+	}
+	b.sel, b.scroll = i, 0
+}
+
+func (b *browser) scrollDetail(delta int) {
+	maxScroll := len(b.detailLines()) - (b.height - 3)
+	b.scroll += delta
+	if b.scroll > maxScroll {
+		b.scroll = maxScroll
+	}
+	if b.scroll < 0 {
+		b.scroll = 0
+	}
+}
+
+// view renders the whole screen.
+func (b *browser) view() string {
+	bodyHeight := b.height - 3
+	if bodyHeight < 1 {
+		bodyHeight = 1
+	}
+
+	header := b.s.headerName.Render("demo-anticheat") + "  " + b.s.meta.Render(fmt.Sprintf("%s · %s · %d players", b.ds.DemoName, b.ds.MapName, len(b.players)))
+
+	list := b.listLines()
+	listStart := 0
+	if b.sel >= bodyHeight {
+		listStart = b.sel - bodyHeight + 1
+	}
+	list = window(list, listStart, bodyHeight)
+
+	detail := window(b.detailLines(), b.scroll, bodyHeight)
+	detailWidth := b.width - browserListWidth - 3
+	if detailWidth < 20 {
+		detailWidth = 20
+	}
+
+	left := b.s.r.NewStyle().Width(browserListWidth).Height(bodyHeight).Render(strings.Join(list, "\n"))
+	sep := b.s.r.NewStyle().Foreground(colorLine).Render(strings.Repeat("│\n", bodyHeight-1) + "│")
+	right := b.s.r.NewStyle().Width(detailWidth).MaxWidth(detailWidth).Height(bodyHeight).Render(strings.Join(detail, "\n"))
+	body := lipgloss.JoinHorizontal(lipgloss.Top, left, " ", sep, " ", right)
+
+	help := b.s.footer.Render("↑/↓ j/k player · PgUp/PgDn scroll details · q quit")
+	return header + "\n\n" + body + "\n" + help
+}
+
+// listLines renders one line per player: name and cheat likelihood.
+func (b *browser) listLines() []string {
+	out := make([]string, 0, len(b.players))
+	for i, p := range b.players {
+		v := b.likelihood(p)
+		name := truncateName(p.Name, browserListWidth-9)
+		pct := b.s.likelihoodStyle(v).Render(fmt.Sprintf("%5.1f%%", v))
+		marker := "  "
+		nameStyle := b.s.tableName
+		if i == b.sel {
+			marker = "▸ "
+			nameStyle = nameStyle.Bold(true)
+		}
+		pad := browserListWidth - 9 - lipgloss.Width(name)
+		if pad < 0 {
+			pad = 0
+		}
+		out = append(out, marker+nameStyle.Render(name)+strings.Repeat(" ", pad)+" "+pct)
+	}
+	return out
+}
+
+// detailLines renders the selected player's verdict and every category.
+func (b *browser) detailLines() []string {
+	if len(b.players) == 0 {
+		return []string{"No players"}
+	}
+	p := b.players[b.sel]
+	v := b.likelihood(p)
+	out := []string{
+		b.s.plyrName.Render(p.Name) + "  " + b.s.plyrID.Render(fmt.Sprintf("%d", p.SteamID)),
+		"Cheat likelihood " + b.s.likelihoodStyle(v).Render(fmt.Sprintf("%.1f%%", v)),
+	}
+	if expl, ok := b.ds.Players[p.SteamID].GetMetric(stats.CatAntiCheat, stats.KeyCheatExplanation); ok && expl.StringValue != "" {
+		out = append(out, b.s.categoryNote.Render(expl.StringValue))
+	}
+	for _, sec := range p.Sections {
+		out = append(out, "", b.s.categoryTitle.Render(sec.Title))
+		for _, row := range sec.Rows {
+			out = append(out, "  "+b.s.metricLabel.Render(row.Label)+"  "+b.s.metricValue.Render(row.Value))
+		}
+	}
+	return out
+}
+
+// truncateName shortens name to at most n cells.
+func truncateName(name string, n int) string {
+	if lipgloss.Width(name) <= n {
+		return name
+	}
+	r := []rune(name)
+	for len(r) > 0 && lipgloss.Width(string(r))+1 > n {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "…"
+}
+
+// window returns up to n lines starting at start.
+func window(lines []string, start, n int) []string {
+	if start > len(lines) {
+		start = len(lines)
+	}
+	end := start + n
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return lines[start:end]
+}
+
+// Browse runs the interactive browser for ds on the terminal behind in and
+// out until the user quits. Both must be a terminal.
+func Browse(ds *stats.DemoStats, in, out *os.File) error {
+	if !term.IsTerminal(in.Fd()) || !term.IsTerminal(out.Fd()) {
+		return fmt.Errorf("tui needs an interactive terminal")
+	}
+	state, err := term.MakeRaw(in.Fd())
+	if err != nil {
+		return err
+	}
+	defer term.Restore(in.Fd(), state)
+
+	s := newStyles(out, true)
+	s.r.SetColorProfile(termenv.ANSI256)
+	width, height, err := term.GetSize(out.Fd())
+	if err != nil {
+		width, height = 100, 30
+	}
+	b := newBrowser(ds, s, width, height)
+
+	io.WriteString(out, "\x1b[?1049h\x1b[?25l") // alternate screen, hide cursor
+	defer io.WriteString(out, "\x1b[?25h\x1b[?1049l")
+
+	buf := make([]byte, 16)
+	for {
+		if w, h, err := term.GetSize(out.Fd()); err == nil {
+			b.width, b.height = w, h
+		}
+		// Raw mode doesn't translate \n, so move to column 0 explicitly.
+		frame := strings.ReplaceAll(b.view(), "\n", "\r\n")
+		if _, err := io.WriteString(out, "\x1b[H\x1b[2J"+frame); err != nil {
+			return err
+		}
+
+		n, err := in.Read(buf)
+		if err != nil {
+			return err
+		}
+		if !b.key(decodeKey(buf[:n])) {
+			return nil
+		}
+	}
+}
+
+// decodeKey maps a raw terminal read to a key name.
+func decodeKey(b []byte) string {
+	switch string(b) {
+	case "\x1b[A", "\x1bOA":
+		return "up"
+	case "\x1b[B", "\x1bOB":
+		return "down"
+	case "\x1b[5~":
+		return "pgup"
+	case "\x1b[6~":
+		return "pgdown"
+	case "\x1b[H", "\x1b[1~", "\x1bOH":
+		return "home"
+	case "\x1b[F", "\x1b[4~", "\x1bOF":
+		return "end"
+	case "\x1b":
+		return "esc"
+	case "\x03":
+		return "ctrl+c"
+	}
+	return string(b)
+}
@@ -0,0 +1,60 @@
+//go:build tui
+
+package tui
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
+)
+
+func TestBrowser(t *testing.T) {
+	ds := stats.NewDemoStats()
+	for sid, p := range map[uint64]struct {
+		name string
+		like float64
+	}{1: {"clean", 12}, 2: {"sus", 81}, 3: {"mid", 40}} {
+		ps := ds.GetOrCreatePlayerStatsBySteamID(sid)
+		ps.Player.Name = p.name
+		ps.AddMetric(stats.CatAntiCheat, stats.KeyCheatLikelihood, stats.Metric{Type: stats.MetricPercentage, FloatValue: p.like})
+		ps.AddMetric(stats.CatKills, stats.KeyTotalKills, stats.Metric{Type: stats.MetricInteger, IntValue: int64(sid) * 5})
+	}
+
+	b := newBrowser(ds, newStyles(io.Discard, false), 100, 20)
+	var names []string
+	for _, p := range b.players {
+		names = append(names, p.Name)
+	}
+	if got := strings.Join(names, ","); got != "sus,mid,clean" {
+		t.Fatalf("order = %s, want by likelihood", got)
+	}
+
+	view := b.view()
+	if !strings.Contains(view, "▸ sus") || !strings.Contains(view, "81.0%") {
+		t.Errorf("first player not selected:\n%s", view)
+	}
+
+	b.key("down")
+	b.key("down")
+	b.key("down") // past the end: stays on the last player
+	if b.sel != 2 || !strings.Contains(b.view(), "▸ clean") {
+		t.Errorf("sel = %d after moving down", b.sel)
+	}
+	b.key("up")
+	if b.sel != 1 {
+		t.Errorf("sel = %d after moving up, want 1", b.sel)
+	}
+	if b.key("q") {
+		t.Error("q didn't quit")
+	}
+}
+
+func TestDecodeKey(t *testing.T) {
+	for raw, want := range map[string]string{"\x1b[A": "up", "\x1b[6~": "pgdown", "j": "j", "\x03": "ctrl+c"} {
+		if got := decodeKey([]byte(raw)); got != want {
+			t.Errorf("decodeKey(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
@@ -1,25 +1,56 @@
 package analyzer
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 
 	dem "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/msg"
 	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
 )
 
-// Analyzer represents a CS2 demo analyzer
+// Analyzer represents a CS2 demo analyzer.
+//
+// Every run parses the demo. Collectors subscribe to the parser's events
+// and read live game state, so there is no frame cache to replay them
+// from; to try new cheat-score weights without re-parsing, rescore a saved
+// JSON report with stats.RescoreCheat, and to try new thresholds on the
+// underlying samples, keep them with EnableRawSamples.
 type Analyzer struct {
-	demoPath   string
-	collectors []stats.Collector
+	demoPath     string
+	collectors   []stats.Collector
+	playerFilter []uint64
+	window       *analysisWindow
+	tickRate     float64
+	rawSamples   bool
+	maxSamples   int
+	includeBots  bool
+	engagement   stats.EngagementConfig
+	logger       stats.Logger
 }
 
 // Results represents the analysis results
 type Results struct {
 	DemoStats  *stats.DemoStats
 	Categories []stats.Category
+
+	// Info is the demo's header and match metadata.
+	Info DemoInfo
+
+	// Partial is set when parsing was cancelled before the end of the demo:
+	// the stats cover only the frames parsed up to then.
+	Partial bool
+
+	// RawSamples holds the snap velocities, times-to-damage and recoil
+	// bullet errors per SteamID, up to the SetMaxSamples cap. Nil unless
+	// EnableRawSamples was called.
+	RawSamples map[uint64]stats.PlayerSamples
 }
 
 // NewAnalyzer creates a new analyzer for the given demo file
@@ -27,21 +58,47 @@ func NewAnalyzer(demoPath string) *Analyzer {
 	analyzer := &Analyzer{
 		demoPath:   demoPath,
 		collectors: []stats.Collector{},
+		engagement: stats.DefaultEngagementConfig(),
+		maxSamples: stats.DefaultMaxSamples,
+		logger:     stats.NopLogger{},
 	}
 
 	// Register default collectors
 	analyzer.RegisterCollector(stats.NewWeaponUsageCollector())
 	analyzer.RegisterCollector(stats.NewHeadshotCollector())
+	analyzer.RegisterCollector(stats.NewHitgroupCollector())   // Where bullet hits land (head/chest/stomach/arms/legs)
+	analyzer.RegisterCollector(stats.NewAccuracyCollector())   // Hit rate of aimed shots by range
+	analyzer.RegisterCollector(stats.NewInaccuracyCollector()) // Rifle hits tighter than movement inaccuracy allows
 	analyzer.RegisterCollector(stats.NewSnapAngleCollector())
+	analyzer.RegisterCollector(stats.NewFireSnapCollector()) // One-tick snaps onto a head on the shot's tick
+	analyzer.RegisterCollector(stats.NewAimPullCollector())  // View movement biased toward enemies while not firing
 	analyzer.RegisterCollector(stats.NewReactionTimeCollector())
+	analyzer.RegisterCollector(stats.NewTimeToKillCollector())    // First damage → kill timing
 	analyzer.RegisterCollector(stats.NewRecoilControlCollector()) // Add the new recoil control collector
 	analyzer.RegisterCollector(stats.NewGameModeCollector())      // Add the game mode collector
 	analyzer.RegisterCollector(stats.NewScoreboardCollector())    // CS2-style basic scoreboard stats
+	analyzer.RegisterCollector(stats.NewSideCollector())          // Rating split by side, across half and overtime swaps
 	analyzer.RegisterCollector(stats.NewGrenadeCollector())       // Per-player grenade usage
+	analyzer.RegisterCollector(stats.NewJumpthrowCollector())     // Frame-perfect jumpthrows (jumpthrow binds)
+	analyzer.RegisterCollector(stats.NewCrouchJitterCollector())  // Crouch toggled every tick or two (jitter scripts)
 	analyzer.RegisterCollector(stats.NewSniperCollector())        // Sniper-specific anomaly tracking (must run before CheatDetector)
+	analyzer.RegisterCollector(stats.NewSniperFlickCollector())   // AWP flick velocity + scope-to-kill timing
 	analyzer.RegisterCollector(stats.NewBehavioralCollector())    // Wallhack-targeted behavioral signals
+	analyzer.RegisterCollector(stats.NewPlacementCollector())     // Head-level crosshair placement on occluded enemies
+	analyzer.RegisterCollector(stats.NewPlayerInfoCollector())    // Name history / aliases per SteamID
+	analyzer.RegisterCollector(stats.NewObjectiveCollector())     // Saves, fake defuses, defuses under pressure
+	analyzer.RegisterCollector(stats.NewOpeningDuelCollector())   // First kill of each round: who took it, how fast
+	analyzer.RegisterCollector(stats.NewConductCollector())       // Friendly-fire damage and team kills
+	analyzer.RegisterCollector(stats.NewFlashCollector())         // Kills while still fully flashed (feeds the flash channel)
+	analyzer.RegisterCollector(stats.NewSmokeCollector())         // Kills through smokes neither player was at
+	analyzer.RegisterCollector(stats.NewWallbangKillCollector())  // Wallbangs, and ones tracked through the wall first
+	analyzer.RegisterCollector(stats.NewBlindTrackingCollector()) // Kills never in line of sight, tracked through cover
+	analyzer.RegisterCollector(stats.NewInfoCollector())          // Kills on enemies nobody on the killer's team had spotted
 	analyzer.RegisterCollector(stats.NewCheatDetector())          // CheatDetector should be last to use results from other collectors
 	analyzer.RegisterCollector(stats.NewGradingCollector())       // Grades come after everything else has run
+	analyzer.RegisterCollector(stats.NewRatingCollector())        // HLTV-style rating from the final scoreboard
+	// Rating, ADR and HS% against the rest of the lobby; reads rating_2
+	analyzer.RegisterCollector(stats.NewOverperformanceCollector())
 
 	return analyzer
 }
@@ -51,36 +108,288 @@ func (a *Analyzer) RegisterCollector(collector stats.Collector) {
 	a.collectors = append(a.collectors, collector)
 }
 
+// SetSprayPatterns overrides the spray patterns used by every registered
+// RecoilControlCollector. See stats.LoadSprayPatterns for the file format.
+func (a *Analyzer) SetSprayPatterns(patterns map[common.EquipmentType][][2]float64) {
+	for _, collector := range a.collectors {
+		if rc, ok := collector.(*stats.RecoilControlCollector); ok {
+			rc.SetSprayPatterns(patterns)
+		}
+	}
+}
+
+// SetRecoilScoreMode picks the model behind recoil_score on every
+// registered RecoilControlCollector. See stats.RecoilScoreSimilarity.
+func (a *Analyzer) SetRecoilScoreMode(m stats.RecoilScoreMode) {
+	for _, collector := range a.collectors {
+		if rc, ok := collector.(*stats.RecoilControlCollector); ok {
+			rc.ScoreMode = m
+		}
+	}
+}
+
+// SetReactionThresholds sets the time-to-damage cutoffs on every
+// registered ReactionTimeCollector; fields at 0 keep their default. See
+// stats.ReactionThresholds.
+func (a *Analyzer) SetReactionThresholds(t stats.ReactionThresholds) {
+	for _, collector := range a.collectors {
+		if rtc, ok := collector.(*stats.ReactionTimeCollector); ok {
+			rtc.Thresholds = t
+		}
+	}
+}
+
+// SetTickRateOverride makes the analysis use rate instead of the tick rate
+// the demo reports, for demos whose header is wrong and whose reaction and
+// snap timings come out obviously off. Every collector and
+// DemoStats.TickRate get rate, and it replaces the 64-tick fallback used
+// before the demo's rate is known as well. A rate of 0 or less clears the
+// override.
+func (a *Analyzer) SetTickRateOverride(rate float64) {
+	a.tickRate = max(rate, 0)
+}
+
+// SetPlayerFilter restricts the results to the given SteamIDs. Every player
+// is still collected and scored, since lobby-relative scoring
+// (normalization, pre-FOV asymmetry, scoreboard position) needs the whole
+// lobby; the others are dropped afterwards, so a filtered likelihood is the
+// one an unfiltered run reports. Call with no IDs to clear the filter.
+func (a *Analyzer) SetPlayerFilter(steamIDs ...uint64) {
+	a.playerFilter = steamIDs
+}
+
+// SetRoundRange restricts collection to rounds start..end (1-based,
+// inclusive). The parser still reads the whole demo, but collectors only see
+// frames and events inside the range. An end of 0 runs to the end of the demo.
+func (a *Analyzer) SetRoundRange(start, end int) {
+	if a.window == nil {
+		a.window = &analysisWindow{}
+	}
+	a.window.startRound, a.window.endRound = start, end
+}
+
+// SetTickRange restricts collection to ticks start..end (inclusive). It can
+// be combined with SetRoundRange; both must match. An end of 0 runs to the
+// end of the demo.
+func (a *Analyzer) SetTickRange(start, end int) {
+	if a.window == nil {
+		a.window = &analysisWindow{}
+	}
+	a.window.startTick, a.window.endTick = start, end
+}
+
+// EnableRawSamples makes Analyze return every individual sample behind the
+// snap, reaction and recoil percentiles in Results.RawSamples, for offline
+// analysis. Off by default: the samples are kept in memory for the whole
+// demo.
+func (a *Analyzer) EnableRawSamples() {
+	a.rawSamples = true
+}
+
+// SetMaxSamples caps the samples each collector keeps per player for its
+// percentiles and for raw-sample export; n <= 0 keeps every sample. See
+// stats.DefaultMaxSamples for the accuracy tradeoff.
+func (a *Analyzer) SetMaxSamples(n int) {
+	a.maxSamples = n
+}
+
+// SetIncludeBots makes bots count as players, for practice and aim-trainer
+// demos: bot kills, fires and deaths are collected and each bot is reported
+// under a synthetic key (see stats.BotKey). By default bots are excluded,
+// since in real matches they stand in for disconnected players.
+func (a *Analyzer) SetIncludeBots(include bool) {
+	a.includeBots = include
+}
+
+// SetEngagementConfig changes the definition of a fight shared by the
+// collectors that sample engagements (reaction, time-to-kill). Scores are
+// calibrated against stats.DefaultEngagementConfig.
+func (a *Analyzer) SetEngagementConfig(cfg stats.EngagementConfig) {
+	a.engagement = cfg
+}
+
+// SetMinKillsForFlag sets the kill count below which no player can be
+// flagged. See stats.CheatDetector.MinKillsForFlag.
+func (a *Analyzer) SetMinKillsForFlag(n int) {
+	for _, collector := range a.collectors {
+		if cd, ok := collector.(*stats.CheatDetector); ok {
+			cd.MinKillsForFlag = n
+		}
+	}
+}
+
+// SetFlagOnLowerBound makes the cheater flag require the lower end of the
+// likelihood's confidence interval to reach the threshold. See
+// stats.CheatDetector.FlagOnLowerBound.
+func (a *Analyzer) SetFlagOnLowerBound(on bool) {
+	for _, collector := range a.collectors {
+		if cd, ok := collector.(*stats.CheatDetector); ok {
+			cd.FlagOnLowerBound = on
+		}
+	}
+}
+
+// SetCheatWeights changes the cheat-score channel weights and flag
+// threshold, e.g. to a calibration from stats.TuneThresholds.
+func (a *Analyzer) SetCheatWeights(w stats.CheatWeights) {
+	for _, collector := range a.collectors {
+		if cd, ok := collector.(*stats.CheatDetector); ok {
+			cd.Weights = w
+		}
+	}
+}
+
+// SetAllowlist keeps these SteamIDs from ever being flagged while still
+// scoring them. See stats.CheatDetector.SetAllowlist.
+func (a *Analyzer) SetAllowlist(ids ...uint64) {
+	for _, collector := range a.collectors {
+		if cd, ok := collector.(*stats.CheatDetector); ok {
+			cd.SetAllowlist(ids...)
+		}
+	}
+}
+
+// AddScoreComponent registers a custom cheat-score channel on the
+// CheatDetector. See stats.CheatDetector.AddComponent.
+func (a *Analyzer) AddScoreComponent(name string, weight float64, fn func(*stats.PlayerStats) float64) {
+	for _, collector := range a.collectors {
+		if cd, ok := collector.(*stats.CheatDetector); ok {
+			cd.AddComponent(name, weight, fn)
+		}
+	}
+}
+
+// SetRatingCoefficients changes the weights of the rating_2 formula and of
+// the per-side ct_rating and t_rating. See stats.RatingCoefficients.
+func (a *Analyzer) SetRatingCoefficients(c stats.RatingCoefficients) {
+	for _, collector := range a.collectors {
+		switch rc := collector.(type) {
+		case *stats.RatingCollector:
+			rc.Coefficients = c
+		case *stats.SideCollector:
+			rc.Coefficients = c
+		}
+	}
+}
+
+// SetScopeZoom changes how much a scope narrows the FOV cone of the
+// attention signal; the pre-FOV cone is never narrowed. See stats.ScopeZoom.
+func (a *Analyzer) SetScopeZoom(z stats.ScopeZoom) {
+	for _, collector := range a.collectors {
+		if bc, ok := collector.(*stats.BehavioralCollector); ok {
+			bc.Zoom = z
+		}
+	}
+}
+
+// SetLogger sends the analyzer's progress and the collectors' diagnostics
+// to l. Nothing is logged by default.
+func (a *Analyzer) SetLogger(l stats.Logger) {
+	if l == nil {
+		l = stats.NopLogger{}
+	}
+	a.logger = l
+}
+
+// newDemoParser creates a parser for r that records the demo header's map
+// name on demoStats once the header message is parsed, and the recording
+// player of a POV demo once it is detected.
+func newDemoParser(r io.Reader, demoStats *stats.DemoStats) dem.Parser {
+	parser := dem.NewParser(r)
+
+	// v5 removed ParseHeader(); subscribe to the demo file header net message instead.
+	parser.RegisterNetMessageHandler(func(m *msg.CDemoFileHeader) {
+		demoStats.MapName = m.GetMapName()
+	})
+	parser.RegisterEventHandler(func(e events.POVRecordingPlayerDetected) {
+		demoStats.SetPOVRecorder(e.PlayerInfo.XUID)
+	})
+
+	return parser
+}
+
 // Analyze performs the analysis of the demo file
 func (a *Analyzer) Analyze() (Results, error) {
+	return a.AnalyzeContext(context.Background())
+}
+
+// AnalyzeContext is Analyze with cancellation. When ctx is cancelled while
+// the demo is parsing, parsing stops, the collectors' final stats are still
+// computed on what was gathered, and those Results are returned with
+// Partial set, together with ctx's error.
+func (a *Analyzer) AnalyzeContext(ctx context.Context) (Results, error) {
+	if err := a.window.validate(); err != nil {
+		return Results{}, err
+	}
+	if err := stats.ValidateMetricDependencies(a.collectors); err != nil {
+		return Results{}, fmt.Errorf("invalid collector set: %w", err)
+	}
+
 	// Open the demo file
 	f, err := os.Open(a.demoPath)
 	if err != nil {
 		return Results{}, fmt.Errorf("failed to open demo file: %w", err)
 	}
 	defer f.Close()
-
-	// Create a new parser
-	parser := dem.NewParser(f)
-	defer parser.Close()
+	a.logger.Infof("Analyzing demo file: %s", a.demoPath)
 
 	// Initialize demo stats
 	demoStats := stats.NewDemoStats()
 	demoStats.DemoName = filepath.Base(a.demoPath)
+	demoStats.SetIncludeBots(a.includeBots)
 
-	// v5 removed ParseHeader(); subscribe to the demo file header net message instead.
-	parser.RegisterNetMessageHandler(func(m *msg.CDemoFileHeader) {
-		demoStats.MapName = m.GetMapName()
-	})
+	// Create a new parser
+	parser := newDemoParser(f, demoStats)
+	defer parser.Close()
+	infoRecorder := &demoInfoRecorder{}
+	infoRecorder.track(parser)
+
+	// Collectors get a windowed view of the parser when a range is set
+	collectorParser := parser
+	if a.window != nil {
+		a.window.round = 0
+		a.window.track(parser)
+		collectorParser = &windowedParser{Parser: parser, window: a.window}
+	}
+	// Frames and events that rewind the in-game tick are skipped
+	var ticks tickGuard
+	collectorParser = &guardedParser{Parser: collectorParser, guard: &ticks}
+	if a.tickRate > 0 {
+		collectorParser = &tickRateParser{Parser: collectorParser, rate: a.tickRate}
+	}
+
+	// The engagement tracker sees every event and frame before the
+	// collectors that query it
+	engagements := stats.NewEngagementTracker(a.engagement)
+	engagements.Setup(collectorParser, demoStats)
 
 	// Set up collectors
 	for _, collector := range a.collectors {
-		collector.Setup(parser, demoStats)
+		if eu, ok := collector.(stats.EngagementUser); ok {
+			eu.UseEngagements(engagements)
+		}
+		if sl, ok := collector.(stats.SampleLimiter); ok {
+			sl.SetMaxSamples(a.maxSamples)
+		}
+		if rs, ok := collector.(stats.RawSampler); ok && a.rawSamples {
+			rs.KeepRawSamples()
+		}
+		if lu, ok := collector.(stats.LogUser); ok {
+			lu.SetLogger(a.logger)
+		}
+		collector.Setup(collectorParser, demoStats)
 	}
 
 	// Parse all frames
+	a.logger.Infof("Analysis in progress...")
 	frameCount := 0
+	var interrupted error
 	for {
+		if err := ctx.Err(); err != nil {
+			interrupted = err
+			break
+		}
+
 		// Parse the next frame
 		ok, err := parser.ParseNextFrame()
 		if err != nil {
@@ -92,22 +401,30 @@ func (a *Analyzer) Analyze() (Results, error) {
 			break
 		}
 
-		// Collect stats for this frame
-		for _, collector := range a.collectors {
-			collector.CollectFrame(parser, demoStats)
+		frameCount++
+
+		// Outside the window the parser advances but nothing is collected
+		if a.window != nil && !a.window.active(parser.GameState().IngameTick()) {
+			continue
 		}
 
-		frameCount++
+		// Collect stats for this frame
+		collectFrame(collectorParser, &ticks, engagements, a.collectors, demoStats)
 	}
 
 	// Store total frames parsed
 	demoStats.TickCount = frameCount
-	demoStats.TickRate = parser.TickRate()
+	demoStats.TickRate = collectorParser.TickRate()
+	if ticks.rewinds > 0 {
+		a.logger.Infof("Warning: %d frames went back in time and were skipped; the demo may be corrupt", ticks.rewinds)
+	}
+	demoStats.SetIntegrityWarnings(ticks.rewinds)
 
 	// Calculate final stats
 	for _, collector := range a.collectors {
 		collector.CollectFinalStats(demoStats)
 	}
+	demoStats.RetainPlayers(a.playerFilter...)
 
 	// Collect categories from all collectors
 	categories := make([]stats.Category, 0)
@@ -122,8 +439,44 @@ func (a *Analyzer) Analyze() (Results, error) {
 		}
 	}
 
-	return Results{
+	results := Results{
 		DemoStats:  demoStats,
 		Categories: categories,
-	}, nil
+		Info:       infoRecorder.finish(parser.GameState().IngameTick(), demoStats.TickRate, demoStats),
+		Partial:    interrupted != nil,
+	}
+	if a.rawSamples {
+		results.RawSamples = collectRawSamples(a.collectors, a.playerFilter)
+	}
+	return results, interrupted
+}
+
+// collectFrame runs the engagement tracker and every collector on the
+// parser's current frame, unless the frame rewinds the in-game tick.
+func collectFrame(parser dem.Parser, ticks *tickGuard, engagements *stats.EngagementTracker, collectors []stats.Collector, demoStats *stats.DemoStats) {
+	if !ticks.admit(parser.GameState().IngameTick()) {
+		return
+	}
+	engagements.Update(parser)
+	for _, collector := range collectors {
+		collector.CollectFrame(parser, demoStats)
+	}
+}
+
+// collectRawSamples gathers the retained samples of every RawSampler, for
+// the players in filter when it is set.
+func collectRawSamples(collectors []stats.Collector, filter []uint64) map[uint64]stats.PlayerSamples {
+	merged := make(map[uint64]*stats.PlayerSamples)
+	for _, collector := range collectors {
+		if rs, ok := collector.(stats.RawSampler); ok {
+			rs.AppendRawSamples(merged)
+		}
+	}
+	out := make(map[uint64]stats.PlayerSamples, len(merged))
+	for sid, s := range merged {
+		if len(filter) == 0 || slices.Contains(filter, sid) {
+			out[sid] = *s
+		}
+	}
+	return out
 }
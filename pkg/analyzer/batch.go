@@ -0,0 +1,88 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
+)
+
+// AnalyzeMany analyzes paths with up to workers demos in flight and returns
+// each demo's Results keyed by path, plus every successful demo merged with
+// stats.MergeDemoStats for cross-demo ranking. The merge follows the order
+// of paths, not completion order, so the combined stats are the same on
+// every run.
+//
+// A demo that fails to open or parse doesn't stop the others: its error is
+// collected and the demo is left out of both return values. The returned
+// error joins every per-file error (each prefixed with its path) and is nil
+// only if all demos succeeded. Cancelling ctx stops demos that haven't
+// started yet and interrupts the ones being parsed; interrupted demos count
+// as failed, so no partial stats are merged.
+//
+// Parallelism is per demo only. demoinfocs can't seek to a keyframe, so a
+// single demo can't be split into segments parsed side by side, and most
+// collectors carry state across rounds (engagements, view history, lobby
+// baselines) that per-segment stats merged with MergeDemoStats would lose:
+// averaged per-segment likelihoods aren't the demo's likelihood.
+func AnalyzeMany(ctx context.Context, paths []string, workers int) (map[string]Results, *stats.DemoStats, error) {
+	return AnalyzeManyWith(ctx, paths, workers, NewAnalyzer)
+}
+
+// AnalyzeManyWith is AnalyzeMany with the Analyzer for each path built by
+// newAnalyzer, so every demo in the batch can share the same configuration.
+func AnalyzeManyWith(ctx context.Context, paths []string, workers int, newAnalyzer func(path string) *Analyzer) (map[string]Results, *stats.DemoStats, error) {
+	results, errs := analyzeEach(ctx, paths, workers, newAnalyzer)
+
+	byPath := make(map[string]Results, len(paths))
+	demos := make([]*stats.DemoStats, 0, len(paths))
+	for i, path := range paths {
+		if errs[i] != nil {
+			continue
+		}
+		byPath[path] = results[i]
+		demos = append(demos, results[i].DemoStats)
+	}
+	return byPath, stats.MergeDemoStats(demos...), errors.Join(errs...)
+}
+
+// analyzeEach runs the analyzer newAnalyzer builds for each path, with up to
+// workers demos in flight. results[i] and errs[i] belong to paths[i]; each
+// error is prefixed with its path.
+func analyzeEach(ctx context.Context, paths []string, workers int, newAnalyzer func(path string) *Analyzer) ([]Results, []error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]Results, len(paths))
+	errs := make([]error, len(paths))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					errs[i] = fmt.Errorf("%s: %w", paths[i], err)
+					continue
+				}
+				res, err := newAnalyzer(paths[i]).AnalyzeContext(ctx)
+				if err != nil {
+					errs[i] = fmt.Errorf("%s: %w", paths[i], err)
+					continue
+				}
+				results[i] = res
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results, errs
+}
@@ -0,0 +1,49 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeManyCollectsErrors(t *testing.T) {
+	paths := []string{"testdata/missing-a.dem", "testdata/missing-b.dem"}
+	results, merged, err := AnalyzeMany(context.Background(), paths, 4)
+	if err == nil {
+		t.Fatal("expected an error for missing demos")
+	}
+	for _, p := range paths {
+		if !strings.Contains(err.Error(), p) {
+			t.Errorf("error %q does not mention %s", err, p)
+		}
+	}
+	if len(results) != 0 || merged == nil || len(merged.Players) != 0 {
+		t.Errorf("got %d results, merged %+v; want none", len(results), merged)
+	}
+}
+
+func TestAnalyzeManyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := AnalyzeMany(ctx, []string{"a.dem"}, 1)
+	if err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) {
+		t.Errorf("err = %v, want context canceled", err)
+	}
+}
+
+func TestAnalyzeContextCancelledIsPartial(t *testing.T) {
+	if _, err := os.Stat(wingmanDemoPath); err != nil {
+		t.Skipf("demo %s not present, skipping", wingmanDemoPath)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	res, err := NewAnalyzer(wingmanDemoPath).AnalyzeContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context canceled", err)
+	}
+	if !res.Partial || res.DemoStats == nil {
+		t.Errorf("results = %+v, want partial stats", res)
+	}
+}
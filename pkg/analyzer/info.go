@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"time"
+
+	dem "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/msg"
+	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
+)
+
+// DemoInfo is the provenance of an analyzed demo, read from the demo header,
+// the trailing file-info message and the game rules. Fields a demo doesn't
+// carry are left empty: POV demos have no server name, and demos cut off
+// before the file-info message get their duration from the last tick.
+type DemoInfo struct {
+	MapName    string
+	ServerName string
+
+	// Recorder is the client that recorded the demo: the player's name on
+	// POV demos, the GOTV client name otherwise.
+	Recorder string
+
+	// POV is set when the demo was recorded client-side by a player rather
+	// than by GOTV. Perspective says the same as stats.PerspectivePOV or
+	// stats.PerspectiveGOTV; on POV demos the aim, reaction and recoil
+	// metrics cover the recorder only.
+	POV         bool
+	Perspective string
+
+	// NetworkProtocol is the demo's patch (protocol) version and BuildNum
+	// the game build that recorded it.
+	NetworkProtocol int
+	BuildNum        int
+	DemoVersion     string
+
+	Duration time.Duration
+	Ticks    int
+	TickRate float64
+
+	// GameMode and Rounds come from the game_info metrics.
+	GameMode string
+	Rounds   int
+
+	// IntegrityWarnings counts frames whose tick went backwards. They
+	// were skipped, but a demo with any is damaged and its stats deserve
+	// less trust.
+	IntegrityWarnings int
+}
+
+// demoInfoRecorder fills a DemoInfo while the demo is parsed.
+type demoInfoRecorder struct {
+	info         DemoInfo
+	playbackTime time.Duration
+}
+
+// track registers the header, file-info and POV handlers on parser.
+func (r *demoInfoRecorder) track(parser dem.Parser) {
+	parser.RegisterNetMessageHandler(func(m *msg.CDemoFileHeader) {
+		r.info.MapName = m.GetMapName()
+		r.info.ServerName = m.GetServerName()
+		r.info.Recorder = m.GetClientName()
+		r.info.NetworkProtocol = int(m.GetPatchVersion())
+		r.info.BuildNum = int(m.GetBuildNum())
+		r.info.DemoVersion = m.GetDemoVersionName()
+	})
+	parser.RegisterNetMessageHandler(func(m *msg.CDemoFileInfo) {
+		r.playbackTime = time.Duration(float64(m.GetPlaybackTime()) * float64(time.Second))
+		r.info.Ticks = int(m.GetPlaybackTicks())
+	})
+	parser.RegisterEventHandler(func(events.POVRecordingPlayerDetected) {
+		r.info.POV = true
+	})
+}
+
+// finish completes the info once parsing is done. lastTick and tickRate
+// stand in for the file-info message when the demo lacks it.
+func (r *demoInfoRecorder) finish(lastTick int, tickRate float64, demoStats *stats.DemoStats) DemoInfo {
+	info := r.info
+	info.TickRate = tickRate
+	info.Perspective = demoStats.Perspective()
+	if info.Ticks <= 0 {
+		info.Ticks = lastTick
+	}
+	info.Duration = r.playbackTime
+	if info.Duration <= 0 && tickRate > 0 {
+		info.Duration = time.Duration(float64(info.Ticks) / tickRate * float64(time.Second))
+	}
+
+	if m, ok := demoStats.GetMetric(stats.CatGameInfo, stats.KeyGameMode); ok {
+		info.GameMode = m.StringValue
+	}
+	if m, ok := demoStats.GetMetric(stats.CatGameInfo, stats.KeyRoundCount); ok {
+		info.Rounds = int(m.IntValue)
+	}
+	if m, ok := demoStats.GetMetric(stats.CatGameInfo, stats.KeyDemoIntegrityWarnings); ok {
+		info.IntegrityWarnings = int(m.IntValue)
+	}
+	return info
+}
@@ -0,0 +1,33 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
+)
+
+func TestDemoInfoFinish(t *testing.T) {
+	ds := stats.NewDemoStats()
+	ds.AddMetric(stats.Category("game_info"), stats.Key("game_mode"), stats.Metric{Type: stats.MetricString, StringValue: "Competitive"})
+	ds.AddMetric(stats.Category("game_info"), stats.Key("round_count"), stats.Metric{Type: stats.MetricInteger, IntValue: 24})
+
+	// No file-info message: duration falls back to the last tick.
+	r := &demoInfoRecorder{info: DemoInfo{Recorder: "player", POV: true}}
+	info := r.finish(6400, 64, ds)
+	if info.Ticks != 6400 || info.Duration != 100*time.Second {
+		t.Errorf("fallback: ticks %d, duration %v; want 6400, 100s", info.Ticks, info.Duration)
+	}
+	if info.ServerName != "" || !info.POV {
+		t.Errorf("POV info = %+v", info)
+	}
+	if info.GameMode != "Competitive" || info.Rounds != 24 {
+		t.Errorf("game rules: mode %q, rounds %d", info.GameMode, info.Rounds)
+	}
+
+	// The file-info message wins when present.
+	r = &demoInfoRecorder{playbackTime: 90 * time.Second, info: DemoInfo{Ticks: 5760}}
+	if info := r.finish(6400, 64, ds); info.Ticks != 5760 || info.Duration != 90*time.Second {
+		t.Errorf("file info: ticks %d, duration %v; want 5760, 90s", info.Ticks, info.Duration)
+	}
+}
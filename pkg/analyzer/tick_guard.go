@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	dem "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	dp "github.com/markus-wa/godispatch"
+)
+
+// rewindResync is how many rewound frames in a row the tick guard skips
+// before it accepts the lower tick as the demo's new timeline. A short
+// rewind is a glitch worth skipping; a long one is a backwards splice, and
+// waiting for the tick to pass the old maximum would drop the rest of the
+// demo.
+const rewindResync = 128
+
+// tickGuard keeps collectors from sampling a frame or event whose in-game
+// tick goes backwards. Collectors take time from the in-game tick, so a
+// frame that rewinds it (a corrupt or badly spliced demo) would feed them
+// negative time deltas. The frame counter can't be used for this: it counts
+// demo commands and always advances.
+//
+// A frame repeating the last tick is admitted: the demo format carries
+// several commands per tick, so repeats are routine, and collectors that
+// compare consecutive frames (FireSnapCollector, AimPullCollector) would
+// lose samples across a skipped one. Only rewinds are skipped, and they are
+// counted as integrity warnings. After rewindResync rewound frames in a row
+// the guard resyncs to the lower tick.
+type tickGuard struct {
+	last     int
+	rewinds  int
+	run      int
+	sampling bool
+}
+
+// admit reports whether a frame at tick should be collected. Ticks before
+// the first net tick (0 or less) are admitted without being tracked.
+func (g *tickGuard) admit(tick int) bool {
+	if tick <= 0 {
+		return true
+	}
+	if g.behind(tick) {
+		g.run++
+		if g.run < rewindResync {
+			g.rewinds++
+			return false
+		}
+	}
+	g.last, g.run, g.sampling = tick, 0, true
+	return true
+}
+
+// behind reports whether tick is before the last admitted one.
+func (g *tickGuard) behind(tick int) bool {
+	return g.sampling && tick > 0 && tick < g.last
+}
+
+// guardedParser is handed to collectors so that their event handlers are
+// skipped while the in-game tick is behind the guard, the same frames
+// collectFrame skips. Everything else is delegated unchanged.
+type guardedParser struct {
+	dem.Parser
+	guard *tickGuard
+}
+
+// RegisterEventHandler wraps handler so it is skipped on rewound ticks.
+func (p *guardedParser) RegisterEventHandler(handler any) dp.HandlerIdentifier {
+	return registerGated(p.Parser, handler, func() bool {
+		return !p.guard.behind(p.Parser.GameState().IngameTick())
+	})
+}
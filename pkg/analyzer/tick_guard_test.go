@@ -0,0 +1,154 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	dem "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+
+	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
+)
+
+func TestTickGuard(t *testing.T) {
+	var g tickGuard
+	cases := []struct {
+		tick int
+		want bool
+	}{
+		{0, true}, // before the first net tick
+		{100, true},
+		{100, true}, // second command on the same tick
+		{101, true},
+		{90, false}, // rewind
+		{95, false}, // still behind
+		{102, true},
+	}
+	for _, c := range cases {
+		if got := g.admit(c.tick); got != c.want {
+			t.Errorf("tick %d: admit = %v, want %v", c.tick, got, c.want)
+		}
+	}
+	if g.rewinds != 2 {
+		t.Errorf("rewinds = %d, want 2", g.rewinds)
+	}
+}
+
+func TestTickGuardResyncsAfterSustainedRewind(t *testing.T) {
+	var g tickGuard
+	g.admit(5000)
+
+	// A splice back to tick 100: skipped at first, then taken as the new
+	// timeline instead of dropping every frame until tick 5000.
+	tick := 100
+	for i := 1; i < rewindResync; i++ {
+		if g.admit(tick) {
+			t.Fatalf("rewound frame %d admitted before the resync", i)
+		}
+		tick++
+	}
+	if !g.admit(tick) {
+		t.Fatalf("frame %d of the rewind not admitted, want a resync", rewindResync)
+	}
+	if !g.admit(tick + 1) {
+		t.Error("frame after the resync not admitted")
+	}
+	if g.rewinds != rewindResync-1 {
+		t.Errorf("rewinds = %d, want %d", g.rewinds, rewindResync-1)
+	}
+
+	// A short rewind that catches up starts the count over
+	g.admit(tick - 10)
+	g.admit(tick + 2)
+	if g.run != 0 {
+		t.Errorf("run = %d after catching up, want 0", g.run)
+	}
+}
+
+func TestGuardedParserSkipsRewoundEvents(t *testing.T) {
+	stub := &rateStubParser{}
+	var g tickGuard
+	p := &guardedParser{Parser: stub, guard: &g}
+
+	fired := 0
+	p.RegisterEventHandler(func(events.Kill) { fired++ })
+	handler := stub.handlers[0].(func(events.Kill))
+
+	g.admit(1000)
+	for _, tick := range []int{1000, 990, 1001} {
+		stub.tick = tick
+		handler(events.Kill{})
+	}
+	if fired != 2 {
+		t.Errorf("handler fired %d times, want 2 (ticks 1000 and 1001)", fired)
+	}
+	if g.rewinds != 0 {
+		t.Errorf("rewinds = %d, want 0: only frames are counted", g.rewinds)
+	}
+}
+
+// guardStubParser replays a sequence of in-game ticks, one per demo frame.
+type guardStubParser struct {
+	dem.Parser
+	frame int
+	ticks []int
+}
+
+type guardStubState struct {
+	dem.GameState
+	tick int
+}
+
+type guardStubParticipants struct{ dem.Participants }
+
+func (p *guardStubParser) CurrentFrame() int { return p.frame }
+func (p *guardStubParser) CurrentTime() time.Duration {
+	return time.Duration(p.frame) * time.Second / 64
+}
+func (p *guardStubParser) GameState() dem.GameState {
+	return guardStubState{tick: p.ticks[p.frame-1]}
+}
+func (s guardStubState) IngameTick() int                { return s.tick }
+func (s guardStubState) IsWarmupPeriod() bool           { return false }
+func (s guardStubState) Participants() dem.Participants { return guardStubParticipants{} }
+func (guardStubParticipants) Playing() []*common.Player { return nil }
+
+// frameGapCollector counts collected frames that directly follow the
+// previously collected one, the check FireSnapCollector and
+// AimPullCollector make before comparing two frames.
+type frameGapCollector struct {
+	*stats.BaseCollector
+	prev     int
+	adjacent int
+}
+
+func (c *frameGapCollector) CollectFrame(parser dem.Parser, _ *stats.DemoStats) {
+	frame := parser.CurrentFrame()
+	if frame-c.prev == 1 {
+		c.adjacent++
+	}
+	c.prev = frame
+}
+
+func TestTickGuardKeepsRepeatedTicksForFrameCollectors(t *testing.T) {
+	// Two commands per tick, then a rewind of two frames.
+	parser := &guardStubParser{ticks: []int{100, 100, 101, 101, 102, 102, 90, 95, 103, 103}}
+	gaps := &frameGapCollector{BaseCollector: stats.NewBaseCollector("gaps")}
+	engagements := stats.NewEngagementTracker(stats.DefaultEngagementConfig())
+	demoStats := stats.NewDemoStats()
+
+	var ticks tickGuard
+	for parser.frame = 1; parser.frame <= len(parser.ticks); parser.frame++ {
+		collectFrame(parser, &ticks, engagements, []stats.Collector{gaps}, demoStats)
+	}
+
+	// Frames 1-6 and 9-10 are collected; only the pair across the rewind
+	// (6 → 9) isn't adjacent.
+	if gaps.adjacent != 7 {
+		t.Errorf("adjacent collected frames = %d, want 7", gaps.adjacent)
+	}
+	if ticks.rewinds != 2 {
+		t.Errorf("rewinds = %d, want 2", ticks.rewinds)
+	}
+}
@@ -0,0 +1,43 @@
+package analyzer
+
+import (
+	"time"
+
+	dem "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+	dp "github.com/markus-wa/godispatch"
+)
+
+// tickRateParser is handed to collectors in place of the real parser when a
+// tick rate override is set. Collectors read the rate in Setup and refresh
+// it from TickRateInfoAvailable, and time frames with CurrentTime; all three
+// report the override, so the demo's own rate reaches no collector.
+type tickRateParser struct {
+	dem.Parser
+	rate float64
+}
+
+// TickRate returns the override.
+func (p *tickRateParser) TickRate() float64 { return p.rate }
+
+// TickTime returns the duration of one tick at the override.
+func (p *tickRateParser) TickTime() time.Duration {
+	return time.Duration(float64(time.Second) / p.rate)
+}
+
+// CurrentTime returns the in-game tick's time at the override.
+func (p *tickRateParser) CurrentTime() time.Duration {
+	return time.Duration(float64(p.GameState().IngameTick()) / p.rate * float64(time.Second))
+}
+
+// RegisterEventHandler hands TickRateInfoAvailable handlers the override
+// instead of the demo's rate.
+func (p *tickRateParser) RegisterEventHandler(handler any) dp.HandlerIdentifier {
+	if h, ok := handler.(func(events.TickRateInfoAvailable)); ok {
+		return p.Parser.RegisterEventHandler(func(e events.TickRateInfoAvailable) {
+			e.TickRate, e.TickTime = p.rate, p.TickTime()
+			h(e)
+		})
+	}
+	return p.Parser.RegisterEventHandler(handler)
+}
@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	dem "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+	dp "github.com/markus-wa/godispatch"
+)
+
+// rateStubParser reports a 64-tick demo at a fixed in-game tick and keeps
+// the handlers registered on it.
+type rateStubParser struct {
+	dem.Parser
+	tick     int
+	handlers []any
+}
+
+type rateStubState struct {
+	dem.GameState
+	tick int
+}
+
+func (s rateStubState) IngameTick() int { return s.tick }
+
+func (p *rateStubParser) GameState() dem.GameState { return rateStubState{tick: p.tick} }
+func (p *rateStubParser) TickRate() float64        { return 64 }
+func (p *rateStubParser) RegisterEventHandler(h any) dp.HandlerIdentifier {
+	p.handlers = append(p.handlers, h)
+	return nil
+}
+
+func TestTickRateParser(t *testing.T) {
+	stub := &rateStubParser{tick: 1280}
+	p := &tickRateParser{Parser: stub, rate: 128}
+
+	if got := p.TickRate(); got != 128 {
+		t.Errorf("TickRate = %v, want 128", got)
+	}
+	if got := p.CurrentTime(); got != 10*time.Second {
+		t.Errorf("CurrentTime = %v, want 10s at 128 tick", got)
+	}
+
+	var seen events.TickRateInfoAvailable
+	p.RegisterEventHandler(func(e events.TickRateInfoAvailable) { seen = e })
+	stub.handlers[0].(func(events.TickRateInfoAvailable))(events.TickRateInfoAvailable{TickRate: 64, TickTime: time.Second / 64})
+	if seen.TickRate != 128 || seen.TickTime != time.Second/128 {
+		t.Errorf("handler saw %+v, want the override", seen)
+	}
+}
+
+func TestSetTickRateOverride(t *testing.T) {
+	a := NewAnalyzer("demo.dem")
+	a.SetTickRateOverride(128)
+	if a.tickRate != 128 {
+		t.Errorf("tickRate = %v, want 128", a.tickRate)
+	}
+	a.SetTickRateOverride(-1)
+	if a.tickRate != 0 {
+		t.Errorf("negative override left tickRate = %v, want it cleared", a.tickRate)
+	}
+}
@@ -0,0 +1,130 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
+)
+
+// PlayerTimeline is one player's results across several demos, oldest
+// first, for following a suspect over a run of matches: a headshot rate or
+// cheat likelihood that keeps creeping up shows in Trend.
+type PlayerTimeline struct {
+	SteamID uint64
+
+	// Name is the player's name in the latest demo.
+	Name string
+
+	Points []TimelinePoint
+
+	// Absent lists the demos that parsed but in which the player never
+	// appeared, in the order they were given.
+	Absent []string
+}
+
+// TimelinePoint is the player's result in one demo.
+type TimelinePoint struct {
+	Path string
+
+	// Played is the demo file's modification time. Demos don't record when
+	// they were played, so this is the best date available; copying a demo
+	// without preserving its mtime moves it in the timeline.
+	Played time.Time
+
+	Info DemoInfo
+
+	Kills              int
+	HeadshotPercentage float64 // 0–100
+
+	// Stats holds the typed aim, reaction, recoil and cheat-score metrics.
+	Stats stats.TypedPlayer
+}
+
+// AnalyzePlayerAcrossDemos analyzes each demo and returns steamID's results
+// in chronological order. Demos are analyzed in parallel, one per CPU.
+//
+// Each demo is analyzed in full and the player picked out afterwards, so
+// the likelihoods match what analyze reports for the same demo.
+//
+// Like AnalyzeMany, a demo that fails doesn't stop the others: the
+// returned error joins every per-file error and the timeline holds the
+// demos that succeeded.
+func AnalyzePlayerAcrossDemos(paths []string, steamID uint64) (PlayerTimeline, error) {
+	results, errs := analyzeEach(context.Background(), paths, runtime.GOMAXPROCS(0), NewAnalyzer)
+
+	timeline := PlayerTimeline{SteamID: steamID}
+	for i, path := range paths {
+		if errs[i] != nil {
+			continue
+		}
+		point, ok := timelinePoint(results[i], steamID)
+		if !ok {
+			timeline.Absent = append(timeline.Absent, path)
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			errs[i] = fmt.Errorf("%s: %w", path, err)
+			continue
+		}
+		point.Path, point.Played = path, fi.ModTime()
+		timeline.Points = append(timeline.Points, point)
+	}
+
+	sort.SliceStable(timeline.Points, func(i, j int) bool {
+		return timeline.Points[i].Played.Before(timeline.Points[j].Played)
+	})
+	if n := len(timeline.Points); n > 0 {
+		timeline.Name = timeline.Points[n-1].Stats.Name
+	}
+	return timeline, errors.Join(errs...)
+}
+
+// timelinePoint picks steamID's stats out of one demo's results.
+func timelinePoint(res Results, steamID uint64) (TimelinePoint, bool) {
+	ps, ok := res.DemoStats.Players[steamID]
+	if !ok {
+		return TimelinePoint{}, false
+	}
+	point := TimelinePoint{Info: res.Info}
+	if m, ok := ps.GetMetric(stats.CatKills, stats.KeyTotalKills); ok {
+		point.Kills = int(m.IntValue)
+	}
+	if m, ok := ps.GetMetric(stats.CatKills, stats.KeyHeadshotPercentage); ok {
+		point.HeadshotPercentage = m.FloatValue
+	}
+	for _, tp := range res.DemoStats.Typed().Players {
+		if tp.SteamID64 == steamID {
+			point.Stats = tp
+		}
+	}
+	return point, true
+}
+
+// Trend returns the least-squares slope of value across the timeline, in
+// units per demo: positive when it rises from match to match. Demos are
+// spaced evenly regardless of the time between them. With fewer than two
+// points there is no trend and it returns 0.
+//
+//	hs := timeline.Trend(func(p TimelinePoint) float64 { return p.HeadshotPercentage })
+func (t PlayerTimeline) Trend(value func(TimelinePoint) float64) float64 {
+	n := float64(len(t.Points))
+	if n < 2 {
+		return 0
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i, p := range t.Points {
+		x, y := float64(i), value(p)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+}
@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"math"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzePlayerAcrossDemosCollectsErrors(t *testing.T) {
+	paths := []string{"testdata/missing-a.dem", "testdata/missing-b.dem"}
+	timeline, err := AnalyzePlayerAcrossDemos(paths, 76561198000000001)
+	if err == nil {
+		t.Fatal("expected an error for missing demos")
+	}
+	for _, p := range paths {
+		if !strings.Contains(err.Error(), p) {
+			t.Errorf("error %q does not mention %s", err, p)
+		}
+	}
+	if len(timeline.Points) != 0 || len(timeline.Absent) != 0 {
+		t.Errorf("timeline = %+v, want empty", timeline)
+	}
+}
+
+func TestAnalyzePlayerAcrossDemosAbsentPlayer(t *testing.T) {
+	if _, err := os.Stat(wingmanDemoPath); err != nil {
+		t.Skipf("demo %s not present, skipping", wingmanDemoPath)
+	}
+	timeline, err := AnalyzePlayerAcrossDemos([]string{wingmanDemoPath}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timeline.Points) != 0 || len(timeline.Absent) != 1 {
+		t.Errorf("timeline = %+v, want the demo listed as absent", timeline)
+	}
+}
+
+func TestAnalyzePlayerAcrossDemosMatchesFullAnalysis(t *testing.T) {
+	scores := runAnalyze(t, wingmanDemoPath)
+	var want playerScore
+	for _, s := range scores {
+		want = s
+		break
+	}
+
+	timeline, err := AnalyzePlayerAcrossDemos([]string{wingmanDemoPath}, want.steamID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timeline.Points) != 1 {
+		t.Fatalf("timeline = %+v, want one point", timeline)
+	}
+	if got := timeline.Points[0].Stats.Cheat.Likelihood; got != want.likelihood {
+		t.Errorf("%s: timeline likelihood = %.2f, full analysis = %.2f", want.name, got, want.likelihood)
+	}
+}
+
+func TestPlayerTimelineTrend(t *testing.T) {
+	hs := func(p TimelinePoint) float64 { return p.HeadshotPercentage }
+	timeline := PlayerTimeline{Points: []TimelinePoint{
+		{HeadshotPercentage: 40},
+		{HeadshotPercentage: 50},
+		{HeadshotPercentage: 60},
+		{HeadshotPercentage: 70},
+	}}
+	if got := timeline.Trend(hs); math.Abs(got-10) > 1e-9 {
+		t.Errorf("Trend = %v, want 10 per demo", got)
+	}
+
+	timeline.Points = timeline.Points[:1]
+	if got := timeline.Trend(hs); got != 0 {
+		t.Errorf("Trend of one demo = %v, want 0", got)
+	}
+}
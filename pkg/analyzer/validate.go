@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	dem "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
+)
+
+// DefaultValidateFrames is the number of frames Validate parses when no
+// explicit limit is given. The file header, server info (tick rate) and
+// string tables all arrive well within the first few hundred frames.
+const DefaultValidateFrames = 512
+
+// ValidationResult describes whether a demo file could be opened and parsed
+// as a CS2 demo.
+type ValidationResult struct {
+	DemoPath     string
+	MapName      string
+	TickRate     float64
+	FramesParsed int
+	Err          error // nil when the demo is valid
+}
+
+// Valid reports whether the demo passed every check.
+func (r ValidationResult) Valid() bool {
+	return r.Err == nil
+}
+
+// Validate parses the header and up to maxFrames frames of a demo without
+// running any collectors. A demo is valid when it carries the CS2 file
+// stamp, its header names a map, and a tick rate is known by the time
+// parsing stops. A demo that ends before maxFrames is not an error.
+func Validate(demoPath string, maxFrames int) ValidationResult {
+	if maxFrames <= 0 {
+		maxFrames = DefaultValidateFrames
+	}
+	result := ValidationResult{DemoPath: demoPath}
+
+	f, err := os.Open(demoPath)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to open demo file: %w", err)
+		return result
+	}
+	defer f.Close()
+
+	demoStats := stats.NewDemoStats()
+	demoStats.DemoName = filepath.Base(demoPath)
+
+	parser := newDemoParser(f, demoStats)
+	defer parser.Close()
+
+	for result.FramesParsed < maxFrames {
+		ok, err := parser.ParseNextFrame()
+		if err != nil {
+			if errors.Is(err, dem.ErrInvalidFileType) {
+				result.Err = fmt.Errorf("not a CS2 demo: %w", err)
+			} else {
+				result.Err = fmt.Errorf("error parsing frame %d: %w", result.FramesParsed, err)
+			}
+			return result
+		}
+		if !ok {
+			break
+		}
+		result.FramesParsed++
+	}
+
+	result.MapName = demoStats.MapName
+	result.TickRate = parser.TickRate()
+
+	switch {
+	case result.MapName == "":
+		result.Err = errors.New("demo header has no map name")
+	case result.TickRate <= 0:
+		result.Err = errors.New("tick rate unknown")
+	}
+	return result
+}
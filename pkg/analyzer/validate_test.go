@@ -0,0 +1,49 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/timanthonyalexander/demo-anticheat/pkg/stats"
+)
+
+func TestValidate_RejectsNonDemo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bogus.dem")
+	if err := os.WriteFile(path, []byte("HL2DEMO\x00not a cs2 demo"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if r := Validate(path, 16); r.Valid() {
+		t.Fatalf("expected %s to be invalid", path)
+	}
+}
+
+func TestValidate_MissingFile(t *testing.T) {
+	if r := Validate(filepath.Join(t.TempDir(), "missing.dem"), 16); r.Valid() {
+		t.Fatal("expected missing file to be invalid")
+	}
+}
+
+func TestDefaultCollectorsSatisfyDependencies(t *testing.T) {
+	if err := stats.ValidateMetricDependencies(NewAnalyzer("x.dem").collectors); err != nil {
+		t.Fatalf("default collector set: %v", err)
+	}
+}
+
+func TestMissingProducerFailsAnalyze(t *testing.T) {
+	a := NewAnalyzer(filepath.Join(t.TempDir(), "missing.dem"))
+	kept := a.collectors[:0]
+	for _, c := range a.collectors {
+		if _, ok := c.(*stats.RecoilControlCollector); !ok {
+			kept = append(kept, c)
+		}
+	}
+	a.collectors = kept
+
+	_, err := a.Analyze()
+	want := "CheatDetector requires recoil/recoil_score but RecoilControlCollector is not registered"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("err = %v, want it to contain %q", err, want)
+	}
+}
@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"fmt"
+	"reflect"
+
+	dem "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+	dp "github.com/markus-wa/godispatch"
+)
+
+// analysisWindow restricts collection to a round and/or in-game tick range.
+// A zero end means "until the end of the demo"; a zero start means "from
+// the beginning".
+type analysisWindow struct {
+	startRound, endRound int
+	startTick, endTick   int
+
+	// round is the current round number (1-based), updated on RoundStart.
+	round int
+}
+
+// validate rejects negative bounds and ranges that end before they start.
+// A nil window is valid.
+func (w *analysisWindow) validate() error {
+	if w == nil {
+		return nil
+	}
+	if w.startRound < 0 || w.endRound < 0 || (w.endRound > 0 && w.endRound < w.startRound) {
+		return fmt.Errorf("invalid round range %d-%d", w.startRound, w.endRound)
+	}
+	if w.startTick < 0 || w.endTick < 0 || (w.endTick > 0 && w.endTick < w.startTick) {
+		return fmt.Errorf("invalid tick range %d-%d", w.startTick, w.endTick)
+	}
+	return nil
+}
+
+// active reports whether collection should run at the given in-game tick.
+func (w *analysisWindow) active(tick int) bool {
+	if w.startRound > 0 && w.round < w.startRound {
+		return false
+	}
+	if w.endRound > 0 && w.round > w.endRound {
+		return false
+	}
+	if w.startTick > 0 && tick < w.startTick {
+		return false
+	}
+	if w.endTick > 0 && tick > w.endTick {
+		return false
+	}
+	return true
+}
+
+// windowedParser is handed to collectors in place of the real parser when a
+// window is set. Event handlers registered through it only fire inside the
+// window; everything else is delegated unchanged, so the parser still
+// advances through the whole demo.
+type windowedParser struct {
+	dem.Parser
+	window *analysisWindow
+}
+
+// alwaysDispatched lists events that carry parser state rather than
+// gameplay, so collectors must see them even outside the window.
+var alwaysDispatched = map[reflect.Type]bool{
+	reflect.TypeOf(events.TickRateInfoAvailable{}): true,
+}
+
+// RegisterEventHandler wraps handler so it is skipped outside the window.
+func (p *windowedParser) RegisterEventHandler(handler any) dp.HandlerIdentifier {
+	return registerGated(p.Parser, handler, func() bool {
+		return p.window.active(p.Parser.GameState().IngameTick())
+	})
+}
+
+// registerGated registers handler on parser so that it only runs while open
+// returns true. Handlers for alwaysDispatched events, and anything that
+// isn't a one-argument func, are registered unchanged.
+func registerGated(parser dem.Parser, handler any, open func() bool) dp.HandlerIdentifier {
+	fn := reflect.ValueOf(handler)
+	if fn.Kind() != reflect.Func || fn.Type().NumIn() != 1 || alwaysDispatched[fn.Type().In(0)] {
+		return parser.RegisterEventHandler(handler)
+	}
+	wrapped := reflect.MakeFunc(fn.Type(), func(args []reflect.Value) []reflect.Value {
+		if !open() {
+			return nil
+		}
+		return fn.Call(args)
+	})
+	return parser.RegisterEventHandler(wrapped.Interface())
+}
+
+// track registers the round counter on the real parser. It must run before
+// collectors are set up so their RoundStart handlers see the new round.
+func (w *analysisWindow) track(parser dem.Parser) {
+	parser.RegisterEventHandler(func(_ events.RoundStart) {
+		w.round = parser.GameState().TotalRoundsPlayed() + 1
+	})
+}
@@ -0,0 +1,64 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+func TestAnalysisWindowActive(t *testing.T) {
+	w := &analysisWindow{startRound: 3, endRound: 5, startTick: 1000}
+
+	cases := []struct {
+		round, tick int
+		want        bool
+	}{
+		{2, 5000, false},
+		{3, 5000, true},
+		{5, 5000, true},
+		{6, 5000, false},
+		{4, 999, false},
+	}
+	for _, c := range cases {
+		w.round = c.round
+		if got := w.active(c.tick); got != c.want {
+			t.Errorf("round %d tick %d: active = %v, want %v", c.round, c.tick, got, c.want)
+		}
+	}
+
+	open := &analysisWindow{startRound: 20, round: 30}
+	if !open.active(0) {
+		t.Error("open-ended round range should include later rounds")
+	}
+}
+
+func TestAnalysisWindowValidate(t *testing.T) {
+	var nilWindow *analysisWindow
+	if err := nilWindow.validate(); err != nil {
+		t.Errorf("nil window: %v", err)
+	}
+	if err := (&analysisWindow{startRound: 5, endRound: 3}).validate(); err == nil {
+		t.Error("expected error for round range ending before it starts")
+	}
+	if err := (&analysisWindow{startTick: -1}).validate(); err == nil {
+		t.Error("expected error for negative tick")
+	}
+}
+
+func TestWindowedParserUsesIngameTick(t *testing.T) {
+	stub := &rateStubParser{}
+	p := &windowedParser{Parser: stub, window: &analysisWindow{startTick: 1000, endTick: 2000}}
+
+	fired := 0
+	p.RegisterEventHandler(func(events.Kill) { fired++ })
+	handler := stub.handlers[0].(func(events.Kill))
+
+	// The stub has no frame counter; only the in-game tick decides
+	for _, tick := range []int{999, 1000, 2000, 2001} {
+		stub.tick = tick
+		handler(events.Kill{})
+	}
+	if fired != 2 {
+		t.Errorf("handler fired %d times, want 2 (ticks 1000 and 2000)", fired)
+	}
+}
@@ -0,0 +1,347 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+const (
+	// accuracyCloseRange and accuracyLongRange split shots into close
+	// (< 500u), mid and long (>= 1500u) range buckets.
+	accuracyCloseRange = 500.0
+	accuracyLongRange  = 1500.0
+
+	// accuracyAimConeDeg is how far off an enemy's chest a shot may be and
+	// still count as aimed at them. Shots with nobody in the cone are spam
+	// or pre-fires and don't say anything about accuracy at any range.
+	accuracyAimConeDeg = 10.0
+
+	// accuracyMinShots is the number of aimed shots a bucket needs before
+	// its accuracy is published.
+	accuracyMinShots = 10
+)
+
+// Shot kinds for the crosshair-on-target rates: a first shot is one fired
+// after a pause longer than DefaultMaxBurstGap, a follow-up any later shot
+// of the same burst.
+const (
+	shotFirst = iota
+	shotFollowUp
+)
+
+// accuracyBucketKeys are the published keys, indexed by range bucket.
+var accuracyBucketKeys = [3]Key{"accuracy_close", "accuracy_mid", "accuracy_long"}
+
+// accuracyShot is one aimed bullet awaiting its hit or miss.
+type accuracyShot struct {
+	tick   int
+	bucket int
+	hit    bool
+}
+
+// accuracyPos is a player's position on the last sampled frame.
+type accuracyPos struct {
+	eye, head, chest r3.Vector
+	team             common.Team
+	alive            bool
+}
+
+// AccuracyCollector measures hit rate by range. Every gun shot is paired
+// with the enemy nearest the crosshair and bucketed by the distance to them;
+// a PlayerHurt from the shooter on the same or next tick turns it into a
+// hit. Human accuracy falls off with range — spread, recoil and smaller
+// targets all bite harder at distance — while an aimbot's stays flat, so
+// high accuracy_long next to accuracy_close is the tell.
+//
+// Positions are sampled every frame, so a shot is bucketed by where both
+// players stood on the tick it was fired.
+//
+// The same aimed shots also give crosshair_on_target_fire_rate: how often
+// the crosshair sat on an enemy's head or chest (within
+// preciseSnapTolerance) when a first shot was fired. Aimbots fire with the
+// crosshair on the target nearly every time; humans often click a moment
+// early or late. Follow-up shots of a spray drift off legitimately with
+// recoil, so they are reported apart as crosshair_on_target_spray_rate.
+type AccuracyCollector struct {
+	*BaseCollector
+
+	currentTick int
+	positions   map[uint64]accuracyPos
+
+	pending map[uint64][]accuracyShot
+	shots   map[uint64]*[3]int
+	hits    map[uint64]*[3]int
+
+	lastShotAt    map[uint64]time.Duration
+	onTargetShots map[uint64]*[2]int // aimed shots, by shot kind
+	onTarget      map[uint64]*[2]int // of those, fired with the crosshair on the enemy
+}
+
+// NewAccuracyCollector creates a new AccuracyCollector.
+func NewAccuracyCollector() *AccuracyCollector {
+	return &AccuracyCollector{
+		BaseCollector: NewBaseCollector("Accuracy by Range", CatAccuracy),
+		positions:     make(map[uint64]accuracyPos),
+		pending:       make(map[uint64][]accuracyShot),
+		shots:         make(map[uint64]*[3]int),
+		hits:          make(map[uint64]*[3]int),
+		lastShotAt:    make(map[uint64]time.Duration),
+		onTargetShots: make(map[uint64]*[2]int),
+		onTarget:      make(map[uint64]*[2]int),
+	}
+}
+
+// Setup registers the fire and hurt handlers.
+func (ac *AccuracyCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	parser.RegisterEventHandler(func(e events.WeaponFire) {
+		if inWarmup(parser) || !ac.countsWeapon(e.Weapon) {
+			return
+		}
+		if !demoStats.CountsAimPlayer(e.Shooter) {
+			return
+		}
+		ac.fire(demoStats.PlayerKey(e.Shooter), e.Shooter, demoTime(parser, parser.TickRate()))
+	})
+
+	parser.RegisterEventHandler(func(e events.PlayerHurt) {
+		if inWarmup(parser) || !ac.countsWeapon(e.Weapon) {
+			return
+		}
+		if e.Attacker == nil || e.Player == nil || e.Attacker.Team == e.Player.Team {
+			return
+		}
+		if !demoStats.CountsAimPlayer(e.Attacker) {
+			return
+		}
+		ac.hurt(demoStats.PlayerKey(e.Attacker), demoStats.PlayerKey(e.Player))
+	})
+}
+
+// countsWeapon keeps guns and drops knives, grenades and equipment.
+func (ac *AccuracyCollector) countsWeapon(w *common.Equipment) bool {
+	if w == nil || isKnife(w) {
+		return false
+	}
+	switch weaponClass(w) {
+	case "grenade", "equipment":
+		return false
+	}
+	return true
+}
+
+// fire records a shot by shooter (keyed sid) at time at.
+func (ac *AccuracyCollector) fire(sid uint64, shooter *common.Player, at time.Duration) {
+	yaw, pitch, ok := viewAngles(shooter)
+	if !ok {
+		return
+	}
+	ac.aim(sid, float64(yaw), signedPitch(float64(pitch)), at)
+}
+
+// aim pairs a shot fired along yaw/pitch (signed) at time at with the enemy
+// nearest the crosshair, and notes whether the crosshair was on an enemy.
+func (ac *AccuracyCollector) aim(sid uint64, yaw, pitch float64, at time.Duration) {
+	kind := shotFirst
+	if prev, ok := ac.lastShotAt[sid]; ok && at >= prev {
+		if at-prev < DefaultFireDedupWindow {
+			return // the same shot surfaced twice
+		}
+		if at-prev <= DefaultMaxBurstGap {
+			kind = shotFollowUp
+		}
+	}
+	ac.lastShotAt[sid] = at
+
+	from, ok := ac.positions[sid]
+	if !ok || !from.alive {
+		return
+	}
+	best, bestDist := accuracyAimConeDeg, -1.0
+	onTarget := false
+	for other, to := range ac.positions {
+		if other == sid || !to.alive || to.team == from.team {
+			continue
+		}
+		if off := aimResidualDeg(from.eye, yaw, pitch, to.chest); off <= best {
+			best, bestDist = off, to.chest.Sub(from.eye).Norm()
+		}
+		for _, target := range []r3.Vector{to.head, to.chest} {
+			if aimResidualDeg(from.eye, yaw, pitch, target) <= preciseSnapTolerance(target.Sub(from.eye).Norm()) {
+				onTarget = true
+			}
+		}
+	}
+	if bestDist < 0 {
+		return
+	}
+	ac.pending[sid] = append(ac.pending[sid], accuracyShot{tick: ac.currentTick, bucket: accuracyBucket(bestDist)})
+
+	if ac.onTargetShots[sid] == nil {
+		ac.onTargetShots[sid], ac.onTarget[sid] = &[2]int{}, &[2]int{}
+	}
+	ac.onTargetShots[sid][kind]++
+	if onTarget {
+		ac.onTarget[sid][kind]++
+	}
+}
+
+// hurt marks the attacker's latest unresolved shot as a hit, re-bucketed by
+// the distance to the player actually hit. Further hits with every pending
+// shot already a hit are shotgun pellets or one bullet through two players
+// and don't add shots. A hit with no pending shot at all (the victim was
+// outside the cone on the sampled frame) is counted as an aimed shot that
+// hit.
+func (ac *AccuracyCollector) hurt(attacker, victim uint64) {
+	bucket := 1
+	from, okFrom := ac.positions[attacker]
+	to, okTo := ac.positions[victim]
+	if okFrom && okTo {
+		bucket = accuracyBucket(to.chest.Sub(from.eye).Norm())
+	}
+	shots := ac.pending[attacker]
+	for i := len(shots) - 1; i >= 0; i-- {
+		if !shots[i].hit {
+			shots[i].hit, shots[i].bucket = true, bucket
+			return
+		}
+	}
+	if len(shots) > 0 {
+		return
+	}
+	ac.pending[attacker] = append(shots, accuracyShot{tick: ac.currentTick, bucket: bucket, hit: true})
+}
+
+// accuracyBucket maps a distance in units to its range bucket.
+func accuracyBucket(dist float64) int {
+	switch {
+	case dist < accuracyCloseRange:
+		return 0
+	case dist < accuracyLongRange:
+		return 1
+	}
+	return 2
+}
+
+// CollectFrame resolves shots from earlier ticks and samples every player's
+// position for the next frame's shots.
+func (ac *AccuracyCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	ac.currentTick = parser.CurrentFrame()
+	ac.resolve(ac.currentTick)
+
+	clear(ac.positions)
+	for _, p := range parser.GameState().Participants().Playing() {
+		key := demoStats.PlayerKey(p)
+		if key == 0 {
+			continue
+		}
+		head := headPosition(p)
+		feet := p.Position()
+		ac.positions[key] = accuracyPos{
+			eye:   eyePosition(p),
+			head:  head,
+			chest: feet.Add(head.Sub(feet).Mul(0.7)),
+			team:  p.Team,
+			alive: p.IsAlive(),
+		}
+	}
+}
+
+// resolve tallies pending shots fired before tick; a shot's hit can arrive
+// on the tick it was fired or the one after.
+func (ac *AccuracyCollector) resolve(tick int) {
+	for sid, shots := range ac.pending {
+		kept := shots[:0]
+		for _, s := range shots {
+			if s.tick >= tick-1 {
+				kept = append(kept, s)
+				continue
+			}
+			ac.tally(sid, s)
+		}
+		ac.pending[sid] = kept
+	}
+}
+
+// tally counts one resolved shot.
+func (ac *AccuracyCollector) tally(sid uint64, s accuracyShot) {
+	if ac.shots[sid] == nil {
+		ac.shots[sid], ac.hits[sid] = &[3]int{}, &[3]int{}
+	}
+	ac.shots[sid][s.bucket]++
+	if s.hit {
+		ac.hits[sid][s.bucket]++
+	}
+}
+
+// CollectFinalStats publishes the hit rate of every bucket, and the
+// crosshair-on-target rate of first and follow-up shots, with at least
+// accuracyMinShots aimed shots.
+func (ac *AccuracyCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, shots := range ac.pending {
+		for _, s := range shots {
+			ac.tally(sid, s)
+		}
+	}
+	ac.pending = make(map[uint64][]accuracyShot)
+
+	for sid, shots := range ac.shots {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		total := shots[0] + shots[1] + shots[2]
+		ps.AddMetric(CatAccuracy, KeyAimedShots, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(total),
+			Description: "Gun shots fired with an enemy near the crosshair",
+		})
+		for b, key := range accuracyBucketKeys {
+			if shots[b] < accuracyMinShots {
+				continue
+			}
+			ps.AddMetric(CatAccuracy, key, Metric{
+				Type:        MetricPercentage,
+				FloatValue:  float64(ac.hits[sid][b]) / float64(shots[b]) * 100.0,
+				Description: accuracyDescriptions[b],
+			})
+		}
+	}
+
+	for sid, shots := range ac.onTargetShots {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		for kind, key := range onTargetKeys {
+			if shots[kind] < accuracyMinShots {
+				continue
+			}
+			ps.AddMetric(CatAccuracy, key, Metric{
+				Type:        MetricPercentage,
+				FloatValue:  float64(ac.onTarget[sid][kind]) / float64(shots[kind]) * 100.0,
+				Description: onTargetDescriptions[kind],
+			})
+		}
+	}
+}
+
+// onTargetKeys are the crosshair-on-target keys, indexed by shot kind.
+var onTargetKeys = [2]Key{KeyCrosshairOnTargetFireRate, KeyCrosshairOnTargetSprayRate}
+
+// onTargetDescriptions describe onTargetKeys.
+var onTargetDescriptions = [2]string{
+	"Aimed first shots fired with the crosshair on an enemy's head or chest",
+	"Aimed follow-up shots of a spray fired with the crosshair on an enemy",
+}
+
+// accuracyDescriptions describe the bucket metrics, indexed like
+// accuracyBucketKeys.
+var accuracyDescriptions = [3]string{
+	"Hit rate of aimed shots under 500 units",
+	"Hit rate of aimed shots from 500 to 1500 units",
+	"Hit rate of aimed shots beyond 1500 units",
+}
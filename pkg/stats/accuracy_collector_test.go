@@ -0,0 +1,98 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+)
+
+func TestAccuracyBucket(t *testing.T) {
+	for dist, want := range map[float64]int{0: 0, 499: 0, 500: 1, 1499: 1, 1500: 2, 4000: 2} {
+		if got := accuracyBucket(dist); got != want {
+			t.Errorf("accuracyBucket(%v) = %d, want %d", dist, got, want)
+		}
+	}
+}
+
+func TestAccuracyHitResolution(t *testing.T) {
+	ac := NewAccuracyCollector()
+	ac.positions[1] = accuracyPos{alive: true}
+	ac.positions[2] = accuracyPos{alive: true}
+
+	// Two shots on tick 10, one hit; a second pellet of the same volley
+	// doesn't add a shot
+	ac.currentTick = 10
+	ac.pending[1] = []accuracyShot{{tick: 10, bucket: 2}, {tick: 10, bucket: 2}}
+	ac.hurt(1, 2)
+	ac.hurt(1, 2)
+	ac.hurt(1, 2)
+
+	ac.resolve(11)
+	if len(ac.pending[1]) != 2 {
+		t.Fatalf("shots resolved before their grace tick: %+v", ac.pending[1])
+	}
+	ac.resolve(12)
+	if got := ac.shots[1]; got == nil || got[0] != 2 || ac.hits[1][0] != 2 {
+		t.Errorf("shots %v hits %v, want two close-range hits", ac.shots[1], ac.hits[1])
+	}
+
+	// A hit with nothing pending counts as one aimed shot
+	ac.hurt(1, 2)
+	ac.resolve(20)
+	if ac.shots[1][0] != 3 || ac.hits[1][0] != 3 {
+		t.Errorf("unpaired hit: shots %v hits %v", ac.shots[1], ac.hits[1])
+	}
+}
+
+func TestAccuracyPublishes(t *testing.T) {
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(7)
+	ac := NewAccuracyCollector()
+	ac.shots[7] = &[3]int{20, 5, 10}
+	ac.hits[7] = &[3]int{10, 5, 9}
+	ac.CollectFinalStats(ds)
+
+	ps := ds.Players[7]
+	if v, _ := psGetFloat(ps, CatAccuracy, "accuracy_close"); v != 50 {
+		t.Errorf("accuracy_close = %v, want 50", v)
+	}
+	if v, _ := psGetFloat(ps, CatAccuracy, "accuracy_long"); v != 90 {
+		t.Errorf("accuracy_long = %v, want 90", v)
+	}
+	if _, ok := ps.GetMetric(CatAccuracy, "accuracy_mid"); ok {
+		t.Error("accuracy_mid published below accuracyMinShots")
+	}
+}
+
+func TestCrosshairOnTargetRates(t *testing.T) {
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(1)
+	ac := NewAccuracyCollector()
+	ac.positions[1] = accuracyPos{alive: true, team: 2}
+	ac.positions[2] = accuracyPos{alive: true, team: 3, head: r3.Vector{X: 1000}, chest: r3.Vector{X: 1000, Z: -20}}
+
+	at := time.Duration(0)
+	// 10 first shots a second apart, 8 with the crosshair on the head.
+	for i := 0; i < 10; i++ {
+		at += time.Second
+		yaw := 0.0
+		if i < 2 {
+			yaw = 4 // aimed near the enemy but off the hitbox
+		}
+		ac.aim(1, yaw, 0, at)
+		// Each opens a spray of follow-ups drifting off target; the
+		// duplicate event on the same tick isn't another shot.
+		ac.aim(1, 0, 0, at+10*time.Millisecond)
+		ac.aim(1, 5, 0, at+100*time.Millisecond)
+	}
+	ac.CollectFinalStats(ds)
+
+	ps := ds.Players[1]
+	if v, _ := psGetFloat(ps, CatAccuracy, KeyCrosshairOnTargetFireRate); v != 80 {
+		t.Errorf("crosshair_on_target_fire_rate = %v, want 80", v)
+	}
+	if v, _ := psGetFloat(ps, CatAccuracy, KeyCrosshairOnTargetSprayRate); v != 0 {
+		t.Errorf("crosshair_on_target_spray_rate = %v, want 0", v)
+	}
+}
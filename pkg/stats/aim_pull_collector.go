@@ -0,0 +1,180 @@
+package stats
+
+import (
+	"math"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+const (
+	// pullMinMoveDeg is the smallest one-tick view movement that has a
+	// direction worth comparing; below it the delta is mouse noise.
+	pullMinMoveDeg = 0.05
+
+	// pullMaxOffsetDeg is how far off the crosshair an enemy may be and
+	// still count as the one being pulled toward. Soft aimbots act inside a
+	// small field of view.
+	pullMaxOffsetDeg = 30.0
+
+	// pullFireQuiet is how long after a shot a player's view movement is
+	// left out: recoil control and follow-up aim aren't free movement.
+	pullFireQuiet = 500 * time.Millisecond
+
+	// pullMinSamples is the number of moving, non-firing ticks with an
+	// enemy in view before pull_bias is published — two seconds at 64 tick.
+	pullMinSamples = 128
+)
+
+// AimPullCollector looks for the soft aimbot's continuous pull: while a
+// player isn't shooting, each tick's view movement is compared with the
+// direction from the previous view to the nearest visible enemy. The cosine
+// between the two is +1 when the view moves straight at the enemy, −1 when
+// it moves straight away, and averages near 0 for movement that ignores the
+// enemy.
+//
+//   - pull_samples: moving, non-firing ticks with a spotted enemy within
+//     pullMaxOffsetDeg of the crosshair.
+//   - pull_bias: the mean cosine over those ticks, once there are
+//     pullMinSamples. Tracking an enemy by hand also reads positive, so
+//     compare it across the lobby; a clear outlier is what a pull looks like.
+type AimPullCollector struct {
+	*BaseCollector
+
+	// views keeps the last two frames of each player's view.
+	views    map[uint64]*RingBuffer
+	lastFire map[uint64]time.Duration
+
+	sum     map[uint64]float64
+	samples map[uint64]int
+}
+
+// NewAimPullCollector creates a new AimPullCollector.
+func NewAimPullCollector() *AimPullCollector {
+	return &AimPullCollector{
+		BaseCollector: NewBaseCollector("Aim Pull", CatAiming),
+		views:         make(map[uint64]*RingBuffer),
+		lastFire:      make(map[uint64]time.Duration),
+		sum:           make(map[uint64]float64),
+		samples:       make(map[uint64]int),
+	}
+}
+
+// Setup records each player's latest shot.
+func (ac *AimPullCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	parser.RegisterEventHandler(func(e events.WeaponFire) {
+		if !demoStats.CountsAimPlayer(e.Shooter) {
+			return
+		}
+		ac.lastFire[demoStats.PlayerKey(e.Shooter)] = demoTime(parser, parser.TickRate())
+	})
+}
+
+// CollectFrame compares every alive player's view movement since the last
+// frame with the direction to the nearest spotted enemy.
+func (ac *AimPullCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	if inWarmup(parser) {
+		return
+	}
+	frame := parser.CurrentFrame()
+	now := demoTime(parser, parser.TickRate())
+	playing := parser.GameState().Participants().Playing()
+	for _, player := range playing {
+		if !demoStats.CountsAimPlayer(player) || !player.IsAlive() {
+			continue
+		}
+		yaw, pitch, ok := viewAngles(player)
+		if !ok {
+			continue
+		}
+		sid := demoStats.PlayerKey(player)
+		buf, ok := ac.views[sid]
+		if !ok {
+			buf = NewRingBuffer(2)
+			ac.views[sid] = buf
+		}
+		prev := buf.GetLast(1)[0]
+		cur := ViewAngleSnapshot{Tick: frame, Yaw: yaw, Pitch: pitch}
+		buf.Add(cur)
+		if prev.Tick != frame-1 {
+			continue // no view on the frame right before
+		}
+		if shot, ok := ac.lastFire[sid]; ok && now-shot < pullFireQuiet {
+			continue
+		}
+
+		eye := eyePosition(player)
+		var target r3.Vector
+		best := math.Inf(1)
+		for _, enemy := range playing {
+			if enemy == nil || !enemy.IsAlive() || enemy.Team == player.Team || !enemy.IsSpottedBy(player) {
+				continue
+			}
+			head := headPosition(enemy)
+			if off := aimResidualDeg(eye, float64(prev.Yaw), signedPitch(float64(prev.Pitch)), head); off < best {
+				best, target = off, head
+			}
+		}
+		if math.IsInf(best, 1) {
+			continue
+		}
+		if cos, ok := pullCosine(eye, prev, cur, target); ok {
+			ac.sum[sid] += cos
+			ac.samples[sid]++
+		}
+	}
+}
+
+// pullCosine returns the cosine between the view's movement from before to
+// after and the direction from before to target, both in view-angle space
+// with yaw scaled by the cosine of the pitch. ok is false when the view
+// barely moved, or when target is already under the crosshair (within
+// preciseSnapTolerance) or more than pullMaxOffsetDeg away.
+func pullCosine(eye r3.Vector, before, after ViewAngleSnapshot, target r3.Vector) (cos float64, ok bool) {
+	d := target.Sub(eye)
+	horiz := math.Hypot(d.X, d.Y)
+	if horiz == 0 && d.Z == 0 {
+		return 0, false
+	}
+	targetYaw := math.Atan2(d.Y, d.X) * 180.0 / math.Pi
+	targetPitch := -math.Atan2(d.Z, horiz) * 180.0 / math.Pi
+
+	fromPitch := signedPitch(float64(before.Pitch))
+	scale := math.Cos(fromPitch * math.Pi / 180.0)
+	moveYaw := math.Remainder(float64(after.Yaw)-float64(before.Yaw), 360) * scale
+	movePitch := signedPitch(float64(after.Pitch)) - fromPitch
+	offYaw := math.Remainder(targetYaw-float64(before.Yaw), 360) * scale
+	offPitch := targetPitch - fromPitch
+
+	move, off := math.Hypot(moveYaw, movePitch), math.Hypot(offYaw, offPitch)
+	if move < pullMinMoveDeg || off > pullMaxOffsetDeg || off <= preciseSnapTolerance(d.Norm()) {
+		return 0, false
+	}
+	return (moveYaw*offYaw + movePitch*offPitch) / (move * off), true
+}
+
+// CollectFinalStats publishes pull_samples for every sampled player and
+// pull_bias for those with at least pullMinSamples.
+func (ac *AimPullCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, n := range ac.samples {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		ps.AddMetric(CatAiming, KeyPullSamples, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(n),
+			Description: "Moving, non-firing ticks with a spotted enemy near the crosshair",
+		})
+		if n < pullMinSamples {
+			continue
+		}
+		ps.AddMetric(CatAiming, KeyPullBias, Metric{
+			Type:        MetricFloat,
+			FloatValue:  ac.sum[sid] / float64(n),
+			Description: "Mean cosine between view movement and the direction to the nearest enemy (-1 to 1)",
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+)
+
+func TestPullCosine(t *testing.T) {
+	eye := r3.Vector{}
+	target := r3.Vector{X: 1000} // straight ahead: yaw 0, pitch 0
+	view := func(yaw, pitch float32) ViewAngleSnapshot { return ViewAngleSnapshot{Yaw: yaw, Pitch: pitch} }
+
+	cases := []struct {
+		name          string
+		before, after ViewAngleSnapshot
+		want          float64
+		ok            bool
+	}{
+		{"toward across the yaw seam", view(10, 0), view(9.5, 0), 1, true},
+		{"toward from the right", view(350, 0), view(350.5, 0), 1, true},
+		{"away", view(10, 0), view(10.5, 0), -1, true},
+		{"perpendicular", view(10, 0), view(10, 0.5), 0, true},
+		{"looking up, moving down", view(0, 350), view(0, 350.5), 1, true},
+		{"looking up, moving further up", view(0, 350), view(0, 349.5), -1, true},
+		{"barely moving", view(10, 0), view(10.01, 0), 0, false},
+		{"already on target", view(0.3, 0), view(0.1, 0), 0, false},
+		{"target out of range", view(90, 0), view(89, 0), 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := pullCosine(eye, tc.before, tc.after, target)
+		if ok != tc.ok || (ok && math.Abs(got-tc.want) > 1e-3) {
+			t.Errorf("%s: pullCosine = %.3f, %v; want %.3f, %v", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestAimPullFinalStats(t *testing.T) {
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(1)
+	ds.GetOrCreatePlayerStatsBySteamID(2)
+	ac := NewAimPullCollector()
+	ac.sum[1], ac.samples[1] = 0.5*pullMinSamples, pullMinSamples
+	ac.sum[2], ac.samples[2] = 3, pullMinSamples-1
+	ac.CollectFinalStats(ds)
+
+	if v, ok := psGetFloat(ds.Players[1], CatAiming, KeyPullBias); !ok || v != 0.5 {
+		t.Errorf("pull_bias = %v, %v; want 0.5", v, ok)
+	}
+	if _, ok := ds.Players[2].GetMetric(CatAiming, KeyPullBias); ok {
+		t.Error("pull_bias published below pullMinSamples")
+	}
+	if n := intMetric(ds.Players[2], CatAiming, KeyPullSamples); n != pullMinSamples-1 {
+		t.Errorf("pull_samples = %d, want %d", n, pullMinSamples-1)
+	}
+}
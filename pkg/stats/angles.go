@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// legacySnapScale is the factor snap velocities were inflated by while the
+// snap collector converted view angles as if they were radians (×57.3).
+// The snap thresholds were fit on those values; dividing by it keeps their
+// calibration in real °/ms until they are refit on the corpus.
+const legacySnapScale = 57.29578
+
+// legacyRecoilScale is the factor recoil errors were off by under the same
+// mistake: ×57.3 for the radians, then ×0.01 to pull the result back into a
+// human-looking range. Recoil thresholds fit on those values divide by it.
+const legacyRecoilScale = legacySnapScale * 0.01
+
+// viewAngles reads a player's view direction. demoinfocs reports it in
+// degrees — yaw 0–360, pitch 270–90 where 270 is 90° up — so collectors
+// compare the values with angleDiffDeg and never convert from radians. ok
+// is false when the pawn isn't readable.
+func viewAngles(p *common.Player) (yaw, pitch float32, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+	return p.ViewDirectionX(), p.ViewDirectionY(), true
+}
+
+// angleDiffDeg calculates the shortest angular difference between two angles in degrees
+func angleDiffDeg(a, b float64) float64 {
+	diff := math.Mod(b-a+180, 360) - 180
+	if diff < -180 {
+		diff += 360
+	}
+	return math.Abs(diff)
+}
+
+// normalizeAngle ensures an angle is between 0 and 360 degrees
+func normalizeAngle(angle float64) float64 {
+	// Normalize to 0-360 range
+	return math.Mod(math.Mod(angle, 360)+360, 360)
+}
+
+// angularDistance combines the wrapped yaw and pitch differences between
+// two view directions, in degrees.
+func angularDistance(yaw1, pitch1, yaw2, pitch2 float64) float64 {
+	return math.Hypot(angleDiffDeg(yaw1, yaw2), angleDiffDeg(pitch1, pitch2))
+}
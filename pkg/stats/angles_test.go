@@ -0,0 +1,37 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+// TestNinetyDegreeTurn feeds the same 90° turns, as demoinfocs reports view
+// angles, through the snap and the recoil measurement.
+func TestNinetyDegreeTurn(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		yaw1, pitch1 float32
+		yaw2, pitch2 float32
+	}{
+		{"yaw", 10, 0, 100, 0},
+		{"yaw across 0", 315, 0, 45, 0},
+		{"pitch up", 0, 0, 0, 270},
+	} {
+		snap := snapAngle(ViewAngleSnapshot{Yaw: tc.yaw1, Pitch: tc.pitch1}, ViewAngleSnapshot{Yaw: tc.yaw2, Pitch: tc.pitch2})
+		if math.Abs(snap-90) > 1e-4 {
+			t.Errorf("%s: snap angle = %v, want 90", tc.name, snap)
+		}
+		recoil := bulletError(normalizeAngle(float64(tc.yaw1)), normalizeAngle(float64(tc.pitch1)), 0, 0,
+			normalizeAngle(float64(tc.yaw2)), normalizeAngle(float64(tc.pitch2)))
+		if math.Abs(recoil-90) > 1e-4 {
+			t.Errorf("%s: recoil error = %v, want 90", tc.name, recoil)
+		}
+	}
+}
+
+func TestBulletErrorCancelsOffset(t *testing.T) {
+	// Pulling down by exactly the pattern's pitch offset is a perfect bullet.
+	if got := bulletError(90, 0, 0, 2.8, 90, 357.2); got > 1e-9 {
+		t.Errorf("compensated bullet error = %v, want 0", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"sort"
 
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
 )
 
@@ -21,11 +22,21 @@ import (
 //     attention drifts toward enemies they can't legally see.
 //
 // All three metrics are computed without map BSP / line-of-sight data using
-// only positional and view-angle information from the demo.
+// only positional and view-angle information from the demo. On POV demos all
+// three read view angles only the recorder has (see CountsAimPlayer): the
+// back-kill rate is taken on the recorder's deaths, pre-FOV and attention on
+// the recorder's view.
+//
+// "In FOV" is a fovEntryDegrees cone around the crosshair. The attention
+// metric narrows it while a player is scoped (see ScopeZoom): an enemy 4°
+// off an AWP's crosshair is outside the scope, so that frame isn't an
+// engagement to skip. The pre-FOV cone stays unscoped: narrowing it would
+// move the FOV entry closer to the kill and lower every AWPer's
+// pre_fov_aim_median, which is calibrated on the unscoped cone.
 
 const (
 	// fovEntryDegrees is the half-angle (deg) where we consider a target
-	// "in FOV" of a player — matches the reaction collector's threshold.
+	// "in FOV" of an unscoped player.
 	fovEntryDegrees = 5.0
 	// preFOVLookbackMs is how far before FOV-entry we sample the killer's
 	// crosshair-to-victim angle.
@@ -49,6 +60,34 @@ const (
 	minAttentionSamples = 200
 )
 
+// ScopeZoom is how many times a scope narrows the FOV cone at each zoom
+// level: at Half the cone is fovEntryDegrees/Half. A factor of 1 or less
+// leaves the cone unscoped.
+type ScopeZoom struct {
+	Half, Full float64
+}
+
+// DefaultScopeZoom returns 2x for the first zoom level and 4x for the
+// second.
+func DefaultScopeZoom() ScopeZoom {
+	return ScopeZoom{Half: 2, Full: 4}
+}
+
+// factor returns the cone divisor for a player at zoom level, 1 when not
+// scoped.
+func (z ScopeZoom) factor(scoped bool, level common.ZoomLevel) float64 {
+	f := 1.0
+	if scoped {
+		switch level {
+		case common.ZoomHalf:
+			f = z.Half
+		case common.ZoomFull:
+			f = z.Full
+		}
+	}
+	return math.Max(f, 1)
+}
+
 // playerSnapshot captures view direction + eye-level position at a tick.
 type playerSnapshot struct {
 	tick  int
@@ -76,12 +115,15 @@ type BehavioralCollector struct {
 	backKillGivenBack  map[uint64]int // kills where victim was looking away from this killer
 	preFOVAngles       map[uint64][]float64
 	attentionMin       map[uint64][]float64
+
+	// Zoom narrows the FOV cone of scoped players; see ScopeZoom.
+	Zoom ScopeZoom
 }
 
 // NewBehavioralCollector creates a new BehavioralCollector.
 func NewBehavioralCollector() *BehavioralCollector {
 	return &BehavioralCollector{
-		BaseCollector:      NewBaseCollector("Behavioral Wallhack Signals", Category("behavioral")),
+		BaseCollector:      NewBaseCollector("Behavioral Wallhack Signals", CatBehavioral),
 		history:            make(map[uint64][]playerSnapshot),
 		backKillTotal:      make(map[uint64]int),
 		backKillBack:       make(map[uint64]int),
@@ -89,6 +131,7 @@ func NewBehavioralCollector() *BehavioralCollector {
 		backKillGivenBack:  make(map[uint64]int),
 		preFOVAngles:       make(map[uint64][]float64),
 		attentionMin:       make(map[uint64][]float64),
+		Zoom:               DefaultScopeZoom(),
 	}
 }
 
@@ -105,7 +148,7 @@ func (bc *BehavioralCollector) Setup(parser demoinfocs.Parser, demoStats *DemoSt
 	})
 
 	parser.RegisterEventHandler(func(e events.Kill) {
-		bc.handleKill(e)
+		bc.handleKill(e, demoStats)
 	})
 }
 
@@ -118,7 +161,8 @@ func (bc *BehavioralCollector) CollectFrame(parser demoinfocs.Parser, demoStats
 
 	// Snapshot every alive player into rolling history.
 	for _, p := range playing {
-		if p == nil || p.SteamID64 == 0 || !p.IsAlive() {
+		key := demoStats.PlayerKey(p)
+		if key == 0 || !p.IsAlive() {
 			continue
 		}
 		pos := p.Position()
@@ -130,12 +174,12 @@ func (bc *BehavioralCollector) CollectFrame(parser demoinfocs.Parser, demoStats
 			posY:  pos.Y,
 			posZ:  pos.Z,
 		}
-		buf := bc.history[p.SteamID64]
+		buf := bc.history[key]
 		buf = append(buf, snap)
 		if len(buf) > behavioralBufferTicks {
 			buf = buf[len(buf)-behavioralBufferTicks:]
 		}
-		bc.history[p.SteamID64] = buf
+		bc.history[key] = buf
 	}
 
 	// Off-engagement attention: for each alive player, find the smallest
@@ -143,18 +187,19 @@ func (bc *BehavioralCollector) CollectFrame(parser demoinfocs.Parser, demoStats
 	// no enemy is currently in FOV (>= fovEntryDegrees from the closest one),
 	// so we measure attention drift, not active engagements.
 	for _, attacker := range playing {
-		if attacker == nil || attacker.SteamID64 == 0 || !attacker.IsAlive() {
+		if !demoStats.CountsAimPlayer(attacker) || !attacker.IsAlive() {
 			continue
 		}
+		attackerID := demoStats.PlayerKey(attacker)
 		viewVec := viewDirectionToVector(float64(attacker.ViewDirectionX()), float64(attacker.ViewDirectionY()))
 		attackerPos := attacker.Position()
 
 		minAngle := 180.0
 		for _, opponent := range playing {
-			if opponent == nil || opponent.SteamID64 == 0 || !opponent.IsAlive() {
+			if opponent == nil || demoStats.PlayerKey(opponent) == 0 || !opponent.IsAlive() {
 				continue
 			}
-			if opponent.Team == attacker.Team || opponent.SteamID64 == attacker.SteamID64 {
+			if opponent.Team == attacker.Team || opponent == attacker {
 				continue
 			}
 			oppPos := opponent.Position()
@@ -166,28 +211,36 @@ func (bc *BehavioralCollector) CollectFrame(parser demoinfocs.Parser, demoStats
 
 		// Skip frames where attacker is actively in an engagement; those
 		// are the legitimate "look at the enemy you're shooting at" frames.
-		if minAngle < fovEntryDegrees {
+		if minAngle < fovEntryDegrees/bc.zoom(attacker) {
 			continue
 		}
-		bc.attentionMin[attacker.SteamID64] = append(bc.attentionMin[attacker.SteamID64], minAngle)
+		bc.attentionMin[attackerID] = append(bc.attentionMin[attackerID], minAngle)
+	}
+}
+
+// zoom returns p's ScopeZoom factor this frame.
+func (bc *BehavioralCollector) zoom(p *common.Player) float64 {
+	var level common.ZoomLevel
+	if w := p.ActiveWeapon(); w != nil {
+		level = w.ZoomLevel()
 	}
+	return bc.Zoom.factor(p.IsScoped(), level)
 }
 
 // handleKill computes back-kill rate and pre-FOV pre-aim angle for the killer.
-func (bc *BehavioralCollector) handleKill(e events.Kill) {
+func (bc *BehavioralCollector) handleKill(e events.Kill, demoStats *DemoStats) {
 	if e.Killer == nil || e.Victim == nil {
 		return
 	}
 	if e.Killer.Team == e.Victim.Team {
 		return // ignore team kills
 	}
-	if e.Killer.SteamID64 == 0 || e.Victim.SteamID64 == 0 {
+	killerID := demoStats.PlayerKey(e.Killer)
+	victimID := demoStats.PlayerKey(e.Victim)
+	if killerID == 0 || victimID == 0 {
 		return
 	}
 
-	killerID := e.Killer.SteamID64
-	victimID := e.Victim.SteamID64
-
 	// --- Back-kill metric (charged to both sides) --------------------
 	// Was the victim looking away from the killer at the moment of death?
 	// The same yes/no answer counts as evidence on opposite directions:
@@ -196,31 +249,41 @@ func (bc *BehavioralCollector) handleKill(e events.Kill) {
 	//   - killer side: high rate = kills mostly from behind = could mean either
 	//                  preferred flanking style OR a wallhacker exploiting
 	//                  positional info to approach unseen.
-	killerPos := e.Killer.Position()
-	victimPos := e.Victim.Position()
-	victimView := viewDirectionToVector(float64(e.Victim.ViewDirectionX()), float64(e.Victim.ViewDirectionY()))
-	angVictimToKiller := angleBetweenViewAndTarget(victimView, victimPos.X, victimPos.Y, victimPos.Z, killerPos.X, killerPos.Y, killerPos.Z)
-	bc.backKillTotal[victimID]++
-	bc.backKillGivenTotal[killerID]++
-	if angVictimToKiller >= backKillThresholdDeg {
-		bc.backKillBack[victimID]++
-		bc.backKillGivenBack[killerID]++
+	if demoStats.CountsAimPlayer(e.Victim) {
+		killerPos := e.Killer.Position()
+		victimPos := e.Victim.Position()
+		victimView := viewDirectionToVector(float64(e.Victim.ViewDirectionX()), float64(e.Victim.ViewDirectionY()))
+		angVictimToKiller := angleBetweenViewAndTarget(victimView, victimPos.X, victimPos.Y, victimPos.Z, killerPos.X, killerPos.Y, killerPos.Z)
+		bc.backKillTotal[victimID]++
+		bc.backKillGivenTotal[killerID]++
+		if angVictimToKiller >= backKillThresholdDeg {
+			bc.backKillBack[victimID]++
+			bc.backKillGivenBack[killerID]++
+		}
 	}
 
 	// --- Pre-FOV pre-aim metric (charged to the KILLER) -------------
-	// Walk the killer's history backward from the kill tick to find when
-	// the victim FIRST entered the killer's FOV. Then look further back by
-	// preFOVLookbackMs and measure the killer's view-to-victim angle then.
-	killerHistory := bc.history[killerID]
-	if len(killerHistory) < 2 {
+	if !demoStats.CountsAimPlayer(e.Killer) {
 		return
 	}
+	if ang, ok := bc.preFOVAngle(bc.history[killerID], bc.history[victimID]); ok {
+		bc.preFOVAngles[killerID] = append(bc.preFOVAngles[killerID], ang)
+	}
+}
+
+// preFOVAngle walks the killer's history backward from the kill tick to
+// find when the victim FIRST entered the killer's FOV, then looks further
+// back by preFOVLookbackMs and returns the killer's view-to-victim angle
+// then. ok is false without enough history on either side.
+func (bc *BehavioralCollector) preFOVAngle(killerHistory, victimHistory []playerSnapshot) (float64, bool) {
+	if len(killerHistory) < 2 {
+		return 0, false
+	}
 
 	// Walk back through killer history, computing angle from killer's view
 	// to victim's position. Victim's position at each tick is approximated
 	// by the victim's history at the same tick if available; otherwise use
 	// the kill-time position (small error for short engagements).
-	victimHistory := bc.history[victimID]
 	victimByTick := make(map[int]playerSnapshot, len(victimHistory))
 	for _, s := range victimHistory {
 		victimByTick[s.tick] = s
@@ -235,7 +298,7 @@ func (bc *BehavioralCollector) handleKill(e events.Kill) {
 		}
 		viewVec := viewDirectionToVector(ks.yawX, ks.pitch)
 		ang := angleBetweenViewAndTarget(viewVec, ks.posX, ks.posY, ks.posZ, vs.posX, vs.posY, vs.posZ)
-		if ang >= fovEntryDegrees {
+		if ang >= fovEntryDegrees { // unscoped, see BehavioralCollector
 			// First tick going backward where victim is OUT of FOV → the
 			// frame after this one is the FOV entry from the killer's POV.
 			fovEntryIdx = i + 1
@@ -243,7 +306,7 @@ func (bc *BehavioralCollector) handleKill(e events.Kill) {
 		}
 	}
 	if fovEntryIdx <= 0 || fovEntryIdx >= len(killerHistory) {
-		return
+		return 0, false
 	}
 
 	// Look back preFOVLookbackMs from the FOV-entry tick.
@@ -265,34 +328,49 @@ func (bc *BehavioralCollector) handleKill(e events.Kill) {
 		}
 	}
 	if !foundK {
-		return // not enough history
+		return 0, false // not enough history
 	}
 	if v, ok := victimByTick[ks.tick]; ok {
 		vs = v
 		foundV = true
 	}
 	if !foundV {
-		return
+		return 0, false
 	}
 
 	viewVec := viewDirectionToVector(ks.yawX, ks.pitch)
-	preFOVAngle := angleBetweenViewAndTarget(viewVec, ks.posX, ks.posY, ks.posZ, vs.posX, vs.posY, vs.posZ)
-	bc.preFOVAngles[killerID] = append(bc.preFOVAngles[killerID], preFOVAngle)
+	return angleBetweenViewAndTarget(viewVec, ks.posX, ks.posY, ks.posZ, vs.posX, vs.posY, vs.posZ), true
+}
+
+// ProducedMetrics lists the metrics the cheat detector reads from BehavioralCollector.
+func (bc *BehavioralCollector) ProducedMetrics() []KeyRef {
+	return []KeyRef{
+		{CatBehavioral, KeyPreFOVAimMedianDeg},
+		{CatBehavioral, KeyPreFOVAimSamples},
+		{CatBehavioral, KeyNearestEnemyAngleMedianDeg},
+		{CatBehavioral, KeyNearestEnemyAngleSamples},
+		{CatBehavioral, KeyBackKilledPct},
+		{CatBehavioral, KeyBackKillGivenPct},
+	}
 }
 
 // CollectFinalStats publishes the per-player aggregates as metrics.
 func (bc *BehavioralCollector) CollectFinalStats(demoStats *DemoStats) {
 	for sid, ps := range demoStats.Players {
+		// A POV demo's recorder is detected a little way in; views
+		// sampled from anyone else before then are dropped here.
+		aim := demoStats.POVRecorder() == 0 || sid == demoStats.POVRecorder()
+
 		// --- Back-kill rate (victim side) ---------------------------
-		if total := bc.backKillTotal[sid]; total >= minBackKillSamples {
+		if total := bc.backKillTotal[sid]; aim && total >= minBackKillSamples {
 			back := bc.backKillBack[sid]
 			rate := float64(back) / float64(total)
-			ps.AddMetric(Category("behavioral"), Key("back_killed_pct"), Metric{
+			ps.AddMetric(CatBehavioral, KeyBackKilledPct, Metric{
 				Type:        MetricPercentage,
 				FloatValue:  rate * 100.0,
 				Description: "Percent of own deaths where this player was looking away from the killer (low = suspicious)",
 			})
-			ps.AddMetric(Category("behavioral"), Key("back_killed_total_deaths"), Metric{
+			ps.AddMetric(CatBehavioral, KeyBackKilledTotalDeaths, Metric{
 				Type:        MetricInteger,
 				IntValue:    int64(total),
 				Description: "Total deaths used for back-kill rate",
@@ -308,17 +386,17 @@ func (bc *BehavioralCollector) CollectFinalStats(demoStats *DemoStats) {
 		if total := bc.backKillGivenTotal[sid]; total >= minBackKillSamples {
 			back := bc.backKillGivenBack[sid]
 			rate := float64(back) / float64(total)
-			ps.AddMetric(Category("behavioral"), Key("back_kill_given_pct"), Metric{
+			ps.AddMetric(CatBehavioral, KeyBackKillGivenPct, Metric{
 				Type:        MetricPercentage,
 				FloatValue:  rate * 100.0,
 				Description: "Percent of own kills where the victim was looking away from this player (high = flanking or info exploit)",
 			})
-			ps.AddMetric(Category("behavioral"), Key("back_kill_given_count"), Metric{
+			ps.AddMetric(CatBehavioral, KeyBackKillGivenCount, Metric{
 				Type:        MetricInteger,
 				IntValue:    int64(back),
 				Description: "Number of own kills where the victim was looking away",
 			})
-			ps.AddMetric(Category("behavioral"), Key("back_kill_given_total_kills"), Metric{
+			ps.AddMetric(CatBehavioral, KeyBackKillGivenTotalKills, Metric{
 				Type:        MetricInteger,
 				IntValue:    int64(total),
 				Description: "Total kills used for back-kill-given rate",
@@ -326,14 +404,14 @@ func (bc *BehavioralCollector) CollectFinalStats(demoStats *DemoStats) {
 		}
 
 		// --- Pre-FOV pre-aim angle ---------------------------------
-		if angles := bc.preFOVAngles[sid]; len(angles) >= minPreFOVSamples {
+		if angles := bc.preFOVAngles[sid]; aim && len(angles) >= minPreFOVSamples {
 			med := median(angles)
-			ps.AddMetric(Category("behavioral"), Key("pre_fov_aim_median_deg"), Metric{
+			ps.AddMetric(CatBehavioral, KeyPreFOVAimMedianDeg, Metric{
 				Type:        MetricFloat,
 				FloatValue:  med,
 				Description: "Median angle (deg) between killer view and victim position 200 ms before FOV entry (low = suspicious)",
 			})
-			ps.AddMetric(Category("behavioral"), Key("pre_fov_aim_samples"), Metric{
+			ps.AddMetric(CatBehavioral, KeyPreFOVAimSamples, Metric{
 				Type:        MetricInteger,
 				IntValue:    int64(len(angles)),
 				Description: "Number of kills contributing to pre-FOV pre-aim metric",
@@ -341,14 +419,14 @@ func (bc *BehavioralCollector) CollectFinalStats(demoStats *DemoStats) {
 		}
 
 		// --- Off-engagement enemy attention ------------------------
-		if angles := bc.attentionMin[sid]; len(angles) >= minAttentionSamples {
+		if angles := bc.attentionMin[sid]; aim && len(angles) >= minAttentionSamples {
 			med := median(angles)
-			ps.AddMetric(Category("behavioral"), Key("nearest_enemy_angle_median_deg"), Metric{
+			ps.AddMetric(CatBehavioral, KeyNearestEnemyAngleMedianDeg, Metric{
 				Type:        MetricFloat,
 				FloatValue:  med,
 				Description: "Median per-frame angle (deg) from view direction to nearest enemy when not in FOV (low = suspicious)",
 			})
-			ps.AddMetric(Category("behavioral"), Key("nearest_enemy_angle_samples"), Metric{
+			ps.AddMetric(CatBehavioral, KeyNearestEnemyAngleSamples, Metric{
 				Type:        MetricInteger,
 				IntValue:    int64(len(angles)),
 				Description: "Number of frames contributing to nearest-enemy attention metric",
@@ -410,3 +488,20 @@ func median(xs []float64) float64 {
 	}
 	return (cp[n/2-1] + cp[n/2]) / 2.0
 }
+
+// stddev returns the population standard deviation of xs (0 when empty).
+func stddev(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	mean := sum / float64(len(xs))
+	ss := 0.0
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	return math.Sqrt(ss / float64(len(xs)))
+}
@@ -0,0 +1,117 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+func TestScopeZoomNarrowsFOV(t *testing.T) {
+	z := DefaultScopeZoom()
+	tests := []struct {
+		name    string
+		scoped  bool
+		level   common.ZoomLevel
+		wantFOV float64
+	}{
+		{"unscoped", false, common.ZoomNone, 5},
+		{"zoom level left over after unscoping", false, common.ZoomFull, 5},
+		{"first zoom", true, common.ZoomHalf, 2.5},
+		{"second zoom", true, common.ZoomFull, 1.25},
+	}
+	for _, tt := range tests {
+		if got := fovEntryDegrees / z.factor(tt.scoped, tt.level); got != tt.wantFOV {
+			t.Errorf("%s: fov = %v, want %v", tt.name, got, tt.wantFOV)
+		}
+	}
+
+	// Factors below 1 can't widen the cone.
+	if got := (ScopeZoom{Half: 0.5}).factor(true, common.ZoomHalf); got != 1 {
+		t.Errorf("factor 0.5 = %v, want 1", got)
+	}
+}
+
+// TestPreFOVConeUnscoped has a victim swing into the crosshair at 0.5° per
+// tick. The pre-FOV sample comes 200 ms (12 ticks) before the victim enters
+// the unscoped 5° cone at tick 31, which applies to AWPers too; the 1.25°
+// cone of a fully zoomed AWP would enter at tick 39 and read 4°
+// lower.
+func TestPreFOVConeUnscoped(t *testing.T) {
+	bc := NewBehavioralCollector()
+	bc.tickRate = 64
+
+	var killer, victim []playerSnapshot
+	for tick := 0; tick <= 40; tick++ {
+		killer = append(killer, playerSnapshot{tick: tick})
+		a := (20.25 - 0.5*float64(tick)) * math.Pi / 180
+		victim = append(victim, playerSnapshot{tick: tick, posX: 1000 * math.Cos(a), posY: 1000 * math.Sin(a)})
+	}
+
+	got, ok := bc.preFOVAngle(killer, victim)
+	if !ok {
+		t.Fatal("no pre-FOV sample")
+	}
+	if want := 10.75; math.Abs(got-want) > 0.01 {
+		t.Errorf("pre-FOV angle = %.2f°, want %.2f° from the unscoped cone", got, want)
+	}
+}
+
+// TestBehavioralPOVRecorderOnly checks that on a POV demo only the recorder
+// gets the view-based pre_fov and attention channels, both for kills after
+// the recorder is known and for samples taken before it was detected.
+func TestBehavioralPOVRecorderOnly(t *testing.T) {
+	recorder := &common.Player{Name: "rec", SteamID64: 76561198000000001, Team: common.TeamCounterTerrorists}
+	other := &common.Player{Name: "other", SteamID64: 76561198000000002, Team: common.TeamTerrorists}
+
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStats(recorder)
+	ds.GetOrCreatePlayerStats(other)
+	bc := NewBehavioralCollector()
+	bc.tickRate = 64
+
+	// Sampled before the recorder was detected
+	for i := 0; i < minAttentionSamples; i++ {
+		bc.attentionMin[recorder.SteamID64] = append(bc.attentionMin[recorder.SteamID64], 20)
+		bc.attentionMin[other.SteamID64] = append(bc.attentionMin[other.SteamID64], 20)
+	}
+	bc.preFOVAngles[other.SteamID64] = []float64{3, 3, 3, 3, 3, 3, 3, 3}
+
+	ds.SetPOVRecorder(recorder.SteamID64)
+
+	// Histories for a victim swinging into either killer's crosshair
+	var still, swing []playerSnapshot
+	for tick := 0; tick <= 40; tick++ {
+		still = append(still, playerSnapshot{tick: tick})
+		a := (20.25 - 0.5*float64(tick)) * math.Pi / 180
+		swing = append(swing, playerSnapshot{tick: tick, posX: 1000 * math.Cos(a), posY: 1000 * math.Sin(a)})
+	}
+	for i := 0; i < minPreFOVSamples; i++ {
+		bc.history[recorder.SteamID64], bc.history[other.SteamID64] = still, swing
+		bc.handleKill(events.Kill{Killer: recorder, Victim: other}, ds)
+		bc.history[recorder.SteamID64], bc.history[other.SteamID64] = swing, still
+		bc.handleKill(events.Kill{Killer: other, Victim: recorder}, ds)
+	}
+	if n := len(bc.preFOVAngles[recorder.SteamID64]); n != minPreFOVSamples {
+		t.Errorf("recorder pre-FOV samples = %d, want %d", n, minPreFOVSamples)
+	}
+	if n := len(bc.preFOVAngles[other.SteamID64]); n != 8 {
+		t.Errorf("non-recorder pre-FOV samples = %d, want only the 8 from before detection", n)
+	}
+
+	bc.CollectFinalStats(ds)
+	for _, ps := range ds.Players {
+		ps.AddMetric(CatKills, KeyTotalKills, Metric{Type: MetricInteger, IntValue: 20})
+	}
+	NewCheatDetector().CollectFinalStats(ds)
+
+	for _, ch := range []string{"pre_fov", "attention"} {
+		if conf, _ := psGetFloat(ds.Players[recorder.SteamID64], CatAntiCheat, Key(ch+"_confidence")); conf <= 0 {
+			t.Errorf("recorder has no %s channel", ch)
+		}
+		if conf, _ := psGetFloat(ds.Players[other.SteamID64], CatAntiCheat, Key(ch+"_confidence")); conf != 0 {
+			t.Errorf("non-recorder %s confidence = %.2f, want no channel", ch, conf)
+		}
+	}
+}
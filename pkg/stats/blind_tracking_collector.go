@@ -0,0 +1,269 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+const (
+	// blindLeadIn is how far before the engagement the kill ends the window
+	// starts. A one-tap through a wall opens the engagement with the kill
+	// itself, so the tracking that led to it happens before.
+	blindLeadIn = time.Second
+
+	// blindTrackMin is how long the crosshair must have sat on the hidden
+	// victim within the window for a blind kill to count as tracked.
+	blindTrackMin = 250 * time.Millisecond
+)
+
+// blindPair is what a killer knew about one enemy this round: when they
+// last had line of sight, and the frames since on which their crosshair
+// was on the enemy anyway.
+type blindPair struct {
+	seen     bool
+	lastSeen time.Duration
+	onTarget []blindSample
+}
+
+// blindSample is a frame with the crosshair on a hidden enemy, held for dur
+// until the next frame.
+type blindSample struct {
+	at, dur time.Duration
+}
+
+// BlindTrackingCollector looks for kills where the killer never had line of
+// sight to the victim during the fight — the victim stayed behind a wall or
+// smoke the whole time — yet the killer's crosshair followed them before
+// the kill. Prefiring a common spot lands without seeing anyone; holding
+// the crosshair on a moving player you can't see is what a wallhack shows.
+//
+// Line of sight is the engine's spotted mask, sampled on every frame for
+// every pair of opponents. The window is the killer's engagement with the
+// victim (see EngagementTracker) plus blindLeadIn before it:
+//
+//   - blind_kills: gun kills with no line of sight anywhere in the window.
+//   - blind_tracking_kills: blind kills with the crosshair on the hidden
+//     victim's head or chest for at least blindTrackMin of the window. Feeds
+//     the blind_tracking channel.
+//
+// Both read the killer's view angles, so on POV demos only the recorder's
+// kills and crosshair are sampled (see CountsAimPlayer).
+type BlindTrackingCollector struct {
+	*BaseCollector
+	tickRate float64
+
+	// engagements is the shared fight definition. Collectors used outside
+	// the Analyzer run a private tracker.
+	engagements *EngagementTracker
+	ownTracker  bool
+
+	// pairs[killerSID][victimSID] is reset every round.
+	pairs     map[uint64]map[uint64]*blindPair
+	lastFrame time.Duration
+
+	kills   map[uint64]int
+	blind   map[uint64]int
+	tracked map[uint64]int
+}
+
+// NewBlindTrackingCollector creates a new BlindTrackingCollector.
+func NewBlindTrackingCollector() *BlindTrackingCollector {
+	return &BlindTrackingCollector{
+		BaseCollector: NewBaseCollector("Blind Tracking", CatWallhack),
+		pairs:         make(map[uint64]map[uint64]*blindPair),
+		kills:         make(map[uint64]int),
+		blind:         make(map[uint64]int),
+		tracked:       make(map[uint64]int),
+	}
+}
+
+// UseEngagements makes the collector window kills by a shared tracker.
+func (bc *BlindTrackingCollector) UseEngagements(t *EngagementTracker) {
+	bc.engagements = t
+	bc.ownTracker = false
+}
+
+// Setup registers the kill and round handlers.
+func (bc *BlindTrackingCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	if bc.engagements == nil {
+		bc.engagements = NewEngagementTracker(DefaultEngagementConfig())
+		bc.ownTracker = true
+	}
+	if bc.ownTracker {
+		bc.engagements.Setup(parser, demoStats)
+	}
+
+	bc.tickRate = parser.TickRate()
+	if bc.tickRate <= 0 {
+		bc.tickRate = 64.0
+	}
+	parser.RegisterEventHandler(func(e events.TickRateInfoAvailable) {
+		if e.TickRate > 0 {
+			bc.tickRate = e.TickRate
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.Kill) {
+		if inWarmup(parser) || e.Killer == nil || e.Victim == nil || e.Weapon == nil {
+			return
+		}
+		if !demoStats.CountsAimPlayer(e.Killer) || e.Killer.Team == e.Victim.Team {
+			return
+		}
+		switch weaponClass(e.Weapon) {
+		case "grenade", "equipment":
+			return
+		}
+		if isKnife(e.Weapon) {
+			return
+		}
+
+		killer, victim := demoStats.PlayerKey(e.Killer), demoStats.PlayerKey(e.Victim)
+		bc.kills[killer]++
+		now := demoTime(parser, bc.tickRate)
+		from := now - blindLeadIn
+		if eng := bc.engagements.Engagement(killer, victim); eng != nil && eng.Start-blindLeadIn < from {
+			from = eng.Start - blindLeadIn
+		}
+		blind, held := bc.pairs[killer][victim].window(from)
+		if !blind {
+			return
+		}
+		bc.blind[killer]++
+		if held >= blindTrackMin {
+			bc.tracked[killer]++
+		}
+	})
+
+	parser.RegisterEventHandler(func(_ events.RoundStart) {
+		bc.pairs = make(map[uint64]map[uint64]*blindPair)
+	})
+}
+
+// CollectFrame samples line of sight and crosshair placement for every
+// living pair of opponents.
+func (bc *BlindTrackingCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	if bc.ownTracker {
+		bc.engagements.Update(parser)
+	}
+	if inWarmup(parser) {
+		return
+	}
+	now := demoTime(parser, bc.tickRate)
+	step := now - bc.lastFrame
+	bc.lastFrame = now
+	if step <= 0 || step > time.Second {
+		step = time.Duration(float64(time.Second) / bc.tickRate)
+	}
+
+	playing := parser.GameState().Participants().Playing()
+	frames := make(map[uint64]wallbangFrame, len(playing))
+	for _, p := range playing {
+		key := demoStats.PlayerKey(p)
+		if key == 0 || !p.IsAlive() {
+			continue
+		}
+		yaw, pitch, ok := viewAngles(p)
+		if !ok {
+			continue
+		}
+		head := headPosition(p)
+		feet := p.Position()
+		frames[key] = wallbangFrame{
+			eye:   eyePosition(p),
+			head:  head,
+			chest: feet.Add(head.Sub(feet).Mul(0.7)),
+			yaw:   float64(yaw),
+			pitch: signedPitch(float64(pitch)),
+		}
+	}
+
+	for _, p := range playing {
+		if !demoStats.CountsAimPlayer(p) {
+			continue
+		}
+		pKey := demoStats.PlayerKey(p)
+		k, ok := frames[pKey]
+		if !ok {
+			continue
+		}
+		for _, opp := range playing {
+			oppKey := demoStats.PlayerKey(opp)
+			v, ok := frames[oppKey]
+			if !ok || opp.Team == p.Team {
+				continue
+			}
+			pair := bc.pair(pKey, oppKey)
+			switch {
+			case opp.IsSpottedBy(p):
+				pair.sight(now)
+			case aimOnTarget(k, v):
+				pair.onTarget = append(pair.onTarget, blindSample{at: now, dur: step})
+			}
+		}
+	}
+}
+
+// pair returns killer's state for victim, creating it.
+func (bc *BlindTrackingCollector) pair(killer, victim uint64) *blindPair {
+	victims := bc.pairs[killer]
+	if victims == nil {
+		victims = make(map[uint64]*blindPair)
+		bc.pairs[killer] = victims
+	}
+	p := victims[victim]
+	if p == nil {
+		p = &blindPair{}
+		victims[victim] = p
+	}
+	return p
+}
+
+// sight records line of sight at now. Crosshair time before it no longer
+// matters: a window that reaches back past a sighting isn't blind.
+func (p *blindPair) sight(now time.Duration) {
+	p.seen, p.lastSeen = true, now
+	p.onTarget = p.onTarget[:0]
+}
+
+// window reports whether the killer went without line of sight from
+// onwards, and how long their crosshair was on the hidden victim in that
+// time. A nil pair was never sampled (a victim without a key, a kill on the
+// round's first frame), so nothing is known and it isn't blind.
+func (p *blindPair) window(from time.Duration) (blind bool, held time.Duration) {
+	if p == nil {
+		return false, 0
+	}
+	if p.seen && p.lastSeen >= from {
+		return false, 0
+	}
+	for _, s := range p.onTarget {
+		if s.at >= from {
+			held += s.dur
+		}
+	}
+	return true, held
+}
+
+// CollectFinalStats publishes blind_kills and blind_tracking_kills for
+// every player with a gun kill.
+func (bc *BlindTrackingCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid := range bc.kills {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		ps.AddMetric(CatWallhack, KeyBlindKills, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(bc.blind[sid]),
+			Description: "Kills with no line of sight to the victim during the whole engagement",
+		})
+		ps.AddMetric(CatWallhack, KeyBlindTrackingKills, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(bc.tracked[sid]),
+			Description: "Blind kills after holding the crosshair on the hidden victim",
+		})
+	}
+}
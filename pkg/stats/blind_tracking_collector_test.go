@@ -0,0 +1,100 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+func TestBlindPairWindow(t *testing.T) {
+	step := time.Second / 64
+	track := func(p *blindPair, from, to time.Duration) {
+		for at := from; at < to; at += step {
+			p.onTarget = append(p.onTarget, blindSample{at: at, dur: step})
+		}
+	}
+
+	// Hidden the whole round, crosshair on them for the last half second.
+	p := &blindPair{}
+	track(p, 4500*time.Millisecond, 5*time.Second)
+	blind, held := p.window(4 * time.Second)
+	if !blind || held < blindTrackMin {
+		t.Errorf("tracked blind kill: blind=%v held=%v", blind, held)
+	}
+
+	// Tracking before the window doesn't count.
+	if _, held := p.window(4900 * time.Millisecond); held >= blindTrackMin {
+		t.Errorf("held %v counted from before the window", held)
+	}
+
+	// A sighting inside the window means the killer saw them.
+	p.sight(4800 * time.Millisecond)
+	if blind, _ := p.window(4 * time.Second); blind {
+		t.Error("sighting inside the window still blind")
+	}
+
+	// One long before the window doesn't, and tracking since it counts.
+	p = &blindPair{}
+	p.sight(time.Second)
+	track(p, 3*time.Second, 4*time.Second)
+	if blind, held := p.window(3 * time.Second); !blind || held < blindTrackMin {
+		t.Errorf("sighting before the window: blind=%v held=%v", blind, held)
+	}
+
+	// A pair never sampled isn't evidence.
+	var none *blindPair
+	if blind, _ := none.window(0); blind {
+		t.Error("unsampled pair counted as blind")
+	}
+}
+
+func TestEvaluateBlindTracking(t *testing.T) {
+	ps := &PlayerStats{Categories: make(map[Category]map[Key]Metric)}
+	ps.AddMetric(CatKills, KeyTotalKills, Metric{Type: MetricInteger, IntValue: 20})
+	if ch := evaluateBlindTracking(ps); ch.HasData {
+		t.Fatalf("scored without wallhack data: %+v", ch)
+	}
+	ps.AddMetric(CatWallhack, KeyBlindTrackingKills, Metric{Type: MetricInteger, IntValue: 1})
+	if ch := evaluateBlindTracking(ps); !ch.HasData || ch.Score != 0 {
+		t.Errorf("one blind tracking kill: %+v", ch)
+	}
+	ps.AddMetric(CatWallhack, KeyBlindTrackingKills, Metric{Type: MetricInteger, IntValue: 3})
+	if ch := evaluateBlindTracking(ps); ch.Score != 1 || ch.Confidence != 1 {
+		t.Errorf("3 blind tracking kills over 20 kills: %+v", ch)
+	}
+}
+
+func TestBlindTrackingPOVRecorderOnly(t *testing.T) {
+	recorder := &common.Player{Name: "rec", SteamID64: 76561198000000001, Team: common.TeamCounterTerrorists}
+	other := &common.Player{Name: "other", SteamID64: 76561198000000002, Team: common.TeamTerrorists}
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStats(recorder)
+	ds.GetOrCreatePlayerStats(other)
+	ds.SetPOVRecorder(recorder.SteamID64)
+
+	parser := &warmupStubParser{frame: 320} // 5 s in
+	bc := NewBlindTrackingCollector()
+	bc.Setup(parser, ds)
+
+	// Both hid from each other with the crosshair on the other for the
+	// last half second.
+	for _, pair := range [][2]*common.Player{{recorder, other}, {other, recorder}} {
+		p := bc.pair(pair[0].SteamID64, pair[1].SteamID64)
+		for at := 4500 * time.Millisecond; at < 5*time.Second; at += time.Second / 64 {
+			p.onTarget = append(p.onTarget, blindSample{at: at, dur: time.Second / 64})
+		}
+	}
+	ak := common.NewEquipment(common.EqAK47)
+	parser.dispatch(events.Kill{Killer: recorder, Victim: other, Weapon: ak})
+	parser.dispatch(events.Kill{Killer: other, Victim: recorder, Weapon: ak})
+
+	if bc.tracked[recorder.SteamID64] != 1 {
+		t.Errorf("recorder blind tracking kills = %d, want 1", bc.tracked[recorder.SteamID64])
+	}
+	if bc.kills[other.SteamID64] != 0 || bc.tracked[other.SteamID64] != 0 {
+		t.Errorf("non-recorder kills scored from interpolated view: kills %d, tracked %d",
+			bc.kills[other.SteamID64], bc.tracked[other.SteamID64])
+	}
+}
@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+)
+
+// bootstrapResamples is the number of resamples behind each bootstrap
+// interval. Two hundred puts the interval ends within about a percentile
+// point of where many more resamples would land, at a cost that stays small
+// with samples capped at DefaultMaxSamples.
+const bootstrapResamples = 200
+
+// bootstrapMinSamples is the fewest samples worth resampling; below it every
+// resample is one of a handful of values and the interval means nothing.
+const bootstrapMinSamples = 5
+
+// bootstrapInterval returns the 90% percentile-bootstrap interval of stat
+// over samples: stat is evaluated on bootstrapResamples resamples drawn with
+// replacement, and the 5th and 95th percentiles of the results are returned.
+// stat receives each resample sorted ascending. The random source is seeded
+// from the sample count so a report is reproducible. ok is false when there
+// are fewer than bootstrapMinSamples samples.
+//
+// When samples is a capped subset (see DefaultMaxSamples) the interval is
+// that of the subset, so it is wider than the full sample set would give:
+// the error is on the cautious side.
+func bootstrapInterval(samples []float64, stat func(sorted []float64) float64) (lo, hi float64, ok bool) {
+	n := len(samples)
+	if n < bootstrapMinSamples {
+		return 0, 0, false
+	}
+	rng := rand.New(rand.NewPCG(0xb007, uint64(n)))
+	resample := make([]float64, n)
+	results := make([]float64, bootstrapResamples)
+	for i := range results {
+		for j := range resample {
+			resample[j] = samples[rng.IntN(n)]
+		}
+		sort.Float64s(resample)
+		results[i] = stat(resample)
+	}
+	sort.Float64s(results)
+	return results[bootstrapResamples*5/100], results[bootstrapResamples*95/100-1], true
+}
+
+// addIntervalMetrics publishes <key>_low and <key>_high for a bootstrap
+// interval of key.
+func addIntervalMetrics(ps *PlayerStats, cat Category, key Key, lo, hi float64) {
+	ps.AddMetric(cat, key+"_low", Metric{
+		Type:        MetricFloat,
+		FloatValue:  lo,
+		Description: fmt.Sprintf("Lower end of the 90%% bootstrap interval of %s", key),
+	})
+	ps.AddMetric(cat, key+"_high", Metric{
+		Type:        MetricFloat,
+		FloatValue:  hi,
+		Description: fmt.Sprintf("Upper end of the 90%% bootstrap interval of %s", key),
+	})
+}
+
+// sortedMedian and sortedP95 are the statistics the collectors publish,
+// as functions of an ascending slice.
+func sortedMedian(s []float64) float64 { return s[len(s)/2] }
+
+func sortedP95(s []float64) float64 {
+	idx := int(float64(len(s)) * 0.95)
+	if idx >= len(s) {
+		idx = len(s) - 1
+	}
+	return s[idx]
+}
@@ -0,0 +1,99 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBootstrapInterval(t *testing.T) {
+	spread := func(n int) (lo, median, hi float64) {
+		samples := make([]float64, n)
+		for i := range samples {
+			samples[i] = 150 + float64(i*37%n)*400/float64(n) // 150–550 ms, shuffled
+		}
+		lo, hi, ok := bootstrapInterval(samples, sortedMedian)
+		if !ok {
+			t.Fatalf("n=%d: no interval", n)
+		}
+		r := newSampleReservoir(0)
+		for _, v := range samples {
+			r.add(v)
+		}
+		return lo, sortedMedian(r.sorted()), hi
+	}
+	lo20, med20, hi20 := spread(20)
+	if lo20 > med20 || hi20 < med20 {
+		t.Errorf("interval [%.0f, %.0f] doesn't bracket the median %.0f", lo20, hi20, med20)
+	}
+	lo500, _, hi500 := spread(500)
+	if hi500-lo500 >= hi20-lo20 {
+		t.Errorf("interval didn't narrow with more samples: %.1f at n=500, %.1f at n=20", hi500-lo500, hi20-lo20)
+	}
+
+	if _, _, ok := bootstrapInterval([]float64{1, 2, 3}, sortedMedian); ok {
+		t.Error("interval from 3 samples")
+	}
+}
+
+func TestFillScoreBounds(t *testing.T) {
+	channels := []Channel{
+		{ID: "hs", Score: 1, SampleN: 3, HasData: true},
+		{ID: "hs", Score: 1, SampleN: 300, HasData: true},
+		{ID: "mine", Score: 0.7, HasData: true, custom: true},
+		{ID: "snap", Score: 0.5, ScoreLow: 0.4, ScoreHigh: 0.9, bounded: true, HasData: true},
+	}
+	fillScoreBounds(channels)
+	if c := channels[0]; c.ScoreHigh != 1 || c.ScoreLow > 0.6 {
+		t.Errorf("blatant on 3 samples: [%.2f, %.2f], want a wide interval", c.ScoreLow, c.ScoreHigh)
+	}
+	if c := channels[1]; c.ScoreLow < 0.98 {
+		t.Errorf("blatant on 300 samples: low %.3f, want ≈ 1", c.ScoreLow)
+	}
+	if c := channels[2]; c.ScoreLow != 0.7 || c.ScoreHigh != 0.7 {
+		t.Errorf("no sample count: [%v, %v], want no width", c.ScoreLow, c.ScoreHigh)
+	}
+	if c := channels[3]; c.ScoreLow != 0.4 || c.ScoreHigh != 0.9 {
+		t.Errorf("bootstrap bounds overwritten: [%v, %v]", c.ScoreLow, c.ScoreHigh)
+	}
+}
+
+func TestCheatLikelihoodInterval(t *testing.T) {
+	score := func(strict bool) *PlayerStats {
+		ds := zeroKillDemo(5)
+		ps := ds.Players[1]
+		ps.AddMetric(Category("kills"), Key("total_kills"), Metric{Type: MetricInteger, IntValue: 20})
+		ps.AddMetric(Category("kills"), Key("headshot_percentage"), Metric{Type: MetricPercentage, FloatValue: 90})
+		ps.AddMetric(Category("reaction"), Key("ttd_samples"), Metric{Type: MetricInteger, IntValue: 10})
+		ps.AddMetric(Category("reaction"), Key("median_ttd"), Metric{Type: MetricFloat, FloatValue: 140})
+		addIntervalMetrics(ps, Category("reaction"), Key("median_ttd"), 120, 420)
+		ps.AddMetric(Category("reaction"), Key("sub_100ms_ttd"), Metric{Type: MetricPercentage, FloatValue: 20})
+		addIntervalMetrics(ps, Category("reaction"), Key("sub_100ms_ttd"), 0, 40)
+		ps.AddMetric(Category("behavioral"), Key("back_killed_total_deaths"), Metric{Type: MetricInteger, IntValue: 10})
+		ps.AddMetric(Category("behavioral"), Key("back_killed_pct"), Metric{Type: MetricPercentage, FloatValue: 2})
+		ps.AddMetric(Category("aiming"), Key("snap_count"), Metric{Type: MetricInteger, IntValue: 10})
+		ps.AddMetric(Category("aiming"), Key("p95_snap_velocity"), Metric{Type: MetricFloat, FloatValue: 4.0})
+		addIntervalMetrics(ps, Category("aiming"), Key("p95_snap_velocity"), 1.8, 4.4)
+		cd := NewCheatDetector()
+		cd.MinKillsForFlag = 0
+		cd.FlagOnLowerBound = strict
+		cd.CollectFinalStats(ds)
+		return ps
+	}
+
+	ps := score(false)
+	v, _ := psGetFloat(ps, Category("anti_cheat"), Key("cheat_likelihood"))
+	lo, _ := psGetFloat(ps, Category("anti_cheat"), Key("cheat_likelihood_low"))
+	hi, _ := psGetFloat(ps, Category("anti_cheat"), Key("cheat_likelihood_high"))
+	if !(lo < v && v <= hi) || math.IsNaN(lo) {
+		t.Fatalf("interval [%.1f, %.1f] around %.1f", lo, hi, v)
+	}
+	if v < cheatscoreFlagThreshold || lo >= cheatscoreFlagThreshold {
+		t.Fatalf("fixture: likelihood %.1f, low %.1f; want flagged point estimate with a low bound under the threshold", v, lo)
+	}
+	if !psHasYes(ps, Key("cheater")) {
+		t.Error("not flagged on the point estimate")
+	}
+	if psHasYes(score(true), Key("cheater")) {
+		t.Error("flagged with FlagOnLowerBound although the low bound is under the threshold")
+	}
+}
@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+func TestPlayerKeyBots(t *testing.T) {
+	human := &common.Player{Name: "human", SteamID64: 76561198000000001}
+	albert := &common.Player{Name: "BOT Albert", IsBot: true}
+	bert := &common.Player{Name: "BOT Bert", IsBot: true}
+
+	ds := NewDemoStats()
+	if ds.CountsPlayer(albert) || ds.GetOrCreatePlayerStats(albert) != nil {
+		t.Fatal("bot counted with bots excluded")
+	}
+	if got := ds.PlayerKey(human); got != human.SteamID64 {
+		t.Errorf("human key = %d, want SteamID", got)
+	}
+
+	ds.SetIncludeBots(true)
+	a, b := ds.PlayerKey(albert), ds.PlayerKey(bert)
+	if a == 0 || b == 0 || a == b {
+		t.Fatalf("bot keys %d, %d: want distinct non-zero", a, b)
+	}
+	if !IsBotKey(a) || IsBotKey(human.SteamID64) {
+		t.Error("IsBotKey misclassifies")
+	}
+	if again := ds.PlayerKey(&common.Player{Name: "BOT Albert", IsBot: true}); again != a {
+		t.Errorf("bot key not stable: %d then %d", a, again)
+	}
+
+	ps := ds.GetOrCreatePlayerStats(albert)
+	if ps == nil || ps.Player.SteamID64 != a || ps.Player.Name != "BOT Albert" {
+		t.Fatalf("bot stats = %+v", ps)
+	}
+	if ds.GetOrCreatePlayerStats(bert) == ps {
+		t.Error("bots collapsed into one entry")
+	}
+
+	ds.SetPlayerFilter(a)
+	if ds.CountsPlayer(bert) || !ds.CountsPlayer(albert) {
+		t.Error("player filter not applied to bot keys")
+	}
+}
@@ -9,11 +9,37 @@ import (
 // package so it can be unit-tested without spinning up a parser.
 type CheatDetector struct {
 	*BaseCollector
+
+	// MinKillsForFlag is the kill count below which cheat_likelihood is
+	// capped under the flag threshold and insufficient_data is published.
+	// Zero or negative disables the gate.
+	MinKillsForFlag int
+
+	// FlagOnLowerBound flags a player only when cheat_likelihood_low, the
+	// lower end of the likelihood's confidence interval, reaches the flag
+	// threshold. It trades missed flags on thin evidence for far fewer
+	// false positives.
+	FlagOnLowerBound bool
+
+	// Weights overrides the channel weights and the flag threshold; the
+	// zero value keeps the built-in calibration. See TuneThresholds.
+	Weights CheatWeights
+
+	// components are the custom channels registered with AddComponent.
+	components []ScoreComponent
+
+	// allowlist holds the SteamIDs never flagged; see SetAllowlist.
+	allowlist map[uint64]bool
 }
 
+// DefaultMinKillsForFlag is the MinKillsForFlag a new CheatDetector starts
+// with. Below ten kills no channel has enough samples to carry a flag alone.
+const DefaultMinKillsForFlag = 10
+
 func NewCheatDetector() *CheatDetector {
 	return &CheatDetector{
-		BaseCollector: NewBaseCollector("Cheat Detection", Category("anti_cheat")),
+		BaseCollector:   NewBaseCollector("Cheat Detection", CatAntiCheat),
+		MinKillsForFlag: DefaultMinKillsForFlag,
 	}
 }
 
@@ -21,9 +47,113 @@ func (cd *CheatDetector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
 
 func (cd *CheatDetector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {}
 
+// RequiredMetrics returns the inputs of the built-in channels, boosts and
+// overrides: every metric the built-in producers declare.
+func (cd *CheatDetector) RequiredMetrics() []KeyRef {
+	var refs []KeyRef
+	for _, p := range builtinProducers() {
+		refs = append(refs, p.ProducedMetrics()...)
+	}
+	return refs
+}
+
 // CollectFinalStats delegates to cheatscoreEvaluate, which writes all
 // anti_cheat metrics (cheat_likelihood, per-channel scores, boost flags,
-// cheater Yes/No) into each player's PlayerStats.
+// cheater Yes/No) into each player's PlayerStats. A demo with nothing to
+// score (see insufficientDemoReason) gets no likelihoods at all, only
+// insufficient_data, so it can't pass for a clean lobby.
 func (cd *CheatDetector) CollectFinalStats(demoStats *DemoStats) {
-	cheatscoreEvaluate(demoStats)
+	if reason := insufficientDemoReason(demoStats); reason != "" {
+		publishInsufficientDemo(demoStats, reason)
+		return
+	}
+	cheatscoreEvaluate(demoStats, cd.config())
+	cd.applyAllowlist(demoStats)
+}
+
+// SetAllowlist marks SteamIDs as trusted — pros, known-legit accounts —
+// so they are never flagged. Their likelihood and channels are still
+// computed and published for transparency; only cheater is forced to No,
+// and anti_cheat/allowlisted says why. Each call replaces the list.
+func (cd *CheatDetector) SetAllowlist(ids ...uint64) {
+	cd.allowlist = make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		cd.allowlist[id] = true
+	}
+}
+
+// applyAllowlist clears the flag of every scored allowlisted player.
+func (cd *CheatDetector) applyAllowlist(ds *DemoStats) {
+	for sid := range cd.allowlist {
+		ps, ok := ds.Players[sid]
+		if !ok {
+			continue
+		}
+		if _, ok := ps.GetMetric(CatAntiCheat, KeyCheater); !ok {
+			continue
+		}
+		markAllowlisted(ps)
+	}
+}
+
+// markAllowlisted forces ps's cheater flag to No and records why.
+func markAllowlisted(ps *PlayerStats) {
+	ps.AddMetric(CatAntiCheat, KeyCheater, Metric{
+		Type:        MetricString,
+		StringValue: "No",
+		Description: "Flag — never Yes for an allowlisted SteamID",
+	})
+	ps.AddMetric(CatAntiCheat, KeyAllowlisted, Metric{
+		Type:        MetricString,
+		StringValue: "Yes",
+		Description: "Trusted SteamID — never flagged; likelihood shown for transparency",
+	})
+}
+
+// insufficientDemoReason returns why the demo as a whole is too thin to
+// score — no completed rounds (warmup-only or truncated demos) or no kills
+// by anyone — or "" when it can be scored. The round check only applies
+// when the game-mode collector recorded a round count.
+func insufficientDemoReason(ds *DemoStats) string {
+	if ds == nil {
+		return ""
+	}
+	if rounds, ok := ds.GetMetric(CatGameInfo, KeyRoundCount); ok && rounds.IntValue == 0 {
+		return "no completed rounds"
+	}
+	for _, ps := range ds.Players {
+		if kills, _ := psGetInt(ps, CatKills, KeyTotalKills); kills > 0 {
+			return ""
+		}
+	}
+	return "no kills recorded"
+}
+
+// publishInsufficientDemo records reason as the demo-wide
+// game_info/insufficient_data and flags every player's anti_cheat
+// insufficient_data in place of a likelihood.
+func publishInsufficientDemo(ds *DemoStats, reason string) {
+	ds.AddMetric(CatGameInfo, KeyInsufficientData, Metric{
+		Type:        MetricString,
+		StringValue: reason,
+		Description: "Why the demo was not scored",
+	})
+	for _, ps := range ds.Players {
+		ps.AddMetric(CatAntiCheat, KeyInsufficientData, Metric{
+			Type:        MetricString,
+			StringValue: "Yes",
+			Description: "Demo not scored (" + reason + ") — no cheat likelihood computed",
+		})
+	}
+}
+
+// config collects the detector's settings for the scoring pipeline.
+func (cd *CheatDetector) config() cheatscoreConfig {
+	return cheatscoreConfig{
+		minKillsForFlag:  cd.MinKillsForFlag,
+		components:       cd.components,
+		flagOnLowerBound: cd.FlagOnLowerBound,
+		weights:          cd.Weights.Channels,
+		flagThreshold:    cd.Weights.FlagThreshold,
+	}
 }
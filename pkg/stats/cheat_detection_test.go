@@ -0,0 +1,188 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+)
+
+// zeroKillDemo is a stub of what a warmup-only or broken demo leaves
+// behind: players and a round count, but no kills.
+func zeroKillDemo(rounds int64) *DemoStats {
+	ds := NewDemoStats()
+	ds.AddMetric(Category("game_info"), Key("round_count"), Metric{Type: MetricInteger, IntValue: rounds})
+	for sid := uint64(1); sid <= 4; sid++ {
+		ds.GetOrCreatePlayerStatsBySteamID(sid).Player.Name = "p"
+	}
+	return ds
+}
+
+func TestCheatDetectorInsufficientDemo(t *testing.T) {
+	tests := []struct {
+		name   string
+		rounds int64
+		want   string
+	}{
+		{"warmup only", 0, "no completed rounds"},
+		{"rounds without kills", 5, "no kills recorded"},
+	}
+	for _, tt := range tests {
+		ds := zeroKillDemo(tt.rounds)
+		NewCheatDetector().CollectFinalStats(ds)
+
+		if got, _ := ds.GetMetric(Category("game_info"), Key("insufficient_data")); got.StringValue != tt.want {
+			t.Errorf("%s: game_info/insufficient_data = %q, want %q", tt.name, got.StringValue, tt.want)
+		}
+		if _, ok := ds.Players[0]; ok {
+			t.Errorf("%s: demo-wide metrics created a SteamID 0 player", tt.name)
+		}
+		for sid := uint64(1); sid <= 4; sid++ {
+			ps := ds.Players[sid]
+			if _, ok := ps.GetMetric(Category("anti_cheat"), Key("cheat_likelihood")); ok {
+				t.Errorf("%s: player %d got a cheat_likelihood", tt.name, sid)
+			}
+			if !psHasYes(ps, Key("insufficient_data")) {
+				t.Errorf("%s: player %d missing insufficient_data", tt.name, sid)
+			}
+		}
+		if summary := ReportSummary(ds); !strings.Contains(summary, "Insufficient data: "+tt.want) {
+			t.Errorf("%s: summary = %q", tt.name, summary)
+		}
+	}
+}
+
+func TestCheatDetectorScoresDemoWithKills(t *testing.T) {
+	ds := zeroKillDemo(5)
+	ds.Players[1].AddMetric(Category("kills"), Key("total_kills"), Metric{Type: MetricInteger, IntValue: 3})
+	NewCheatDetector().CollectFinalStats(ds)
+
+	if _, ok := ds.Players[1].GetMetric(Category("anti_cheat"), Key("cheat_likelihood")); !ok {
+		t.Error("demo with kills was not scored")
+	}
+	if _, ok := ds.GetMetric(Category("game_info"), Key("insufficient_data")); ok {
+		t.Error("demo with kills marked insufficient")
+	}
+}
+
+func TestCheatDetectorAllowlist(t *testing.T) {
+	// A threshold of 1% flags the whole lobby.
+	w := CheatWeights{FlagThreshold: 1}
+	lobby := labeledLobby(0)
+	cd := NewCheatDetectorWithWeights(w)
+	cd.SetAllowlist(1)
+	cd.CollectFinalStats(lobby.Stats)
+
+	trusted, other := lobby.Stats.Players[1], lobby.Stats.Players[2]
+	if psHasYes(trusted, KeyCheater) {
+		t.Error("allowlisted player flagged")
+	}
+	if !psHasYes(trusted, KeyAllowlisted) {
+		t.Error("allowlisted player missing allowlisted=Yes")
+	}
+	if v, ok := psGetFloat(trusted, CatAntiCheat, KeyCheatLikelihood); !ok || v < 1 {
+		t.Errorf("allowlisted player likelihood = %.2f, %v; want it still published", v, ok)
+	}
+	if !psHasYes(other, KeyCheater) || psHasYes(other, KeyAllowlisted) {
+		t.Error("player off the allowlist should be flagged and not allowlisted")
+	}
+
+	// Rescoring a saved report keeps the allowlist.
+	RescoreCheat(lobby.Stats, w, 0)
+	if psHasYes(trusted, KeyCheater) {
+		t.Error("allowlisted player flagged after RescoreCheat")
+	}
+}
+
+func TestApplyMinKillsCap(t *testing.T) {
+	tests := []struct {
+		name      string
+		kills     int64
+		minKills  int
+		score     float64
+		want      float64
+		wantApply bool
+	}{
+		{"below min kills", 4, 10, 90, 49, true},
+		{"below min kills, score already low", 4, 10, 20, 20, true},
+		{"at min kills", 10, 10, 90, 90, false},
+		{"cap disabled", 4, 0, 90, 90, false},
+	}
+	for _, tt := range tests {
+		ps := NewDemoStats().GetOrCreatePlayerStatsBySteamID(1)
+		ps.AddMetric(CatKills, KeyTotalKills, Metric{Type: MetricInteger, IntValue: tt.kills})
+		got, applied := applyMinKillsCap(tt.score, ps, tt.minKills, 50)
+		if got != tt.want || applied != tt.wantApply {
+			t.Errorf("%s: applyMinKillsCap = (%.1f, %v), want (%.1f, %v)", tt.name, got, applied, tt.want, tt.wantApply)
+		}
+	}
+}
+
+func TestCheatDetectorMinKillsCapPerPlayer(t *testing.T) {
+	// Both players trip the wallbang override (pinned to 100); only the one
+	// under MinKillsForFlag is capped.
+	ds := zeroKillDemo(5)
+	for sid, kills := range map[uint64]int64{1: 4, 2: 20} {
+		ps := ds.Players[sid]
+		ps.AddMetric(CatKills, KeyTotalKills, Metric{Type: MetricInteger, IntValue: kills})
+		ps.AddMetric(CatSniper, KeySniperWallbangKills, Metric{Type: MetricInteger, IntValue: 11})
+	}
+	NewCheatDetector().CollectFinalStats(ds)
+
+	capped, full := ds.Players[1], ds.Players[2]
+	if v, _ := psGetFloat(capped, CatAntiCheat, KeyCheatLikelihood); v >= DefaultCheatWeights().FlagThreshold {
+		t.Errorf("4-kill player likelihood = %.2f, want capped below the flag threshold", v)
+	}
+	if psHasYes(capped, KeyCheater) {
+		t.Error("4-kill player flagged")
+	}
+	if !psHasYes(capped, KeyInsufficientData) {
+		t.Error("4-kill player missing insufficient_data")
+	}
+	if v, _ := psGetFloat(full, CatAntiCheat, KeyCheatLikelihood); v != 100 {
+		t.Errorf("20-kill player likelihood = %.2f, want 100", v)
+	}
+	if psHasYes(full, KeyInsufficientData) {
+		t.Error("20-kill player marked insufficient_data")
+	}
+	if _, ok := ds.GetMetric(CatGameInfo, KeyInsufficientData); ok {
+		t.Error("per-player cap set the demo-wide insufficient_data")
+	}
+}
+
+func TestRetainPlayersKeepsFullLobbyScore(t *testing.T) {
+	likelihood := func(ds *DemoStats) float64 {
+		v, ok := psGetFloat(ds.Players[1], CatAntiCheat, KeyCheatLikelihood)
+		if !ok {
+			t.Fatal("player 1 not scored")
+		}
+		return v
+	}
+
+	full := labeledLobby(0).Stats
+	NewCheatDetector().CollectFinalStats(full)
+
+	// Filtered the way the Analyzer does it: score everyone, then narrow
+	retained := labeledLobby(0).Stats
+	retained.AddSuspiciousEvent(2, 64, "fixture")
+	NewCheatDetector().CollectFinalStats(retained)
+	retained.RetainPlayers(1)
+	if len(retained.Players) != 1 {
+		t.Fatalf("RetainPlayers kept %d players, want 1", len(retained.Players))
+	}
+	if len(retained.SuspiciousEvents(2)) != 0 {
+		t.Error("dropped player's suspicious events kept")
+	}
+	if got, want := likelihood(retained), likelihood(full); got != want {
+		t.Errorf("retained likelihood = %.2f, unfiltered = %.2f", got, want)
+	}
+	if psHasYes(retained.Players[1], KeyCheater) != psHasYes(full.Players[1], KeyCheater) {
+		t.Error("retained verdict differs from the unfiltered one")
+	}
+
+	// Filtering at collection time skips lobby normalization
+	collected := labeledLobby(0).Stats
+	collected.RetainPlayers(1)
+	NewCheatDetector().CollectFinalStats(collected)
+	if likelihood(collected) <= likelihood(full) {
+		t.Errorf("fixture: collection-time filter (%.2f) not above the full run (%.2f)", likelihood(collected), likelihood(full))
+	}
+}
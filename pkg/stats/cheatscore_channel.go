@@ -64,6 +64,15 @@ type Channel struct {
 	Zone       Zone
 	Mode       channelMode
 	HasData    bool
+
+	// ScoreLow and ScoreHigh bound Score by the uncertainty of the reading:
+	// the score at the ends of the raw metric's bootstrap interval when the
+	// collector published one, else a Wilson interval on SampleN (see
+	// fillScoreBounds). Both are [0, 1] and bracket Score.
+	ScoreLow, ScoreHigh float64
+	bounded             bool // ScoreLow/ScoreHigh set by the evaluator
+
+	custom bool // registered through CheatDetector.AddComponent
 }
 
 func clamp01(v float64) float64 {
@@ -104,6 +113,49 @@ func sqrtConfidence(n int64, nFull int) float64 {
 	return clamp01(math.Sqrt(float64(n) / float64(nFull)))
 }
 
+// scoreBounds sets ch.ScoreLow and ch.ScoreHigh from the bootstrap
+// interval of key (<key>_low, <key>_high) when the collector published one.
+// score maps a raw value to the channel score, as for Score itself.
+func scoreBounds(ch *Channel, ps *PlayerStats, cat Category, key Key, score func(raw float64) float64) {
+	lo, okLo := psGetFloat(ps, cat, key+"_low")
+	hi, okHi := psGetFloat(ps, cat, key+"_high")
+	if !okLo || !okHi {
+		return
+	}
+	a, b := score(lo), score(hi)
+	ch.ScoreLow, ch.ScoreHigh = math.Min(math.Min(a, b), ch.Score), math.Max(math.Max(a, b), ch.Score)
+	ch.bounded = true
+}
+
+// boundZ is the normal quantile of the 90% intervals behind ScoreLow and
+// ScoreHigh, matching the bootstrap intervals' 5th–95th percentiles.
+const boundZ = 1.645
+
+// fillScoreBounds gives every channel without a bootstrap interval a
+// sample-size-based one: the Wilson score interval of Score read as a rate
+// over SampleN trials. It is crude — most channels aren't rates — but it
+// shrinks as samples accumulate and stays inside [0, 1] at the extremes,
+// where a blatant reading on three samples must not look certain. Channels
+// without a sample count (custom components) get no width.
+func fillScoreBounds(channels []Channel) {
+	for i := range channels {
+		ch := &channels[i]
+		if ch.bounded {
+			continue
+		}
+		ch.ScoreLow, ch.ScoreHigh = ch.Score, ch.Score
+		if !ch.HasData || ch.SampleN <= 0 {
+			continue
+		}
+		n, p := float64(ch.SampleN), ch.Score
+		z2 := boundZ * boundZ
+		center := (p + z2/(2*n)) / (1 + z2/n)
+		half := boundZ * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / (1 + z2/n)
+		ch.ScoreLow = math.Min(clamp01(center-half), p)
+		ch.ScoreHigh = math.Max(clamp01(center+half), p)
+	}
+}
+
 func zoneFor(score float64) Zone {
 	switch {
 	case score < 0.25:
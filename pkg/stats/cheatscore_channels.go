@@ -1,7 +1,7 @@
 package stats
 
 // cheatscore_channels.go: one evaluate*() function per cheat-score channel.
-// PR2 wires 10 channels total:
+// PR2 wires 11 channels total:
 //
 //   - hs                 — headshot % (bidirectional)
 //   - snap               — P95 snap velocity (positive-only)
@@ -14,18 +14,13 @@ package stats
 //   - attention          — nearest-enemy angle median (positive-only)
 //   - back_killed        — back-killed % (positive-only)
 //   - decoupling         — attention − pre_fov delta (positive-only)
+//   - flash              — kills while fully flashed (positive-only)
+//   - moving_scoped      — scoped sniper kills while moving (positive-only)
+//   - blind_tracking     — kills tracked through walls, never seen (positive-only)
 //
 // Each evaluator returns a Channel; channels missing required inputs return
 // HasData=false and contribute nothing to the combiner.
 
-const (
-	channelCategoryKills      = Category("kills")
-	channelCategoryAiming     = Category("aiming")
-	channelCategoryReaction   = Category("reaction")
-	channelCategoryRecoil     = Category("recoil")
-	channelCategoryBehavioral = Category("behavioral")
-)
-
 // evaluateHS scores headshot percentage. Ramp 55%→75%, n_full=20.
 // Positive-only: a high HS% on many kills is real cheat signal, but a low
 // HS% is not exculpatory — supports, awpers, and post-plant playstyles all
@@ -35,11 +30,11 @@ const (
 // player below 55% HS, drowning out legitimate wallhack signals from
 // pre_fov/decoupling/back_killed.
 func evaluateHS(ps *PlayerStats) Channel {
-	totalKills, hasKills := psGetInt(ps, channelCategoryKills, Key("total_kills"))
+	totalKills, hasKills := psGetInt(ps, CatKills, KeyTotalKills)
 	if !hasKills || totalKills <= 0 {
 		return Channel{ID: "hs", Weight: 0.18, Mode: positiveOnly}
 	}
-	hsPct, _ := psGetFloat(ps, channelCategoryKills, Key("headshot_percentage"))
+	hsPct, _ := psGetFloat(ps, CatKills, KeyHeadshotPercentage)
 	score := linearScore(hsPct, 55.0, 75.0)
 	return Channel{
 		ID:         "hs",
@@ -54,23 +49,40 @@ func evaluateHS(ps *PlayerStats) Channel {
 	}
 }
 
-// evaluateSnap scores P95 snap velocity. Ramp 2.0→3.5 °/ms, n_full=10.
-// Positive-only: a low P95 doesn't exonerate, only flags upward.
+// snapRampLow and snapRampHigh are the snap channel's ramp in °/ms. They
+// are the 2.0→3.5 fit when snap velocities were inflated ×57.3, divided by
+// legacySnapScale: the same calibration in real units, not a new fit.
+const (
+	snapRampLow  = 2.0 / legacySnapScale // ≈0.035 °/ms
+	snapRampHigh = 3.5 / legacySnapScale // ≈0.061 °/ms
+)
+
+// evaluateSnap scores P95 snap velocity. Ramp snapRampLow→snapRampHigh,
+// n_full=10. Positive-only: a low P95 doesn't exonerate, only flags upward.
 //
 // Weight 0.10 (down from 0.12): in pro lobbies every aggressive rifler
-// crosses the 2°/ms threshold occasionally, producing raw=1.0 for ~70% of
+// crosses the bottom of the ramp occasionally, producing raw=1.0 for ~70% of
 // the lobby and shrinking to the same adjusted score after lobby norm. The
 // channel becomes noise rather than signal there. Pre-FOV / decoupling /
 // back-killed carry the wallhack signature more reliably, so the weight
 // budget shifts toward those.
-func evaluateSnap(ps *PlayerStats) Channel {
-	snapCount, hasN := psGetInt(ps, channelCategoryAiming, Key("snap_count"))
+//
+// The ramp anchors are scaled by the map profile's SnapScale.
+//
+// Scores precise snaps only (settled aim, crosshair ends on the victim) when
+// the collector published them; legit flicks are fast but land off-body.
+func evaluateSnap(ps *PlayerStats, profile MapProfile) Channel {
+	countKey, p95Key := snapKeys(ps)
+	snapCount, hasN := psGetInt(ps, CatAiming, countKey)
 	if !hasN || snapCount <= 0 {
 		return Channel{ID: "snap", Weight: 0.10, Mode: positiveOnly}
 	}
-	p95, _ := psGetFloat(ps, channelCategoryAiming, Key("p95_snap_velocity"))
-	score := linearScore(p95, 2.0, 3.5)
-	return Channel{
+	p95, _ := psGetFloat(ps, CatAiming, p95Key)
+	ramp := func(v float64) float64 {
+		return linearScore(v, snapRampLow*profile.SnapScale, snapRampHigh*profile.SnapScale)
+	}
+	score := ramp(p95)
+	ch := Channel{
 		ID:         "snap",
 		Score:      score,
 		Confidence: linearConfidence(snapCount, 10),
@@ -81,6 +93,18 @@ func evaluateSnap(ps *PlayerStats) Channel {
 		Mode:       positiveOnly,
 		HasData:    true,
 	}
+	scoreBounds(&ch, ps, CatAiming, p95Key, ramp)
+	return ch
+}
+
+// snapKeys returns the count and P95 keys the snap channel reads: the
+// precise-snap pair when the collector published it, else the raw pair
+// (older saved results, the per-round grid).
+func snapKeys(ps *PlayerStats) (count, p95 Key) {
+	if _, ok := psGetInt(ps, CatAiming, KeyPreciseSnaps); ok {
+		return KeyPreciseSnaps, KeyP95PreciseSnapVelocity
+	}
+	return KeySnapCount, KeyP95SnapVelocity
 }
 
 // evaluateReactionMedianTTD scores median time-to-damage. Ramp 500→150 ms,
@@ -95,14 +119,19 @@ func evaluateSnap(ps *PlayerStats) Channel {
 // callouts) produces a sub-100ms P10 without indicating any cheat behavior.
 // The median averages across the player's whole engagement distribution and
 // only registers when a CONSISTENT pattern of fast reactions exists.
-func evaluateReactionMedianTTD(ps *PlayerStats) Channel {
-	n, hasN := psGetInt(ps, channelCategoryReaction, Key("ttd_samples"))
+//
+// The ramp anchors are scaled by the map profile's ReactionScale.
+func evaluateReactionMedianTTD(ps *PlayerStats, profile MapProfile) Channel {
+	n, hasN := psGetInt(ps, CatReaction, KeyTTDSamples)
 	if !hasN || n <= 0 {
 		return Channel{ID: "reaction", Weight: 0.10, Mode: bidirectional}
 	}
-	median, _ := psGetFloat(ps, channelCategoryReaction, Key("median_ttd"))
-	score := linearScore(median, 500.0, 150.0) // descending: low ms → high score
-	return Channel{
+	median, _ := psGetFloat(ps, CatReaction, KeyMedianTTD)
+	ramp := func(v float64) float64 {
+		return linearScore(v, 500.0*profile.ReactionScale, 150.0*profile.ReactionScale) // descending: low ms → high score
+	}
+	score := ramp(median)
+	ch := Channel{
 		ID:         "reaction",
 		Score:      score,
 		Confidence: sqrtConfidence(n, 10),
@@ -113,6 +142,8 @@ func evaluateReactionMedianTTD(ps *PlayerStats) Channel {
 		Mode:       bidirectional,
 		HasData:    true,
 	}
+	scoreBounds(&ch, ps, CatReaction, KeyMedianTTD, ramp)
+	return ch
 }
 
 // evaluateTTDSub100 scores the sub-100ms TTD rate. Ramp 2%→30%, n_full=30,
@@ -120,18 +151,19 @@ func evaluateReactionMedianTTD(ps *PlayerStats) Channel {
 // 100ms damage events in a single match is the surprising signal, not the
 // rate). Positive-only.
 func evaluateTTDSub100(ps *PlayerStats) Channel {
-	n, hasN := psGetInt(ps, channelCategoryReaction, Key("ttd_samples"))
+	n, hasN := psGetInt(ps, CatReaction, KeyTTDSamples)
 	if !hasN || n <= 0 {
 		return Channel{ID: "ttd_sub100", Weight: 0.10, Mode: positiveOnly}
 	}
-	rate, _ := psGetFloat(ps, channelCategoryReaction, Key("sub_100ms_ttd"))
-	score := linearScore(rate, 2.0, 30.0)
+	rate, _ := psGetFloat(ps, CatReaction, KeySub100msTTD)
+	ramp := func(v float64) float64 { return linearScore(v, 2.0, 30.0) }
+	score := ramp(rate)
 	conf := sqrtConfidence(n, 30)
 	countSub100 := int64(rate / 100.0 * float64(n))
 	if countSub100 >= 2 {
 		conf = 1.0
 	}
-	return Channel{
+	ch := Channel{
 		ID:         "ttd_sub100",
 		Score:      score,
 		Confidence: conf,
@@ -142,14 +174,16 @@ func evaluateTTDSub100(ps *PlayerStats) Channel {
 		Mode:       positiveOnly,
 		HasData:    true,
 	}
+	scoreBounds(&ch, ps, CatReaction, KeySub100msTTD, ramp)
+	return ch
 }
 
 // evaluateRecoil reuses the already-computed recoil_score (0–1, where 1 is
 // suspicious). Confidence ramps over 20 counted bullets. Positive-only — a
 // clean recoil reading just means we didn't see suspicious sprays.
 func evaluateRecoil(ps *PlayerStats) Channel {
-	raw, ok := psGetFloat(ps, channelCategoryRecoil, Key("recoil_score"))
-	bullets, _ := psGetInt(ps, channelCategoryRecoil, Key("total_counted_bullets"))
+	raw, ok := psGetFloat(ps, CatRecoil, KeyRecoilScore)
+	bullets, _ := psGetInt(ps, CatRecoil, KeyTotalCountedBullets)
 	if !ok || bullets <= 0 {
 		return Channel{ID: "recoil", Weight: 0.10, Mode: positiveOnly}
 	}
@@ -181,11 +215,11 @@ func evaluateRecoil(ps *PlayerStats) Channel {
 // normalization, so concentrating weight here improves cheater/clean
 // separation without altering the underlying ramp.
 func evaluatePreFOV(ps *PlayerStats) Channel {
-	n, hasN := psGetInt(ps, channelCategoryBehavioral, Key("pre_fov_aim_samples"))
+	n, hasN := psGetInt(ps, CatBehavioral, KeyPreFOVAimSamples)
 	if !hasN || n <= 0 {
 		return Channel{ID: "pre_fov", Weight: 0.22, Mode: bidirectional}
 	}
-	med, _ := psGetFloat(ps, channelCategoryBehavioral, Key("pre_fov_aim_median_deg"))
+	med, _ := psGetFloat(ps, CatBehavioral, KeyPreFOVAimMedianDeg)
 	score := linearScore(med, 12.0, 4.0)
 	return Channel{
 		ID:         "pre_fov",
@@ -205,11 +239,11 @@ func evaluatePreFOV(ps *PlayerStats) Channel {
 // Positive-only — a high attention angle just means crosshair isn't tight,
 // which isn't exoneration.
 func evaluateAttention(ps *PlayerStats) Channel {
-	n, hasN := psGetInt(ps, channelCategoryBehavioral, Key("nearest_enemy_angle_samples"))
+	n, hasN := psGetInt(ps, CatBehavioral, KeyNearestEnemyAngleSamples)
 	if !hasN || n <= 0 {
 		return Channel{ID: "attention", Weight: 0.06, Mode: positiveOnly}
 	}
-	med, _ := psGetFloat(ps, channelCategoryBehavioral, Key("nearest_enemy_angle_median_deg"))
+	med, _ := psGetFloat(ps, CatBehavioral, KeyNearestEnemyAngleMedianDeg)
 	score := linearScore(med, 33.0, 18.0)
 	return Channel{
 		ID:         "attention",
@@ -227,11 +261,11 @@ func evaluateAttention(ps *PlayerStats) Channel {
 // evaluateBackKilled scores back-killed avoidance. Ramp 25%→3% (clean→blatant
 // — descending; low back-killed rate is suspicious). n_full=8 deaths.
 func evaluateBackKilled(ps *PlayerStats) Channel {
-	n, hasN := psGetInt(ps, channelCategoryBehavioral, Key("back_killed_total_deaths"))
+	n, hasN := psGetInt(ps, CatBehavioral, KeyBackKilledTotalDeaths)
 	if !hasN || n <= 0 {
 		return Channel{ID: "back_killed", Weight: 0.06, Mode: positiveOnly}
 	}
-	rate, _ := psGetFloat(ps, channelCategoryBehavioral, Key("back_killed_pct"))
+	rate, _ := psGetFloat(ps, CatBehavioral, KeyBackKilledPct)
 	score := linearScore(rate, 25.0, 3.0)
 	return Channel{
 		ID:         "back_killed",
@@ -251,13 +285,13 @@ func evaluateBackKilled(ps *PlayerStats) Channel {
 // crosshair drifts during chill moments; legit players are consistent.
 // Ramp 8°→22°, positive-only. Silent if either half is missing.
 func evaluateDecoupling(ps *PlayerStats) Channel {
-	preFOVN, hasFOVN := psGetInt(ps, channelCategoryBehavioral, Key("pre_fov_aim_samples"))
-	attN, hasAttN := psGetInt(ps, channelCategoryBehavioral, Key("nearest_enemy_angle_samples"))
+	preFOVN, hasFOVN := psGetInt(ps, CatBehavioral, KeyPreFOVAimSamples)
+	attN, hasAttN := psGetInt(ps, CatBehavioral, KeyNearestEnemyAngleSamples)
 	if !hasFOVN || preFOVN <= 0 || !hasAttN || attN <= 0 {
 		return Channel{ID: "decoupling", Weight: 0.10, Mode: positiveOnly}
 	}
-	preFOVMed, _ := psGetFloat(ps, channelCategoryBehavioral, Key("pre_fov_aim_median_deg"))
-	attMed, _ := psGetFloat(ps, channelCategoryBehavioral, Key("nearest_enemy_angle_median_deg"))
+	preFOVMed, _ := psGetFloat(ps, CatBehavioral, KeyPreFOVAimMedianDeg)
+	attMed, _ := psGetFloat(ps, CatBehavioral, KeyNearestEnemyAngleMedianDeg)
 	delta := attMed - preFOVMed
 	score := linearScore(delta, 8.0, 22.0)
 
@@ -281,19 +315,96 @@ func evaluateDecoupling(ps *PlayerStats) Channel {
 	}
 }
 
-// evaluateChannelsForPlayer runs the 9 lobby-independent channels for one
+// evaluateFlash scores kills made while still fully blind from a flash.
+// Ramp 1→4 kills, positive-only: one lucky spray through a flash is normal,
+// a pattern of them is aim that doesn't need to see. n_full=8 full flashes
+// taken — the chances a player had to do it.
+func evaluateFlash(ps *PlayerStats) Channel {
+	n, hasN := psGetInt(ps, CatFlash, KeyFullFlashes)
+	if !hasN || n <= 0 {
+		return Channel{ID: "flash", Weight: 0.06, Mode: positiveOnly}
+	}
+	kills, _ := psGetInt(ps, CatFlash, KeyKilledWhileNearBlind)
+	score := linearScore(float64(kills), 1.0, 4.0)
+	return Channel{
+		ID:         "flash",
+		Score:      score,
+		Confidence: linearConfidence(n, 8),
+		Raw:        float64(kills),
+		SampleN:    n,
+		Weight:     0.06,
+		Zone:       zoneFor(score),
+		Mode:       positiveOnly,
+		HasData:    true,
+	}
+}
+
+// evaluateMovingScoped scores scoped sniper kills made while moving too fast
+// to be accurate. Ramp 1→3 kills, positive-only: a single one can be the
+// inaccuracy cone landing kindly, a few are a bot that doesn't wait to stop.
+// n_full=6 scoped sniper kills.
+func evaluateMovingScoped(ps *PlayerStats) Channel {
+	n, hasN := psGetInt(ps, CatSniper, KeyScopedSniperKills)
+	if !hasN || n <= 0 {
+		return Channel{ID: "moving_scoped", Weight: 0.05, Mode: positiveOnly}
+	}
+	kills, _ := psGetInt(ps, CatSniper, KeyMovingScopedKills)
+	score := linearScore(float64(kills), 1.0, 3.0)
+	return Channel{
+		ID:         "moving_scoped",
+		Score:      score,
+		Confidence: linearConfidence(n, 6),
+		Raw:        float64(kills),
+		SampleN:    n,
+		Weight:     0.05,
+		Zone:       zoneFor(score),
+		Mode:       positiveOnly,
+		HasData:    true,
+	}
+}
+
+// evaluateBlindTracking scores kills on a victim the killer never had line
+// of sight to during the fight, after following them with the crosshair
+// through the wall or smoke. Ramp 1→3 kills, positive-only: one can be a
+// sound-read player held behind thin cover, a few are the wallhack's
+// signature. Weighted as high as any channel because nothing but knowing
+// where the victim is produces it. n_full=10 gun kills.
+func evaluateBlindTracking(ps *PlayerStats) Channel {
+	n, hasN := psGetInt(ps, CatKills, KeyTotalKills)
+	kills, hasKills := psGetInt(ps, CatWallhack, KeyBlindTrackingKills)
+	if !hasN || n <= 0 || !hasKills {
+		return Channel{ID: "blind_tracking", Weight: 0.15, Mode: positiveOnly}
+	}
+	score := linearScore(float64(kills), 1.0, 3.0)
+	return Channel{
+		ID:         "blind_tracking",
+		Score:      score,
+		Confidence: linearConfidence(n, 10),
+		Raw:        float64(kills),
+		SampleN:    n,
+		Weight:     0.15,
+		Zone:       zoneFor(score),
+		Mode:       positiveOnly,
+		HasData:    true,
+	}
+}
+
+// evaluateChannelsForPlayer runs the 12 lobby-independent channels for one
 // player. pre_fov_presence is added in the combiner after the lobby context
-// is available.
-func evaluateChannelsForPlayer(ps *PlayerStats) []Channel {
+// is available. profile is the map calibration for the demo being scored.
+func evaluateChannelsForPlayer(ps *PlayerStats, profile MapProfile) []Channel {
 	return []Channel{
 		evaluateHS(ps),
-		evaluateSnap(ps),
-		evaluateReactionMedianTTD(ps),
+		evaluateSnap(ps, profile),
+		evaluateReactionMedianTTD(ps, profile),
 		evaluateTTDSub100(ps),
 		evaluateRecoil(ps),
 		evaluatePreFOV(ps),
 		evaluateAttention(ps),
 		evaluateBackKilled(ps),
 		evaluateDecoupling(ps),
+		evaluateFlash(ps),
+		evaluateMovingScoped(ps),
+		evaluateBlindTracking(ps),
 	}
 }
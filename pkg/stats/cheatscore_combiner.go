@@ -53,14 +53,7 @@ func cheatscoreSigmoid(x float64) float64 {
 func cheatscoreBayesianCombine(channels []Channel) float64 {
 	logOdds := cheatscoreLogit(cheatscorePrior)
 	for _, ch := range channels {
-		if !ch.HasData || ch.Confidence <= 0 || ch.Weight <= 0 {
-			continue
-		}
-		contrib := ch.Weight * ch.Confidence * cheatscoreLogit(ch.Score)
-		if ch.Mode == positiveOnly && contrib < 0 {
-			contrib = 0
-		}
-		logOdds += contrib
+		logOdds += channelContribution(ch)
 	}
 	return cheatscoreSigmoid(logOdds) * 100.0
 }
@@ -120,9 +113,12 @@ func cheatscoreNormalizeLobby(perPlayer map[uint64][]Channel) {
 				if ch.ID != id || !ch.HasData {
 					continue
 				}
-				adjusted := clamp01(ch.Score - lobbyNormAlpha*muTrim)
+				shift := lobbyNormAlpha * muTrim
+				adjusted := clamp01(ch.Score - shift)
 				channels[i].Score = adjusted
 				channels[i].Zone = zoneFor(adjusted)
+				channels[i].ScoreLow = clamp01(ch.ScoreLow - shift)
+				channels[i].ScoreHigh = clamp01(ch.ScoreHigh - shift)
 			}
 			perPlayer[sid] = channels
 		}
@@ -132,14 +128,12 @@ func cheatscoreNormalizeLobby(perPlayer map[uint64][]Channel) {
 // preFOVLobbyTally returns the per-player pre-FOV sample counts and a
 // per-player flag indicating whether the rest of the lobby is asymmetric in
 // pre-FOV samples (≥50% of OTHER players have <2 samples). Used by both the
-// pre_fov_presence channel and the TTD-sub100 floor.
+// pre_fov_presence channel and the TTD-sub100 floor. On POV demos only the
+// recorder has pre-FOV samples, so no one counts as asymmetric.
 func preFOVLobbyTally(demoStats *DemoStats) (samplesBySID map[uint64]int64, asymBySID map[uint64]bool) {
 	samplesBySID = map[uint64]int64{}
 	for sid, ps := range demoStats.Players {
-		if sid == 0 {
-			continue
-		}
-		n, _ := psGetInt(ps, channelCategoryBehavioral, Key("pre_fov_aim_samples"))
+		n, _ := psGetInt(ps, CatBehavioral, KeyPreFOVAimSamples)
 		samplesBySID[sid] = n
 	}
 
@@ -155,7 +149,7 @@ func preFOVLobbyTally(demoStats *DemoStats) (samplesBySID map[uint64]int64, asym
 				lowSampleOthers++
 			}
 		}
-		asymBySID[sid] = others >= 2 && float64(lowSampleOthers)/float64(others) >= 0.5
+		asymBySID[sid] = demoStats.POVRecorder() == 0 && others >= 2 && float64(lowSampleOthers)/float64(others) >= 0.5
 	}
 	return samplesBySID, asymBySID
 }
@@ -171,13 +165,9 @@ func preFOVLobbyTally(demoStats *DemoStats) (samplesBySID map[uint64]int64, asym
 // publish.go still emits the per-channel transparency keys.
 func cheatscoreAddPreFOVPresence(demoStats *DemoStats, perPlayer map[uint64][]Channel, samplesBySID map[uint64]int64, asymBySID map[uint64]bool) {
 	for sid, ps := range demoStats.Players {
-		if sid == 0 {
-			perPlayer[sid] = append(perPlayer[sid], Channel{ID: "pre_fov_presence", Weight: 0.10, Mode: positiveOnly})
-			continue
-		}
 
 		n := samplesBySID[sid]
-		med, _ := psGetFloat(ps, channelCategoryBehavioral, Key("pre_fov_aim_median_deg"))
+		med, _ := psGetFloat(ps, CatBehavioral, KeyPreFOVAimMedianDeg)
 
 		fires := n >= 4 && med > 0 && med <= 10.0 && asymBySID[sid]
 
@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+)
+
+// cheatscore_custom.go lets callers contribute their own channels to the
+// combiner. A custom component is scored like any built-in channel: its
+// output is the channel Score, it enters the log-odds sum as positiveOnly
+// evidence, and it is published as <name>_score, <name>_confidence,
+// <name>_zone and <name>_contribution under anti_cheat.
+
+// ScoreComponent is a caller-supplied cheat-score channel. Fn maps a
+// player's metrics to a suspicion score in [0, 1]; values outside are
+// clamped, and NaN means "no data" and contributes nothing.
+type ScoreComponent struct {
+	Name   string
+	Weight float64
+	Fn     func(*PlayerStats) float64
+}
+
+// builtinChannelIDs are the channel IDs the built-in pipeline evaluates.
+// Custom components can't reuse them.
+var builtinChannelIDs = map[string]bool{
+	"hs": true, "snap": true, "reaction": true, "ttd_sub100": true,
+	"recoil": true, "pre_fov": true, "attention": true, "back_killed": true,
+	"decoupling": true, "flash": true, "pre_fov_presence": true,
+	"moving_scoped": true, "blind_tracking": true,
+}
+
+// AddComponent registers a custom channel. weight is on the same scale as
+// the built-in channel weights (0.06–0.22); the combiner rescales all
+// weights so the total stays that of the built-in channels, which means a
+// custom component dilutes the built-in ones instead of inflating every
+// score. AddComponent panics on an empty or duplicate name, a name that
+// clashes with a built-in channel, a non-positive weight or a nil fn.
+func (cd *CheatDetector) AddComponent(name string, weight float64, fn func(*PlayerStats) float64) {
+	if name == "" || builtinChannelIDs[name] {
+		panic(fmt.Sprintf("stats: invalid cheat-score component name %q", name))
+	}
+	for _, c := range cd.components {
+		if c.Name == name {
+			panic(fmt.Sprintf("stats: duplicate cheat-score component %q", name))
+		}
+	}
+	if weight <= 0 || fn == nil {
+		panic(fmt.Sprintf("stats: cheat-score component %q needs a positive weight and a function", name))
+	}
+	cd.components = append(cd.components, ScoreComponent{Name: name, Weight: weight, Fn: fn})
+}
+
+// evaluateCustomChannels runs every custom component for one player.
+func evaluateCustomChannels(ps *PlayerStats, components []ScoreComponent) []Channel {
+	channels := make([]Channel, 0, len(components))
+	for _, c := range components {
+		ch := Channel{ID: c.Name, Weight: c.Weight, Mode: positiveOnly, custom: true}
+		raw := c.Fn(ps)
+		if !math.IsNaN(raw) {
+			score := clamp01(raw)
+			ch.Score = score
+			ch.Raw = raw
+			ch.Confidence = 1
+			ch.Zone = zoneFor(score)
+			ch.HasData = true
+		}
+		channels = append(channels, ch)
+	}
+	return channels
+}
+
+// normalizeChannelWeights rescales every channel's weight so the total
+// equals that of the built-in channels alone. Without custom channels it is
+// a no-op, so built-in calibration is unchanged.
+func normalizeChannelWeights(channels []Channel) {
+	builtin, total := 0.0, 0.0
+	for _, ch := range channels {
+		total += ch.Weight
+		if !ch.custom {
+			builtin += ch.Weight
+		}
+	}
+	if total <= 0 || builtin == total {
+		return
+	}
+	scale := builtin / total
+	for i := range channels {
+		channels[i].Weight *= scale
+	}
+}
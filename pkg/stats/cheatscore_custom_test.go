@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCheatDetectorCustomComponent(t *testing.T) {
+	wallbangs := func(ps *PlayerStats) float64 {
+		n, ok := psGetInt(ps, Category("wallbang"), Key("tracked_wallbangs"))
+		if !ok {
+			return math.NaN()
+		}
+		return float64(n) / 3.0
+	}
+
+	score := func(withComponent bool) *DemoStats {
+		ds := zeroKillDemo(5)
+		ds.Players[1].AddMetric(Category("kills"), Key("total_kills"), Metric{Type: MetricInteger, IntValue: 20})
+		ds.Players[1].AddMetric(Category("wallbang"), Key("tracked_wallbangs"), Metric{Type: MetricInteger, IntValue: 4})
+		cd := NewCheatDetector()
+		cd.MinKillsForFlag = 0
+		if withComponent {
+			cd.AddComponent("tracked_wallbang", 0.2, wallbangs)
+		}
+		cd.CollectFinalStats(ds)
+		return ds
+	}
+
+	base, custom := score(false), score(true)
+	before, _ := psGetFloat(base.Players[1], Category("anti_cheat"), Key("cheat_likelihood"))
+	after, _ := psGetFloat(custom.Players[1], Category("anti_cheat"), Key("cheat_likelihood"))
+	if after <= before {
+		t.Errorf("cheat_likelihood with component = %.2f, want above %.2f", after, before)
+	}
+
+	if got, _ := psGetFloat(custom.Players[1], Category("anti_cheat"), Key("tracked_wallbang_score")); got != 1 {
+		t.Errorf("tracked_wallbang_score = %v, want 1 (clamped)", got)
+	}
+	if got, _ := psGetFloat(custom.Players[1], Category("anti_cheat"), Key("tracked_wallbang_contribution")); got <= 0 {
+		t.Errorf("tracked_wallbang_contribution = %v, want > 0", got)
+	}
+	if got, _ := psGetFloat(custom.Players[2], Category("anti_cheat"), Key("tracked_wallbang_contribution")); got != 0 {
+		t.Errorf("player without data contributed %v", got)
+	}
+}
+
+func TestNormalizeChannelWeights(t *testing.T) {
+	channels := []Channel{
+		{ID: "hs", Weight: 0.6},
+		{ID: "snap", Weight: 0.4},
+		{ID: "mine", Weight: 1.0, custom: true},
+	}
+	normalizeChannelWeights(channels)
+	total := 0.0
+	for _, ch := range channels {
+		total += ch.Weight
+	}
+	if math.Abs(total-1.0) > 1e-9 {
+		t.Errorf("total weight = %v, want built-in total 1.0", total)
+	}
+	if math.Abs(channels[0].Weight-0.3) > 1e-9 || math.Abs(channels[2].Weight-0.5) > 1e-9 {
+		t.Errorf("weights = %v, %v; want 0.3, 0.5", channels[0].Weight, channels[2].Weight)
+	}
+}
+
+func TestAddComponentRejectsBuiltinName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("AddComponent accepted a built-in channel name")
+		}
+	}()
+	NewCheatDetector().AddComponent("hs", 0.1, func(*PlayerStats) float64 { return 0 })
+}
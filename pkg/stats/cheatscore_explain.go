@@ -0,0 +1,134 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// cheatscore_explain.go builds cheat_explanation: a one-line, review-ready
+// summary of why a player scored what they did. Unlike the narrative (which
+// tiers channels by raw value for readability), the explanation reports each
+// channel's actual log-odds contribution to the combiner. The contributions
+// add in log-odds, on top of the prior: the sigmoid of prior + sum is the
+// pre-boost likelihood, so they are not percentage points. Boosts and
+// overrides that fired follow the channel list in pipeline order.
+
+// explanationMaxFactors bounds how many channel contributions are listed.
+// Smaller contributions are still in the per-channel metrics.
+const explanationMaxFactors = 5
+
+// explanationMinContribution hides channels whose contribution rounds to zero.
+const explanationMinContribution = 0.005
+
+// explanationFactor describes one channel in plain English. raw formats the
+// channel's Raw value with its unit.
+var explanationFactor = map[string]struct {
+	label string
+	raw   func(v float64) string
+}{
+	"hs":               {"headshot rate", func(v float64) string { return fmt.Sprintf("%.0f%%", v) }},
+	"snap":             {"snap velocity", func(v float64) string { return fmt.Sprintf("p95 %.3f°/ms", v) }},
+	"reaction":         {"reaction time", func(v float64) string { return fmt.Sprintf("median %.0f ms", v) }},
+	"ttd_sub100":       {"sub-100 ms reactions", func(v float64) string { return fmt.Sprintf("%.0f%%", v) }},
+	"recoil":           {"recoil control", func(v float64) string { return fmt.Sprintf("score %.2f", v) }},
+	"pre_fov":          {"pre-FOV pre-aim", func(v float64) string { return fmt.Sprintf("median %.1f°", v) }},
+	"pre_fov_presence": {"pre-FOV presence", func(v float64) string { return fmt.Sprintf("%.0f samples", v) }},
+	"attention":        {"idle attention", func(v float64) string { return fmt.Sprintf("median %.1f°", v) }},
+	"back_killed":      {"back-killed rate", func(v float64) string { return fmt.Sprintf("%.0f%%", v) }},
+	"decoupling":       {"fight vs idle decoupling", func(v float64) string { return fmt.Sprintf("Δ %.1f°", v) }},
+	"flash":            {"kills while flashed", func(v float64) string { return fmt.Sprintf("%.0f kills", v) }},
+	"moving_scoped":    {"moving scoped kills", func(v float64) string { return fmt.Sprintf("%.0f kills", v) }},
+	"blind_tracking":   {"kills tracked through walls", func(v float64) string { return fmt.Sprintf("%.0f kills", v) }},
+}
+
+// channelContribution is the log-odds a channel adds in the Bayesian
+// combiner: weight × confidence × logit(score), with negative evidence
+// dropped for positive-only channels.
+func channelContribution(ch Channel) float64 {
+	if !ch.HasData || ch.Confidence <= 0 || ch.Weight <= 0 {
+		return 0
+	}
+	contrib := ch.Weight * ch.Confidence * cheatscoreLogit(ch.Score)
+	if ch.Mode == positiveOnly && contrib < 0 {
+		return 0
+	}
+	return contrib
+}
+
+// buildCheatExplanation returns e.g.
+//
+//	"Headshot rate (78%, +0.45), reaction time (median 140 ms, +0.15); Wingman boost ×1.8 (KPR 0.85)"
+//
+// Channels are ordered by absolute contribution, largest first, ties broken
+// by channel ID so the output is deterministic.
+func buildCheatExplanation(opt publishOptions) string {
+	type factor struct {
+		id      string
+		raw     float64
+		contrib float64
+	}
+	factors := make([]factor, 0, len(opt.channels))
+	for _, ch := range opt.channels {
+		c := channelContribution(ch)
+		if math.Abs(c) < explanationMinContribution {
+			continue
+		}
+		factors = append(factors, factor{id: ch.ID, raw: ch.Raw, contrib: c})
+	}
+	sort.Slice(factors, func(i, j int) bool {
+		ai, aj := math.Abs(factors[i].contrib), math.Abs(factors[j].contrib)
+		if ai != aj {
+			return ai > aj
+		}
+		return factors[i].id < factors[j].id
+	})
+	if len(factors) > explanationMaxFactors {
+		factors = factors[:explanationMaxFactors]
+	}
+
+	parts := make([]string, 0, len(factors))
+	for _, f := range factors {
+		label, value := f.id, fmt.Sprintf("%.2f", f.raw)
+		if d, ok := explanationFactor[f.id]; ok {
+			label, value = d.label, d.raw(f.raw)
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s, %+.2f)", label, value, f.contrib))
+	}
+
+	adjustments := make([]string, 0, 4)
+	if opt.wingmanBoosted {
+		adjustments = append(adjustments, fmt.Sprintf("Wingman boost ×1.8 (%s)", opt.wingmanReason))
+	}
+	if opt.competitiveBoost {
+		adjustments = append(adjustments, "Competitive boost ×1.2")
+	}
+	if opt.positionDiscount > 0 {
+		adjustments = append(adjustments, fmt.Sprintf("position discount −%.0f%%", opt.positionDiscount*100))
+	}
+	if opt.evidenceStacking {
+		adjustments = append(adjustments, fmt.Sprintf("evidence stacking ×%.1f (%d strong channels)", evidenceStackingMultiplier, opt.evidenceStackingCount))
+	}
+	if opt.coOccurrenceBoost {
+		adjustments = append(adjustments, fmt.Sprintf("wallhack co-occurrence ×%.1f", coOccurrenceMultiplier))
+	}
+	if opt.ttdSub100Floor {
+		adjustments = append(adjustments, fmt.Sprintf("sub-100 ms TTD floor %.0f%%", ttdSub100FloorScore))
+	}
+	for _, name := range opt.sniperOverrides {
+		adjustments = append(adjustments, strings.ReplaceAll(name, "_", " ")+" → 100%")
+	}
+	if opt.insufficientData {
+		adjustments = append(adjustments, fmt.Sprintf("capped at %.0f%% (fewer than %d kills)", opt.flagThreshold-insufficientDataMargin, opt.minKillsForFlag))
+	}
+
+	explanation := strings.Join(parts, ", ")
+	if explanation == "" {
+		explanation = "No channel contributed evidence"
+	}
+	if len(adjustments) > 0 {
+		explanation += "; " + strings.Join(adjustments, ", ")
+	}
+	return strings.ToUpper(explanation[:1]) + explanation[1:]
+}
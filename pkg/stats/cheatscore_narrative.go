@@ -111,19 +111,22 @@ func buildCheatscoreNarrative(ps *PlayerStats) string {
 
 	// Closing sentences for boosts that fired. Co-occurrence subsumes evidence
 	// stacking narratively, so don't repeat both.
-	coOccur := psHasYes(ps, Key("wallhack_co_occurrence_boost"))
+	coOccur := psHasYes(ps, KeyWallhackCoOccurrenceBoost)
 	switch {
-	case psHasYes(ps, Key("sniper_wallbang_override")) || psHasYes(ps, Key("scout_precision_override")):
+	case psHasYes(ps, KeySniperWallbangOverride) || psHasYes(ps, KeyScoutPrecisionOverride):
 		sentences = append(sentences, "A sniper-anomaly override pinned likelihood to 100%.")
 	case coOccur:
 		sentences = append(sentences, "The wallhack co-occurrence pattern triggered — both pre-FOV pre-aim AND elevated back-kill-given rate together, the wallhack-via-info signature.")
-	case psHasYes(ps, Key("evidence_stacking_boost")):
+	case psHasYes(ps, KeyEvidenceStackingBoost):
 		sentences = append(sentences, "Multiple strong channels co-occur, triggering the evidence-stacking boost.")
 	}
-	if psHasYes(ps, Key("ttd_sub100_high_floor")) {
+	if psHasYes(ps, KeyTTDSub100HighFloor) {
 		sentences = append(sentences, "The sub-100 ms time-to-damage floor enforced a 55% minimum likelihood.")
 	}
-	if psHasYes(ps, Key("wingman_boost")) {
+	if psHasYes(ps, KeyInsufficientData) {
+		sentences = append(sentences, "Too few kills to support a flag — likelihood was capped below the threshold.")
+	}
+	if psHasYes(ps, KeyWingmanBoost) {
 		sentences = append(sentences, "A Wingman-match KPR boost was applied to reflect the short-format pace.")
 	}
 
@@ -151,17 +154,20 @@ func collectNarrativeChannels(ps *PlayerStats) []narrativeChannel {
 	// HS%: ascending suspicion. Tier breakpoints aim at "above-average rifler",
 	// "highly accurate", "headshot-machine" rather than chasing the channel's
 	// 55→75 ramp directly — the ramp is gentle but human HS rates do cluster.
-	if raw, n, ok := channelRaw(ps, channelCategoryKills, Key("headshot_percentage"), channelCategoryKills, Key("total_kills")); ok && n >= 10 {
+	if raw, n, ok := channelRaw(ps, CatKills, KeyHeadshotPercentage, CatKills, KeyTotalKills); ok && n >= 10 {
 		if tier := narrativeTier(raw, 55.0, 65.0, 75.0, true); tier > 0 {
 			out = append(out, narrativeChannel{id: "hs", tier: tier, raw: raw, sampleN: n})
 		}
 	}
 
-	// Snap P95 velocity: ascending suspicion. The channel ramp is 2.0→3.5 °/ms,
-	// but in practice most riflers cross 2 occasionally — meaningful outliers
-	// start around 6, and the wingman cheaters logged ~8.
-	if raw, n, ok := channelRaw(ps, channelCategoryAiming, Key("p95_snap_velocity"), channelCategoryAiming, Key("snap_count")); ok && n >= 5 {
-		if tier := narrativeTier(raw, 4.0, 6.0, 10.0, true); tier > 0 {
+	// Snap P95 velocity: ascending suspicion. The channel ramp is
+	// snapRampLow→snapRampHigh, but in practice most riflers cross the bottom
+	// occasionally — meaningful outliers start around 6 on the old ×57.3
+	// scale (legacySnapScale), and the wingman cheaters logged ~8 there. The
+	// tiers are those values converted to real °/ms.
+	countKey, p95Key := snapKeys(ps)
+	if raw, n, ok := channelRaw(ps, CatAiming, p95Key, CatAiming, countKey); ok && n >= 5 {
+		if tier := narrativeTier(raw, 4.0/legacySnapScale, 6.0/legacySnapScale, 10.0/legacySnapScale, true); tier > 0 {
 			out = append(out, narrativeChannel{id: "snap", tier: tier, raw: raw, sampleN: n})
 		}
 	}
@@ -172,7 +178,7 @@ func collectNarrativeChannels(ps *PlayerStats) []narrativeChannel {
 	// <150ms. Median is the "well-established" signal; P10 alone is too noisy
 	// because a single pre-fired tick produces sub-100ms reactions
 	// legitimately. Median requires a sustained fast-reaction pattern.
-	if raw, n, ok := channelRaw(ps, channelCategoryReaction, Key("median_ttd"), channelCategoryReaction, Key("ttd_samples")); ok && n >= 10 {
+	if raw, n, ok := channelRaw(ps, CatReaction, KeyMedianTTD, CatReaction, KeyTTDSamples); ok && n >= 10 {
 		if tier := narrativeTier(raw, 400.0, 250.0, 150.0, false); tier > 0 {
 			out = append(out, narrativeChannel{id: "reaction", tier: tier, raw: raw, sampleN: n})
 		}
@@ -180,7 +186,7 @@ func collectNarrativeChannels(ps *PlayerStats) []narrativeChannel {
 
 	// Sub-100ms TTD rate: ascending. Any sustained sub-100ms response rate is
 	// suspicious; 30%+ is the "triggerbot pattern" zone.
-	if raw, n, ok := channelRaw(ps, channelCategoryReaction, Key("sub_100ms_ttd"), channelCategoryReaction, Key("ttd_samples")); ok && n >= 5 {
+	if raw, n, ok := channelRaw(ps, CatReaction, KeySub100msTTD, CatReaction, KeyTTDSamples); ok && n >= 5 {
 		if tier := narrativeTier(raw, 15.0, 25.0, 35.0, true); tier > 0 {
 			out = append(out, narrativeChannel{id: "ttd_sub100", tier: tier, raw: raw, sampleN: n})
 		}
@@ -188,7 +194,7 @@ func collectNarrativeChannels(ps *PlayerStats) []narrativeChannel {
 
 	// Recoil score: ascending, but score-based rather than raw-meaningful.
 	// The published recoil_score is already 0-1.
-	if raw, n, ok := channelRaw(ps, channelCategoryRecoil, Key("recoil_score"), channelCategoryRecoil, Key("total_counted_bullets")); ok && n >= 20 {
+	if raw, n, ok := channelRaw(ps, CatRecoil, KeyRecoilScore, CatRecoil, KeyTotalCountedBullets); ok && n >= 20 {
 		if tier := narrativeTier(raw, 0.30, 0.55, 0.80, true); tier > 0 {
 			out = append(out, narrativeChannel{id: "recoil", tier: tier, raw: raw, sampleN: n})
 		}
@@ -196,7 +202,7 @@ func collectNarrativeChannels(ps *PlayerStats) []narrativeChannel {
 
 	// Pre-FOV pre-aim median: descending (tighter = more suspect). Wingman
 	// cheaters land 6.17° and 7.25°; szpont is at 5.85°. Pros typically 7-10°.
-	if raw, n, ok := channelRaw(ps, channelCategoryBehavioral, Key("pre_fov_aim_median_deg"), channelCategoryBehavioral, Key("pre_fov_aim_samples")); ok && n >= 4 {
+	if raw, n, ok := channelRaw(ps, CatBehavioral, KeyPreFOVAimMedianDeg, CatBehavioral, KeyPreFOVAimSamples); ok && n >= 4 {
 		if tier := narrativeTier(raw, 8.0, 7.0, 6.0, false); tier > 0 {
 			out = append(out, narrativeChannel{id: "pre_fov", tier: tier, raw: raw, sampleN: n})
 		}
@@ -204,8 +210,8 @@ func collectNarrativeChannels(ps *PlayerStats) []narrativeChannel {
 
 	// Pre-FOV presence: fires only when the boost rule fires (lobby asymmetry
 	// + tight median). Use the published *_score to detect that it fired.
-	if score, ok := psGetFloat(ps, cheatscoreCategoryAntiCheat, Key("pre_fov_presence_score")); ok && score >= 0.50 {
-		n, _ := psGetInt(ps, channelCategoryBehavioral, Key("pre_fov_aim_samples"))
+	if score, ok := psGetFloat(ps, CatAntiCheat, KeyPreFOVPresenceScore); ok && score >= 0.50 {
+		n, _ := psGetInt(ps, CatBehavioral, KeyPreFOVAimSamples)
 		tier := 2
 		if score >= 0.80 {
 			tier = 3
@@ -215,7 +221,7 @@ func collectNarrativeChannels(ps *PlayerStats) []narrativeChannel {
 
 	// Off-engagement attention drift: descending (smaller drift to nearest
 	// enemy = more aware = more suspect). Pro baseline ~30°; suspect <25°.
-	if raw, n, ok := channelRaw(ps, channelCategoryBehavioral, Key("nearest_enemy_angle_median_deg"), channelCategoryBehavioral, Key("nearest_enemy_angle_samples")); ok && n >= 200 {
+	if raw, n, ok := channelRaw(ps, CatBehavioral, KeyNearestEnemyAngleMedianDeg, CatBehavioral, KeyNearestEnemyAngleSamples); ok && n >= 200 {
 		if tier := narrativeTier(raw, 27.0, 24.0, 20.0, false); tier > 0 {
 			out = append(out, narrativeChannel{id: "attention", tier: tier, raw: raw, sampleN: n})
 		}
@@ -224,7 +230,7 @@ func collectNarrativeChannels(ps *PlayerStats) []narrativeChannel {
 	// Back-killed rate: descending (lower = never caught from behind = more
 	// suspect). Need ≥8 deaths to draw any conclusion; 0% on a large sample
 	// is the strongest reading.
-	if raw, n, ok := channelRaw(ps, channelCategoryBehavioral, Key("back_killed_pct"), channelCategoryBehavioral, Key("back_killed_total_deaths")); ok && n >= 8 {
+	if raw, n, ok := channelRaw(ps, CatBehavioral, KeyBackKilledPct, CatBehavioral, KeyBackKilledTotalDeaths); ok && n >= 8 {
 		if tier := narrativeTier(raw, 8.0, 4.0, 0.01, false); tier > 0 {
 			out = append(out, narrativeChannel{id: "back_killed", tier: tier, raw: raw, sampleN: n})
 		}
@@ -232,8 +238,8 @@ func collectNarrativeChannels(ps *PlayerStats) []narrativeChannel {
 
 	// Decoupling: use the published score directly (the channel raw is a
 	// derived delta that's less intuitive in prose).
-	if score, ok := psGetFloat(ps, cheatscoreCategoryAntiCheat, Key("decoupling_score")); ok {
-		conf, _ := psGetFloat(ps, cheatscoreCategoryAntiCheat, Key("decoupling_confidence"))
+	if score, ok := psGetFloat(ps, CatAntiCheat, KeyDecouplingScore); ok {
+		conf, _ := psGetFloat(ps, CatAntiCheat, KeyDecouplingConfidence)
 		prod := score * conf
 		var tier int
 		switch {
@@ -257,7 +263,7 @@ func narrativeSentence(c narrativeChannel, adj string) string {
 	case "hs":
 		return fmt.Sprintf("Headshot rate of %.0f%% over %d kills is %s elevated.", c.raw, c.sampleN, adj)
 	case "snap":
-		return fmt.Sprintf("P95 snap velocity of %.2f °/ms across %d snaps is %s above the lobby baseline.", c.raw, c.sampleN, adj)
+		return fmt.Sprintf("P95 snap velocity of %.3f °/ms across %d snaps is %s above the lobby baseline.", c.raw, c.sampleN, adj)
 	case "reaction":
 		return fmt.Sprintf("Median time-to-damage of %.0f ms across %d engagements is %s fast — consistently quick reactions rather than a single prefired tick.", c.raw, c.sampleN, adj)
 	case "ttd_sub100":
@@ -279,6 +285,6 @@ func narrativeSentence(c narrativeChannel, adj string) string {
 }
 
 func psHasYes(ps *PlayerStats, k Key) bool {
-	m, ok := ps.GetMetric(cheatscoreCategoryAntiCheat, k)
+	m, ok := ps.GetMetric(CatAntiCheat, k)
 	return ok && m.StringValue == "Yes"
 }
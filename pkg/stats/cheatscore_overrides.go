@@ -1,6 +1,9 @@
 package stats
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 // PR2 overrides:
 //   - applyWingmanBoost: KPR-based rule (or ≥10 kills backstop) replaces the
@@ -15,9 +18,6 @@ import "fmt"
 //     Applied AFTER boosts so it caps regression, not amplification.
 
 const (
-	cheatscoreCategoryGameInfo  = Category("game_info")
-	cheatscoreCategoryAntiCheat = Category("anti_cheat")
-
 	wingmanKPRThreshold        = 0.7
 	evidenceStackingMinStrong  = 3
 	evidenceStackingMinChannel = 0.30
@@ -35,17 +35,22 @@ const (
 	coOccurrenceBackKillPct    = 8.0
 	coOccurrenceBackKillMin    = 4
 	coOccurrenceMultiplier     = 1.20
+
+	// insufficientDataMargin puts the ceiling applied when a player has
+	// fewer kills than the detector's MinKillsForFlag just under the flag
+	// threshold, so the score stays visible but can never flag on its own.
+	insufficientDataMargin = 1.0
 )
 
 // applyWingmanBoost: ×1.8 in Wingman when KPR ≥ 0.7 OR kills ≥ 10.
 // Returns (new score, fired, human-readable reason).
 func applyWingmanBoost(score float64, ps *PlayerStats) (float64, bool, string) {
-	gameMode, _ := psGetString(ps, cheatscoreCategoryGameInfo, Key("game_mode"))
+	gameMode, _ := psGetString(ps, CatGameInfo, KeyGameMode)
 	if gameMode != "Wingman" {
 		return score, false, ""
 	}
-	totalKills, _ := psGetInt(ps, channelCategoryKills, Key("total_kills"))
-	roundCount, _ := psGetInt(ps, cheatscoreCategoryGameInfo, Key("round_count"))
+	totalKills, _ := psGetInt(ps, CatKills, KeyTotalKills)
+	roundCount, _ := psGetInt(ps, CatGameInfo, KeyRoundCount)
 
 	var kpr float64
 	if roundCount > 0 {
@@ -63,12 +68,12 @@ func applyWingmanBoost(score float64, ps *PlayerStats) (float64, bool, string) {
 
 // applyCompetitiveBoost: ×1.2 in Competitive when totalKills > 39 in ≤30 rounds.
 func applyCompetitiveBoost(score float64, ps *PlayerStats) (float64, bool) {
-	gameMode, _ := psGetString(ps, cheatscoreCategoryGameInfo, Key("game_mode"))
+	gameMode, _ := psGetString(ps, CatGameInfo, KeyGameMode)
 	if gameMode != "Competitive" {
 		return score, false
 	}
-	totalKills, _ := psGetInt(ps, channelCategoryKills, Key("total_kills"))
-	roundCount, _ := psGetInt(ps, cheatscoreCategoryGameInfo, Key("round_count"))
+	totalKills, _ := psGetInt(ps, CatKills, KeyTotalKills)
+	roundCount, _ := psGetInt(ps, CatGameInfo, KeyRoundCount)
 	if totalKills <= 39 || roundCount > 30 {
 		return score, false
 	}
@@ -77,7 +82,7 @@ func applyCompetitiveBoost(score float64, ps *PlayerStats) (float64, bool) {
 
 // applyPositionDiscount multiplies score by (1 - 0.2 × position_factor).
 func applyPositionDiscount(score float64, ps *PlayerStats) (float64, float64) {
-	factor, ok := psGetFloat(ps, scoreboardCategory, Key("position_factor"))
+	factor, ok := psGetFloat(ps, CatScoreboard, KeyPositionFactor)
 	if !ok || factor <= 0 {
 		return score, 0
 	}
@@ -115,20 +120,20 @@ func applyEvidenceStacking(score float64, channels []Channel) (float64, bool, in
 //
 // Applied AFTER boosts so it caps regression, not amplification.
 func applyTTDSub100Floor(score float64, ps *PlayerStats, preFOVLobbyAsymmetric bool) (float64, bool) {
-	n, hasN := psGetInt(ps, channelCategoryReaction, Key("ttd_samples"))
+	n, hasN := psGetInt(ps, CatReaction, KeyTTDSamples)
 	if !hasN || n < ttdSub100FloorSamples {
 		return score, false
 	}
-	rate, hasRate := psGetFloat(ps, channelCategoryReaction, Key("sub_100ms_ttd"))
+	rate, hasRate := psGetFloat(ps, CatReaction, KeySub100msTTD)
 	if !hasRate || rate < ttdSub100FloorRate {
 		return score, false
 	}
 
-	preFOVN, hasFOVN := psGetInt(ps, channelCategoryBehavioral, Key("pre_fov_aim_samples"))
+	preFOVN, hasFOVN := psGetInt(ps, CatBehavioral, KeyPreFOVAimSamples)
 	if !hasFOVN || preFOVN < 3 {
 		return score, false
 	}
-	preFOVMed, hasFOVMed := psGetFloat(ps, channelCategoryBehavioral, Key("pre_fov_aim_median_deg"))
+	preFOVMed, hasFOVMed := psGetFloat(ps, CatBehavioral, KeyPreFOVAimMedianDeg)
 	if !hasFOVMed || preFOVMed <= 0 || preFOVMed > 10.0 {
 		return score, false
 	}
@@ -166,29 +171,46 @@ func applyWallhackCoOccurrenceBoost(score float64, channels []Channel, ps *Playe
 	if preFOVProd < coOccurrencePreFOVProduct {
 		return score, false
 	}
-	pct, hasPct := psGetFloat(ps, channelCategoryBehavioral, Key("back_kill_given_pct"))
+	pct, hasPct := psGetFloat(ps, CatBehavioral, KeyBackKillGivenPct)
 	if !hasPct || pct < coOccurrenceBackKillPct {
 		return score, false
 	}
-	kills, hasKills := psGetInt(ps, channelCategoryBehavioral, Key("back_kill_given_total_kills"))
+	kills, hasKills := psGetInt(ps, CatBehavioral, KeyBackKillGivenTotalKills)
 	if !hasKills || kills < coOccurrenceBackKillMin {
 		return score, false
 	}
 	return score * coOccurrenceMultiplier, true
 }
 
+// applyMinKillsCap caps score just under threshold (see
+// insufficientDataMargin) when the player has fewer than minKills kills.
+// Each channel has its own sample gate, but a single channel can still clear
+// its gate on a handful of kills — this is the global backstop against
+// flagging on too little data. minKills ≤ 0 disables the cap. Returns (new
+// score, whether the cap applied).
+func applyMinKillsCap(score float64, ps *PlayerStats, minKills int, threshold float64) (float64, bool) {
+	if minKills <= 0 {
+		return score, false
+	}
+	totalKills, _ := psGetInt(ps, CatKills, KeyTotalKills)
+	if totalKills >= int64(minKills) {
+		return score, false
+	}
+	return math.Min(score, threshold-insufficientDataMargin), true
+}
+
 // applySniperOverrides pins the score to 100 for Tim's custom high-confidence
 // sniper anomalies. Returns (new score, list of triggered override names).
 func applySniperOverrides(score float64, ps *PlayerStats) (float64, []string) {
 	triggered := []string{}
 
-	if wb, ok := psGetInt(ps, sniperCategory, Key("sniper_wallbang_kills")); ok && wb > 10 {
+	if wb, ok := psGetInt(ps, CatSniper, KeySniperWallbangKills); ok && wb > 10 {
 		score = 100.0
 		triggered = append(triggered, "sniper_wallbang_override")
 	}
 
-	if scoutKills, ok := psGetInt(ps, sniperCategory, Key("scout_kills")); ok && scoutKills > 10 {
-		if scoutHS, ok := psGetFloat(ps, sniperCategory, Key("scout_hs_rate")); ok && scoutHS >= 80.0 {
+	if scoutKills, ok := psGetInt(ps, CatSniper, KeyScoutKills); ok && scoutKills > 10 {
+		if scoutHS, ok := psGetFloat(ps, CatSniper, KeyScoutHSRate); ok && scoutHS >= 80.0 {
 			score = 100.0
 			triggered = append(triggered, "scout_precision_override")
 		}
@@ -2,8 +2,8 @@ package stats
 
 import "fmt"
 
-// cheatscoreFlagThreshold is the cheat_likelihood at or above which a player
-// is flagged. Kept at 50 to match the legacy production constant — the
+// cheatscoreFlagThreshold is the default cheat_likelihood at or above which
+// a player is flagged (see CheatWeights.FlagThreshold). Kept at 50 to match the legacy production constant — the
 // detector_test.go test constant mirrors this.
 const cheatscoreFlagThreshold = 50.0
 
@@ -25,7 +25,26 @@ type publishOptions struct {
 
 	sniperOverrides []string
 
+	// mapProfile is the normalized map name whose calibration profile was
+	// applied, or "" when the neutral fallback was used.
+	mapProfile string
+
+	// insufficientData is set when the likelihood was capped because the
+	// player had fewer than minKillsForFlag kills.
+	insufficientData bool
+	minKillsForFlag  int
+
 	finalLikelihood float64 // [0, 100] after all overrides + boosts
+
+	// likelihoodLow and likelihoodHigh bound finalLikelihood: the pipeline
+	// rerun with every channel at its low or high score bound.
+	likelihoodLow, likelihoodHigh float64
+
+	// flagThreshold is the likelihood at or above which a player is
+	// flagged; flagOnLowerBound flags on likelihoodLow instead of
+	// finalLikelihood.
+	flagThreshold    float64
+	flagOnLowerBound bool
 }
 
 // channelLegacyKey maps a channel ID to the legacy anti_cheat key under which
@@ -43,11 +62,21 @@ var channelLegacyKey = map[string]string{
 // channel emits three keys (<id>_score, <id>_confidence, <id>_zone) plus the
 // legacy alias if one exists.
 func cheatscorePublish(ps *PlayerStats, opt publishOptions) {
-	ps.AddMetric(cheatscoreCategoryAntiCheat, Key("cheat_likelihood"), Metric{
+	ps.AddMetric(CatAntiCheat, KeyCheatLikelihood, Metric{
 		Type:        MetricPercentage,
 		FloatValue:  opt.finalLikelihood,
 		Description: "Estimated likelihood of player cheating",
 	})
+	ps.AddMetric(CatAntiCheat, KeyCheatLikelihoodLow, Metric{
+		Type:        MetricPercentage,
+		FloatValue:  opt.likelihoodLow,
+		Description: "Lower end of the cheat likelihood's confidence interval (every channel at its least suspicious plausible reading)",
+	})
+	ps.AddMetric(CatAntiCheat, KeyCheatLikelihoodHigh, Metric{
+		Type:        MetricPercentage,
+		FloatValue:  opt.likelihoodHigh,
+		Description: "Upper end of the cheat likelihood's confidence interval (every channel at its most suspicious plausible reading)",
+	})
 
 	// Per-channel transparency: <id>_score, <id>_confidence, <id>_zone.
 	// Channels with HasData=false still emit zero values for the score key so
@@ -60,7 +89,7 @@ func cheatscorePublish(ps *PlayerStats, opt publishOptions) {
 
 		// Legacy alias (hs/snap/reaction/recoil keep their old names too).
 		if legacyKey, ok := channelLegacyKey[baseID]; ok {
-			ps.AddMetric(cheatscoreCategoryAntiCheat, Key(legacyKey), Metric{
+			ps.AddMetric(CatAntiCheat, Key(legacyKey), Metric{
 				Type:        MetricFloat,
 				FloatValue:  score,
 				Description: fmt.Sprintf("%s cheat score component (0-1)", baseID),
@@ -70,38 +99,45 @@ func cheatscorePublish(ps *PlayerStats, opt publishOptions) {
 		// Generic <id>_score for non-legacy channels and as the canonical key
 		// for the new ones.
 		if _, hasLegacy := channelLegacyKey[baseID]; !hasLegacy {
-			ps.AddMetric(cheatscoreCategoryAntiCheat, Key(baseID+"_score"), Metric{
+			ps.AddMetric(CatAntiCheat, Key(baseID+"_score"), Metric{
 				Type:        MetricFloat,
 				FloatValue:  score,
 				Description: fmt.Sprintf("%s cheat score component (0-1)", baseID),
 			})
 		}
 
-		ps.AddMetric(cheatscoreCategoryAntiCheat, Key(baseID+"_confidence"), Metric{
+		ps.AddMetric(CatAntiCheat, Key(baseID+"_confidence"), Metric{
 			Type:        MetricFloat,
 			FloatValue:  conf,
 			Description: fmt.Sprintf("Confidence in %s reading (0-1)", baseID),
 		})
-		ps.AddMetric(cheatscoreCategoryAntiCheat, Key(baseID+"_zone"), Metric{
+		ps.AddMetric(CatAntiCheat, Key(baseID+"_zone"), Metric{
 			Type:        MetricString,
 			StringValue: zone.String(),
 			Description: fmt.Sprintf("Interpretation band for %s", baseID),
 		})
+		if ch.custom {
+			ps.AddMetric(CatAntiCheat, Key(baseID+"_contribution"), Metric{
+				Type:        MetricFloat,
+				FloatValue:  channelContribution(ch),
+				Description: fmt.Sprintf("Log-odds contribution of custom component %s", baseID),
+			})
+		}
 	}
 
-	ps.AddMetric(cheatscoreCategoryAntiCheat, Key("total_cheat_score"), Metric{
+	ps.AddMetric(CatAntiCheat, KeyTotalCheatScore, Metric{
 		Type:        MetricFloat,
 		FloatValue:  opt.combined / 100.0,
 		Description: "Pre-boost combined Bayesian likelihood (0-1)",
 	})
 
 	if opt.wingmanBoosted {
-		ps.AddMetric(cheatscoreCategoryAntiCheat, Key("wingman_boost"), Metric{
+		ps.AddMetric(CatAntiCheat, KeyWingmanBoost, Metric{
 			Type:        MetricString,
 			StringValue: "Yes",
 			Description: "Wingman boost applied (" + opt.wingmanReason + ")",
 		})
-		ps.AddMetric(cheatscoreCategoryAntiCheat, Key("wingman_kpr_boost_reason"), Metric{
+		ps.AddMetric(CatAntiCheat, KeyWingmanKPRBoostReason, Metric{
 			Type:        MetricString,
 			StringValue: opt.wingmanReason,
 			Description: "Reason the Wingman boost fired",
@@ -109,7 +145,7 @@ func cheatscorePublish(ps *PlayerStats, opt publishOptions) {
 	}
 
 	if opt.competitiveBoost {
-		ps.AddMetric(cheatscoreCategoryAntiCheat, Key("competitive_boost"), Metric{
+		ps.AddMetric(CatAntiCheat, KeyCompetitiveBoost, Metric{
 			Type:        MetricString,
 			StringValue: "Yes",
 			Description: "Player has more than 39 kills in regulation time (20% boost applied)",
@@ -117,7 +153,7 @@ func cheatscorePublish(ps *PlayerStats, opt publishOptions) {
 	}
 
 	if opt.positionDiscount > 0 {
-		ps.AddMetric(cheatscoreCategoryAntiCheat, Key("position_discount"), Metric{
+		ps.AddMetric(CatAntiCheat, KeyPositionDiscount, Metric{
 			Type:        MetricPercentage,
 			FloatValue:  opt.positionDiscount * 100,
 			Description: "Reduction applied for low scoreboard position vs. teammates",
@@ -125,7 +161,7 @@ func cheatscorePublish(ps *PlayerStats, opt publishOptions) {
 	}
 
 	if opt.evidenceStacking {
-		ps.AddMetric(cheatscoreCategoryAntiCheat, Key("evidence_stacking_boost"), Metric{
+		ps.AddMetric(CatAntiCheat, KeyEvidenceStackingBoost, Metric{
 			Type:        MetricString,
 			StringValue: fmt.Sprintf("Yes (%d strong channels)", opt.evidenceStackingCount),
 			Description: "×1.4 boost — ≥3 channels with score×confidence ≥0.30",
@@ -133,7 +169,7 @@ func cheatscorePublish(ps *PlayerStats, opt publishOptions) {
 	}
 
 	if opt.ttdSub100Floor {
-		ps.AddMetric(cheatscoreCategoryAntiCheat, Key("ttd_sub100_high_floor"), Metric{
+		ps.AddMetric(CatAntiCheat, KeyTTDSub100HighFloor, Metric{
 			Type:        MetricString,
 			StringValue: "Yes",
 			Description: "Score floored at 55% — sub-100ms TTD rate ≥25% with ≥3 samples",
@@ -141,7 +177,7 @@ func cheatscorePublish(ps *PlayerStats, opt publishOptions) {
 	}
 
 	if opt.coOccurrenceBoost {
-		ps.AddMetric(cheatscoreCategoryAntiCheat, Key("wallhack_co_occurrence_boost"), Metric{
+		ps.AddMetric(CatAntiCheat, KeyWallhackCoOccurrenceBoost, Metric{
 			Type:        MetricString,
 			StringValue: "Yes",
 			Description: "×1.2 boost — pre-FOV pre-aim AND back-kill-given both elevated",
@@ -149,20 +185,46 @@ func cheatscorePublish(ps *PlayerStats, opt publishOptions) {
 	}
 
 	for _, name := range opt.sniperOverrides {
-		ps.AddMetric(cheatscoreCategoryAntiCheat, Key(name), Metric{
+		ps.AddMetric(CatAntiCheat, Key(name), Metric{
 			Type:        MetricString,
 			StringValue: "Yes",
 			Description: "Sniper-anomaly override — pinned to 100%",
 		})
 	}
 
+	if opt.mapProfile != "" {
+		ps.AddMetric(CatAntiCheat, KeyMapProfile, Metric{
+			Type:        MetricString,
+			StringValue: opt.mapProfile,
+			Description: "Per-map calibration profile applied to snap / reaction ramps",
+		})
+	}
+
+	if opt.insufficientData {
+		ps.AddMetric(CatAntiCheat, KeyInsufficientData, Metric{
+			Type:        MetricString,
+			StringValue: "Yes",
+			Description: fmt.Sprintf("Fewer than %d kills — likelihood capped below the flag threshold", opt.minKillsForFlag),
+		})
+	}
+
+	ps.AddMetric(CatAntiCheat, KeyCheatExplanation, Metric{
+		Type:        MetricString,
+		StringValue: buildCheatExplanation(opt),
+		Description: "Top contributing channels (log-odds) and the boosts / overrides applied",
+	})
+
 	flag := "No"
-	if opt.finalLikelihood >= cheatscoreFlagThreshold {
+	flagOn, flagKey := opt.finalLikelihood, "cheat_likelihood"
+	if opt.flagOnLowerBound {
+		flagOn, flagKey = opt.likelihoodLow, "cheat_likelihood_low"
+	}
+	if flagOn >= opt.flagThreshold {
 		flag = "Yes"
 	}
-	ps.AddMetric(cheatscoreCategoryAntiCheat, Key("cheater"), Metric{
+	ps.AddMetric(CatAntiCheat, KeyCheater, Metric{
 		Type:        MetricString,
 		StringValue: flag,
-		Description: "Flag — Yes if cheat_likelihood ≥ flagThreshold",
+		Description: fmt.Sprintf("Flag — Yes if %s ≥ %.0f%%", flagKey, opt.flagThreshold),
 	})
 }
@@ -0,0 +1,84 @@
+package stats
+
+import "fmt"
+
+// RescoreCheat recomputes cheat_likelihood, total_cheat_score and the
+// cheater flag of every scored player from the channel metrics an earlier
+// analysis published (<id>_score or its legacy alias, <id>_confidence,
+// <id>_zone), combined with w's channel weights. The boosts, floors,
+// overrides and the minimum-kills cap (at DefaultMinKillsForFlag) run again
+// on the stored metrics they read. threshold is the flag threshold; zero
+// falls back to w.FlagThreshold, then the built-in 50.
+//
+// The published channel scores are already lobby-normalized, so with the
+// weights the demo was analyzed with, RescoreCheat reproduces the original
+// likelihood — use it with LoadJSONReport to try calibrations on saved
+// reports without re-parsing the demos. Custom components are left out
+// (their weights aren't stored), and cheat_likelihood_low/high and
+// cheat_explanation keep their analysis-time values. Players without a
+// stored cheat_likelihood, such as those of an unscored demo, are skipped,
+// and players the analysis allowlisted stay unflagged.
+func RescoreCheat(ds *DemoStats, w CheatWeights, threshold float64) {
+	if ds == nil {
+		return
+	}
+	if threshold <= 0 {
+		threshold = cheatscoreConfig{flagThreshold: w.FlagThreshold}.threshold()
+	}
+	template := builtinChannels()
+	_, asymBySID := preFOVLobbyTally(ds)
+	for sid, ps := range ds.Players {
+		if _, ok := ps.GetMetric(CatAntiCheat, KeyCheatLikelihood); !ok {
+			continue
+		}
+		channels := storedChannels(ps, template)
+		applyChannelWeights(channels, w.Channels)
+
+		combined := cheatscoreBayesianCombine(channels)
+		adj := cheatscoreAdjust(combined, channels, ps, asymBySID[sid], DefaultMinKillsForFlag, threshold)
+		ps.AddMetric(CatAntiCheat, KeyCheatLikelihood, Metric{
+			Type:        MetricPercentage,
+			FloatValue:  adj.score,
+			Description: "Estimated likelihood of player cheating",
+		})
+		ps.AddMetric(CatAntiCheat, KeyTotalCheatScore, Metric{
+			Type:        MetricFloat,
+			FloatValue:  combined / 100.0,
+			Description: "Pre-boost combined Bayesian likelihood (0-1)",
+		})
+		if psHasYes(ps, KeyAllowlisted) {
+			markAllowlisted(ps)
+			continue
+		}
+		flag := "No"
+		if adj.score >= threshold {
+			flag = "Yes"
+		}
+		ps.AddMetric(CatAntiCheat, KeyCheater, Metric{
+			Type:        MetricString,
+			StringValue: flag,
+			Description: fmt.Sprintf("Flag — Yes if cheat_likelihood ≥ %.0f%%", threshold),
+		})
+	}
+}
+
+// storedChannels rebuilds ps's channels from its published anti_cheat
+// metrics, taking ID, mode and weight from template. A channel whose zone
+// is no_data, or that wasn't published, has no data.
+func storedChannels(ps *PlayerStats, template []Channel) []Channel {
+	channels := make([]Channel, len(template))
+	for i, ch := range template {
+		scoreKey := Key(ch.ID + "_score")
+		if legacy, ok := channelLegacyKey[ch.ID]; ok {
+			scoreKey = Key(legacy)
+		}
+		score, okScore := psGetFloat(ps, CatAntiCheat, scoreKey)
+		conf, _ := psGetFloat(ps, CatAntiCheat, Key(ch.ID+"_confidence"))
+		zone, _ := psGetString(ps, CatAntiCheat, Key(ch.ID+"_zone"))
+		if okScore && zone != ZoneNoData.String() {
+			ch.Score, ch.Confidence, ch.Zone, ch.HasData = score, conf, zoneFor(score), true
+		}
+		channels[i] = ch
+	}
+	return channels
+}
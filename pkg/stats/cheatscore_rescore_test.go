@@ -0,0 +1,54 @@
+package stats
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestRescoreCheat(t *testing.T) {
+	lobby := labeledLobby(0)
+	NewCheatDetector().CollectFinalStats(lobby.Stats)
+	var buf bytes.Buffer
+	if err := NewJSONReporter().Report(lobby.Stats, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	saved := buf.Bytes()
+
+	// With the built-in weights the saved report scores as analyzed.
+	loaded, err := LoadJSONReport(bytes.NewReader(saved))
+	if err != nil {
+		t.Fatal(err)
+	}
+	RescoreCheat(loaded, DefaultCheatWeights(), 0)
+	for sid := range lobby.IsCheater {
+		want, _ := psGetFloat(lobby.Stats.Players[sid], CatAntiCheat, KeyCheatLikelihood)
+		got, _ := psGetFloat(loaded.Players[sid], CatAntiCheat, KeyCheatLikelihood)
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("player %d: rescored %.4f, analyzed %.4f", sid, got, want)
+		}
+	}
+
+	// New weights match a fresh analysis with those weights.
+	w := CheatWeights{Channels: map[string]float64{"hs": 0, "pre_fov": 0.4}, FlagThreshold: 30}
+	loaded, _ = LoadJSONReport(bytes.NewReader(saved))
+	RescoreCheat(loaded, w, 0)
+	fresh := labeledLobby(0)
+	NewCheatDetectorWithWeights(w).CollectFinalStats(fresh.Stats)
+	for sid := range fresh.IsCheater {
+		want, _ := psGetFloat(fresh.Stats.Players[sid], CatAntiCheat, KeyCheatLikelihood)
+		got, _ := psGetFloat(loaded.Players[sid], CatAntiCheat, KeyCheatLikelihood)
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("player %d: rescored %.4f, fresh analysis %.4f", sid, got, want)
+		}
+		if g, f := psHasYes(loaded.Players[sid], KeyCheater), psHasYes(fresh.Stats.Players[sid], KeyCheater); g != f {
+			t.Errorf("player %d: rescored flag %v, fresh analysis %v", sid, g, f)
+		}
+	}
+
+	// The threshold argument overrides the weights' threshold.
+	RescoreCheat(loaded, w, 100)
+	if got := FlaggedPlayers(loaded); len(got) != 0 {
+		t.Errorf("flagged at threshold 100: %v", got)
+	}
+}
@@ -1,10 +1,16 @@
 package stats
 
+import "math"
+
 // cheatscoreEvaluate orchestrates the scoring pipeline across every player.
 //
 // PR2 pipeline:
-//  1. Evaluate the 9 lobby-independent channels for every player.
-//  2. Append pre_fov_presence (lobby-dependent) for every player.
+//  1. Evaluate the 11 lobby-independent channels for every player, with the
+//     snap and reaction ramps scaled by the demo's MapProfile.
+//  2. Append pre_fov_presence (lobby-dependent) for every player, apply
+//     weight overrides (CheatWeights), append the custom components, and
+//     rescale weights (see normalizeChannelWeights).
+//     Give every channel its score bounds (see fillScoreBounds).
 //  3. Lobby-relative normalize each channel.
 //  4. Per player:
 //     a. Combine via Bayesian log-odds → pre-boost likelihood [0, 100].
@@ -14,16 +20,89 @@ package stats
 //     e. TTD-sub100 high floor (max(score, 55) when rate ≥25% on ≥3 samples).
 //     f. Sniper overrides (pin to 100 when triggered).
 //     g. Clamp to [0, 100].
-//     h. Publish all metrics.
-func cheatscoreEvaluate(demoStats *DemoStats) {
+//     h. Minimum-kills cap (below the flag threshold when kills < minKillsForFlag).
+//     i. Repeat a–h with every channel at its low and at its high score
+//     bound for cheat_likelihood_low / cheat_likelihood_high.
+//     j. Publish all metrics; flag on the low bound if flagOnLowerBound.
+func cheatscoreEvaluate(demoStats *DemoStats, cfg cheatscoreConfig) {
 	if demoStats == nil || len(demoStats.Players) == 0 {
 		return
 	}
+	minKillsForFlag, threshold := cfg.minKillsForFlag, cfg.threshold()
+	perPlayer, asymBySID, profileMatched := cheatscoreLobbyChannels(demoStats, cfg)
+
+	// Pass 4: combine + boosts + publish. The same chain runs on the
+	// channels' low and high score bounds for the likelihood interval.
+	for sid, ps := range demoStats.Players {
+		channels := perPlayer[sid]
+		if channels == nil {
+			channels = []Channel{}
+		}
+
+		combined := cheatscoreBayesianCombine(channels)
+		adj := cheatscoreAdjust(combined, channels, ps, asymBySID[sid], minKillsForFlag, threshold)
+		lowChannels, highChannels := channelsAtBound(channels, false), channelsAtBound(channels, true)
+		low := cheatscoreAdjust(cheatscoreBayesianCombine(lowChannels), lowChannels, ps, asymBySID[sid], minKillsForFlag, threshold).score
+		high := cheatscoreAdjust(cheatscoreBayesianCombine(highChannels), highChannels, ps, asymBySID[sid], minKillsForFlag, threshold).score
+
+		mapProfileName := ""
+		if profileMatched {
+			mapProfileName = normalizeMapName(demoStats.MapName)
+		}
+
+		cheatscorePublish(ps, publishOptions{
+			channels:              channels,
+			combined:              combined,
+			wingmanBoosted:        adj.wingmanApplied,
+			wingmanReason:         adj.wingmanReason,
+			competitiveBoost:      adj.competitiveApplied,
+			positionDiscount:      adj.discount,
+			evidenceStacking:      adj.stackApplied,
+			evidenceStackingCount: adj.stackCount,
+			coOccurrenceBoost:     adj.coOccurApplied,
+			ttdSub100Floor:        adj.floorApplied,
+			sniperOverrides:       adj.sniperOverrides,
+			mapProfile:            mapProfileName,
+			insufficientData:      adj.insufficientData,
+			minKillsForFlag:       minKillsForFlag,
+			finalLikelihood:       adj.score,
+			likelihoodLow:         math.Min(low, adj.score),
+			likelihoodHigh:        math.Max(high, adj.score),
+			flagThreshold:         threshold,
+			flagOnLowerBound:      cfg.flagOnLowerBound,
+		})
+	}
+}
+
+// cheatscoreConfig is the CheatDetector's configuration of the pipeline.
+type cheatscoreConfig struct {
+	minKillsForFlag  int
+	components       []ScoreComponent
+	flagOnLowerBound bool
+
+	// weights overrides built-in channel weights by channel ID, and
+	// flagThreshold the flag threshold when positive; see CheatWeights.
+	weights       map[string]float64
+	flagThreshold float64
+}
+
+// threshold returns the flag threshold in effect.
+func (cfg cheatscoreConfig) threshold() float64 {
+	if cfg.flagThreshold > 0 {
+		return cfg.flagThreshold
+	}
+	return cheatscoreFlagThreshold
+}
 
-	// Pass 1: per-player channel evaluation.
-	perPlayer := make(map[uint64][]Channel, len(demoStats.Players))
+// cheatscoreLobbyChannels runs passes 1–3: every player's channels,
+// weighted, bounded and lobby-normalized. It also returns the pre-FOV lobby
+// asymmetry the TTD-sub100 floor needs and whether a map profile matched.
+func cheatscoreLobbyChannels(demoStats *DemoStats, cfg cheatscoreConfig) (perPlayer map[uint64][]Channel, asymBySID map[uint64]bool, profileMatched bool) {
+	// Pass 1: per-player channel evaluation, calibrated for the demo's map.
+	profile, profileMatched := mapProfileFor(demoStats.MapName)
+	perPlayer = make(map[uint64][]Channel, len(demoStats.Players))
 	for sid, ps := range demoStats.Players {
-		perPlayer[sid] = evaluateChannelsForPlayer(ps)
+		perPlayer[sid] = evaluateChannelsForPlayer(ps, profile)
 	}
 
 	// Pre-compute lobby pre-FOV tally — used by both pre_fov_presence and
@@ -32,43 +111,75 @@ func cheatscoreEvaluate(demoStats *DemoStats) {
 
 	// Pass 2: lobby-dependent pre_fov_presence channel.
 	cheatscoreAddPreFOVPresence(demoStats, perPlayer, samplesBySID, asymBySID)
+	if len(cfg.weights) > 0 {
+		for _, channels := range perPlayer {
+			applyChannelWeights(channels, cfg.weights)
+		}
+	}
+	if len(cfg.components) > 0 {
+		for sid, ps := range demoStats.Players {
+			perPlayer[sid] = append(perPlayer[sid], evaluateCustomChannels(ps, cfg.components)...)
+			normalizeChannelWeights(perPlayer[sid])
+		}
+	}
+
+	for _, channels := range perPlayer {
+		fillScoreBounds(channels)
+	}
 
 	// Pass 3: lobby-relative trimmed-mean shrinkage across all channels.
 	cheatscoreNormalizeLobby(perPlayer)
+	return perPlayer, asymBySID, profileMatched
+}
 
-	// Pass 4: combine + boosts + publish.
-	for sid, ps := range demoStats.Players {
-		channels := perPlayer[sid]
-		if channels == nil {
-			channels = []Channel{}
-		}
+// cheatscoreAdjusted is the likelihood after steps 4b–4h and which of them
+// fired.
+type cheatscoreAdjusted struct {
+	score float64
 
-		combined := cheatscoreBayesianCombine(channels)
+	wingmanApplied     bool
+	wingmanReason      string
+	competitiveApplied bool
+	discount           float64
+	stackApplied       bool
+	stackCount         int
+	coOccurApplied     bool
+	floorApplied       bool
+	sniperOverrides    []string
+	insufficientData   bool
+}
 
-		score, wingmanApplied, wingmanReason := applyWingmanBoost(combined, ps)
-		score, competitiveApplied := applyCompetitiveBoost(score, ps)
-		score, discount := applyPositionDiscount(score, ps)
-		score, stackApplied, stackCount := applyEvidenceStacking(score, channels)
-		score, coOccurApplied := applyWallhackCoOccurrenceBoost(score, channels, ps)
-		score, floorApplied := applyTTDSub100Floor(score, ps, asymBySID[sid])
-		if score > 100.0 {
-			score = 100.0
-		}
-		score, sniperOverrides := applySniperOverrides(score, ps)
+// cheatscoreAdjust applies the boosts, floors, overrides and caps (steps
+// 4b–4h) to a combined likelihood.
+func cheatscoreAdjust(combined float64, channels []Channel, ps *PlayerStats, preFOVLobbyAsymmetric bool, minKillsForFlag int, threshold float64) cheatscoreAdjusted {
+	var a cheatscoreAdjusted
+	score, wingmanApplied, wingmanReason := applyWingmanBoost(combined, ps)
+	a.wingmanApplied, a.wingmanReason = wingmanApplied, wingmanReason
+	score, a.competitiveApplied = applyCompetitiveBoost(score, ps)
+	score, a.discount = applyPositionDiscount(score, ps)
+	score, a.stackApplied, a.stackCount = applyEvidenceStacking(score, channels)
+	score, a.coOccurApplied = applyWallhackCoOccurrenceBoost(score, channels, ps)
+	score, a.floorApplied = applyTTDSub100Floor(score, ps, preFOVLobbyAsymmetric)
+	if score > 100.0 {
+		score = 100.0
+	}
+	score, a.sniperOverrides = applySniperOverrides(score, ps)
+	a.score, a.insufficientData = applyMinKillsCap(score, ps, minKillsForFlag, threshold)
+	return a
+}
 
-		cheatscorePublish(ps, publishOptions{
-			channels:                channels,
-			combined:                combined,
-			wingmanBoosted:          wingmanApplied,
-			wingmanReason:           wingmanReason,
-			competitiveBoost:        competitiveApplied,
-			positionDiscount:        discount,
-			evidenceStacking:        stackApplied,
-			evidenceStackingCount:   stackCount,
-			coOccurrenceBoost:       coOccurApplied,
-			ttdSub100Floor:          floorApplied,
-			sniperOverrides:         sniperOverrides,
-			finalLikelihood:         score,
-		})
+// channelsAtBound returns a copy of channels with every Score moved to its
+// ScoreHigh (high) or ScoreLow bound.
+func channelsAtBound(channels []Channel, high bool) []Channel {
+	out := make([]Channel, len(channels))
+	for i, ch := range channels {
+		if high {
+			ch.Score = ch.ScoreHigh
+		} else {
+			ch.Score = ch.ScoreLow
+		}
+		ch.Zone = zoneFor(ch.Score)
+		out[i] = ch
 	}
+	return out
 }
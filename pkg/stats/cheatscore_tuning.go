@@ -0,0 +1,201 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// CheatWeights is a calibration of the cheat score: the weight of each
+// channel in the Bayesian combiner and the flag threshold. The zero value
+// keeps the built-in calibration. Fields are JSON-tagged so a tuned
+// calibration can be saved and loaded.
+type CheatWeights struct {
+	// Channels maps a built-in channel ID (hs, snap, reaction, …; see the
+	// README's channel table) to its weight. Channels not listed keep their
+	// built-in weight; a weight of 0 switches a channel off.
+	Channels map[string]float64 `json:"channels,omitempty"`
+
+	// FlagThreshold is the cheat_likelihood (0–100) at or above which a
+	// player is flagged. Zero means the built-in 50.
+	FlagThreshold float64 `json:"flag_threshold,omitempty"`
+}
+
+// DefaultCheatWeights returns the built-in calibration with every channel
+// listed.
+func DefaultCheatWeights() CheatWeights {
+	weights := make(map[string]float64)
+	for _, ch := range builtinChannels() {
+		weights[ch.ID] = ch.Weight
+	}
+	return CheatWeights{Channels: weights, FlagThreshold: cheatscoreFlagThreshold}
+}
+
+// builtinChannels returns the built-in channels with their IDs, modes and
+// built-in weights, and no data. They come from scoring a lobby of one
+// empty player, so channels added at the lobby stage (pre_fov_presence)
+// are included.
+func builtinChannels() []Channel {
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(1)
+	perPlayer, _, _ := cheatscoreLobbyChannels(ds, cheatscoreConfig{})
+	return perPlayer[1]
+}
+
+// NewCheatDetectorWithWeights creates a CheatDetector that scores with w,
+// usually the result of TuneThresholds.
+func NewCheatDetectorWithWeights(w CheatWeights) *CheatDetector {
+	cd := NewCheatDetector()
+	cd.Weights = w
+	return cd
+}
+
+// applyChannelWeights replaces the weight of every channel listed in
+// weights. Negative weights are ignored.
+func applyChannelWeights(channels []Channel, weights map[string]float64) {
+	for i := range channels {
+		if w, ok := weights[channels[i].ID]; ok && w >= 0 {
+			channels[i].Weight = w
+		}
+	}
+}
+
+// LabeledDemo is an analyzed demo with ground truth: IsCheater marks, by
+// SteamID64, the players known to cheat (true) or known to be clean
+// (false). Players missing from IsCheater are left out of tuning. Stats can
+// come straight from an analysis or from LoadJSONReport.
+type LabeledDemo struct {
+	Stats     *DemoStats
+	IsCheater map[uint64]bool
+}
+
+// tuneWeightGrid is the set of weights TuneThresholds tries per channel,
+// spanning switched off to about twice the largest built-in weight.
+var tuneWeightGrid = []float64{0, 0.03, 0.06, 0.10, 0.14, 0.18, 0.22, 0.30, 0.40}
+
+// tuneMaxPasses bounds the coordinate search; it usually settles in two.
+const tuneMaxPasses = 5
+
+// tuneSample is one labeled player with its lobby-normalized channels.
+type tuneSample struct {
+	channels []Channel
+	ps       *PlayerStats
+	asym     bool
+	cheater  bool
+}
+
+// TuneThresholds searches channel weights and the flag threshold for the
+// calibration that best separates the labeled cheaters from the labeled
+// clean players, by F1 score (the harmonic mean of precision and recall of
+// the cheater flag).
+//
+// The search is coordinate ascent from the built-in weights: each pass
+// tries every weight in a small grid for one channel at a time, keeping a
+// change only if it raises F1, or keeps F1 and widens the gap between the
+// lowest flagged and highest unflagged score. For each candidate the
+// threshold is placed midway across the best cut. The boosts and overrides
+// run as in the detector, except the minimum-kills cap, which gates players
+// on top of whatever threshold is chosen.
+//
+// A few labeled demos will overfit: treat the result as a starting point
+// and check it against demos that weren't part of the labeled set. With no
+// labeled players, TuneThresholds returns DefaultCheatWeights.
+func TuneThresholds(labeled []LabeledDemo) CheatWeights {
+	var samples []tuneSample
+	for _, ld := range labeled {
+		if ld.Stats == nil || len(ld.IsCheater) == 0 {
+			continue
+		}
+		perPlayer, asymBySID, _ := cheatscoreLobbyChannels(ld.Stats, cheatscoreConfig{})
+		for sid, cheater := range ld.IsCheater {
+			ps, ok := ld.Stats.Players[sid]
+			if !ok {
+				continue
+			}
+			samples = append(samples, tuneSample{channels: perPlayer[sid], ps: ps, asym: asymBySID[sid], cheater: cheater})
+		}
+	}
+	best := DefaultCheatWeights()
+	if len(samples) == 0 {
+		return best
+	}
+
+	ids := make([]string, 0, len(best.Channels))
+	for id := range best.Channels {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	weights := best.Channels
+	threshold, f1, gap := tuneEvaluate(samples, weights)
+	for pass := 0; pass < tuneMaxPasses; pass++ {
+		improved := false
+		for _, id := range ids {
+			current := weights[id]
+			for _, w := range tuneWeightGrid {
+				if w == current {
+					continue
+				}
+				weights[id] = w
+				t, f, g := tuneEvaluate(samples, weights)
+				if f > f1+1e-9 || (math.Abs(f-f1) <= 1e-9 && g > gap+1e-9) {
+					threshold, f1, gap, current = t, f, g, w
+					improved = true
+				}
+			}
+			weights[id] = current
+		}
+		if !improved {
+			break
+		}
+	}
+	return CheatWeights{Channels: weights, FlagThreshold: threshold}
+}
+
+// tuneEvaluate scores every sample under weights and returns the best
+// threshold with its F1 and the score gap it sits in.
+func tuneEvaluate(samples []tuneSample, weights map[string]float64) (threshold, f1, gap float64) {
+	type scored struct {
+		score   float64
+		cheater bool
+	}
+	scores := make([]scored, len(samples))
+	cheaters := 0
+	for i, s := range samples {
+		channels := append([]Channel(nil), s.channels...)
+		applyChannelWeights(channels, weights)
+		adj := cheatscoreAdjust(cheatscoreBayesianCombine(channels), channels, s.ps, s.asym, 0, cheatscoreFlagThreshold)
+		scores[i] = scored{adj.score, s.cheater}
+		if s.cheater {
+			cheaters++
+		}
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].score > scores[j].score })
+
+	// Cut after the first k players, between two distinct scores.
+	threshold, f1 = cheatscoreFlagThreshold, -1
+	tp := 0
+	for k := 1; k <= len(scores); k++ {
+		if scores[k-1].cheater {
+			tp++
+		}
+		above := scores[k-1].score
+		below := 0.0
+		if k < len(scores) {
+			below = scores[k].score
+		}
+		if above == below {
+			continue
+		}
+		f := 0.0
+		if tp > 0 {
+			f = 2 * float64(tp) / float64(k+cheaters)
+		}
+		if f > f1+1e-9 || (math.Abs(f-f1) <= 1e-9 && above-below > gap) {
+			threshold, f1, gap = (above+below)/2, f, above-below
+		}
+	}
+	if f1 < 0 {
+		f1 = 0
+	}
+	return math.Max(threshold, insufficientDataMargin+1), f1, gap
+}
@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"fmt"
+	"testing"
+)
+
+// labeledLobby builds a lobby where the two cheaters pre-aim through walls
+// on a few kills with ordinary headshot rates, and the clean players are
+// headshot-heavy riflers who never pre-aimed. The built-in weights rank the
+// clean players higher.
+func labeledLobby(seed int) LabeledDemo {
+	ds := zeroKillDemo(20)
+	labels := map[uint64]bool{}
+	for sid := uint64(1); sid <= 6; sid++ {
+		ps := ds.GetOrCreatePlayerStatsBySteamID(sid)
+		ps.Player.Name = fmt.Sprintf("p%d", sid)
+		cheater := sid <= 2
+		labels[sid] = cheater
+		hs, preFOV, preFOVN := 85.0+float64(seed+int(sid))/2, 0.0, int64(0)
+		if cheater {
+			hs, preFOV, preFOVN = 45.0, 4.0, 4
+		}
+		ps.AddMetric(Category("kills"), Key("total_kills"), Metric{Type: MetricInteger, IntValue: 20})
+		ps.AddMetric(Category("kills"), Key("headshot_percentage"), Metric{Type: MetricPercentage, FloatValue: hs})
+		ps.AddMetric(Category("behavioral"), Key("pre_fov_aim_samples"), Metric{Type: MetricInteger, IntValue: preFOVN})
+		ps.AddMetric(Category("behavioral"), Key("pre_fov_aim_median_deg"), Metric{Type: MetricFloat, FloatValue: preFOV})
+	}
+	return LabeledDemo{Stats: ds, IsCheater: labels}
+}
+
+func TestTuneThresholds(t *testing.T) {
+	labeled := []LabeledDemo{labeledLobby(0), labeledLobby(1), labeledLobby(2)}
+	defaults := DefaultCheatWeights()
+	if _, f1, _ := tuneEvaluate(tuneSamples(labeled), defaults.Channels); f1 >= 1 {
+		t.Fatalf("fixture: built-in weights already separate the set (F1 %.2f)", f1)
+	}
+
+	tuned := TuneThresholds(labeled)
+	if tuned.Channels["pre_fov"] <= defaults.Channels["pre_fov"] && tuned.Channels["hs"] >= defaults.Channels["hs"] {
+		t.Errorf("tuning didn't shift weight from hs to pre_fov: %v", tuned.Channels)
+	}
+
+	// The tuned detector separates a lobby it wasn't tuned on.
+	held := labeledLobby(3)
+	cd := NewCheatDetectorWithWeights(tuned)
+	cd.CollectFinalStats(held.Stats)
+	for sid, cheater := range held.IsCheater {
+		if got := psHasYes(held.Stats.Players[sid], Key("cheater")); got != cheater {
+			v, _ := psGetFloat(held.Stats.Players[sid], Category("anti_cheat"), Key("cheat_likelihood"))
+			t.Errorf("player %d: flagged %v at %.1f%% (threshold %.1f), want %v", sid, got, v, tuned.FlagThreshold, cheater)
+		}
+	}
+}
+
+func TestTuneThresholdsNoLabels(t *testing.T) {
+	got := TuneThresholds(nil)
+	if got.FlagThreshold != cheatscoreFlagThreshold || got.Channels["hs"] != 0.18 || len(got.Channels) != len(builtinChannelIDs) {
+		t.Errorf("TuneThresholds(nil) = %+v, want the built-in calibration", got)
+	}
+}
+
+// tuneSamples mirrors the sample collection in TuneThresholds.
+func tuneSamples(labeled []LabeledDemo) []tuneSample {
+	var samples []tuneSample
+	for _, ld := range labeled {
+		perPlayer, asym, _ := cheatscoreLobbyChannels(ld.Stats, cheatscoreConfig{})
+		for sid, cheater := range ld.IsCheater {
+			samples = append(samples, tuneSample{channels: perPlayer[sid], ps: ld.Stats.Players[sid], asym: asym[sid], cheater: cheater})
+		}
+	}
+	return samples
+}
@@ -62,6 +62,45 @@ func (bc *BaseCollector) CollectFinalStats(demoStats *DemoStats) {
 	// Empty base implementation
 }
 
+// weaponClasses lists the buckets non-knife weapons are split into, in
+// display order. Every non-knife tick lands in exactly one bucket, so the
+// class percentages sum to non_knife_percentage.
+var weaponClasses = []struct {
+	Name string
+	Desc string
+}{
+	{"rifle", "a rifle"},
+	{"sniper", "a sniper rifle"},
+	{"smg", "an SMG"},
+	{"heavy", "a heavy weapon"},
+	{"pistol", "a pistol"},
+	{"grenade", "a grenade"},
+	{"equipment", "other equipment (bomb, zeus)"},
+}
+
+// weaponClass returns the weaponClasses bucket for a non-knife weapon.
+// Snipers are split out of demoinfocs' rifle class since AWPers and riflers
+// play very differently.
+func weaponClass(weapon *common.Equipment) string {
+	t := weaponType(weapon)
+	if isSniper(t) {
+		return "sniper"
+	}
+	switch t.Class() {
+	case common.EqClassRifle:
+		return "rifle"
+	case common.EqClassSMG:
+		return "smg"
+	case common.EqClassHeavy:
+		return "heavy"
+	case common.EqClassPistols:
+		return "pistol"
+	case common.EqClassGrenade:
+		return "grenade"
+	}
+	return "equipment" // bomb, zeus, defuse kit, unknown
+}
+
 // WeaponUsageCollector tracks weapon usage statistics
 type WeaponUsageCollector struct {
 	*BaseCollector
@@ -70,7 +109,7 @@ type WeaponUsageCollector struct {
 // NewWeaponUsageCollector creates a new WeaponUsageCollector
 func NewWeaponUsageCollector() *WeaponUsageCollector {
 	return &WeaponUsageCollector{
-		BaseCollector: NewBaseCollector("Weapon Usage", Category("weapons")),
+		BaseCollector: NewBaseCollector("Weapon Usage", CatWeapons),
 	}
 }
 
@@ -79,7 +118,7 @@ func (wuc *WeaponUsageCollector) CollectFrame(parser demoinfocs.Parser, demoStat
 	gs := parser.GameState()
 
 	for _, player := range gs.Participants().Playing() {
-		if player == nil || player.SteamID64 == 0 {
+		if !demoStats.CountsPlayer(player) {
 			continue
 		}
 
@@ -89,21 +128,22 @@ func (wuc *WeaponUsageCollector) CollectFrame(parser demoinfocs.Parser, demoStat
 		}
 
 		// Track total ticks for this player
-		playerStats.IncrementIntMetric(Category("weapons"), Key("total_ticks"))
+		playerStats.IncrementIntMetric(CatWeapons, KeyTotalTicks)
 
 		// Get active weapon
 		activeWeapon := player.ActiveWeapon()
 		if activeWeapon == nil {
 			// Track no-weapon ticks
-			playerStats.IncrementIntMetric(Category("weapons"), Key("no_weapon_ticks"))
+			playerStats.IncrementIntMetric(CatWeapons, KeyNoWeaponTicks)
 			continue
 		}
 
 		// Track weapon-specific ticks
 		if isKnife(activeWeapon) {
-			playerStats.IncrementIntMetric(Category("weapons"), Key("knife_ticks"))
+			playerStats.IncrementIntMetric(CatWeapons, KeyKnifeTicks)
 		} else {
-			playerStats.IncrementIntMetric(Category("weapons"), Key("non_knife_ticks"))
+			playerStats.IncrementIntMetric(CatWeapons, KeyNonKnifeTicks)
+			playerStats.IncrementIntMetric(CatWeapons, Key(weaponClass(activeWeapon)+"_ticks"))
 		}
 	}
 }
@@ -111,15 +151,15 @@ func (wuc *WeaponUsageCollector) CollectFrame(parser demoinfocs.Parser, demoStat
 // CollectFinalStats calculates percentage statistics after parsing
 func (wuc *WeaponUsageCollector) CollectFinalStats(demoStats *DemoStats) {
 	for _, playerStats := range demoStats.Players {
-		totalTicks, found := playerStats.GetMetric(Category("weapons"), Key("total_ticks"))
+		totalTicks, found := playerStats.GetMetric(CatWeapons, KeyTotalTicks)
 		if !found || totalTicks.IntValue == 0 {
 			continue
 		}
 
 		// Calculate knife percentage
-		if knifeTicks, found := playerStats.GetMetric(Category("weapons"), Key("knife_ticks")); found {
+		if knifeTicks, found := playerStats.GetMetric(CatWeapons, KeyKnifeTicks); found {
 			knifePercentage := float64(knifeTicks.IntValue) / float64(totalTicks.IntValue) * 100
-			playerStats.AddMetric(Category("weapons"), Key("knife_percentage"), Metric{
+			playerStats.AddMetric(CatWeapons, KeyKnifePercentage, Metric{
 				Type:        MetricPercentage,
 				FloatValue:  knifePercentage,
 				Description: "Percentage of time with knife equipped",
@@ -127,9 +167,9 @@ func (wuc *WeaponUsageCollector) CollectFinalStats(demoStats *DemoStats) {
 		}
 
 		// Calculate non-knife percentage
-		if nonKnifeTicks, found := playerStats.GetMetric(Category("weapons"), Key("non_knife_ticks")); found {
+		if nonKnifeTicks, found := playerStats.GetMetric(CatWeapons, KeyNonKnifeTicks); found {
 			nonKnifePercentage := float64(nonKnifeTicks.IntValue) / float64(totalTicks.IntValue) * 100
-			playerStats.AddMetric(Category("weapons"), Key("non_knife_percentage"), Metric{
+			playerStats.AddMetric(CatWeapons, KeyNonKnifePercentage, Metric{
 				Type:        MetricPercentage,
 				FloatValue:  nonKnifePercentage,
 				Description: "Percentage of time with non-knife weapons equipped",
@@ -137,27 +177,40 @@ func (wuc *WeaponUsageCollector) CollectFinalStats(demoStats *DemoStats) {
 		}
 
 		// Calculate no-weapon percentage
-		if noWeaponTicks, found := playerStats.GetMetric(Category("weapons"), Key("no_weapon_ticks")); found {
+		if noWeaponTicks, found := playerStats.GetMetric(CatWeapons, KeyNoWeaponTicks); found {
 			noWeaponPercentage := float64(noWeaponTicks.IntValue) / float64(totalTicks.IntValue) * 100
-			playerStats.AddMetric(Category("weapons"), Key("no_weapon_percentage"), Metric{
+			playerStats.AddMetric(CatWeapons, KeyNoWeaponPercentage, Metric{
 				Type:        MetricPercentage,
 				FloatValue:  noWeaponPercentage,
 				Description: "Percentage of time with no weapon equipped",
 			})
 		}
 		
+		// Per-class breakdown of the non-knife time
+		for _, class := range weaponClasses {
+			classTicks, found := playerStats.GetMetric(CatWeapons, Key(class.Name+"_ticks"))
+			if !found {
+				continue
+			}
+			playerStats.AddMetric(CatWeapons, Key(class.Name+"_percentage"), Metric{
+				Type:        MetricPercentage,
+				FloatValue:  float64(classTicks.IntValue) / float64(totalTicks.IntValue) * 100,
+				Description: "Percentage of time with " + class.Desc + " equipped",
+			})
+		}
+
 		// Validate percentages add up to 100%
 		knifePerc := 0.0
 		nonKnifePerc := 0.0
 		noWeaponPerc := 0.0
 		
-		if metric, found := playerStats.GetMetric(Category("weapons"), Key("knife_percentage")); found {
+		if metric, found := playerStats.GetMetric(CatWeapons, KeyKnifePercentage); found {
 			knifePerc = metric.FloatValue
 		}
-		if metric, found := playerStats.GetMetric(Category("weapons"), Key("non_knife_percentage")); found {
+		if metric, found := playerStats.GetMetric(CatWeapons, KeyNonKnifePercentage); found {
 			nonKnifePerc = metric.FloatValue
 		}
-		if metric, found := playerStats.GetMetric(Category("weapons"), Key("no_weapon_percentage")); found {
+		if metric, found := playerStats.GetMetric(CatWeapons, KeyNoWeaponPercentage); found {
 			noWeaponPerc = metric.FloatValue
 		}
 		
@@ -169,17 +222,15 @@ func (wuc *WeaponUsageCollector) CollectFinalStats(demoStats *DemoStats) {
 	}
 }
 
-// isKnife checks if an equipment is a knife
-func isKnife(weapon *common.Equipment) bool {
-	if weapon == nil {
-		return false
-	}
-
-	// Check if the weapon is knife by type or name
-	weaponName := weapon.String()
+// isMelee reports whether weapon is a knife or the Zeus. Kills with either
+// take no aim, so they stay out of the kill counts aim scoring reads.
+func isMelee(weapon *common.Equipment) bool {
+	return isKnife(weapon) || (weapon != nil && weapon.Type == common.EqZeus)
+}
 
-	return weapon.Type == common.EqKnife ||
-		weaponName == "Knife" ||
-		weaponName == "Bayonet" ||
-		weaponName == "Karambit"
+// isKnife reports whether weapon is a knife of any kind: the default
+// knives and every skin model, including ones demoinfocs doesn't know yet
+// (see weaponType).
+func isKnife(weapon *common.Equipment) bool {
+	return weaponType(weapon) == common.EqKnife
 }
@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// ConductCollector tracks friendly fire: health damage dealt to teammates
+// and teammates killed. Not an anti-cheat signal — a player who keeps
+// shooting their own team is griefing, which reviewers want to see next to
+// the verdict. Every other collector drops same-team hurts and kills, so
+// none of this reaches damage, ADR or the kill counts.
+type ConductCollector struct {
+	*BaseCollector
+
+	teamDamage map[uint64]int64
+	teamKills  map[uint64]int64
+}
+
+// NewConductCollector creates a new ConductCollector.
+func NewConductCollector() *ConductCollector {
+	return &ConductCollector{
+		BaseCollector: NewBaseCollector("Conduct", CatConduct),
+		teamDamage:    make(map[uint64]int64),
+		teamKills:     make(map[uint64]int64),
+	}
+}
+
+// Setup registers the hurt and kill handlers. Warmup friendly fire is
+// ignored.
+func (cc *ConductCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	parser.RegisterEventHandler(func(e events.PlayerHurt) {
+		if inWarmup(parser) || e.Attacker == nil || e.Player == nil || e.Attacker == e.Player {
+			return
+		}
+		if e.Attacker.Team != e.Player.Team || !demoStats.CountsPlayer(e.Attacker) {
+			return
+		}
+		cc.teamDamage[demoStats.PlayerKey(e.Attacker)] += int64(e.HealthDamageTaken)
+	})
+
+	parser.RegisterEventHandler(func(e events.Kill) {
+		if inWarmup(parser) || e.Killer == nil || e.Victim == nil || e.Killer == e.Victim {
+			return
+		}
+		if e.Killer.Team != e.Victim.Team || !demoStats.CountsPlayer(e.Killer) {
+			return
+		}
+		cc.teamKills[demoStats.PlayerKey(e.Killer)]++
+	})
+}
+
+// CollectFrame is a no-op; everything is event-driven.
+func (cc *ConductCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {}
+
+// CollectFinalStats publishes team_damage and team_kills for every player.
+func (cc *ConductCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, ps := range demoStats.Players {
+		ps.AddMetric(CatConduct, KeyTeamDamage, Metric{
+			Type:        MetricInteger,
+			IntValue:    cc.teamDamage[sid],
+			Description: "Health damage dealt to teammates",
+		})
+		ps.AddMetric(CatConduct, KeyTeamKills, Metric{
+			Type:        MetricInteger,
+			IntValue:    cc.teamKills[sid],
+			Description: "Teammates killed",
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+func TestConductCollectorFriendlyFire(t *testing.T) {
+	parser := &warmupStubParser{warmupFrames: 1}
+	ds := NewDemoStats()
+	cc := NewConductCollector()
+	cc.Setup(parser, ds)
+	sc := NewScoreboardCollector()
+	sc.Setup(parser, ds)
+
+	griefer := &common.Player{SteamID64: 1, Name: "g", Team: common.TeamTerrorists}
+	mate := &common.Player{SteamID64: 2, Name: "m", Team: common.TeamTerrorists}
+	enemy := &common.Player{SteamID64: 3, Name: "e", Team: common.TeamCounterTerrorists}
+	ds.GetOrCreatePlayerStats(griefer)
+	ds.GetOrCreatePlayerStats(mate)
+
+	parser.dispatch(events.PlayerHurt{Attacker: griefer, Player: mate, HealthDamageTaken: 50}) // warmup: ignored
+	parser.advance()
+	parser.dispatch(events.PlayerHurt{Attacker: griefer, Player: mate, HealthDamageTaken: 30})
+	parser.dispatch(events.PlayerHurt{Attacker: griefer, Player: mate, HealthDamageTaken: 70})
+	parser.dispatch(events.Kill{Killer: griefer, Victim: mate})
+	parser.dispatch(events.PlayerHurt{Attacker: griefer, Player: griefer, HealthDamageTaken: 20}) // self damage
+	parser.dispatch(events.PlayerHurt{Attacker: griefer, Player: enemy, HealthDamageTaken: 40})
+	cc.CollectFinalStats(ds)
+
+	ps := ds.Players[1]
+	if n := intMetric(ps, CatConduct, KeyTeamDamage); n != 100 {
+		t.Errorf("team_damage = %d, want 100", n)
+	}
+	if n := intMetric(ps, CatConduct, KeyTeamKills); n != 1 {
+		t.Errorf("team_kills = %d, want 1", n)
+	}
+	if n := intMetric(ps, CatScoreboard, KeyDamage); n != 40 {
+		t.Errorf("scoreboard damage = %d, want 40 (enemy damage only)", n)
+	}
+	if n := intMetric(ds.Players[2], CatConduct, KeyTeamDamage); n != 0 {
+		t.Errorf("victim team_damage = %d, want 0", n)
+	}
+}
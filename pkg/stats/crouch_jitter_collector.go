@@ -0,0 +1,163 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+)
+
+const (
+	// crouchJitterMaxGap is the longest a crouch key press or release may
+	// follow the previous one and still be jitter — two ticks at 64 tick.
+	// Mashing the key by hand doesn't toggle it faster than every 50 ms or
+	// so, and a crouch peek holds it for hundreds.
+	crouchJitterMaxGap = 35 * time.Millisecond
+
+	// crouchJitterMinRun is the number of back-to-back jitter toggles a run
+	// needs to count: a single fast tap is two toggles, a script keeps it up.
+	crouchJitterMinRun = 6
+
+	// Jitter toggles (those inside counted runs) at or below which
+	// crouch_jitter_score is 0 and at or above which it is 1; the blatant
+	// end is a second of toggling every tick at 64 tick.
+	crouchJitterClean   = 0
+	crouchJitterBlatant = 64
+)
+
+// crouchTrack is one player's crouch key state and the run of fast toggles
+// it is in.
+type crouchTrack struct {
+	seen       bool
+	pressed    bool
+	hasToggled bool
+	lastToggle time.Duration
+
+	run, longestRun int
+	toggles, jitter int
+}
+
+// observe records the crouch key state at now. Call reset instead when the
+// player wasn't observed on the previous frame, so a gap isn't read as a
+// toggle.
+func (t *crouchTrack) observe(pressed bool, now time.Duration) {
+	if !t.seen {
+		t.seen, t.pressed = true, pressed
+		return
+	}
+	if pressed == t.pressed {
+		return
+	}
+	t.pressed = pressed
+	t.toggles++
+	if t.hasToggled && now-t.lastToggle <= crouchJitterMaxGap {
+		t.run++
+	} else {
+		t.endRun()
+		t.run = 1
+	}
+	t.lastToggle, t.hasToggled = now, true
+}
+
+// endRun counts the current run's toggles as jitter if it was long enough.
+func (t *crouchTrack) endRun() {
+	if t.run >= crouchJitterMinRun {
+		t.jitter += t.run
+	}
+	t.longestRun = max(t.longestRun, t.run)
+	t.run = 0
+}
+
+// reset forgets the key state and ends the current run, keeping the counts.
+func (t *crouchTrack) reset() {
+	t.endRun()
+	t.seen, t.hasToggled = false, false
+}
+
+// CrouchJitterCollector looks for crouch-spam jitter: movement and anti-aim
+// cheats that toggle crouch every tick or two to break hitboxes. Each frame
+// reads every alive player's crouch key (not the fully-crouched flag, which
+// never settles at that rate); a press or release within crouchJitterMaxGap
+// of the previous one extends a run, and runs of at least
+// crouchJitterMinRun toggles are jitter. Crouch peeking and spamming the key
+// by hand are far slower.
+//
+//   - crouch_toggles: crouch key presses and releases.
+//   - crouch_jitter_toggles: those inside jitter runs.
+//   - crouch_jitter_longest_run: the most back-to-back fast toggles.
+//   - crouch_jitter_score: jitter toggles scored 0-1 between
+//     crouchJitterClean and crouchJitterBlatant.
+type CrouchJitterCollector struct {
+	*BaseCollector
+
+	tracks map[uint64]*crouchTrack
+	frames map[uint64]int
+}
+
+// NewCrouchJitterCollector creates a new CrouchJitterCollector.
+func NewCrouchJitterCollector() *CrouchJitterCollector {
+	return &CrouchJitterCollector{
+		BaseCollector: NewBaseCollector("Crouch Jitter", CatMovement),
+		tracks:        make(map[uint64]*crouchTrack),
+		frames:        make(map[uint64]int),
+	}
+}
+
+// Setup is a no-op; crouch state is read per frame.
+func (cc *CrouchJitterCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {}
+
+// CollectFrame reads every alive player's crouch key.
+func (cc *CrouchJitterCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	if inWarmup(parser) {
+		return
+	}
+	frame := parser.CurrentFrame()
+	now := demoTime(parser, parser.TickRate())
+	for _, player := range parser.GameState().Participants().Playing() {
+		if !demoStats.CountsAimPlayer(player) || !player.IsAlive() {
+			continue
+		}
+		sid := demoStats.PlayerKey(player)
+		track, ok := cc.tracks[sid]
+		if !ok {
+			track = &crouchTrack{}
+			cc.tracks[sid] = track
+		}
+		if last, ok := cc.frames[sid]; ok && frame-last != 1 {
+			track.reset()
+		}
+		cc.frames[sid] = frame
+		track.observe(player.Flags().DuckingKeyPressed(), now)
+	}
+}
+
+// CollectFinalStats publishes the crouch counts for every player who
+// toggled crouch.
+func (cc *CrouchJitterCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, track := range cc.tracks {
+		ps, ok := demoStats.Players[sid]
+		if !ok || track.toggles == 0 {
+			continue
+		}
+		track.endRun()
+		ps.AddMetric(CatMovement, KeyCrouchToggles, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(track.toggles),
+			Description: "Crouch key presses and releases",
+		})
+		ps.AddMetric(CatMovement, KeyCrouchJitterToggles, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(track.jitter),
+			Description: "Crouch toggles in sustained runs a tick or two apart",
+		})
+		ps.AddMetric(CatMovement, KeyCrouchJitterLongestRun, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(track.longestRun),
+			Description: "Most back-to-back crouch toggles a tick or two apart",
+		})
+		ps.AddMetric(CatMovement, KeyCrouchJitterScore, Metric{
+			Type:        MetricFloat,
+			FloatValue:  linearScore(float64(track.jitter), crouchJitterClean, crouchJitterBlatant),
+			Description: "Crouch-spam jitter (0 = none, 1 = sustained every-tick toggling)",
+		})
+	}
+}
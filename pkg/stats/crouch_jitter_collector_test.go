@@ -0,0 +1,89 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+// crouchAt feeds track one crouch key state per 64-tick frame from start,
+// toggling it every `every` frames for n toggles.
+func crouchAt(track *crouchTrack, start time.Duration, every, n int) time.Duration {
+	tick := time.Second / 64
+	now := start
+	pressed := track.pressed
+	for i := 0; i < n; i++ {
+		for f := 0; f < every; f++ {
+			now += tick
+			track.observe(pressed, now)
+		}
+		pressed = !pressed
+		now += tick
+		track.observe(pressed, now)
+	}
+	return now
+}
+
+func TestCrouchTrack(t *testing.T) {
+	// A jitter script toggles every tick for a second
+	jitter := &crouchTrack{}
+	jitter.observe(false, 0)
+	crouchAt(jitter, 0, 0, 64)
+	jitter.endRun()
+	if jitter.toggles != 64 || jitter.jitter != 64 {
+		t.Errorf("every-tick jitter: %d toggles, %d jitter; want 64, 64", jitter.toggles, jitter.jitter)
+	}
+	if s := linearScore(float64(jitter.jitter), crouchJitterClean, crouchJitterBlatant); s != 1 {
+		t.Errorf("every-tick jitter scores %.2f, want 1", s)
+	}
+
+	// Crouch peeks hold the key for a quarter second, and mashing it by hand
+	// toggles every four ticks; neither is jitter
+	legit := &crouchTrack{}
+	legit.observe(false, 0)
+	now := crouchAt(legit, 0, 16, 20)
+	crouchAt(legit, now, 4, 20)
+	legit.endRun()
+	if legit.toggles != 40 || legit.jitter != 0 {
+		t.Errorf("legit crouching: %d toggles, %d jitter; want 40, 0", legit.toggles, legit.jitter)
+	}
+
+	// Two quick taps are too short a run to count
+	taps := &crouchTrack{}
+	taps.observe(false, 0)
+	crouchAt(taps, 0, 1, 4)
+	taps.endRun()
+	if taps.jitter != 0 || taps.longestRun != 4 {
+		t.Errorf("quick taps: %d jitter, longest run %d; want 0, 4", taps.jitter, taps.longestRun)
+	}
+
+	// A gap in observation isn't a toggle
+	gap := &crouchTrack{}
+	gap.observe(false, 0)
+	gap.reset()
+	gap.observe(true, time.Second)
+	if gap.toggles != 0 {
+		t.Errorf("state change across a gap counted as %d toggles", gap.toggles)
+	}
+}
+
+func TestCrouchJitterMetrics(t *testing.T) {
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(1)
+	ds.GetOrCreatePlayerStatsBySteamID(2)
+	cc := NewCrouchJitterCollector()
+	cc.tracks[1] = &crouchTrack{}
+	cc.tracks[1].observe(false, 0)
+	crouchAt(cc.tracks[1], 0, 1, 32)
+	cc.tracks[2] = &crouchTrack{}
+	cc.CollectFinalStats(ds)
+
+	if n := intMetric(ds.Players[1], CatMovement, KeyCrouchJitterToggles); n != 32 {
+		t.Errorf("crouch_jitter_toggles = %d, want 32", n)
+	}
+	if s, _ := psGetFloat(ds.Players[1], CatMovement, KeyCrouchJitterScore); s != 0.5 {
+		t.Errorf("crouch_jitter_score = %.2f, want 0.5", s)
+	}
+	if _, ok := ds.Players[2].Categories[CatMovement]; ok {
+		t.Error("published movement metrics for a player who never crouched")
+	}
+}
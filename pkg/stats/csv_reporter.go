@@ -0,0 +1,86 @@
+package stats
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// CSVReporter writes one row per player metric in long format:
+//
+//	demo,map,steam_id,name,category,key,type,value
+//
+// Values are raw (no % sign or rounding; durations in seconds), so the
+// file loads straight into a spreadsheet or dataframe. Rows are ordered by
+// SteamID, then category, then key.
+type CSVReporter struct{}
+
+// NewCSVReporter creates a CSVReporter.
+func NewCSVReporter() *CSVReporter {
+	return &CSVReporter{}
+}
+
+// Extension returns "csv".
+func (cr *CSVReporter) Extension() string { return "csv" }
+
+// Report writes the header and one row per metric. When categories is
+// non-empty only those categories are written.
+func (cr *CSVReporter) Report(demoStats *DemoStats, categories []Category, writer io.Writer) error {
+	w := csv.NewWriter(writer)
+	if err := w.Write([]string{"demo", "map", "steam_id", "name", "category", "key", "type", "value"}); err != nil {
+		return err
+	}
+
+	model := BuildReport(demoStats, categories)
+	for _, p := range model.Players {
+		sections := append([]ReportSection(nil), p.Sections...)
+		sort.Slice(sections, func(i, j int) bool { return sections[i].Category < sections[j].Category })
+		for _, s := range sections {
+			rows := append([]ReportRow(nil), s.Rows...)
+			sort.Slice(rows, func(i, j int) bool { return rows[i].Key < rows[j].Key })
+			for _, r := range rows {
+				if err := w.Write([]string{
+					model.Demo,
+					model.Map,
+					strconv.FormatUint(p.SteamID, 10),
+					p.Name,
+					string(s.Category),
+					string(r.Key),
+					string(r.Metric.Type),
+					metricRawString(r.Metric),
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// metricRawString formats a metric's value without display decoration.
+func metricRawString(m Metric) string {
+	switch m.Type {
+	case MetricPercentage, MetricFloat:
+		return strconv.FormatFloat(m.FloatValue, 'f', -1, 64)
+	case MetricInteger, MetricCount:
+		return strconv.FormatInt(m.IntValue, 10)
+	case MetricDuration:
+		return strconv.FormatFloat(m.DurationValue.Seconds(), 'f', -1, 64)
+	case MetricString:
+		return m.StringValue
+	}
+	return ""
+}
+
+// sortedSteamIDs returns the SteamIDs in ds in ascending order.
+func sortedSteamIDs(ds *DemoStats) []uint64 {
+	ids := make([]uint64, 0, len(ds.Players))
+	for sid := range ds.Players {
+		ids = append(ids, sid)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -0,0 +1,42 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReportToDirCSV(t *testing.T) {
+	ds := NewDemoStats()
+	ds.DemoName = "match.dem"
+	ps := ds.GetOrCreatePlayerStatsBySteamID(42)
+	ps.Player.Name = "alpha"
+	ps.AddMetric(Category("kills"), Key("headshot_percentage"), Metric{Type: MetricPercentage, FloatValue: 62.5})
+	ps.AddMetric(Category("kills"), Key("total_kills"), Metric{Type: MetricInteger, IntValue: 16})
+	ps.AddMetric(Category("recoil"), Key("recoil_interpretation"), Metric{Type: MetricString, StringValue: "Very tight"})
+
+	dir := filepath.Join(t.TempDir(), "out")
+	if err := ReportToDir(ds, nil, dir, NewCSVReporter()); err != nil {
+		t.Fatalf("ReportToDir: %v", err)
+	}
+
+	kills, err := os.ReadFile(filepath.Join(dir, "kills.csv"))
+	if err != nil {
+		t.Fatalf("kills.csv: %v", err)
+	}
+	want := "demo,map,steam_id,name,category,key,type,value\n" +
+		"match.dem,,42,alpha,kills,headshot_percentage,percentage,62.5\n" +
+		"match.dem,,42,alpha,kills,total_kills,integer,16\n"
+	if string(kills) != want {
+		t.Errorf("kills.csv =\n%s\nwant\n%s", kills, want)
+	}
+
+	recoil, err := os.ReadFile(filepath.Join(dir, "recoil.csv"))
+	if err != nil {
+		t.Fatalf("recoil.csv: %v", err)
+	}
+	if strings.Contains(string(recoil), "total_kills") {
+		t.Errorf("recoil.csv contains another category:\n%s", recoil)
+	}
+}
@@ -0,0 +1,149 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// DiffHighlightFactor is the suspect/benchmark ratio beyond which DiffReport
+// marks a metric. 1.5× is deliberately loose — it points a reviewer at rows
+// worth a closer look, it is not a verdict.
+const DiffHighlightFactor = 1.5
+
+// diffLowerSuspicious lists the keys where a lower value is the suspicious
+// direction — reaction / kill timings and aim angles. DiffReport highlights
+// these when the suspect is DiffHighlightFactor× below the benchmark.
+var diffLowerSuspicious = map[Key]bool{
+	KeyMedianTTD:                  true,
+	KeyP10TTD:                     true,
+	KeyTimeToKillMs:               true,
+	KeyP10TimeToKillMs:            true,
+	KeyScopeToKillMs:              true,
+	KeyMedianUnblindToKillMs:      true,
+	KeyPreFOVAimMedianDeg:         true,
+	KeyNearestEnemyAngleMedianDeg: true,
+	KeyBackKilledPct:              true,
+}
+
+// DiffReport prints every metric of player steamIDA in demo a (the suspect)
+// next to player steamIDB in demo b (the benchmark), with the delta for
+// numeric metrics. Rows where the suspect differs from the benchmark by more
+// than DiffHighlightFactor in the suspicious direction are marked with "!":
+// above it for most metrics, below it for the keys in diffLowerSuspicious.
+// a and b may be the same demo.
+//
+// Every category / key present for either player is listed; a missing side
+// renders as "-".
+func DiffReport(a, b *DemoStats, steamIDA, steamIDB uint64, w io.Writer) error {
+	psA, err := diffPlayer(a, steamIDA, "suspect")
+	if err != nil {
+		return err
+	}
+	psB, err := diffPlayer(b, steamIDB, "benchmark")
+	if err != nil {
+		return err
+	}
+
+	cats := make([]Category, 0, len(psA.Categories)+len(psB.Categories))
+	seen := make(map[Category]bool)
+	for _, ps := range []*PlayerStats{psA, psB} {
+		for cat := range ps.Categories {
+			if !seen[cat] {
+				seen[cat] = true
+				cats = append(cats, cat)
+			}
+		}
+	}
+	sort.Slice(cats, func(i, j int) bool { return cats[i] < cats[j] })
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Suspect:\t%s (%d)\t%s\n", fallback(psA.Player.Name, "Unknown"), steamIDA, a.DemoName)
+	fmt.Fprintf(tw, "Benchmark:\t%s (%d)\t%s\n", fallback(psB.Player.Name, "Unknown"), steamIDB, b.DemoName)
+
+	highlighted := 0
+	for _, cat := range cats {
+		keys := diffKeys(psA.Categories[cat], psB.Categories[cat])
+		sort.Slice(keys, func(i, j int) bool {
+			return categoryKeyOrder(cat, keys[i]) < categoryKeyOrder(cat, keys[j])
+		})
+
+		fmt.Fprintf(tw, "\n[%s]\t\t\t\t\n", cat)
+		fmt.Fprintln(tw, "\tmetric\tsuspect\tbenchmark\tdelta")
+		for _, k := range keys {
+			mA, okA := psA.GetMetric(cat, k)
+			mB, okB := psB.GetMetric(cat, k)
+
+			valA, valB := "-", "-"
+			if okA {
+				valA = formatMetricValue(mA)
+			}
+			if okB {
+				valB = formatMetricValue(mB)
+			}
+
+			delta, mark := "", " "
+			fA, numA := diffNumeric(mA)
+			fB, numB := diffNumeric(mB)
+			if okA && okB && numA && numB {
+				delta = fmt.Sprintf("%+.2f", fA-fB)
+				if diffSuspicious(k, fA, fB) {
+					mark = "!"
+					highlighted++
+				}
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", mark, metricLabel(cat, k), valA, valB, delta)
+		}
+	}
+
+	fmt.Fprintf(tw, "\n%d metrics differ from the benchmark by more than %.1f× in the suspicious direction\n", highlighted, DiffHighlightFactor)
+	return tw.Flush()
+}
+
+// diffSuspicious reports whether suspect value a is more than
+// DiffHighlightFactor away from benchmark value b in k's suspicious direction.
+func diffSuspicious(k Key, a, b float64) bool {
+	if diffLowerSuspicious[k] {
+		return a >= 0 && a*DiffHighlightFactor < b
+	}
+	return b > 0 && a/b > DiffHighlightFactor
+}
+
+func diffPlayer(ds *DemoStats, steamID uint64, role string) (*PlayerStats, error) {
+	if ds == nil {
+		return nil, fmt.Errorf("diff: %s demo stats are nil", role)
+	}
+	ps, ok := ds.Players[steamID]
+	if !ok {
+		return nil, fmt.Errorf("diff: %s player %d not found in %s", role, steamID, ds.DemoName)
+	}
+	return ps, nil
+}
+
+// diffKeys returns the union of keys in two category maps.
+func diffKeys(a, b map[Key]Metric) []Key {
+	keys := make([]Key, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, dup := a[k]; !dup {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// diffNumeric returns the metric's value as a float when it has one.
+func diffNumeric(m Metric) (float64, bool) {
+	switch m.Type {
+	case MetricPercentage, MetricFloat:
+		return m.FloatValue, true
+	case MetricInteger, MetricCount:
+		return float64(m.IntValue), true
+	case MetricDuration:
+		return m.DurationValue.Seconds(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDiffReport(t *testing.T) {
+	ds := NewDemoStats()
+	suspect := ds.GetOrCreatePlayerStatsBySteamID(1)
+	benchmark := ds.GetOrCreatePlayerStatsBySteamID(2)
+	suspect.AddMetric(Category("kills"), Key("headshot_percentage"), Metric{Type: MetricPercentage, FloatValue: 90})
+	benchmark.AddMetric(Category("kills"), Key("headshot_percentage"), Metric{Type: MetricPercentage, FloatValue: 45})
+	suspect.AddMetric(Category("kills"), Key("total_kills"), Metric{Type: MetricInteger, IntValue: 20})
+	benchmark.AddMetric(Category("kills"), Key("total_kills"), Metric{Type: MetricInteger, IntValue: 18})
+	benchmark.AddMetric(Category("sniper"), Key("scout_kills"), Metric{Type: MetricInteger, IntValue: 3})
+
+	var buf bytes.Buffer
+	if err := DiffReport(ds, ds, 1, 2, &buf); err != nil {
+		t.Fatalf("DiffReport: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{"+45.00", "+2.00", "[sniper]", "1 metrics differ"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "Headshot") && !strings.HasPrefix(line, "!") {
+			t.Errorf("headshot row not highlighted: %q", line)
+		}
+	}
+}
+
+func TestDiffReport_MissingPlayer(t *testing.T) {
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(1)
+	if err := DiffReport(ds, ds, 1, 2, &bytes.Buffer{}); err == nil {
+		t.Fatal("expected error for missing benchmark player")
+	}
+}
+
+func TestDiffReport_LowerIsSuspicious(t *testing.T) {
+	ds := NewDemoStats()
+	suspect := ds.GetOrCreatePlayerStatsBySteamID(1)
+	benchmark := ds.GetOrCreatePlayerStatsBySteamID(2)
+	suspect.AddMetric(CatReaction, KeyMedianTTD, Metric{Type: MetricFloat, FloatValue: 150})
+	benchmark.AddMetric(CatReaction, KeyMedianTTD, Metric{Type: MetricFloat, FloatValue: 400})
+	suspect.AddMetric(CatReaction, KeyP10TTD, Metric{Type: MetricFloat, FloatValue: 600})
+	benchmark.AddMetric(CatReaction, KeyP10TTD, Metric{Type: MetricFloat, FloatValue: 200})
+
+	var buf bytes.Buffer
+	if err := DiffReport(ds, ds, 1, 2, &buf); err != nil {
+		t.Fatalf("DiffReport: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "1 metrics differ") {
+		t.Errorf("want exactly one highlighted row:\n%s", out)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.Contains(line, "-250.00") && !strings.HasPrefix(line, "!"):
+			t.Errorf("faster median TTD not highlighted: %q", line)
+		case strings.Contains(line, "+400.00") && strings.HasPrefix(line, "!"):
+			t.Errorf("slower p10 TTD highlighted: %q", line)
+		}
+	}
+}
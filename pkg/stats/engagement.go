@@ -0,0 +1,325 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// DefaultEngagementTimeout is how long an engagement survives without line
+// of sight, damage or gunfire between the two players. Brief visibility
+// flickers between ticks shouldn't split one fight into two.
+const DefaultEngagementTimeout = 200 * time.Millisecond
+
+// EngagementConfig defines when two players are in a fight.
+type EngagementConfig struct {
+	// Timeout ends an engagement once neither player has seen, damaged or
+	// fired while engaged with the other for this long. The next sighting
+	// or hit starts a fresh engagement.
+	Timeout time.Duration
+
+	// Proximity is the maximum distance in world units at which line of
+	// sight alone opens an engagement; spotting someone across the map isn't
+	// a fight until shots land. Damage opens an engagement at any range.
+	// 0 means no limit.
+	Proximity float64
+}
+
+// DefaultEngagementConfig returns the engagement definition the built-in
+// collectors are calibrated against.
+func DefaultEngagementConfig() EngagementConfig {
+	return EngagementConfig{Timeout: DefaultEngagementTimeout}
+}
+
+// Engagement is one player's fight with one opponent. Times are in-game
+// times (see demoTime).
+type Engagement struct {
+	Opponent uint64
+
+	// StartTick and Start are when the engagement opened: the first
+	// sighting or damage in either direction.
+	StartTick int
+	Start     time.Duration
+
+	// FirstSeen is when the player first had line of sight to the opponent
+	// during this engagement; Seen is false until then.
+	FirstSeen time.Duration
+	Seen      bool
+
+	// FirstDamage is when the player first damaged the opponent during this
+	// engagement; Damaged is false until then.
+	FirstDamage time.Duration
+	Damaged     bool
+
+	// LastActive is the last sighting, damage or gunfire; the engagement
+	// times out Timeout after it.
+	LastActive time.Duration
+
+	ended bool
+}
+
+// Ended reports whether a kill ended the engagement on the current frame.
+func (e *Engagement) Ended() bool {
+	return e.ended
+}
+
+// EngagementTracker is the shared definition of a fight. It follows every
+// pair of opposing players from line of sight (the engine's spotted mask),
+// damage and gunfire, so collectors that sample "during a fight" all sample
+// the same moments:
+//
+//   - An engagement of A with B opens when B becomes visible to A (within
+//     Proximity), or when either damages the other.
+//   - It stays open while A sees B, either damages the other, or A fires.
+//   - It ends after Timeout without any of those, at a round end, when A
+//     kills B or B kills A, or when either dies. A kill leaves the killer's
+//     other engagements open: an enemy already in view when the killer
+//     sprays onto them was seen when they came into view, not at the kill.
+//
+// The Analyzer runs one tracker ahead of all collectors and hands it to
+// every collector implementing EngagementUser.
+type EngagementTracker struct {
+	cfg      EngagementConfig
+	tickRate float64
+	key      func(*common.Player) uint64
+
+	// open[playerSID][opponentSID] is the current engagement.
+	open map[uint64]map[uint64]*Engagement
+
+	// shots times damage at the sub-tick moment of the shot that dealt it.
+	shots shotClock
+}
+
+// EngagementUser is implemented by collectors that sample fights through
+// a shared EngagementTracker. UseEngagements is called before Setup.
+type EngagementUser interface {
+	UseEngagements(t *EngagementTracker)
+}
+
+// NewEngagementTracker creates a tracker. A zero Timeout falls back to
+// DefaultEngagementTimeout.
+func NewEngagementTracker(cfg EngagementConfig) *EngagementTracker {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultEngagementTimeout
+	}
+	return &EngagementTracker{
+		cfg:   cfg,
+		open:  make(map[uint64]map[uint64]*Engagement),
+		shots: make(shotClock),
+	}
+}
+
+// Config returns the tracker's engagement definition.
+func (t *EngagementTracker) Config() EngagementConfig {
+	return t.cfg
+}
+
+// Setup registers the damage, gunfire, kill and round handlers. It must run
+// before the Setup of any collector that queries the tracker from its own
+// handlers, so the tracker has already seen each event. Players are keyed
+// by demoStats.PlayerKey; anyone without a key (bots, unless included) is
+// ignored. Nothing is tracked during warmup, and engagements still open
+// when it ends are dropped.
+func (t *EngagementTracker) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	t.key = demoStats.PlayerKey
+	t.tickRate = parser.TickRate()
+	if t.tickRate <= 0 {
+		t.tickRate = 64.0
+	}
+	parser.RegisterEventHandler(func(e events.TickRateInfoAvailable) {
+		if e.TickRate > 0 {
+			t.tickRate = e.TickRate
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.PlayerHurt) {
+		if inWarmup(parser) || e.Attacker == nil || e.Player == nil || e.Attacker.Team == e.Player.Team {
+			return
+		}
+		tick, now := parser.CurrentFrame(), demoTime(parser, t.tickRate)
+		attacker := t.key(e.Attacker)
+		t.damage(attacker, t.key(e.Player), tick, t.shots.hitTime(attacker, tick, now))
+	})
+
+	parser.RegisterEventHandler(func(e events.WeaponFire) {
+		if inWarmup(parser) || e.Shooter == nil {
+			return
+		}
+		at := fireTime(parser, e.Weapon, t.tickRate)
+		t.shots.fire(t.key(e.Shooter), parser.CurrentFrame(), at)
+		t.fire(t.key(e.Shooter), at)
+	})
+
+	parser.RegisterEventHandler(func(e events.Kill) {
+		// Ending everything involving the victim ends the killer↔victim
+		// pair too.
+		if e.Victim != nil {
+			t.end(t.key(e.Victim))
+		}
+	})
+
+	parser.RegisterEventHandler(func(_ events.RoundEnd) {
+		t.open = make(map[uint64]map[uint64]*Engagement)
+	})
+
+	onWarmupEnd(parser, func() {
+		t.open = make(map[uint64]map[uint64]*Engagement)
+		t.shots = make(shotClock)
+	})
+}
+
+// Update opens and refreshes engagements from line of sight and drops ones
+// that ended or timed out. Call it once per frame, before any collector's
+// CollectFrame.
+func (t *EngagementTracker) Update(parser demoinfocs.Parser) {
+	if inWarmup(parser) {
+		return
+	}
+	tick, now := parser.CurrentFrame(), demoTime(parser, t.tickRate)
+	t.expire(now)
+
+	playing := parser.GameState().Participants().Playing()
+	for _, p := range playing {
+		pKey := t.key(p)
+		if pKey == 0 || !p.IsAlive() {
+			continue
+		}
+		for _, opp := range playing {
+			oppKey := t.key(opp)
+			if oppKey == 0 || oppKey == pKey {
+				continue
+			}
+			if opp.Team == p.Team || !opp.IsAlive() || !opp.IsSpottedBy(p) {
+				continue
+			}
+			if !t.withinProximity(p, opp) {
+				continue
+			}
+			t.sight(pKey, oppKey, tick, now)
+		}
+	}
+}
+
+// Engagement returns playerID's current engagement with opponentID, or nil.
+// An engagement a kill just ended stays readable until the next Update, so
+// Kill handlers can still see the fight the kill finished.
+func (t *EngagementTracker) Engagement(playerID, opponentID uint64) *Engagement {
+	return t.open[playerID][opponentID]
+}
+
+// Engaged reports whether playerID is currently in an engagement with
+// opponentID and, if so, the tick it started on.
+func (t *EngagementTracker) Engaged(playerID, opponentID uint64) (since int, ok bool) {
+	eng := t.Engagement(playerID, opponentID)
+	if eng == nil || eng.ended {
+		return 0, false
+	}
+	return eng.StartTick, true
+}
+
+// HitTime returns when a hit by playerID reported at tick happened: the
+// sub-tick moment of their shot on that tick when the demo records it (see
+// fireTime), else now.
+func (t *EngagementTracker) HitTime(playerID uint64, tick int, now time.Duration) time.Duration {
+	return t.shots.hitTime(playerID, tick, now)
+}
+
+// InEngagement reports whether playerID is engaged with anyone.
+func (t *EngagementTracker) InEngagement(playerID uint64) bool {
+	for _, eng := range t.open[playerID] {
+		if !eng.ended {
+			return true
+		}
+	}
+	return false
+}
+
+// withinProximity applies the Proximity limit to a sighting.
+func (t *EngagementTracker) withinProximity(p, opp *common.Player) bool {
+	if t.cfg.Proximity <= 0 {
+		return true
+	}
+	return p.Position().Sub(opp.Position()).Norm() <= t.cfg.Proximity
+}
+
+// engagement returns the open engagement of player with opponent, starting
+// one at tick/now if there is none.
+func (t *EngagementTracker) engagement(player, opponent uint64, tick int, now time.Duration) *Engagement {
+	if player == 0 || opponent == 0 {
+		return nil
+	}
+	victims := t.open[player]
+	if victims == nil {
+		victims = make(map[uint64]*Engagement)
+		t.open[player] = victims
+	}
+	eng := victims[opponent]
+	if eng == nil || eng.ended || now-eng.LastActive > t.cfg.Timeout {
+		eng = &Engagement{Opponent: opponent, StartTick: tick, Start: now, LastActive: now}
+		victims[opponent] = eng
+	}
+	return eng
+}
+
+// sight records player seeing opponent.
+func (t *EngagementTracker) sight(player, opponent uint64, tick int, now time.Duration) {
+	eng := t.engagement(player, opponent, tick, now)
+	if eng == nil {
+		return
+	}
+	if !eng.Seen {
+		eng.Seen, eng.FirstSeen = true, now
+	}
+	eng.LastActive = now
+}
+
+// damage records attacker hurting victim. Being shot is a fight for the
+// victim too, so both directions open or refresh.
+func (t *EngagementTracker) damage(attacker, victim uint64, tick int, now time.Duration) {
+	if eng := t.engagement(attacker, victim, tick, now); eng != nil {
+		if !eng.Damaged {
+			eng.Damaged, eng.FirstDamage = true, now
+		}
+		eng.LastActive = now
+	}
+	if eng := t.engagement(victim, attacker, tick, now); eng != nil {
+		eng.LastActive = now
+	}
+}
+
+// fire keeps every open engagement of the shooter alive.
+func (t *EngagementTracker) fire(shooter uint64, now time.Duration) {
+	for _, eng := range t.open[shooter] {
+		if !eng.ended && now-eng.LastActive <= t.cfg.Timeout {
+			eng.LastActive = now
+		}
+	}
+}
+
+// end marks every engagement involving playerID as ended.
+func (t *EngagementTracker) end(playerID uint64) {
+	for _, eng := range t.open[playerID] {
+		eng.ended = true
+	}
+	for _, victims := range t.open {
+		if eng := victims[playerID]; eng != nil {
+			eng.ended = true
+		}
+	}
+}
+
+// expire drops ended and timed-out engagements.
+func (t *EngagementTracker) expire(now time.Duration) {
+	for player, victims := range t.open {
+		for opponent, eng := range victims {
+			if eng.ended || now-eng.LastActive > t.cfg.Timeout {
+				delete(victims, opponent)
+			}
+		}
+		if len(victims) == 0 {
+			delete(t.open, player)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEngagementLifecycle(t *testing.T) {
+	et := NewEngagementTracker(EngagementConfig{})
+	if et.Config().Timeout != DefaultEngagementTimeout {
+		t.Fatalf("timeout = %v, want default", et.Config().Timeout)
+	}
+	ms := time.Millisecond
+
+	// Damage before sight opens the fight for both sides, unseen
+	et.damage(1, 2, 10, 100*ms)
+	eng := et.Engagement(1, 2)
+	if eng == nil || eng.Seen || !eng.Damaged || eng.FirstDamage != 100*ms {
+		t.Fatalf("after damage: %+v", eng)
+	}
+	if since, ok := et.Engaged(2, 1); !ok || since != 10 {
+		t.Errorf("victim Engaged = %d, %v; want 10, true", since, ok)
+	}
+
+	// Sighting joins the same engagement; gunfire keeps it alive
+	et.sight(1, 2, 15, 150*ms)
+	et.fire(1, 300*ms)
+	et.expire(400 * ms)
+	if got := et.Engagement(1, 2); got != eng || !got.Seen || got.FirstSeen != 150*ms {
+		t.Fatalf("after sight+fire: %+v", got)
+	}
+
+	// Idle past the timeout ends it; the next sighting starts a new one
+	et.expire(600 * ms)
+	if et.InEngagement(1) {
+		t.Fatal("engagement survived the timeout")
+	}
+	et.sight(1, 2, 40, 600*ms)
+	if since, _ := et.Engaged(1, 2); since != 40 {
+		t.Errorf("new engagement since %d, want 40", since)
+	}
+}
+
+func TestEngagementKill(t *testing.T) {
+	et := NewEngagementTracker(DefaultEngagementConfig())
+	et.sight(1, 2, 1, 0)
+	et.sight(1, 3, 1, 0)
+	et.sight(4, 1, 1, 0)
+
+	et.end(2) // 1 killed 2
+	eng := et.Engagement(1, 2)
+	if eng == nil || !eng.Ended() {
+		t.Fatalf("ended engagement not readable before Update: %+v", eng)
+	}
+	if _, ok := et.Engaged(1, 3); !ok {
+		t.Error("killer's other engagement ended with the kill")
+	}
+	if _, ok := et.Engaged(4, 1); !ok {
+		t.Error("engagement against the killer ended with the kill")
+	}
+
+	et.expire(0)
+	if et.Engagement(1, 2) != nil {
+		t.Error("ended engagement not dropped on the next frame")
+	}
+	et.sight(1, 3, 2, 0)
+	if eng := et.Engagement(1, 3); eng == nil || eng.StartTick != 1 {
+		t.Errorf("killer's engagement with 3 restarted after the kill: %+v", eng)
+	}
+}
@@ -0,0 +1,116 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// clipLeadIn and clipFollow are how much of the demo an evidence clip
+	// shows before and after the suspicious tick: the lead-in shows the
+	// player coming round the corner, the follow the kill or the spray.
+	clipLeadIn = time.Second
+	clipFollow = 500 * time.Millisecond
+)
+
+// SuspiciousEvent is one moment a collector found suspicious: the in-game
+// tick it happened on and a short human-readable reason.
+type SuspiciousEvent struct {
+	Tick   int    `json:"tick"`
+	Reason string `json:"reason"`
+}
+
+// AddSuspiciousEvent records a suspicious moment for steamID. Events
+// without a tick and from players outside the filter are dropped.
+func (ds *DemoStats) AddSuspiciousEvent(steamID uint64, tick int, reason string) {
+	if tick <= 0 || steamID == 0 || !ds.TracksPlayer(steamID) {
+		return
+	}
+	if ds.suspiciousEvents == nil {
+		ds.suspiciousEvents = make(map[uint64][]SuspiciousEvent)
+	}
+	ds.suspiciousEvents[steamID] = append(ds.suspiciousEvents[steamID], SuspiciousEvent{Tick: tick, Reason: reason})
+}
+
+// SuspiciousEvents returns steamID's suspicious events in tick order.
+func (ds *DemoStats) SuspiciousEvents(steamID uint64) []SuspiciousEvent {
+	events := append([]SuspiciousEvent(nil), ds.suspiciousEvents[steamID]...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Tick < events[j].Tick })
+	return events
+}
+
+// Clip is a tick range of the demo worth watching, with the reasons it was
+// cut. Reasons of merged clips are joined with "; ".
+type Clip struct {
+	StartTick int    `json:"start_tick"`
+	EndTick   int    `json:"end_tick"`
+	Reason    string `json:"reason"`
+}
+
+// EvidenceBundle turns steamID's suspicious events into clips to review:
+// each event at tick T becomes T-clipLeadIn..T+clipFollow (T-64..T+32 at 64
+// tick), and overlapping clips are merged. Clips are in tick order; the
+// result is empty when the player has no suspicious events.
+func (ds *DemoStats) EvidenceBundle(steamID uint64) []Clip {
+	rate := ds.TickRate
+	if rate <= 0 {
+		rate = 64
+	}
+	before := int(math.Round(clipLeadIn.Seconds() * rate))
+	after := int(math.Round(clipFollow.Seconds() * rate))
+
+	var clips []Clip
+	for _, ev := range ds.SuspiciousEvents(steamID) {
+		start, end := max(ev.Tick-before, 0), ev.Tick+after
+		if n := len(clips); n > 0 && start <= clips[n-1].EndTick {
+			last := &clips[n-1]
+			last.EndTick = max(last.EndTick, end)
+			if !strings.Contains("; "+last.Reason+"; ", "; "+ev.Reason+"; ") {
+				last.Reason += "; " + ev.Reason
+			}
+			continue
+		}
+		clips = append(clips, Clip{StartTick: start, EndTick: end, Reason: ev.Reason})
+	}
+	return clips
+}
+
+// evidenceExport is the WriteEvidenceBundle document.
+type evidenceExport struct {
+	SteamID  uint64  `json:"steam_id"`
+	Name     string  `json:"name"`
+	Demo     string  `json:"demo"`
+	Map      string  `json:"map"`
+	TickRate float64 `json:"tick_rate"`
+	Clips    []Clip  `json:"clips"`
+}
+
+// WriteEvidenceBundle writes steamID's EvidenceBundle as an indented JSON
+// document with the demo and tick rate needed to find the clips, for
+// handing to a reviewer with the demo file. It returns an error if steamID
+// isn't in the demo.
+func (ds *DemoStats) WriteEvidenceBundle(steamID uint64, w io.Writer) error {
+	ps, ok := ds.Players[steamID]
+	if !ok {
+		return fmt.Errorf("player %d not found in demo", steamID)
+	}
+	doc := evidenceExport{
+		SteamID:  steamID,
+		Name:     ps.Player.Name,
+		Demo:     ds.DemoName,
+		Map:      ds.MapName,
+		TickRate: ds.TickRate,
+		Clips:    ds.EvidenceBundle(steamID),
+	}
+	if doc.Clips == nil {
+		doc.Clips = []Clip{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
@@ -0,0 +1,75 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEvidenceBundle(t *testing.T) {
+	ds := NewDemoStats()
+	ds.TickRate = 64
+	ds.GetOrCreatePlayerStatsBySteamID(1).Player.Name = "suspect"
+	ds.GetOrCreatePlayerStatsBySteamID(2).Player.Name = "clean"
+
+	ds.AddSuspiciousEvent(1, 5000, "precise snap 3.1°/ms onto x")
+	ds.AddSuspiciousEvent(1, 1000, "damage 60 ms after spotting y")
+	// Within a clip of the previous event: merged.
+	ds.AddSuspiciousEvent(1, 1050, "precise snap 2.4°/ms onto y")
+	ds.AddSuspiciousEvent(1, 1060, "precise snap 2.4°/ms onto y")
+	// Near the start of the demo: clamped at tick 0.
+	ds.AddSuspiciousEvent(1, 20, "shot snapped onto an enemy head")
+	// Tick 0 is unknown and dropped.
+	ds.AddSuspiciousEvent(1, 0, "dropped")
+
+	want := []Clip{
+		{StartTick: 0, EndTick: 52, Reason: "shot snapped onto an enemy head"},
+		{StartTick: 936, EndTick: 1092, Reason: "damage 60 ms after spotting y; precise snap 2.4°/ms onto y"},
+		{StartTick: 4936, EndTick: 5032, Reason: "precise snap 3.1°/ms onto x"},
+	}
+	if got := ds.EvidenceBundle(1); !reflect.DeepEqual(got, want) {
+		t.Errorf("EvidenceBundle(1) = %+v, want %+v", got, want)
+	}
+	if got := ds.EvidenceBundle(2); len(got) != 0 {
+		t.Errorf("EvidenceBundle(2) = %+v, want none", got)
+	}
+
+	// 128 tick doubles the clip length in ticks.
+	ds.TickRate = 128
+	if got := ds.EvidenceBundle(1)[2]; got.StartTick != 4872 || got.EndTick != 5064 {
+		t.Errorf("128 tick clip = %+v, want 4872..5064", got)
+	}
+}
+
+func TestWriteEvidenceBundle(t *testing.T) {
+	ds := NewDemoStats()
+	ds.DemoName = "match.dem"
+	ds.TickRate = 64
+	ds.GetOrCreatePlayerStatsBySteamID(1).Player.Name = "suspect"
+	ds.AddSuspiciousEvent(1, 2000, "precise snap 2.8°/ms onto x")
+
+	var buf bytes.Buffer
+	if err := ds.WriteEvidenceBundle(1, &buf); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		SteamID uint64 `json:"steam_id"`
+		Name    string `json:"name"`
+		Demo    string `json:"demo"`
+		Clips   []Clip `json:"clips"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if doc.SteamID != 1 || doc.Name != "suspect" || doc.Demo != "match.dem" {
+		t.Errorf("identity = %+v", doc)
+	}
+	if len(doc.Clips) != 1 || doc.Clips[0].StartTick != 1936 || doc.Clips[0].EndTick != 2032 {
+		t.Errorf("clips = %+v", doc.Clips)
+	}
+
+	if err := ds.WriteEvidenceBundle(99, &buf); err == nil {
+		t.Error("expected an error for an unknown player")
+	}
+}
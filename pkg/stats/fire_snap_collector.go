@@ -0,0 +1,147 @@
+package stats
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// fireSnapMinTurnDeg is the smallest one-tick turn that counts as a snap.
+// Tracking a strafing target moves the view a degree or two per tick; a
+// trigger aimbot's correction is a jump well past that.
+const fireSnapMinTurnDeg = 4.0
+
+// FireSnapCollector catches aimbots that engage on fire: the shot's tick
+// turns the view onto an enemy's head that the view on the tick before
+// wasn't on. A human who clicks while flicking lands on the head after the
+// shot, or misses — landing on it the exact tick the trigger is pulled is
+// the aimbot's fingerprint.
+//
+//   - fire_snap_shots: shots with a view on both the shot tick and the one
+//     before.
+//   - fire_snap_count: of those, shots whose one-tick turn of at least
+//     fireSnapMinTurnDeg ended on an enemy head (within
+//     preciseSnapTolerance) that the previous view was off.
+//
+// Unlike SnapAngleCollector, this looks at every shot, not only kills.
+type FireSnapCollector struct {
+	*BaseCollector
+
+	// last is each player's view on the latest collected frame. WeaponFire
+	// handlers run before the frame is collected, so it is the view on the
+	// tick before the shot.
+	last map[uint64]ViewAngleSnapshot
+
+	shots    map[uint64]int
+	snaps    map[uint64]int
+	lastShot map[uint64]int // frame of the latest counted shot
+}
+
+// NewFireSnapCollector creates a new FireSnapCollector.
+func NewFireSnapCollector() *FireSnapCollector {
+	return &FireSnapCollector{
+		BaseCollector: NewBaseCollector("Fire Snaps", CatAiming),
+		last:          make(map[uint64]ViewAngleSnapshot),
+		shots:         make(map[uint64]int),
+		snaps:         make(map[uint64]int),
+		lastShot:      make(map[uint64]int),
+	}
+}
+
+// Setup registers the WeaponFire handler.
+func (fc *FireSnapCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	parser.RegisterEventHandler(func(e events.WeaponFire) {
+		shooter := e.Shooter
+		if inWarmup(parser) || !demoStats.CountsAimPlayer(shooter) || !shooter.IsAlive() {
+			return
+		}
+		if e.Weapon == nil || isMelee(e.Weapon) {
+			return
+		}
+		switch weaponClass(e.Weapon) {
+		case "grenade", "equipment":
+			return
+		}
+		sid := demoStats.PlayerKey(shooter)
+		frame := parser.CurrentFrame()
+		prev, ok := fc.last[sid]
+		if !ok || frame-prev.Tick > 1 {
+			return // no view on the frame right before
+		}
+		// One shot can surface as several events on a tick.
+		if n, seen := fc.lastShot[sid]; seen && n == frame {
+			return
+		}
+		yaw, pitch, ok := viewAngles(shooter)
+		if !ok {
+			return
+		}
+		fc.lastShot[sid] = frame
+		fc.shots[sid]++
+
+		var heads []r3.Vector
+		for _, enemy := range parser.GameState().Participants().Playing() {
+			if enemy == nil || !enemy.IsAlive() || enemy.Team == shooter.Team {
+				continue
+			}
+			heads = append(heads, headPosition(enemy))
+		}
+		after := ViewAngleSnapshot{Tick: frame, Yaw: yaw, Pitch: pitch}
+		if snapsOnto(eyePosition(shooter), prev, after, heads) {
+			fc.snaps[sid]++
+			demoStats.AddSuspiciousEvent(sid, parser.GameState().IngameTick(), "shot snapped onto an enemy head")
+		}
+	})
+}
+
+// snapsOnto reports whether turning from before to after (one tick) is a
+// snap of at least fireSnapMinTurnDeg that lands on one of the targets
+// before was off.
+func snapsOnto(eye r3.Vector, before, after ViewAngleSnapshot, targets []r3.Vector) bool {
+	if snapAngle(before, after) < fireSnapMinTurnDeg {
+		return false
+	}
+	for _, target := range targets {
+		tol := preciseSnapTolerance(target.Sub(eye).Norm())
+		onAfter := aimResidualDeg(eye, float64(after.Yaw), signedPitch(float64(after.Pitch)), target) <= tol
+		onBefore := aimResidualDeg(eye, float64(before.Yaw), signedPitch(float64(before.Pitch)), target) <= tol
+		if onAfter && !onBefore {
+			return true
+		}
+	}
+	return false
+}
+
+// CollectFrame records every alive player's view for the next frame's shots.
+func (fc *FireSnapCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	for _, player := range parser.GameState().Participants().Playing() {
+		if !demoStats.CountsAimPlayer(player) || !player.IsAlive() {
+			continue
+		}
+		yaw, pitch, ok := viewAngles(player)
+		if !ok {
+			continue
+		}
+		fc.last[demoStats.PlayerKey(player)] = ViewAngleSnapshot{Tick: parser.CurrentFrame(), Yaw: yaw, Pitch: pitch}
+	}
+}
+
+// CollectFinalStats publishes the shot and fire-snap counts.
+func (fc *FireSnapCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, shots := range fc.shots {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		ps.AddMetric(CatAiming, KeyFireSnapShots, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(shots),
+			Description: "Shots with a known view on the tick before",
+		})
+		ps.AddMetric(CatAiming, KeyFireSnapCount, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(fc.snaps[sid]),
+			Description: "Shots whose tick snapped the view onto an enemy head",
+		})
+	}
+}
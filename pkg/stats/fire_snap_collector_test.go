@@ -0,0 +1,31 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+)
+
+func TestSnapsOnto(t *testing.T) {
+	eye := r3.Vector{}
+	head := r3.Vector{X: 1000} // straight ahead: yaw 0, pitch 0
+	view := func(yaw, pitch float32) ViewAngleSnapshot { return ViewAngleSnapshot{Yaw: yaw, Pitch: pitch} }
+
+	if !snapsOnto(eye, view(20, 0), view(0.2, 0), []r3.Vector{head}) {
+		t.Error("20° one-tick snap onto the head not flagged")
+	}
+	if snapsOnto(eye, view(1.5, 0), view(0.2, 0), []r3.Vector{head}) {
+		t.Error("small tracking correction flagged")
+	}
+	if snapsOnto(eye, view(20, 0), view(10, 0), []r3.Vector{head}) {
+		t.Error("snap that ends off the head flagged")
+	}
+	if snapsOnto(eye, view(0, 0), view(350, 355), []r3.Vector{head, {Y: 1000}}) {
+		t.Error("snap off a target flagged")
+	}
+	// Across the 0/360 seam and onto a head slightly below eye level.
+	low := r3.Vector{X: 1000, Z: -50}
+	if !snapsOnto(eye, view(340, 0), view(0, 2.9), []r3.Vector{low}) {
+		t.Error("snap across yaw 0 onto a lower head not flagged")
+	}
+}
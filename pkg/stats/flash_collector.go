@@ -0,0 +1,173 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+const (
+	// flashFade is the tail of a flash during which vision gradually
+	// returns. Before it the screen is fully white; a flash no longer than
+	// this never fully blinds and is ignored.
+	flashFade = 2 * time.Second
+
+	// flashRecoveryWindow bounds the unblind-to-kill timings: kills later
+	// than this after the fade began aren't a reaction to the flash ending.
+	flashRecoveryWindow = 3 * time.Second
+
+	// flashMinTimings is the number of timings needed before the median is
+	// published.
+	flashMinTimings = 3
+)
+
+// flashState is one player's most recent full flash.
+type flashState struct {
+	start, end time.Duration
+}
+
+// FlashCollector measures how soon players kill after a full flash.
+// A human flashed fully white has to wait for the fade and then reorient;
+// a cheat that aims for them kills straight through it. Kills before the
+// fade begins count as killed_while_near_blind and feed the "flash"
+// cheat-score channel. Every kill within flashRecoveryWindow of the fade
+// adds a timing (negative while still fully blind) for
+// median_unblind_to_kill_ms.
+type FlashCollector struct {
+	*BaseCollector
+	tickRate float64
+
+	active      map[uint64]flashState
+	fullFlashes map[uint64]int
+	flashed     map[uint64]int // kills with any flash remaining
+	nearBlind   map[uint64]int
+	timingsMs   map[uint64][]float64
+}
+
+// NewFlashCollector creates a new FlashCollector.
+func NewFlashCollector() *FlashCollector {
+	return &FlashCollector{
+		BaseCollector: NewBaseCollector("Flash", CatFlash),
+		active:        make(map[uint64]flashState),
+		fullFlashes:   make(map[uint64]int),
+		flashed:       make(map[uint64]int),
+		nearBlind:     make(map[uint64]int),
+		timingsMs:     make(map[uint64][]float64),
+	}
+}
+
+// Setup registers the flash, kill and round handlers.
+func (fc *FlashCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	fc.tickRate = parser.TickRate()
+	if fc.tickRate <= 0 {
+		fc.tickRate = 64.0
+	}
+	parser.RegisterEventHandler(func(e events.TickRateInfoAvailable) {
+		if e.TickRate > 0 {
+			fc.tickRate = e.TickRate
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.PlayerFlashed) {
+		if inWarmup(parser) || !demoStats.CountsPlayer(e.Player) {
+			return
+		}
+		fc.recordFlash(demoStats.PlayerKey(e.Player), demoTime(parser, fc.tickRate), e.FlashDuration())
+	})
+
+	parser.RegisterEventHandler(func(e events.Kill) {
+		if inWarmup(parser) || e.Killer == nil || e.Victim == nil || e.Weapon == nil {
+			return
+		}
+		delete(fc.active, demoStats.PlayerKey(e.Victim))
+		if !demoStats.CountsPlayer(e.Killer) || e.Killer.Team == e.Victim.Team || isKnife(e.Weapon) {
+			return
+		}
+		switch weaponClass(e.Weapon) {
+		case "grenade", "equipment":
+			return
+		}
+		fc.recordKill(demoStats.PlayerKey(e.Killer), demoTime(parser, fc.tickRate))
+	})
+
+	parser.RegisterEventHandler(func(_ events.RoundStart) {
+		fc.active = make(map[uint64]flashState)
+	})
+}
+
+// recordFlash notes a flash of duration on steamID at now. Flashes too short
+// to fully blind are ignored, and never shorten a flash already running.
+func (fc *FlashCollector) recordFlash(steamID uint64, now, duration time.Duration) {
+	if duration <= flashFade {
+		return
+	}
+	if cur, ok := fc.active[steamID]; ok && cur.end >= now+duration {
+		return
+	}
+	fc.active[steamID] = flashState{start: now, end: now + duration}
+	fc.fullFlashes[steamID]++
+}
+
+// recordKill classifies a kill by steamID at now against their last flash.
+func (fc *FlashCollector) recordKill(steamID uint64, now time.Duration) {
+	f, ok := fc.active[steamID]
+	if !ok || now < f.start {
+		return
+	}
+	if now < f.end {
+		fc.flashed[steamID]++
+	}
+	unblind := f.end - flashFade
+	delta := now - unblind
+	if delta < 0 {
+		fc.nearBlind[steamID]++
+	}
+	if delta <= flashRecoveryWindow {
+		fc.timingsMs[steamID] = append(fc.timingsMs[steamID], float64(delta)/float64(time.Millisecond))
+	}
+}
+
+// CollectFrame is a no-op; everything is event-driven.
+func (fc *FlashCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {}
+
+// ProducedMetrics lists the metrics the cheat detector reads from FlashCollector.
+func (fc *FlashCollector) ProducedMetrics() []KeyRef {
+	return []KeyRef{
+		{CatFlash, KeyFullFlashes},
+		{CatFlash, KeyKilledWhileNearBlind},
+	}
+}
+
+// CollectFinalStats publishes the flash metrics for every player who was
+// fully flashed at least once.
+func (fc *FlashCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, n := range fc.fullFlashes {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		ps.AddMetric(CatFlash, KeyFullFlashes, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(n),
+			Description: "Times fully blinded by a flash",
+		})
+		ps.AddMetric(CatFlash, KeyKillsWhileFlashed, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(fc.flashed[sid]),
+			Description: "Kills with a flash still on screen",
+		})
+		ps.AddMetric(CatFlash, KeyKilledWhileNearBlind, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(fc.nearBlind[sid]),
+			Description: "Kills while still fully blind, before the flash began to fade",
+		})
+		if timings := fc.timingsMs[sid]; len(timings) >= flashMinTimings {
+			ps.AddMetric(CatFlash, KeyMedianUnblindToKillMs, Metric{
+				Type:        MetricFloat,
+				FloatValue:  median(timings),
+				Description: "Median time from a flash starting to fade to the next kill (negative: still blind)",
+			})
+		}
+	}
+}
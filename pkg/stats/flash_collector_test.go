@@ -0,0 +1,43 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlashCollectorTimings(t *testing.T) {
+	fc := NewFlashCollector()
+	s := time.Second
+
+	fc.recordFlash(1, 10*s, 1500*time.Millisecond) // too short to fully blind
+	fc.recordKill(1, 10*s+100*time.Millisecond)
+	if fc.fullFlashes[1] != 0 || fc.nearBlind[1] != 0 {
+		t.Fatalf("partial flash counted: %d flashes, %d near-blind kills", fc.fullFlashes[1], fc.nearBlind[1])
+	}
+
+	// 4 s flash at 20 s: fully white until 22 s, clear at 24 s.
+	fc.recordFlash(1, 20*s, 4*s)
+	fc.recordKill(1, 20*s+500*time.Millisecond) // still white
+	fc.recordKill(1, 23*s)                      // fading
+	fc.recordKill(1, 30*s)                      // long after
+	if fc.nearBlind[1] != 1 || fc.flashed[1] != 2 {
+		t.Errorf("near-blind %d, flashed %d; want 1, 2", fc.nearBlind[1], fc.flashed[1])
+	}
+	want := []float64{-1500, 1000}
+	got := fc.timingsMs[1]
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("timings = %v, want %v", got, want)
+	}
+}
+
+func TestEvaluateFlash(t *testing.T) {
+	ps := &PlayerStats{Categories: make(map[Category]map[Key]Metric)}
+	if ch := evaluateFlash(ps); ch.HasData {
+		t.Fatalf("scored without flash data: %+v", ch)
+	}
+	ps.AddMetric(CatFlash, Key("full_flashes"), Metric{Type: MetricInteger, IntValue: 8})
+	ps.AddMetric(CatFlash, Key("killed_while_near_blind"), Metric{Type: MetricInteger, IntValue: 4})
+	if ch := evaluateFlash(ps); ch.Score != 1 || ch.Confidence != 1 {
+		t.Errorf("4 blind kills over 8 flashes: %+v", ch)
+	}
+}
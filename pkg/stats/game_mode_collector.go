@@ -5,16 +5,22 @@ import (
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
 )
 
-// GameModeCollector tracks information about the game mode and round counts
+// GameModeCollector tracks information about the game mode and round counts.
+// With an analysis window set, RoundEnd only reaches it inside the window,
+// so round_count is the number of rounds that ended there, not in the demo.
 type GameModeCollector struct {
 	*BaseCollector
 	roundCount int
+
+	// maxPlaying is the most players seen on a team at once, counted over
+	// the parser's participants so the player filter doesn't shrink it.
+	maxPlaying int
 }
 
 // NewGameModeCollector creates a new GameModeCollector
 func NewGameModeCollector() *GameModeCollector {
 	return &GameModeCollector{
-		BaseCollector: NewBaseCollector("Game Mode", Category("game_info")),
+		BaseCollector: NewBaseCollector("Game Mode", CatGameInfo),
 		roundCount:    0,
 	}
 }
@@ -27,9 +33,28 @@ func (gmc *GameModeCollector) Setup(parser demoinfocs.Parser, demoStats *DemoSta
 	})
 }
 
-// CollectFrame is not needed for this collector as we're using event handlers
+// CollectFrame records how many players are in the match. It reads the
+// parser's participants rather than demoStats.Players: with a player filter
+// set, Players holds only the filtered IDs, and a lobby of one must not be
+// mistaken for Wingman.
 func (gmc *GameModeCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
-	// No per-frame processing needed, we use event handlers
+	playing := 0
+	for _, p := range parser.GameState().Participants().Playing() {
+		if demoStats.PlayerKey(p) != 0 {
+			playing++
+		}
+	}
+	if playing > gmc.maxPlaying {
+		gmc.maxPlaying = playing
+	}
+}
+
+// ProducedMetrics lists the metrics the cheat detector reads from GameModeCollector.
+func (gmc *GameModeCollector) ProducedMetrics() []KeyRef {
+	return []KeyRef{
+		{CatGameInfo, KeyGameMode},
+		{CatGameInfo, KeyRoundCount},
+	}
 }
 
 // CollectFinalStats calculates game mode and stores round count
@@ -41,18 +66,15 @@ func (gmc *GameModeCollector) CollectFinalStats(demoStats *DemoStats) {
 		Description: "Number of rounds played",
 	}
 
-	// Since DemoStats doesn't have an AddMetric method, we'll store this in a "global" player stats
-	// Create a special "global" player to store demo-wide metrics if it doesn't exist
-	globalStats := demoStats.GetOrCreatePlayerStatsBySteamID(0)
-	globalStats.AddMetric(Category("game_info"), Key("round_count"), gameInfoMetric)
+	// Demo-wide metrics live apart from the players
+	demoStats.AddMetric(CatGameInfo, KeyRoundCount, gameInfoMetric)
 
-	// Determine game mode based on real player count (exclude the sid=0
-	// "Unknown" placeholder used by some collectors for demo-wide metrics).
-	playerCount := 0
-	for sid := range demoStats.Players {
-		if sid != 0 {
-			playerCount++
-		}
+	// Determine game mode based on the real player count. Without any
+	// frames (a saved report being re-finalized) fall back to the players
+	// collected.
+	playerCount := gmc.maxPlaying
+	if playerCount == 0 {
+		playerCount = len(demoStats.Players)
 	}
 
 	// Game mode detection is approximate:
@@ -62,13 +84,13 @@ func (gmc *GameModeCollector) CollectFinalStats(demoStats *DemoStats) {
 
 	// Store game mode
 	if isWingman {
-		globalStats.AddMetric(Category("game_info"), Key("game_mode"), Metric{
+		demoStats.AddMetric(CatGameInfo, KeyGameMode, Metric{
 			Type:        MetricString,
 			StringValue: "Wingman",
 			Description: "Detected game mode",
 		})
 	} else {
-		globalStats.AddMetric(Category("game_info"), Key("game_mode"), Metric{
+		demoStats.AddMetric(CatGameInfo, KeyGameMode, Metric{
 			Type:        MetricString,
 			StringValue: "Competitive",
 			Description: "Detected game mode",
@@ -77,20 +99,31 @@ func (gmc *GameModeCollector) CollectFinalStats(demoStats *DemoStats) {
 
 	// Also store the game mode and round count for each player for easier access
 	for _, playerStats := range demoStats.Players {
-		playerStats.AddMetric(Category("game_info"), Key("round_count"), gameInfoMetric)
+		playerStats.AddMetric(CatGameInfo, KeyRoundCount, gameInfoMetric)
 
 		if isWingman {
-			playerStats.AddMetric(Category("game_info"), Key("game_mode"), Metric{
+			playerStats.AddMetric(CatGameInfo, KeyGameMode, Metric{
 				Type:        MetricString,
 				StringValue: "Wingman",
 				Description: "Detected game mode",
 			})
 		} else {
-			playerStats.AddMetric(Category("game_info"), Key("game_mode"), Metric{
+			playerStats.AddMetric(CatGameInfo, KeyGameMode, Metric{
 				Type:        MetricString,
 				StringValue: "Competitive",
 				Description: "Detected game mode",
 			})
 		}
 	}
+
+	// POV demos only carry the recorder's own aim at full rate; reports
+	// need to say so next to everyone else's stats.
+	perspective := Metric{
+		Type:        MetricString,
+		StringValue: demoStats.Perspective(),
+		Description: "Who recorded the demo: GOTV, or a player (POV — aim stats for the recorder only)",
+	}
+	for _, playerStats := range demoStats.Players {
+		playerStats.AddMetric(CatGameInfo, KeyDemoPerspective, perspective)
+	}
 }
@@ -0,0 +1,92 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// participantsStubParser reports a fixed set of players as playing.
+type participantsStubParser struct {
+	demoinfocs.Parser
+	playing []*common.Player
+}
+
+type participantsStubState struct {
+	demoinfocs.GameState
+	playing []*common.Player
+}
+
+type participantsStub struct {
+	demoinfocs.Participants
+	playing []*common.Player
+}
+
+func (p *participantsStubParser) GameState() demoinfocs.GameState {
+	return participantsStubState{playing: p.playing}
+}
+func (s participantsStubState) Participants() demoinfocs.Participants {
+	return participantsStub{playing: s.playing}
+}
+func (p participantsStub) Playing() []*common.Player { return p.playing }
+
+// gameModeLobby finalizes a 10-player demo in which only the suspect
+// (SteamID 1) has any stats, optionally filtered to the suspect.
+func gameModeLobby(filtered bool) *DemoStats {
+	parser := &participantsStubParser{}
+	for sid := uint64(1); sid <= 10; sid++ {
+		parser.playing = append(parser.playing, &common.Player{SteamID64: sid, Name: "p"})
+	}
+
+	ds := NewDemoStats()
+	if filtered {
+		ds.SetPlayerFilter(1)
+	}
+	for _, p := range parser.playing {
+		ds.GetOrCreatePlayerStats(p)
+	}
+	suspect := ds.Players[1]
+	suspect.AddMetric(CatKills, KeyTotalKills, Metric{Type: MetricInteger, IntValue: 14})
+	suspect.AddMetric(CatKills, KeyHeadshotPercentage, Metric{Type: MetricPercentage, FloatValue: 70})
+
+	gmc := NewGameModeCollector()
+	gmc.roundCount = 12
+	gmc.CollectFrame(parser, ds)
+	gmc.CollectFinalStats(ds)
+	NewCheatDetector().CollectFinalStats(ds)
+	return ds
+}
+
+func TestGameModeIgnoresPlayerFilter(t *testing.T) {
+	full, filtered := gameModeLobby(false), gameModeLobby(true)
+	if len(filtered.Players) != 1 {
+		t.Fatalf("fixture: filtered run kept %d players", len(filtered.Players))
+	}
+
+	if m, _ := filtered.GetMetric(CatGameInfo, KeyGameMode); m.StringValue != "Competitive" {
+		t.Errorf("filtered 10-player demo game_mode = %q, want Competitive", m.StringValue)
+	}
+	if psHasYes(filtered.Players[1], KeyWingmanBoost) {
+		t.Error("Wingman boost applied to a filtered 10-player demo")
+	}
+
+	want, ok := psGetFloat(full.Players[1], CatAntiCheat, KeyCheatLikelihood)
+	if !ok {
+		t.Fatal("unfiltered run published no cheat_likelihood")
+	}
+	if got, _ := psGetFloat(filtered.Players[1], CatAntiCheat, KeyCheatLikelihood); got != want {
+		t.Errorf("filtered likelihood = %.2f, unfiltered = %.2f", got, want)
+	}
+}
+
+func TestGameModeFallsBackToPlayersWithoutFrames(t *testing.T) {
+	ds := NewDemoStats()
+	for sid := uint64(1); sid <= 4; sid++ {
+		ds.GetOrCreatePlayerStatsBySteamID(sid)
+	}
+	NewGameModeCollector().CollectFinalStats(ds)
+	if m, _ := ds.GetMetric(CatGameInfo, KeyGameMode); m.StringValue != "Wingman" {
+		t.Errorf("game_mode = %q, want Wingman", m.StringValue)
+	}
+}
@@ -42,14 +42,17 @@ var (
 		{850, "C"},
 		{950, "D"},
 	}
+	// Lower-is-better mean recoil error, in degrees. The edges were set on
+	// the old error scale (0.6°…2.0°) and are converted by
+	// legacyRecoilScale.
 	recoilBands = []gradeBand{
-		{0.6, "A+"},
-		{0.8, "A"},
-		{1.0, "B+"},
-		{1.2, "B"},
-		{1.4, "C+"},
-		{1.7, "C"},
-		{2.0, "D"},
+		{0.6 / legacyRecoilScale, "A+"},
+		{0.8 / legacyRecoilScale, "A"},
+		{1.0 / legacyRecoilScale, "B+"},
+		{1.2 / legacyRecoilScale, "B"},
+		{1.4 / legacyRecoilScale, "C+"},
+		{1.7 / legacyRecoilScale, "C"},
+		{2.0 / legacyRecoilScale, "D"},
 	}
 )
 
@@ -111,24 +114,21 @@ type GradingCollector struct {
 
 func NewGradingCollector() *GradingCollector {
 	return &GradingCollector{
-		BaseCollector: NewBaseCollector("Grading", Category("rating")),
+		BaseCollector: NewBaseCollector("Grading", CatRating),
 	}
 }
 
 func (g *GradingCollector) CollectFinalStats(demoStats *DemoStats) {
-	for sid, ps := range demoStats.Players {
-		if sid == 0 {
-			continue
-		}
+	for _, ps := range demoStats.Players {
 		grades := make([]string, 0, 4)
 
 		// Combat — K/D from scoreboard. Need at least one death to be honest;
 		// otherwise we'd hand out A+ for a one-engagement sample.
-		kills := intMetric(ps, scoreboardCategory, Key("kills"))
-		deaths := intMetric(ps, scoreboardCategory, Key("deaths"))
+		kills := intMetric(ps, CatScoreboard, KeyKills)
+		deaths := intMetric(ps, CatScoreboard, KeyDeaths)
 		if deaths > 0 {
 			grade := gradeHigher(float64(kills)/float64(deaths), combatBands)
-			ps.AddMetric(Category("kills"), Key("grade"), Metric{
+			ps.AddMetric(CatKills, KeyGrade, Metric{
 				Type: MetricString, StringValue: grade,
 				Description: "Combat grade — K/D ratio",
 			})
@@ -136,9 +136,9 @@ func (g *GradingCollector) CollectFinalStats(demoStats *DemoStats) {
 		}
 
 		// Reaction — P10 sight-to-shot in ms.
-		if m, ok := ps.GetMetric(Category("reaction"), Key("p10_ttd")); ok && m.FloatValue > 0 {
+		if m, ok := ps.GetMetric(CatReaction, KeyP10TTD); ok && m.FloatValue > 0 {
 			grade := gradeLower(m.FloatValue, reactionBands)
-			ps.AddMetric(Category("reaction"), Key("grade"), Metric{
+			ps.AddMetric(CatReaction, KeyGrade, Metric{
 				Type: MetricString, StringValue: grade,
 				Description: "Reaction grade — P10 sight-to-shot",
 			})
@@ -146,9 +146,9 @@ func (g *GradingCollector) CollectFinalStats(demoStats *DemoStats) {
 		}
 
 		// Recoil — mean angular error in degrees.
-		if m, ok := ps.GetMetric(Category("recoil"), Key("mean_angular_error")); ok && m.FloatValue > 0 {
+		if m, ok := ps.GetMetric(CatRecoil, KeyMeanAngularError); ok && m.FloatValue > 0 {
 			grade := gradeLower(m.FloatValue, recoilBands)
-			ps.AddMetric(Category("recoil"), Key("grade"), Metric{
+			ps.AddMetric(CatRecoil, KeyGrade, Metric{
 				Type: MetricString, StringValue: grade,
 				Description: "Recoil grade — mean angular error",
 			})
@@ -159,11 +159,11 @@ func (g *GradingCollector) CollectFinalStats(demoStats *DemoStats) {
 		// grade: a single lucky 44-damage HE thrown in one round produces
 		// damage_per_round = 2.75 which would otherwise land in the C band,
 		// despite the sample being statistically meaningless.
-		thrown := intMetric(ps, grenadeCategory, Key("thrown"))
+		thrown := intMetric(ps, CatUtility, KeyThrown)
 		if thrown >= 4 {
-			if m, ok := ps.GetMetric(grenadeCategory, Key("damage_per_round")); ok && m.FloatValue > 0 {
+			if m, ok := ps.GetMetric(CatUtility, KeyDamagePerRound); ok && m.FloatValue > 0 {
 				grade := gradeHigher(m.FloatValue, grenadeBands)
-				ps.AddMetric(grenadeCategory, Key("grade"), Metric{
+				ps.AddMetric(CatUtility, KeyGrade, Metric{
 					Type: MetricString, StringValue: grade,
 					Description: "Grenade grade — HE damage per round (≥4 throws required)",
 				})
@@ -172,7 +172,7 @@ func (g *GradingCollector) CollectFinalStats(demoStats *DemoStats) {
 		}
 
 		if overall := averageGrade(grades); overall != "" {
-			ps.AddMetric(Category("rating"), Key("overall"), Metric{
+			ps.AddMetric(CatRating, KeyOverall, Metric{
 				Type: MetricString, StringValue: overall,
 				Description: "Overall skill grade — average across category grades",
 			})
@@ -7,8 +7,6 @@ import (
 	"github.com/oklog/ulid/v2"
 )
 
-const grenadeCategory = Category("utility")
-
 // GrenadeCollector tracks per-player HE grenade usage and damage. We restrict
 // to HE because that's the unit authoritative demo tools report on and the
 // metric that carries cheat signal — a player landing every HE on enemies
@@ -29,7 +27,7 @@ type heExplosion struct {
 
 func NewGrenadeCollector() *GrenadeCollector {
 	return &GrenadeCollector{
-		BaseCollector: NewBaseCollector("Grenades", grenadeCategory),
+		BaseCollector: NewBaseCollector("Grenades", CatUtility),
 		heExplosions:  map[ulid.ULID]*heExplosion{},
 	}
 }
@@ -42,24 +40,24 @@ func (gc *GrenadeCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats
 	// Each HE detonation is one "thrown" by the thrower. Tracking by Equipment
 	// UniqueID2 lets us attribute damage events back to the specific HE.
 	parser.RegisterEventHandler(func(e events.HeExplode) {
-		if parser.GameState().IsWarmupPeriod() {
+		if inWarmup(parser) {
 			return
 		}
 		if e.Thrower == nil || e.Grenade == nil {
 			return
 		}
 		gc.heExplosions[e.Grenade.UniqueID2()] = &heExplosion{
-			thrower: e.Thrower.SteamID64,
+			thrower: demoStats.PlayerKey(e.Thrower),
 		}
 		ps := demoStats.GetOrCreatePlayerStats(e.Thrower)
 		if ps == nil {
 			return
 		}
-		ps.IncrementIntMetric(grenadeCategory, Key("thrown"))
+		ps.IncrementIntMetric(CatUtility, KeyThrown)
 	})
 
 	parser.RegisterEventHandler(func(e events.PlayerHurt) {
-		if parser.GameState().IsWarmupPeriod() {
+		if inWarmup(parser) {
 			return
 		}
 		if e.Attacker == nil || e.Player == nil || e.Attacker == e.Player {
@@ -76,8 +74,8 @@ func (gc *GrenadeCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats
 			return
 		}
 		dmg := int64(e.HealthDamageTaken)
-		addIntMetric(ps, grenadeCategory, Key("damage"), dmg)
-		ps.IncrementIntMetric(grenadeCategory, Key("enemy_hits"))
+		addIntMetric(ps, CatUtility, KeyDamage, dmg)
+		ps.IncrementIntMetric(CatUtility, KeyEnemyHits)
 
 		if info, ok := gc.heExplosions[e.Weapon.UniqueID2()]; ok {
 			info.damage += dmg
@@ -86,7 +84,7 @@ func (gc *GrenadeCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats
 	})
 
 	parser.RegisterEventHandler(func(e events.Kill) {
-		if parser.GameState().IsWarmupPeriod() {
+		if inWarmup(parser) {
 			return
 		}
 		if e.Killer == nil || e.Victim == nil || e.Killer == e.Victim {
@@ -102,7 +100,7 @@ func (gc *GrenadeCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats
 		if ps == nil {
 			return
 		}
-		ps.IncrementIntMetric(grenadeCategory, Key("killed"))
+		ps.IncrementIntMetric(CatUtility, KeyKilled)
 	})
 }
 
@@ -115,35 +113,32 @@ func (gc *GrenadeCollector) CollectFinalStats(demoStats *DemoStats) {
 	}
 
 	for sid, ps := range demoStats.Players {
-		if sid == 0 {
-			continue
-		}
-		thrown := intMetric(ps, grenadeCategory, Key("thrown"))
+		thrown := intMetric(ps, CatUtility, KeyThrown)
 		if thrown == 0 {
 			continue
 		}
-		damage := intMetric(ps, grenadeCategory, Key("damage"))
-		hits := intMetric(ps, grenadeCategory, Key("enemy_hits"))
+		damage := intMetric(ps, CatUtility, KeyDamage)
+		hits := intMetric(ps, CatUtility, KeyEnemyHits)
 
-		ps.AddMetric(grenadeCategory, Key("damage_per_throw"), Metric{
+		ps.AddMetric(CatUtility, KeyDamagePerThrow, Metric{
 			Type:        MetricFloat,
 			FloatValue:  float64(damage) / float64(thrown),
 			Description: "Avg HE damage per HE thrown",
 		})
-		ps.AddMetric(grenadeCategory, Key("enemies_per_throw"), Metric{
+		ps.AddMetric(CatUtility, KeyEnemiesPerThrow, Metric{
 			Type:        MetricFloat,
 			FloatValue:  float64(hits) / float64(thrown),
 			Description: "Avg enemy-damage events per HE thrown",
 		})
 		if gc.roundCount > 0 {
-			ps.AddMetric(grenadeCategory, Key("damage_per_round"), Metric{
+			ps.AddMetric(CatUtility, KeyDamagePerRound, Metric{
 				Type:        MetricFloat,
 				FloatValue:  float64(damage) / float64(gc.roundCount),
 				Description: "Avg HE damage per round",
 			})
 		}
 
-		ps.AddMetric(grenadeCategory, Key("he_zero_damage"), Metric{
+		ps.AddMetric(CatUtility, KeyHEZeroDamage, Metric{
 			Type:        MetricInteger,
 			IntValue:    heZero[sid],
 			Description: "HE grenades that dealt zero damage (lineups, prefires, missed reads — normal humans always have some)",
@@ -0,0 +1,114 @@
+package stats
+
+import (
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// hitgroupMinHits is the number of bullet hits needed before the
+// distribution is published; a handful of hits says nothing about style.
+const hitgroupMinHits = 10
+
+// hitgroupBuckets maps engine hitgroups onto the reported buckets. Neck hits
+// take head damage and are counted as head; generic (grenade, fire) and gear
+// hits are not bullet placement and are dropped.
+var hitgroupBuckets = map[events.HitGroup]string{
+	events.HitGroupHead:     "head",
+	events.HitGroupNeck:     "head",
+	events.HitGroupChest:    "chest",
+	events.HitGroupStomach:  "stomach",
+	events.HitGroupLeftArm:  "arms",
+	events.HitGroupRightArm: "arms",
+	events.HitGroupLeftLeg:  "legs",
+	events.HitGroupRightLeg: "legs",
+}
+
+// hitgroupOrder is the publish order of the buckets.
+var hitgroupOrder = []string{"head", "chest", "stomach", "arms", "legs"}
+
+// HitgroupCollector tallies where each attacker's bullets land on enemies.
+// headshot_percentage only sees the killing blow; the share of *all* hits
+// that are head hits is harder to keep human — even strong players tag a
+// lot of chest and arm while tracking — so an aimbot's pure-head
+// distribution stands out here first.
+type HitgroupCollector struct {
+	*BaseCollector
+	hits map[uint64]map[string]int
+}
+
+// NewHitgroupCollector creates a new HitgroupCollector.
+func NewHitgroupCollector() *HitgroupCollector {
+	return &HitgroupCollector{
+		BaseCollector: NewBaseCollector("Hitgroups", CatHitgroups),
+		hits:          make(map[uint64]map[string]int),
+	}
+}
+
+// Setup registers the PlayerHurt handler.
+func (hc *HitgroupCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	parser.RegisterEventHandler(func(e events.PlayerHurt) {
+		if inWarmup(parser) {
+			return
+		}
+		hc.processHurt(e, demoStats)
+	})
+}
+
+// processHurt counts one bullet hit by a tracked human on an enemy.
+func (hc *HitgroupCollector) processHurt(e events.PlayerHurt, demoStats *DemoStats) {
+	if e.Attacker == nil || e.Player == nil || e.Weapon == nil {
+		return
+	}
+	if !demoStats.CountsPlayer(e.Attacker) || e.Attacker.Team == e.Player.Team {
+		return
+	}
+	if isKnife(e.Weapon) {
+		return
+	}
+	switch weaponClass(e.Weapon) {
+	case "grenade", "equipment":
+		return
+	}
+	bucket, ok := hitgroupBuckets[e.HitGroup]
+	if !ok {
+		return
+	}
+	sid := demoStats.PlayerKey(e.Attacker)
+	if hc.hits[sid] == nil {
+		hc.hits[sid] = make(map[string]int)
+	}
+	hc.hits[sid][bucket]++
+}
+
+// CollectFrame is a no-op; everything is event-driven.
+func (hc *HitgroupCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {}
+
+// CollectFinalStats publishes bullet_hits and, from hitgroupMinHits on, the
+// share of hits per bucket.
+func (hc *HitgroupCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, byBucket := range hc.hits {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		total := 0
+		for _, n := range byBucket {
+			total += n
+		}
+		ps.AddMetric(CatHitgroups, KeyBulletHits, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(total),
+			Description: "Bullet hits on enemies with a known hitgroup",
+		})
+		if total < hitgroupMinHits {
+			continue
+		}
+		for _, bucket := range hitgroupOrder {
+			ps.AddMetric(CatHitgroups, Key(bucket+"_hit_pct"), Metric{
+				Type:        MetricPercentage,
+				FloatValue:  float64(byBucket[bucket]) / float64(total) * 100.0,
+				Description: "Share of bullet hits landing on the " + bucket,
+			})
+		}
+	}
+}
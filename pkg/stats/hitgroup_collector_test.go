@@ -0,0 +1,45 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+func TestHitgroupCollector(t *testing.T) {
+	ds := NewDemoStats()
+	attacker := &common.Player{SteamID64: 1, Name: "a", Team: common.TeamTerrorists}
+	enemy := &common.Player{SteamID64: 2, Name: "b", Team: common.TeamCounterTerrorists}
+	mate := &common.Player{SteamID64: 3, Name: "c", Team: common.TeamTerrorists}
+	ds.GetOrCreatePlayerStats(attacker)
+
+	ak := common.NewEquipment(common.EqAK47)
+	he := common.NewEquipment(common.EqHE)
+	hc := NewHitgroupCollector()
+	hurt := func(victim *common.Player, w *common.Equipment, g events.HitGroup) {
+		hc.processHurt(events.PlayerHurt{Attacker: attacker, Player: victim, Weapon: w, HitGroup: g}, ds)
+	}
+	for i := 0; i < 6; i++ {
+		hurt(enemy, ak, events.HitGroupHead)
+	}
+	hurt(enemy, ak, events.HitGroupNeck)
+	hurt(enemy, ak, events.HitGroupChest)
+	hurt(enemy, ak, events.HitGroupLeftArm)
+	hurt(enemy, ak, events.HitGroupRightLeg)
+	hurt(enemy, he, events.HitGroupGeneric) // grenade: ignored
+	hurt(mate, ak, events.HitGroupHead)     // team damage: ignored
+	hurt(enemy, ak, events.HitGroupGeneric) // no placement: ignored
+
+	hc.CollectFinalStats(ds)
+	ps := ds.Players[1]
+	if n, _ := psGetInt(ps, CatHitgroups, Key("bullet_hits")); n != 10 {
+		t.Fatalf("bullet_hits = %d, want 10", n)
+	}
+	want := map[string]float64{"head": 70, "chest": 10, "stomach": 0, "arms": 10, "legs": 10}
+	for bucket, pct := range want {
+		if got, _ := psGetFloat(ps, CatHitgroups, Key(bucket+"_hit_pct")); got != pct {
+			t.Errorf("%s_hit_pct = %v, want %v", bucket, got, pct)
+		}
+	}
+}
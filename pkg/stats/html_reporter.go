@@ -8,6 +8,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 //go:embed report.tmpl.html
@@ -27,6 +29,9 @@ func NewHTMLReporter() (*HTMLReporter, error) {
 	return &HTMLReporter{tmpl: tmpl}, nil
 }
 
+// Extension returns "html".
+func (hr *HTMLReporter) Extension() string { return "html" }
+
 // Report writes an HTML report. The categories argument is accepted for
 // Reporter compatibility but the HTML reporter derives its own ordering.
 func (hr *HTMLReporter) Report(demoStats *DemoStats, _ []Category, writer io.Writer) error {
@@ -35,9 +40,8 @@ func (hr *HTMLReporter) Report(demoStats *DemoStats, _ []Category, writer io.Wri
 }
 
 const (
-	flagThreshold    = 50.0
-	warnThreshold    = 25.0
-	placeholderSteam = 0
+	flagThreshold = 50.0
+	warnThreshold = 25.0
 )
 
 type htmlData struct {
@@ -52,6 +56,9 @@ type htmlData struct {
 	LowestName        string
 	GameMode          string
 	RoundCount        int64
+	InsufficientData  string         // why the demo wasn't scored, "" when it was
+	IntegrityWarnings int64          // frames skipped for going back in time
+	DemoInfo          []htmlCategory // demo-wide metrics, see DemoStats.AddMetric
 	MetricCount       int
 	Teams             []htmlTeam
 	Players           []htmlPlayer
@@ -70,8 +77,11 @@ type htmlScoreRow struct {
 	ADR     string
 	HS      string
 	MVPs    string
-	// sortKills is unexported but used for ordering before render.
+	Rating  string
+	// sortKills and sortSID are unexported but used for ordering before
+	// render.
 	sortKills int64
+	sortSID   uint64
 }
 
 type htmlPlayer struct {
@@ -84,6 +94,7 @@ type htmlPlayer struct {
 	OverallGradeClass string
 	Grades            []htmlGrade
 	Narrative         string
+	Explanation       string
 	Channels          []htmlChannel
 	Boosts            []htmlMetric
 	Categories        []htmlCategory
@@ -129,30 +140,36 @@ func buildHTMLData(ds *DemoStats) htmlData {
 		MapName:     ds.MapName,
 	}
 
-	if global, ok := ds.Players[placeholderSteam]; ok {
-		if m, found := global.GetMetric(Category("game_info"), Key("game_mode")); found {
-			data.GameMode = m.StringValue
-		}
-		if m, found := global.GetMetric(Category("game_info"), Key("round_count")); found {
-			data.RoundCount = m.IntValue
-		}
+	if m, found := ds.GetMetric(CatGameInfo, KeyGameMode); found {
+		data.GameMode = m.StringValue
+	}
+	if m, found := ds.GetMetric(CatGameInfo, KeyRoundCount); found {
+		data.RoundCount = m.IntValue
 	}
+	if m, found := ds.GetMetric(CatGameInfo, KeyInsufficientData); found {
+		data.InsufficientData = m.StringValue
+	}
+	if m, found := ds.GetMetric(CatGameInfo, KeyDemoIntegrityWarnings); found {
+		data.IntegrityWarnings = m.IntValue
+	}
+	data.DemoInfo = buildCategories(&PlayerStats{Categories: ds.GlobalMetrics})
 
 	realPlayers := make([]*PlayerStats, 0, len(ds.Players))
-	for sid, ps := range ds.Players {
-		if sid == placeholderSteam {
-			continue
-		}
+	for _, ps := range ds.Players {
 		realPlayers = append(realPlayers, ps)
 	}
 
 	sort.Slice(realPlayers, func(i, j int) bool {
-		li := getMetricFloatValue(realPlayers[i], Category("anti_cheat"), Key("cheat_likelihood"))
-		lj := getMetricFloatValue(realPlayers[j], Category("anti_cheat"), Key("cheat_likelihood"))
+		li := getMetricFloatValue(realPlayers[i], CatAntiCheat, KeyCheatLikelihood)
+		lj := getMetricFloatValue(realPlayers[j], CatAntiCheat, KeyCheatLikelihood)
 		if li != lj {
 			return li > lj
 		}
-		return realPlayers[i].Player.Name < realPlayers[j].Player.Name
+		if realPlayers[i].Player.Name != realPlayers[j].Player.Name {
+			return realPlayers[i].Player.Name < realPlayers[j].Player.Name
+		}
+		// Duplicate or empty names: SteamID keeps the order reproducible
+		return realPlayers[i].Player.SteamID64 < realPlayers[j].Player.SteamID64
 	})
 
 	data.PlayerCount = len(realPlayers)
@@ -189,8 +206,7 @@ func buildScoreboard(players []*PlayerStats) []htmlTeam {
 	for _, ps := range players {
 		row, side := buildScoreRow(ps)
 		if row.sortKills == 0 && row.Deaths == "0" && row.ADR == "—" && row.Assists == "0" {
-			// No scoreboard activity at all — skip (probably a spectator or
-			// placeholder slot).
+			// No scoreboard activity at all — skip (probably a spectator).
 			continue
 		}
 		groups[side] = append(groups[side], row)
@@ -202,39 +218,57 @@ func buildScoreboard(players []*PlayerStats) []htmlTeam {
 		if len(rows) == 0 {
 			continue
 		}
-		sort.Slice(rows, func(i, j int) bool { return rows[i].sortKills > rows[j].sortKills })
+		sortScoreRows(rows)
 		out = append(out, htmlTeam{Label: side, Players: rows})
 	}
 
 	// Fall back to a single "All" table if no team side was recorded.
 	if len(out) == 0 {
 		if rows := groups[""]; len(rows) > 0 {
-			sort.Slice(rows, func(i, j int) bool { return rows[i].sortKills > rows[j].sortKills })
+			sortScoreRows(rows)
 			out = append(out, htmlTeam{Label: "All", Players: rows})
 		}
 	}
 	return out
 }
 
+// sortScoreRows orders rows by kills, highest first, then by name and
+// SteamID so ties render the same on every run.
+func sortScoreRows(rows []htmlScoreRow) {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].sortKills != rows[j].sortKills {
+			return rows[i].sortKills > rows[j].sortKills
+		}
+		if rows[i].Name != rows[j].Name {
+			return rows[i].Name < rows[j].Name
+		}
+		return rows[i].sortSID < rows[j].sortSID
+	})
+}
+
 func buildScoreRow(ps *PlayerStats) (htmlScoreRow, string) {
 	side := ""
-	if m, ok := ps.GetMetric(scoreboardCategory, Key("team")); ok {
+	if m, ok := ps.GetMetric(CatScoreboard, KeyTeam); ok {
 		side = m.StringValue
 	}
 
-	kills := intMetric(ps, scoreboardCategory, Key("kills"))
-	deaths := intMetric(ps, scoreboardCategory, Key("deaths"))
-	assists := intMetric(ps, scoreboardCategory, Key("assists"))
-	mvps := intMetric(ps, scoreboardCategory, Key("mvps"))
+	kills := intMetric(ps, CatScoreboard, KeyKills)
+	deaths := intMetric(ps, CatScoreboard, KeyDeaths)
+	assists := intMetric(ps, CatScoreboard, KeyAssists)
+	mvps := intMetric(ps, CatScoreboard, KeyMVPs)
 
 	adr := "—"
-	if m, ok := ps.GetMetric(scoreboardCategory, Key("adr")); ok && m.FloatValue > 0 {
+	if m, ok := ps.GetMetric(CatScoreboard, KeyADR); ok && m.FloatValue > 0 {
 		adr = fmt.Sprintf("%.1f", m.FloatValue)
 	}
 	hs := "—"
-	if m, ok := ps.GetMetric(scoreboardCategory, Key("hs_percentage")); ok && kills > 0 {
+	if m, ok := ps.GetMetric(CatScoreboard, KeyHSPercentage); ok && kills > 0 {
 		hs = fmt.Sprintf("%.0f%%", m.FloatValue)
 	}
+	rating := "—"
+	if m, ok := ps.GetMetric(CatRating, KeyRating2); ok {
+		rating = fmt.Sprintf("%.2f", m.FloatValue)
+	}
 
 	row := htmlScoreRow{
 		Name:      fallback(ps.Player.Name, "Unknown"),
@@ -244,7 +278,9 @@ func buildScoreRow(ps *PlayerStats) (htmlScoreRow, string) {
 		ADR:       adr,
 		HS:        hs,
 		MVPs:      fmt.Sprintf("%d", mvps),
+		Rating:    rating,
 		sortKills: kills,
+		sortSID:   ps.Player.SteamID64,
 	}
 	return row, side
 }
@@ -257,15 +293,16 @@ func intMetric(ps *PlayerStats, cat Category, k Key) int64 {
 }
 
 func buildPlayer(ps *PlayerStats) htmlPlayer {
-	likelihood := getMetricFloatValue(ps, Category("anti_cheat"), Key("cheat_likelihood"))
+	likelihood := getMetricFloatValue(ps, CatAntiCheat, KeyCheatLikelihood)
 	flagged := false
-	if m, found := ps.GetMetric(Category("anti_cheat"), Key("cheater")); found && m.StringValue == "Yes" {
+	if m, found := ps.GetMetric(CatAntiCheat, KeyCheater); found && m.StringValue == "Yes" {
 		flagged = true
 	}
 
 	grades, overall, overallClass := buildGrades(ps)
 	channels := buildChannels(ps)
 	boosts := buildAntiCheatBoosts(ps)
+	explanation, _ := psGetString(ps, CatAntiCheat, KeyCheatExplanation)
 
 	return htmlPlayer{
 		Name:              fallback(ps.Player.Name, "Unknown"),
@@ -277,6 +314,7 @@ func buildPlayer(ps *PlayerStats) htmlPlayer {
 		OverallGradeClass: overallClass,
 		Grades:            grades,
 		Narrative:         buildCheatscoreNarrative(ps),
+		Explanation:       explanation,
 		Channels:          channels,
 		Boosts:            boosts,
 		Categories:        buildCategories(ps),
@@ -298,7 +336,7 @@ var gradeCategories = []struct {
 
 func buildGrades(ps *PlayerStats) (grades []htmlGrade, overall, overallClass string) {
 	for _, gc := range gradeCategories {
-		m, ok := ps.GetMetric(gc.Cat, Key("grade"))
+		m, ok := ps.GetMetric(gc.Cat, KeyGrade)
 		if !ok || m.StringValue == "" || m.StringValue == "-" {
 			continue
 		}
@@ -308,7 +346,7 @@ func buildGrades(ps *PlayerStats) (grades []htmlGrade, overall, overallClass str
 			Class: gradeClass(m.StringValue),
 		})
 	}
-	if m, ok := ps.GetMetric(Category("rating"), Key("overall")); ok && m.StringValue != "" && m.StringValue != "-" {
+	if m, ok := ps.GetMetric(CatRating, KeyOverall); ok && m.StringValue != "" && m.StringValue != "-" {
 		overall = m.StringValue
 		overallClass = gradeClass(m.StringValue)
 	}
@@ -346,6 +384,8 @@ var channelDisplay = []struct {
 	{"decoupling", "Fight vs idle decoupling"},
 	{"attention", "Idle attention"},
 	{"back_killed", "Back-killed avoidance"},
+	{"flash", "Kills while flashed"},
+	{"blind_tracking", "Kills tracked through walls"},
 }
 
 // channelScoreKey maps a channel ID to the anti_cheat metric key holding its
@@ -370,16 +410,16 @@ func buildChannels(ps *PlayerStats) []htmlChannel {
 	for _, cd := range channelDisplay {
 		score := 0.0
 		hasScore := false
-		if m, ok := ps.GetMetric(Category("anti_cheat"), channelScoreKey(cd.ID)); ok {
+		if m, ok := ps.GetMetric(CatAntiCheat, channelScoreKey(cd.ID)); ok {
 			score = m.FloatValue
 			hasScore = true
 		}
 		conf := 0.0
-		if m, ok := ps.GetMetric(Category("anti_cheat"), Key(cd.ID+"_confidence")); ok {
+		if m, ok := ps.GetMetric(CatAntiCheat, Key(cd.ID+"_confidence")); ok {
 			conf = m.FloatValue
 		}
 		zone := ""
-		if m, ok := ps.GetMetric(Category("anti_cheat"), Key(cd.ID+"_zone")); ok {
+		if m, ok := ps.GetMetric(CatAntiCheat, Key(cd.ID+"_zone")); ok {
 			zone = m.StringValue
 		}
 		hasData := hasScore && zone != "" && zone != "no_data"
@@ -442,12 +482,15 @@ var antiCheatBoostKeys = []struct {
 	{Key("ttd_sub100_high_floor"), "Sub-100ms TTD floor"},
 	{Key("sniper_wallbang_override"), "Sniper wallbang override"},
 	{Key("scout_precision_override"), "Scout precision override"},
+	{Key("map_profile"), "Map profile"},
+	{Key("insufficient_data"), "Insufficient data"},
+	{Key("allowlisted"), "Allowlisted"},
 }
 
 func buildAntiCheatBoosts(ps *PlayerStats) []htmlMetric {
 	out := make([]htmlMetric, 0, len(antiCheatBoostKeys))
 	for _, b := range antiCheatBoostKeys {
-		m, ok := ps.GetMetric(Category("anti_cheat"), b.Key)
+		m, ok := ps.GetMetric(CatAntiCheat, b.Key)
 		if !ok {
 			continue
 		}
@@ -458,7 +501,7 @@ func buildAntiCheatBoosts(ps *PlayerStats) []htmlMetric {
 		out = append(out, htmlMetric{
 			Label: b.Label,
 			Value: val,
-			Class: metricClass(Category("anti_cheat"), b.Key, m),
+			Class: metricClass(CatAntiCheat, b.Key, m),
 		})
 	}
 	return out
@@ -475,90 +518,108 @@ var categoryDisplay = []struct {
 	Note  string
 }{
 	{Category("kills"), "Combat", ""},
+	{Category("hitgroups"), "Hit Distribution", "informational"},
 	{Category("aiming"), "Aim Snap", ""},
 	{Category("reaction"), "Reaction Time", ""},
+	{Category("ttk"), "Time To Kill", ""},
 	{Category("recoil"), "Recoil Control", ""},
 	{Category("weapons"), "Weapon Usage", ""},
 	{Category("utility"), "Grenades", ""},
+	{Category("scripts"), "Jumpthrow Scripts", "informational"},
+	{Category("movement"), "Movement", "informational"},
 	{Category("sniper"), "Sniper Anomalies", ""},
 	{Category("behavioral"), "Behavioral", "informational"},
+	{Category("placement"), "Crosshair Placement", "informational"},
+	{Category("objective"), "Objective", "informational"},
+	{Category("impact"), "Opening Duels", "informational"},
+	{Category("conduct"), "Conduct", "informational"},
+	{Category("performance"), "Performance vs Lobby", "informational"},
+	{Category("team"), "Sides", "informational"},
+	{Category("accuracy"), "Accuracy by Range", "informational"},
+	{Category("inaccuracy"), "Weapon Inaccuracy", "informational"},
+	{Category("flash"), "Flashes", ""},
+	{Category("smoke"), "Smokes", "informational"},
+	{Category("wallbang"), "Wallbangs", "informational"},
+	{Category("wallhack"), "Blind Tracking", ""},
+	{Category("info"), "Spotted Info", "informational"},
 	{Category("game_info"), "Game Info", ""},
+	{Category("player_info"), "Player Info", ""},
 }
 
+// buildCategories lists ps's categories for the bottom grid, in the
+// ReportModel's order, leaving out the ones rendered in their own card
+// sections.
 func buildCategories(ps *PlayerStats) []htmlCategory {
 	out := make([]htmlCategory, 0, len(categoryDisplay))
-	seen := make(map[Category]bool)
-	// scoreboard, anti_cheat, and rating render in their own card sections.
-	seen[scoreboardCategory] = true
-	seen[Category("anti_cheat")] = true
-	seen[Category("rating")] = true
-
-	for _, spec := range categoryDisplay {
-		seen[spec.Key] = true
-		metrics := metricsForCategory(ps, spec.Key)
-		if len(metrics) == 0 {
+	for _, s := range reportSections(ps, nil) {
+		// scoreboard, anti_cheat, and rating render in their own card sections.
+		if s.Category == CatScoreboard || s.Category == CatAntiCheat || s.Category == CatRating {
 			continue
 		}
-		out = append(out, htmlCategory{Title: spec.Title, Note: spec.Note, Metrics: metrics})
-	}
-
-	leftover := make([]Category, 0)
-	for cat := range ps.Categories {
-		if !seen[cat] {
-			leftover = append(leftover, cat)
-		}
-	}
-	sort.Slice(leftover, func(i, j int) bool { return string(leftover[i]) < string(leftover[j]) })
-	for _, cat := range leftover {
-		metrics := metricsForCategory(ps, cat)
+		metrics := displayMetrics(s)
 		if len(metrics) == 0 {
 			continue
 		}
-		out = append(out, htmlCategory{Title: titleize(string(cat)), Metrics: metrics})
+		out = append(out, htmlCategory{Title: s.Title, Note: s.Note, Metrics: metrics})
 	}
 	return out
 }
 
+// metricsForCategory returns the displayable metrics of cat.
 func metricsForCategory(ps *PlayerStats, cat Category) []htmlMetric {
-	keys := make([]Key, 0)
-	for k := range ps.Categories[cat] {
-		if skipKey(cat, k) {
-			continue
-		}
-		keys = append(keys, k)
+	return displayMetrics(reportSection(ps, cat))
+}
+
+// displayMetrics returns the rows of s the rendered reports show. Raw _ticks
+// counters are normally hidden behind the ratios derived from them; when a
+// category holds nothing but counters (its CollectFinalStats never derived
+// anything) they are shown with a "(raw)" label instead, so the broken
+// category surfaces rather than silently disappearing.
+func displayMetrics(s ReportSection) []htmlMetric {
+	if out := sectionMetrics(s, false); len(out) > 0 {
+		return out
 	}
-	sort.Slice(keys, func(i, j int) bool {
-		return categoryKeyOrder(cat, keys[i]) < categoryKeyOrder(cat, keys[j])
-	})
+	return sectionMetrics(s, true)
+}
 
-	out := make([]htmlMetric, 0, len(keys))
-	for _, k := range keys {
-		m := ps.Categories[cat][k]
-		val := formatMetricValue(m)
-		if val == "-" {
+// sectionMetrics lists the rows of s with a value, or only its raw _ticks
+// counters when rawTicks is set.
+func sectionMetrics(s ReportSection, rawTicks bool) []htmlMetric {
+	out := make([]htmlMetric, 0, len(s.Rows))
+	for _, r := range s.Rows {
+		if rawTicks != isTickCounter(r.Key) || (!rawTicks && skipKey(s.Category, r.Key)) || r.Value == "-" {
 			continue
 		}
+		label := r.Label
+		if rawTicks {
+			label += " (raw)"
+		}
 		out = append(out, htmlMetric{
-			Label: metricLabel(cat, k),
-			Value: val,
-			Class: metricClass(cat, k, m),
+			Label: label,
+			Value: r.Value,
+			Class: metricClass(s.Category, r.Key, r.Metric),
 		})
 	}
 	return out
 }
 
+// isTickCounter reports whether k is a raw per-tick counter.
+func isTickCounter(k Key) bool {
+	return strings.HasSuffix(string(k), "_ticks")
+}
+
 func skipKey(cat Category, k Key) bool {
-	s := string(k)
-	if strings.HasSuffix(s, "_ticks") {
+	if isTickCounter(k) {
 		return true
 	}
 	// The gauge + badge already represent these — skip in the breakdown table.
-	if cat == Category("anti_cheat") && (k == Key("cheat_likelihood") || k == Key("cheater")) {
+	// The explanation renders as its own line under the narrative.
+	if cat == CatAntiCheat && (k == KeyCheatLikelihood || k == KeyCheater || k == KeyCheatExplanation) {
 		return true
 	}
 	// Grade rows surface as highlighted badges at the top of the card; don't
 	// also list them inside the category metric tables.
-	if k == Key("grade") {
+	if k == KeyGrade {
 		return true
 	}
 	return false
@@ -594,22 +655,46 @@ func categoryKeyOrder(cat Category, k Key) string {
 			Key("scout_kills"),
 			Key("scout_hs_kills"),
 			Key("scout_hs_rate"),
+			Key("scoped_sniper_kills"),
+			Key("moving_scoped_kills"),
+			Key("awp_flick_velocity"),
+			Key("awp_flick_samples"),
+			Key("scope_to_kill_ms"),
 		},
 		Category("kills"): {
 			Key("grade"),
 			Key("total_kills"),
 			Key("headshot_kills"),
 			Key("headshot_percentage"),
+			Key("melee_kills"),
 		},
 		Category("aiming"): {
 			Key("snap_count"),
 			Key("avg_snap_velocity"),
 			Key("median_snap_velocity"),
 			Key("p95_snap_velocity"),
+			Key("precise_snaps"),
+			Key("p95_precise_snap_velocity"),
+			Key("snap_residual_mode"),
+			Key("snap_fov_clamp"),
+			Key("overshoot_checked_snaps"),
+			Key("no_overshoot_snap_ratio"),
+			Key("fire_snap_shots"),
+			Key("fire_snap_count"),
+			Key("pull_samples"),
+			Key("pull_bias"),
 		},
 		Category("recoil"): {
 			Key("grade"),
 			Key("mean_angular_error"),
+			Key("recoil_consistency_stddev"),
+			Key("recoil_pattern_similarity"),
+			Key("recoil_pattern_magnitude_error"),
+			Key("long_spray_perfect_ratio"),
+			Key("long_sprays"),
+			Key("median_spray_bullets"),
+			Key("most_suspicious_recoil_weapon"),
+			Key("blended_recoil_score"),
 			Key("burst_count"),
 			Key("total_counted_bullets"),
 			Key("total_error_sum"),
@@ -628,6 +713,116 @@ func categoryKeyOrder(cat Category, k Key) string {
 		Category("game_info"): {
 			Key("game_mode"),
 			Key("round_count"),
+			Key("fast_ttd_ms"),
+			Key("demo_integrity_warnings"),
+		},
+		Category("weapons"): {
+			Key("non_knife_percentage"),
+			Key("rifle_percentage"),
+			Key("sniper_percentage"),
+			Key("smg_percentage"),
+			Key("heavy_percentage"),
+			Key("pistol_percentage"),
+			Key("grenade_percentage"),
+			Key("equipment_percentage"),
+			Key("knife_percentage"),
+			Key("no_weapon_percentage"),
+		},
+		Category("ttk"): {
+			Key("ttk_samples"),
+			Key("p10_time_to_kill_ms"),
+			Key("time_to_kill_ms"),
+		},
+		Category("placement"): {
+			Key("prehead_ratio"),
+		},
+		Category("objective"): {
+			Key("saves"),
+			Key("fake_defuses"),
+			Key("defuse_under_pressure"),
+		},
+		Category("conduct"): {
+			Key("team_damage"),
+			Key("team_kills"),
+		},
+		Category("performance"): {
+			Key("lobby_relative_rating"),
+			Key("lobby_relative_adr"),
+			Key("lobby_relative_hs"),
+			Key("overperformer"),
+		},
+		Category("scripts"): {
+			Key("jumpthrows"),
+			Key("scripted_jumpthrows"),
+			Key("jumpthrow_script"),
+		},
+		Category("team"): {
+			Key("ct_rating"),
+			Key("t_rating"),
+			Key("ct_rounds"),
+			Key("t_rounds"),
+			Key("side_switches"),
+		},
+		Category("movement"): {
+			Key("crouch_jitter_score"),
+			Key("crouch_jitter_toggles"),
+			Key("crouch_jitter_longest_run"),
+			Key("crouch_toggles"),
+		},
+		Category("impact"): {
+			Key("opening_duels"),
+			Key("opening_wins"),
+			Key("opening_survivals"),
+			Key("opening_win_rate"),
+			Key("avg_first_blood_time"),
+		},
+		Category("hitgroups"): {
+			Key("bullet_hits"),
+			Key("head_hit_pct"),
+			Key("chest_hit_pct"),
+			Key("stomach_hit_pct"),
+			Key("arms_hit_pct"),
+			Key("legs_hit_pct"),
+		},
+		Category("accuracy"): {
+			Key("aimed_shots"),
+			Key("accuracy_close"),
+			Key("accuracy_mid"),
+			Key("accuracy_long"),
+			Key("crosshair_on_target_fire_rate"),
+			Key("crosshair_on_target_spray_rate"),
+		},
+		Category("inaccuracy"): {
+			Key("inaccuracy_checked_hits"),
+			Key("beyond_inaccuracy_hits"),
+		},
+		Category("flash"): {
+			Key("full_flashes"),
+			Key("kills_while_flashed"),
+			Key("killed_while_near_blind"),
+			Key("median_unblind_to_kill_ms"),
+		},
+		Category("smoke"): {
+			Key("through_smoke_kills"),
+		},
+		Category("wallbang"): {
+			Key("wallbang_kills"),
+			Key("tracked_wallbangs"),
+		},
+		Category("wallhack"): {
+			Key("blind_kills"),
+			Key("blind_tracking_kills"),
+		},
+		Category("info"): {
+			Key("spotted_kills"),
+			Key("team_spotted_kills"),
+			Key("kills_on_unspotted"),
+			Key("unspotted_kill_pct"),
+		},
+		Category("player_info"): {
+			Key("aliases"),
+			Key("name_changes"),
+			Key("disconnects"),
 		},
 		Category("utility"): {
 			Key("grade"),
@@ -690,6 +885,14 @@ func metricLabel(_ Category, k Key) string {
 		Key("snap_score"):           "Snap score",
 		Key("reaction_score"):       "Reaction score",
 		Key("recoil_score"):         "Recoil score",
+		Key("recoil_consistency_stddev"): "Burst consistency (σ)",
+		Key("most_suspicious_recoil_weapon"): "Most suspicious weapon",
+		Key("long_spray_perfect_ratio"):      "Perfect long sprays",
+		Key("long_sprays"):                   "Sprays over 15 bullets",
+		Key("median_spray_bullets"):          "Median spray length",
+		Key("recoil_pattern_similarity"):      "Spray shape similarity",
+		Key("recoil_pattern_magnitude_error"): "Spray size error",
+		Key("blended_recoil_score"): "Blended recoil score",
 		Key("total_cheat_score"):    "Combined score",
 		Key("wingman_boost"):        "Wingman boost",
 		Key("competitive_boost"):    "Competitive boost",
@@ -698,6 +901,8 @@ func metricLabel(_ Category, k Key) string {
 		Key("avg_snap_velocity"):    "Avg snap velocity",
 		Key("median_snap_velocity"): "Median snap velocity",
 		Key("snap_count"):           "Snap count",
+		Key("precise_snaps"):        "Precise snaps",
+		Key("p95_precise_snap_velocity"): "P95 precise snap velocity",
 		Key("p10_ttd"):              "P10 time-to-damage",
 		Key("median_ttd"):           "Median time-to-damage",
 		Key("sub_100ms_ttd"):        "Sub-100 ms TTD share",
@@ -707,9 +912,17 @@ func metricLabel(_ Category, k Key) string {
 		Key("headshot_percentage"):  "Headshot %",
 		Key("game_mode"):            "Game mode",
 		Key("round_count"):          "Rounds",
+		Key("fast_ttd_ms"):          "Fast TTD cutoff (ms)",
 		Key("knife_percentage"):     "Knife time",
 		Key("non_knife_percentage"): "Weapon time",
 		Key("no_weapon_percentage"): "Unarmed time",
+		Key("rifle_percentage"):     "Rifle time",
+		Key("sniper_percentage"):    "Sniper time",
+		Key("smg_percentage"):       "SMG time",
+		Key("heavy_percentage"):     "Heavy time",
+		Key("pistol_percentage"):    "Pistol time",
+		Key("grenade_percentage"):   "Grenade time",
+		Key("equipment_percentage"): "Other equipment time",
 		Key("thrown"):               "Thrown",
 		Key("damage"):               "Damage",
 		Key("enemy_hits"):           "Enemy hits",
@@ -725,6 +938,39 @@ func metricLabel(_ Category, k Key) string {
 		Key("scout_kills"):           "Scout kills",
 		Key("scout_hs_kills"):        "Scout headshot kills",
 		Key("scout_hs_rate"):         "Scout headshot %",
+		Key("scoped_sniper_kills"):   "Scoped sniper kills",
+		Key("moving_scoped_kills"):   "Moving scoped kills",
+		Key("awp_flick_velocity"):    "AWP flick velocity",
+		Key("awp_flick_samples"):     "AWP flick samples",
+		Key("scope_to_kill_ms"):      "Scope-to-kill (ms)",
+		Key("time_to_kill_ms"):       "Median TTK (ms)",
+		Key("p10_time_to_kill_ms"):   "P10 TTK (ms)",
+		Key("ttk_samples"):           "TTK samples",
+		Key("prehead_ratio"):         "Head-level on occluded enemy",
+		Key("aliases"):               "Aliases",
+		Key("name_changes"):          "Name changes",
+		Key("disconnects"):           "Disconnects",
+		Key("defuse_under_pressure"): "Defuses under pressure",
+		Key("crosshair_on_target_fire_rate"):  "Crosshair on target (first shot)",
+		Key("crosshair_on_target_spray_rate"): "Crosshair on target (spray)",
+		Key("fire_snap_count"):       "Snaps onto a head on fire",
+		Key("scripted_jumpthrows"):   "Frame-perfect jumpthrows",
+		Key("crouch_jitter_toggles"): "Crouch jitter toggles",
+		Key("ct_rating"):             "CT rating",
+		Key("t_rating"):              "T rating",
+		Key("ct_rounds"):             "CT rounds",
+		Key("t_rounds"):              "T rounds",
+		Key("crouch_jitter_longest_run"): "Longest crouch jitter run",
+		Key("lobby_relative_rating"): "Rating vs lobby",
+		Key("lobby_relative_adr"):    "ADR vs lobby",
+		Key("lobby_relative_hs"):     "HS% vs lobby",
+		Key("pull_bias"):             "Aim pull toward enemies",
+		Key("snap_residual_mode"):    "Most common snap distance (°)",
+		Key("snap_fov_clamp"):        "Snaps clamped at an FOV edge",
+		Key("no_overshoot_snap_ratio"): "Flicks without overshoot",
+		Key("avg_first_blood_time"): "Avg first-blood time (s)",
+		Key("first_kill_ticks"):     "First-kill ticks",
+		Key("beyond_inaccuracy_hits"): "Hits beyond weapon inaccuracy",
 		Key("sniper_wallbang_override"): "Sniper wallbang override",
 		Key("scout_precision_override"): "Scout precision override",
 	}
@@ -742,7 +988,7 @@ func metricClass(cat Category, k Key, m Metric) string {
 		return ""
 	}
 
-	if cat == Category("anti_cheat") && strings.HasSuffix(string(k), "_score") {
+	if cat == CatAntiCheat && strings.HasSuffix(string(k), "_score") {
 		if m.FloatValue >= 0.7 {
 			return "hot"
 		}
@@ -760,11 +1006,12 @@ func metricClass(cat Category, k Key, m Metric) string {
 		if m.FloatValue >= 55 {
 			return "warm"
 		}
-	case Key("p95_snap_velocity"):
-		if m.FloatValue >= 3.0 {
+	case Key("p95_snap_velocity"), Key("p95_precise_snap_velocity"):
+		// 3.0 and 2.0 °/ms on the old ×57.3 scale, converted.
+		if m.FloatValue >= 3.0/legacySnapScale {
 			return "hot"
 		}
-		if m.FloatValue >= 2.0 {
+		if m.FloatValue >= snapRampLow {
 			return "warm"
 		}
 	case Key("p10_ttd"):
@@ -795,13 +1042,22 @@ func likelihoodClass(v float64) string {
 	return "ok"
 }
 
+// titleize turns a snake_case key into "Title Case" words. It works on
+// runes, so a non-ASCII first letter is capitalized rather than split
+// mid-byte. Words mixing letters and digits are weapon or percentile tags
+// ("m4a4", "ak47", "p95") and are upper-cased whole.
 func titleize(s string) string {
 	words := strings.Split(s, "_")
 	for i, w := range words {
 		if w == "" {
 			continue
 		}
-		words[i] = strings.ToUpper(w[:1]) + w[1:]
+		if strings.ContainsFunc(w, unicode.IsDigit) && strings.ContainsFunc(w, unicode.IsLetter) {
+			words[i] = strings.ToUpper(w)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToTitle(r)) + w[size:]
 	}
 	return strings.Join(words, " ")
 }
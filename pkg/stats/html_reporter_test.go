@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMetricsForCategoryRawTicksFallback(t *testing.T) {
+	ps := NewDemoStats().GetOrCreatePlayerStatsBySteamID(1)
+	ps.AddMetric(Category("weapons"), Key("total_ticks"), Metric{Type: MetricInteger, IntValue: 6400})
+	ps.AddMetric(Category("weapons"), Key("knife_ticks"), Metric{Type: MetricInteger, IntValue: 640})
+
+	metrics := metricsForCategory(ps, Category("weapons"))
+	if len(metrics) != 2 {
+		t.Fatalf("got %d metrics, want both raw counters: %+v", len(metrics), metrics)
+	}
+	for _, m := range metrics {
+		if !strings.HasSuffix(m.Label, " (raw)") {
+			t.Errorf("label %q missing (raw)", m.Label)
+		}
+	}
+
+	// Once a derived metric exists the counters are hidden again
+	ps.AddMetric(Category("weapons"), Key("knife_percentage"), Metric{Type: MetricPercentage, FloatValue: 10})
+	metrics = metricsForCategory(ps, Category("weapons"))
+	if len(metrics) != 1 || metrics[0].Value == "" {
+		t.Errorf("derived category shows %+v, want only knife_percentage", metrics)
+	}
+}
+
+func TestBuildHTMLDataOrderIsDeterministic(t *testing.T) {
+	ds := NewDemoStats()
+	for _, sid := range []uint64{9, 3, 7, 1, 5} {
+		ps := ds.GetOrCreatePlayerStatsBySteamID(sid)
+		ps.Player.Name = "dup" // same name and likelihood for everyone
+		ps.AddMetric(CatScoreboard, Key("kills"), Metric{Type: MetricInteger, IntValue: 4})
+		ps.AddMetric(CatScoreboard, Key("team"), Metric{Type: MetricString, StringValue: "T"})
+	}
+
+	for run := 0; run < 20; run++ {
+		data := buildHTMLData(ds)
+		for i, want := range []uint64{1, 3, 5, 7, 9} {
+			wantID := strconv.FormatUint(want, 10)
+			if got := data.Players[i].SteamID; got != wantID {
+				t.Fatalf("run %d: players[%d] = %s, want %s", run, i, got, wantID)
+			}
+			if got := data.Teams[0].Players[i].sortSID; got != want {
+				t.Fatalf("run %d: scoreboard[%d] = %d, want %d", run, i, got, want)
+			}
+		}
+	}
+}
+
+func TestDemoInfoSection(t *testing.T) {
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(1).Player.Name = "alice"
+	ds.AddMetric(CatGameInfo, KeyRoundCount, Metric{Type: MetricInteger, IntValue: 24})
+	ds.AddMetric(CatGameInfo, KeyGameMode, Metric{Type: MetricString, StringValue: "Competitive"})
+
+	data := buildHTMLData(ds)
+	if len(data.DemoInfo) != 1 || data.DemoInfo[0].Title != "Game Info" {
+		t.Fatalf("DemoInfo = %+v, want the game_info category", data.DemoInfo)
+	}
+	if got := data.DemoInfo[0].Metrics; len(got) != 2 || got[0].Label != "Game mode" || got[1].Value != "24" {
+		t.Errorf("DemoInfo metrics = %+v", got)
+	}
+
+	var md strings.Builder
+	if err := NewMarkdownReporter().Report(ds, nil, &md); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(md.String(), "## Demo Info") || !strings.Contains(md.String(), "| Rounds | 24 |") {
+		t.Errorf("markdown lacks the demo info section:\n%s", md.String())
+	}
+}
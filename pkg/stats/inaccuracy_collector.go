@@ -0,0 +1,187 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// weaponInaccuracy is a weapon's first-shot inaccuracy in milliradians (the
+// unit of the item scripts' inaccuracy_* values), and the max speed the
+// movement penalty is scaled against.
+type weaponInaccuracy struct {
+	stand    float64 // standing still
+	move     float64 // added at full max speed
+	air      float64 // added while airborne
+	maxSpeed float64 // units/s
+}
+
+// rifleInaccuracy holds the rifle values from the CS2 item scripts, rounded
+// down so the model errs towards a more accurate weapon than the real one.
+// Recoil only widens the cone further and is left out for the same reason.
+var rifleInaccuracy = map[common.EquipmentType]weaponInaccuracy{
+	common.EqAK47:  {stand: 4.8, move: 175, air: 140, maxSpeed: 215},
+	common.EqM4A4:  {stand: 3.9, move: 137, air: 140, maxSpeed: 225},
+	common.EqM4A1:  {stand: 3.6, move: 123, air: 140, maxSpeed: 225},
+	common.EqGalil: {stand: 5.4, move: 154, air: 140, maxSpeed: 215},
+	common.EqFamas: {stand: 4.9, move: 131, air: 140, maxSpeed: 220},
+	common.EqAUG:   {stand: 3.6, move: 125, air: 140, maxSpeed: 220},
+	common.EqSG553: {stand: 3.6, move: 130, air: 140, maxSpeed: 210},
+}
+
+// inaccuracyAccurateFraction is the share of max speed below which CS2
+// applies no movement penalty; the penalty ramps linearly to full at max
+// speed.
+const inaccuracyAccurateFraction = 0.34
+
+// Target radii (units) a bullet has to land within: the head hitbox, and a
+// generous torso for every other hitgroup.
+const (
+	inaccuracyHeadRadius = 4.0
+	inaccuracyBodyRadius = 8.0
+)
+
+// inaccuracyImplausibleRatio is how many times wider than the target the
+// spread cone has to be at the victim's distance before a hit is flagged.
+// With spread landing uniformly in the cone the chance of such a hit is at
+// most 1/ratio², so 4 leaves one honest hit in sixteen.
+const inaccuracyImplausibleRatio = 4.0
+
+// expectedInaccuracy returns the spread half-angle (radians) of weapon t
+// fired at the given horizontal speed, or ok=false when t isn't modelled.
+func expectedInaccuracy(t common.EquipmentType, speed float64, airborne bool) (float64, bool) {
+	w, ok := rifleInaccuracy[t]
+	if !ok {
+		return 0, false
+	}
+	mrad := w.stand
+	if airborne {
+		mrad += w.air
+	} else {
+		free := w.maxSpeed * inaccuracyAccurateFraction
+		frac := math.Max(0, math.Min(1, (speed-free)/(w.maxSpeed-free)))
+		mrad += frac * w.move
+	}
+	return mrad / 1000, true
+}
+
+// beyondInaccuracy reports whether a hit at distance dist landing inside a
+// target of the given radius is implausible for a cone of half-angle
+// inaccuracy.
+func beyondInaccuracy(inaccuracy, dist, targetRadius float64) bool {
+	return dist*math.Tan(inaccuracy) >= targetRadius*inaccuracyImplausibleRatio
+}
+
+// InaccuracyCollector flags rifle hits that land tighter than the weapon's
+// inaccuracy cone allows given how the shooter was moving. A running or
+// jumping AK spreads over metres at long range; landing such a shot on the
+// head is luck once, and a pattern when it keeps happening.
+//
+//   - inaccuracy_checked_hits: rifle hits on enemies with a known stance.
+//   - beyond_inaccuracy_hits: of those, hits where the cone at the victim's
+//     distance was inaccuracyImplausibleRatio times wider than the hitgroup.
+//
+// Speed and airborne state come from the frame before the hit, the same way
+// SniperCollector reads them.
+type InaccuracyCollector struct {
+	*BaseCollector
+
+	stances map[uint64]sniperStance
+	checked map[uint64]int64
+	beyond  map[uint64]int64
+}
+
+// NewInaccuracyCollector creates a new InaccuracyCollector.
+func NewInaccuracyCollector() *InaccuracyCollector {
+	return &InaccuracyCollector{
+		BaseCollector: NewBaseCollector("Weapon Inaccuracy", CatInaccuracy),
+		stances:       make(map[uint64]sniperStance),
+		checked:       make(map[uint64]int64),
+		beyond:        make(map[uint64]int64),
+	}
+}
+
+// Setup registers the PlayerHurt handler.
+func (ic *InaccuracyCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	parser.RegisterEventHandler(func(e events.PlayerHurt) {
+		if inWarmup(parser) {
+			return
+		}
+		ic.processHurt(e, demoStats)
+	})
+}
+
+// processHurt checks one rifle hit on an enemy against the cone.
+func (ic *InaccuracyCollector) processHurt(e events.PlayerHurt, demoStats *DemoStats) {
+	if e.Attacker == nil || e.Player == nil || e.Weapon == nil {
+		return
+	}
+	if !demoStats.CountsAimPlayer(e.Attacker) || e.Attacker.Team == e.Player.Team {
+		return
+	}
+	bucket, ok := hitgroupBuckets[e.HitGroup]
+	if !ok {
+		return
+	}
+	sid := demoStats.PlayerKey(e.Attacker)
+	st, ok := ic.stances[sid]
+	if !ok || (st.speed < 0 && !st.airborne) {
+		return
+	}
+	inaccuracy, ok := expectedInaccuracy(e.Weapon.Type, st.speed, st.airborne)
+	if !ok {
+		return
+	}
+	radius := inaccuracyBodyRadius
+	if bucket == "head" {
+		radius = inaccuracyHeadRadius
+	}
+	ic.checked[sid]++
+	if beyondInaccuracy(inaccuracy, st.pos.Distance(e.Player.Position()), radius) {
+		ic.beyond[sid]++
+	}
+}
+
+// CollectFrame records every alive player's speed and airborne state for
+// the hits of the next frame.
+func (ic *InaccuracyCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	now := demoTime(parser, parser.TickRate())
+	for _, player := range parser.GameState().Participants().Playing() {
+		if !demoStats.CountsAimPlayer(player) || !player.IsAlive() {
+			continue
+		}
+		sid := demoStats.PlayerKey(player)
+		st := sniperStance{
+			pos:      player.Position(),
+			at:       now,
+			speed:    -1,
+			airborne: player.IsAirborne(),
+		}
+		if prev, ok := ic.stances[sid]; ok {
+			st.speed = horizontalSpeed(prev.pos, st.pos, now-prev.at)
+		}
+		ic.stances[sid] = st
+	}
+}
+
+// CollectFinalStats publishes the checked and flagged hit counts.
+func (ic *InaccuracyCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, n := range ic.checked {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		ps.AddMetric(CatInaccuracy, KeyInaccuracyCheckedHits, Metric{
+			Type:        MetricInteger,
+			IntValue:    n,
+			Description: "Rifle hits checked against the weapon's inaccuracy",
+		})
+		ps.AddMetric(CatInaccuracy, KeyBeyondInaccuracyHits, Metric{
+			Type:        MetricInteger,
+			IntValue:    ic.beyond[sid],
+			Description: "Rifle hits tighter than the shooter's movement inaccuracy allows",
+		})
+	}
+}
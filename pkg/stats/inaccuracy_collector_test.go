@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+func TestExpectedInaccuracy(t *testing.T) {
+	stand, _ := expectedInaccuracy(common.EqAK47, 0, false)
+	walk, _ := expectedInaccuracy(common.EqAK47, 70, false)
+	run, _ := expectedInaccuracy(common.EqAK47, 215, false)
+	air, _ := expectedInaccuracy(common.EqAK47, 0, true)
+	if stand != walk {
+		t.Errorf("below a third of max speed: %v, want standing %v", walk, stand)
+	}
+	if math.Abs(run-(4.8+175)/1000) > 1e-9 {
+		t.Errorf("full speed = %v", run)
+	}
+	if air <= stand {
+		t.Errorf("airborne %v not above standing %v", air, stand)
+	}
+	if _, ok := expectedInaccuracy(common.EqAWP, 0, false); ok {
+		t.Error("AWP modelled; only rifles are")
+	}
+}
+
+func TestInaccuracyCollector(t *testing.T) {
+	ds := NewDemoStats()
+	attacker := &common.Player{SteamID64: 1, Name: "a", Team: common.TeamTerrorists}
+	enemy := &common.Player{SteamID64: 2, Name: "b", Team: common.TeamCounterTerrorists}
+	ds.GetOrCreatePlayerStats(attacker)
+
+	ak := common.NewEquipment(common.EqAK47)
+	ic := NewInaccuracyCollector()
+	hurt := func(st sniperStance, g events.HitGroup) {
+		ic.stances[1] = st
+		ic.processHurt(events.PlayerHurt{Attacker: attacker, Player: enemy, Weapon: ak, HitGroup: g}, ds)
+	}
+	far := r3.Vector{X: 1500}
+	hurt(sniperStance{pos: far, speed: 0}, events.HitGroupHead)                  // standing tap: fine
+	hurt(sniperStance{pos: far, speed: 215}, events.HitGroupHead)                // running head at range: flagged
+	hurt(sniperStance{pos: far, speed: -1, airborne: true}, events.HitGroupHead) // jumping head at range: flagged
+	hurt(sniperStance{pos: r3.Vector{X: 100}, speed: 215}, events.HitGroupChest) // running body up close: fine
+	hurt(sniperStance{pos: far, speed: -1}, events.HitGroupHead)                 // unknown speed: skipped
+
+	ic.CollectFinalStats(ds)
+	ps := ds.Players[1]
+	if n := intMetric(ps, CatInaccuracy, KeyInaccuracyCheckedHits); n != 4 {
+		t.Errorf("inaccuracy_checked_hits = %d, want 4", n)
+	}
+	if n := intMetric(ps, CatInaccuracy, KeyBeyondInaccuracyHits); n != 2 {
+		t.Errorf("beyond_inaccuracy_hits = %d, want 2", n)
+	}
+}
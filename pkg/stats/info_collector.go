@@ -0,0 +1,193 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+const (
+	// infoSpottedWindow is how long a sighting counts as information before
+	// a kill. Radar keeps a spotted enemy's last position for about this
+	// long, so a kill within it was on an enemy the team knew about.
+	infoSpottedWindow = 2 * time.Second
+
+	// infoMinKills is the number of counted kills before unspotted_kill_pct
+	// is published.
+	infoMinKills = 10
+)
+
+// InfoCollector compares each kill against the engine's spotted state: did
+// the killer see the victim before the kill, did only a teammate (which
+// puts the victim on the killer's radar), or did nobody on the killer's
+// team see them at all? kills_on_unspotted counts the last kind — killing
+// enemies no one on your team legitimately knew about is a wallhack tell.
+//
+// Wallbangs and sprays through smoke also land on unspotted enemies, so a
+// few are normal; it's the rate next to the wallbang and smoke categories
+// that matters.
+type InfoCollector struct {
+	*BaseCollector
+	tickRate float64
+
+	// seen[victimEntityID][observerEntityID] is the last time the observer
+	// had the victim spotted this round. Entity IDs keep bots' sightings,
+	// which still feed their team's radar.
+	seen map[int]map[int]time.Duration
+
+	spotted     map[uint64]int
+	teamSpotted map[uint64]int
+	unspotted   map[uint64]int
+}
+
+// NewInfoCollector creates a new InfoCollector.
+func NewInfoCollector() *InfoCollector {
+	return &InfoCollector{
+		BaseCollector: NewBaseCollector("Spotted Info", CatInfo),
+		seen:          make(map[int]map[int]time.Duration),
+		spotted:       make(map[uint64]int),
+		teamSpotted:   make(map[uint64]int),
+		unspotted:     make(map[uint64]int),
+	}
+}
+
+// Setup registers the kill and round handlers.
+func (ic *InfoCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	ic.tickRate = parser.TickRate()
+	if ic.tickRate <= 0 {
+		ic.tickRate = 64.0
+	}
+	parser.RegisterEventHandler(func(e events.TickRateInfoAvailable) {
+		if e.TickRate > 0 {
+			ic.tickRate = e.TickRate
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.Kill) {
+		if inWarmup(parser) || e.Killer == nil || e.Victim == nil || e.Weapon == nil {
+			return
+		}
+		if !demoStats.CountsPlayer(e.Killer) || e.Killer.Team == e.Victim.Team {
+			return
+		}
+		switch weaponClass(e.Weapon) {
+		case "grenade", "equipment":
+			return // grenades land on enemies nobody saw by design
+		}
+
+		now := demoTime(parser, ic.tickRate)
+		teammates := make(map[int]bool)
+		for _, p := range parser.GameState().Participants().Playing() {
+			if p != nil && p != e.Killer && p.Team == e.Killer.Team {
+				teammates[p.EntityID] = true
+			}
+		}
+		sid := demoStats.PlayerKey(e.Killer)
+		switch ic.classify(e.Victim.EntityID, e.Killer.EntityID, teammates, now, e.Victim.IsSpottedBy(e.Killer)) {
+		case infoSpotted:
+			ic.spotted[sid]++
+		case infoTeamSpotted:
+			ic.teamSpotted[sid]++
+		default:
+			ic.unspotted[sid]++
+		}
+	})
+
+	parser.RegisterEventHandler(func(_ events.RoundStart) {
+		ic.seen = make(map[int]map[int]time.Duration)
+	})
+}
+
+// infoKill is what the killer's team knew about the victim at a kill.
+type infoKill int
+
+const (
+	infoUnspotted   infoKill = iota // nobody on the team had seen the victim
+	infoTeamSpotted                 // a teammate had; the killer hadn't
+	infoSpotted                     // the killer had
+)
+
+// classify decides what the killer's team knew about victim at now.
+// spottedNow is the engine's spotted flag at the kill itself, which can
+// flip on the kill tick before any frame recorded it.
+func (ic *InfoCollector) classify(victim, killer int, teammates map[int]bool, now time.Duration, spottedNow bool) infoKill {
+	if spottedNow {
+		return infoSpotted
+	}
+	recent := func(t time.Duration) bool { return now-t <= infoSpottedWindow }
+	seenBy := ic.seen[victim]
+	if t, ok := seenBy[killer]; ok && recent(t) {
+		return infoSpotted
+	}
+	for observer, t := range seenBy {
+		if teammates[observer] && recent(t) {
+			return infoTeamSpotted
+		}
+	}
+	return infoUnspotted
+}
+
+// CollectFrame records, for every living player, which players currently
+// have them spotted.
+func (ic *InfoCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	now := demoTime(parser, ic.tickRate)
+	playing := parser.GameState().Participants().Playing()
+	for _, victim := range playing {
+		if victim == nil || !victim.IsAlive() {
+			continue
+		}
+		for _, observer := range playing {
+			if observer == nil || observer.Team == victim.Team || !observer.IsAlive() {
+				continue
+			}
+			if !victim.IsSpottedBy(observer) {
+				continue
+			}
+			if ic.seen[victim.EntityID] == nil {
+				ic.seen[victim.EntityID] = make(map[int]time.Duration)
+			}
+			ic.seen[victim.EntityID][observer.EntityID] = now
+		}
+	}
+}
+
+// CollectFinalStats publishes the kill split for every player with at
+// least one counted kill.
+func (ic *InfoCollector) CollectFinalStats(demoStats *DemoStats) {
+	killers := make(map[uint64]bool)
+	for _, m := range []map[uint64]int{ic.spotted, ic.teamSpotted, ic.unspotted} {
+		for sid := range m {
+			killers[sid] = true
+		}
+	}
+	for sid := range killers {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		ps.AddMetric(CatInfo, KeySpottedKills, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(ic.spotted[sid]),
+			Description: "Kills on enemies the killer had spotted",
+		})
+		ps.AddMetric(CatInfo, KeyTeamSpottedKills, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(ic.teamSpotted[sid]),
+			Description: "Kills on enemies only a teammate had spotted (radar info)",
+		})
+		ps.AddMetric(CatInfo, KeyKillsOnUnspotted, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(ic.unspotted[sid]),
+			Description: "Kills on enemies nobody on the killer's team had spotted",
+		})
+		total := ic.spotted[sid] + ic.teamSpotted[sid] + ic.unspotted[sid]
+		if total >= infoMinKills {
+			ps.AddMetric(CatInfo, KeyUnspottedKillPct, Metric{
+				Type:        MetricPercentage,
+				FloatValue:  float64(ic.unspotted[sid]) / float64(total) * 100.0,
+				Description: "Share of kills on enemies nobody on the killer's team had spotted",
+			})
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInfoClassify(t *testing.T) {
+	ic := NewInfoCollector()
+	const victim, killer, mate, enemyMate = 5, 1, 2, 7
+	teammates := map[int]bool{mate: true}
+	now := 10 * time.Second
+
+	tests := []struct {
+		name       string
+		seen       map[int]time.Duration
+		spottedNow bool
+		want       infoKill
+	}{
+		{"spotted on the kill tick", nil, true, infoSpotted},
+		{"killer saw recently", map[int]time.Duration{killer: now - time.Second}, false, infoSpotted},
+		{"only a teammate saw", map[int]time.Duration{mate: now - time.Second}, false, infoTeamSpotted},
+		{"sighting too old", map[int]time.Duration{killer: now - 5*time.Second, mate: now - 3*time.Second}, false, infoUnspotted},
+		{"seen only by someone off the team", map[int]time.Duration{enemyMate: now}, false, infoUnspotted},
+		{"never seen", nil, false, infoUnspotted},
+	}
+	for _, tt := range tests {
+		ic.seen = map[int]map[int]time.Duration{victim: tt.seen}
+		if got := ic.classify(victim, killer, teammates, now, tt.spottedNow); got != tt.want {
+			t.Errorf("%s: classify = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestInfoPublishes(t *testing.T) {
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(3)
+	ic := NewInfoCollector()
+	ic.spotted[3], ic.teamSpotted[3], ic.unspotted[3] = 6, 2, 4
+	ic.CollectFinalStats(ds)
+
+	ps := ds.Players[3]
+	if n, _ := psGetInt(ps, CatInfo, "kills_on_unspotted"); n != 4 {
+		t.Errorf("kills_on_unspotted = %d, want 4", n)
+	}
+	if pct, _ := psGetFloat(ps, CatInfo, "unspotted_kill_pct"); pct < 33.3 || pct > 33.4 {
+		t.Errorf("unspotted_kill_pct = %v, want 33.3", pct)
+	}
+}
@@ -0,0 +1,148 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// JSONReporter writes the whole analysis as one indented JSON document:
+// demo metadata plus, per player, metrics nested by category and key.
+// For one object per player per line, see JSONLinesReporter.
+type JSONReporter struct{}
+
+// NewJSONReporter creates a JSONReporter.
+func NewJSONReporter() *JSONReporter {
+	return &JSONReporter{}
+}
+
+// Extension returns "json".
+func (jr *JSONReporter) Extension() string { return "json" }
+
+type jsonDocument struct {
+	Demo      string                    `json:"demo"`
+	Map       string                    `json:"map"`
+	TickRate  float64                   `json:"tick_rate"`
+	TickCount int                       `json:"tick_count"`
+	Global    map[string]map[string]any `json:"global,omitempty"`
+	Players   []jsonPlayer              `json:"players"`
+}
+
+type jsonPlayer struct {
+	SteamID uint64                    `json:"steam_id"`
+	Name    string                    `json:"name"`
+	Metrics map[string]map[string]any `json:"metrics"`
+}
+
+// Report writes the document, players ordered by SteamID, with the
+// demo-wide metrics under "global". When categories is non-empty only those
+// categories are included. Values follow the same rules as
+// JSONLinesReporter.
+func (jr *JSONReporter) Report(demoStats *DemoStats, categories []Category, writer io.Writer) error {
+	model := BuildReport(demoStats, categories)
+	doc := jsonDocument{
+		Demo:      model.Demo,
+		Map:       model.Map,
+		TickRate:  model.TickRate,
+		TickCount: model.TickCount,
+		Players:   make([]jsonPlayer, 0, len(model.Players)),
+	}
+	if len(model.Global) > 0 {
+		doc.Global = jsonSections(model.Global)
+	}
+	for _, p := range model.Players {
+		doc.Players = append(doc.Players, jsonPlayer{SteamID: p.SteamID, Name: p.Name, Metrics: jsonSections(p.Sections)})
+	}
+
+	enc := json.NewEncoder(writer)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// jsonSections nests the rows of sections by category and key.
+func jsonSections(sections []ReportSection) map[string]map[string]any {
+	out := make(map[string]map[string]any, len(sections))
+	for _, s := range sections {
+		metrics := make(map[string]any, len(s.Rows))
+		for _, r := range s.Rows {
+			metrics[string(r.Key)] = metricJSONValue(r.Metric)
+		}
+		out[string(s.Category)] = metrics
+	}
+	return out
+}
+
+// legacyGlobalSteamID is the SteamID reports from before the "global"
+// section kept the demo-wide metrics under.
+const legacyGlobalSteamID = 0
+
+// LoadJSONReport rebuilds a DemoStats from a JSONReporter document, so a
+// saved analysis can be browsed or re-reported without the demo. JSON
+// doesn't record metric types: strings load as MetricString, whole numbers
+// as MetricInteger and other numbers as MetricFloat, with both IntValue and
+// FloatValue set either way; nulls (NaN) load as a NaN MetricFloat.
+// Reports from before the "global" section kept the demo-wide metrics on a
+// SteamID 0 player; those load as global metrics too.
+func LoadJSONReport(r io.Reader) (*DemoStats, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var doc jsonDocument
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decoding JSON report: %w", err)
+	}
+
+	ds := NewDemoStats()
+	ds.DemoName = doc.Demo
+	ds.MapName = doc.Map
+	ds.TickRate = doc.TickRate
+	ds.TickCount = doc.TickCount
+	for cat, metrics := range doc.Global {
+		for key, v := range metrics {
+			m, err := metricFromJSON(v)
+			if err != nil {
+				return nil, fmt.Errorf("global %s.%s: %w", cat, key, err)
+			}
+			ds.AddMetric(Category(cat), Key(key), m)
+		}
+	}
+	for _, jp := range doc.Players {
+		add := ds.AddMetric
+		if jp.SteamID != legacyGlobalSteamID {
+			ps := ds.GetOrCreatePlayerStatsBySteamID(jp.SteamID)
+			ps.Player.Name = jp.Name
+			add = ps.AddMetric
+		}
+		for cat, metrics := range jp.Metrics {
+			for key, v := range metrics {
+				m, err := metricFromJSON(v)
+				if err != nil {
+					return nil, fmt.Errorf("player %d %s.%s: %w", jp.SteamID, cat, key, err)
+				}
+				add(Category(cat), Key(key), m)
+			}
+		}
+	}
+	return ds, nil
+}
+
+// metricFromJSON reverses metricJSONValue as far as JSON allows.
+func metricFromJSON(v any) (Metric, error) {
+	switch v := v.(type) {
+	case nil:
+		return Metric{Type: MetricFloat, FloatValue: math.NaN()}, nil
+	case string:
+		return Metric{Type: MetricString, StringValue: v}, nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return Metric{}, err
+		}
+		if n, err := v.Int64(); err == nil && !strings.ContainsAny(v.String(), ".eE") {
+			return Metric{Type: MetricInteger, IntValue: n, FloatValue: f}, nil
+		}
+		return Metric{Type: MetricFloat, FloatValue: f, IntValue: int64(f)}, nil
+	}
+	return Metric{}, fmt.Errorf("unsupported value %v", v)
+}
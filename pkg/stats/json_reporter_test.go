@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestLoadJSONReportRoundTrip(t *testing.T) {
+	ds := NewDemoStats()
+	ds.DemoName = "match.dem"
+	ds.MapName = "de_mirage"
+	ds.TickRate = 64
+	p := ds.GetOrCreatePlayerStatsBySteamID(76561198000000001)
+	p.Player.Name = "alice"
+	p.AddMetric(Category("anti_cheat"), Key("cheat_likelihood"), Metric{Type: MetricPercentage, FloatValue: 72.5})
+	p.AddMetric(Category("anti_cheat"), Key("cheater"), Metric{Type: MetricString, StringValue: "Yes"})
+	p.AddMetric(Category("kills"), Key("total_kills"), Metric{Type: MetricInteger, IntValue: 17})
+	p.AddMetric(Category("snap"), Key("p95_snap_velocity"), Metric{Type: MetricFloat, FloatValue: math.NaN()})
+	ds.AddMetric(CatGameInfo, KeyRoundCount, Metric{Type: MetricInteger, IntValue: 24})
+
+	var buf bytes.Buffer
+	if err := NewJSONReporter().Report(ds, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadJSONReport(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.DemoName != "match.dem" || got.MapName != "de_mirage" || got.TickRate != 64 {
+		t.Errorf("metadata = %q %q %v", got.DemoName, got.MapName, got.TickRate)
+	}
+	lp := got.Players[76561198000000001]
+	if lp == nil || lp.Player.Name != "alice" {
+		t.Fatalf("player not loaded: %+v", lp)
+	}
+	if v, _ := psGetFloat(lp, Category("anti_cheat"), Key("cheat_likelihood")); v != 72.5 {
+		t.Errorf("cheat_likelihood = %v", v)
+	}
+	if !psHasYes(lp, Key("cheater")) {
+		t.Error("cheater flag lost")
+	}
+	if m, _ := lp.GetMetric(Category("kills"), Key("total_kills")); m.Type != MetricInteger || m.IntValue != 17 || m.FloatValue != 17 {
+		t.Errorf("total_kills = %+v", m)
+	}
+	if v, _ := psGetFloat(lp, Category("snap"), Key("p95_snap_velocity")); !math.IsNaN(v) {
+		t.Errorf("null metric = %v, want NaN", v)
+	}
+	if m, _ := got.GetMetric(CatGameInfo, KeyRoundCount); m.IntValue != 24 {
+		t.Errorf("global round_count = %+v", m)
+	}
+	if len(got.Players) != 1 {
+		t.Errorf("loaded %d players, want 1", len(got.Players))
+	}
+}
+
+func TestLoadJSONReportLegacyPlaceholder(t *testing.T) {
+	doc := `{"demo":"old.dem","players":[
+		{"steam_id":0,"name":"Unknown","metrics":{"game_info":{"game_mode":"Competitive"}}},
+		{"steam_id":76561198000000001,"name":"alice","metrics":{"kills":{"total_kills":3}}}
+	]}`
+	got, err := LoadJSONReport(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.Players[legacyGlobalSteamID]; ok {
+		t.Error("placeholder loaded as a player")
+	}
+	if m, _ := got.GetMetric(CatGameInfo, KeyGameMode); m.StringValue != "Competitive" {
+		t.Errorf("game_mode = %+v, want it moved to the global metrics", m)
+	}
+}
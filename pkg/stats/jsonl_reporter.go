@@ -0,0 +1,99 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// JSONLinesReporter writes newline-delimited JSON: one compact object per
+// player, each carrying the demo metadata alongside that player's metrics
+// flattened to "category.key". Lines are self-contained, so output from
+// several demos can be concatenated and fed straight into jq or a log
+// pipeline.
+type JSONLinesReporter struct{}
+
+// NewJSONLinesReporter creates a JSONLinesReporter.
+func NewJSONLinesReporter() *JSONLinesReporter {
+	return &JSONLinesReporter{}
+}
+
+// jsonLine is the object written for each player.
+type jsonLine struct {
+	Demo      string         `json:"demo"`
+	Map       string         `json:"map"`
+	TickRate  float64        `json:"tick_rate"`
+	TickCount int            `json:"tick_count"`
+	SteamID   uint64         `json:"steam_id"`
+	Name      string         `json:"name"`
+	Metrics   map[string]any `json:"metrics"`
+}
+
+// flusher is implemented by buffered writers such as bufio.Writer.
+type flusher interface {
+	Flush() error
+}
+
+// Extension returns "jsonl".
+func (jr *JSONLinesReporter) Extension() string { return "jsonl" }
+
+// Report writes one line per player, ordered by SteamID. The categories
+// argument is accepted for Reporter compatibility; every category the
+// player has is emitted. If writer can be flushed it is flushed after each
+// line so downstream consumers see players as they are written.
+func (jr *JSONLinesReporter) Report(demoStats *DemoStats, _ []Category, writer io.Writer) error {
+	enc := json.NewEncoder(writer)
+	f, canFlush := writer.(flusher)
+	model := BuildReport(demoStats, nil)
+	for _, p := range model.Players {
+		line := jsonLine{
+			Demo:      model.Demo,
+			Map:       model.Map,
+			TickRate:  model.TickRate,
+			TickCount: model.TickCount,
+			SteamID:   p.SteamID,
+			Name:      p.Name,
+			Metrics:   flattenMetrics(p.Sections),
+		}
+		if err := enc.Encode(line); err != nil {
+			return fmt.Errorf("jsonl: player %d: %w", p.SteamID, err)
+		}
+		if canFlush {
+			if err := f.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// flattenMetrics maps every row of sections to "category.key" with its
+// native JSON value. Durations are written in seconds; NaN and ±Inf, which
+// JSON cannot represent, are written as null.
+func flattenMetrics(sections []ReportSection) map[string]any {
+	out := make(map[string]any)
+	for _, s := range sections {
+		for _, r := range s.Rows {
+			out[string(s.Category)+"."+string(r.Key)] = metricJSONValue(r.Metric)
+		}
+	}
+	return out
+}
+
+func metricJSONValue(m Metric) any {
+	switch m.Type {
+	case MetricPercentage, MetricFloat:
+		if math.IsNaN(m.FloatValue) || math.IsInf(m.FloatValue, 0) {
+			return nil
+		}
+		return m.FloatValue
+	case MetricInteger, MetricCount:
+		return m.IntValue
+	case MetricDuration:
+		return m.DurationValue.Seconds()
+	case MetricString:
+		return m.StringValue
+	}
+	return nil
+}
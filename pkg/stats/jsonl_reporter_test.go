@@ -0,0 +1,42 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestJSONLinesReporter(t *testing.T) {
+	ds := NewDemoStats()
+	ds.DemoName = "match.dem"
+	ds.MapName = "de_mirage"
+	ds.GetOrCreatePlayerStatsBySteamID(2).AddMetric(Category("kills"), Key("total_kills"), Metric{Type: MetricInteger, IntValue: 17})
+	p1 := ds.GetOrCreatePlayerStatsBySteamID(1)
+	p1.AddMetric(Category("anti_cheat"), Key("cheat_likelihood"), Metric{Type: MetricPercentage, FloatValue: 72.5})
+	p1.AddMetric(Category("snap"), Key("p95_snap_velocity"), Metric{Type: MetricFloat, FloatValue: math.NaN()})
+
+	var buf bytes.Buffer
+	if err := NewJSONLinesReporter().Report(ds, nil, &buf); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+
+	var first jsonLine
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("line 1 is not valid JSON: %v", err)
+	}
+	if first.SteamID != 1 || first.Map != "de_mirage" || first.Demo != "match.dem" {
+		t.Errorf("unexpected metadata: %+v", first)
+	}
+	if got := first.Metrics["anti_cheat.cheat_likelihood"]; got != 72.5 {
+		t.Errorf("cheat_likelihood = %v, want 72.5", got)
+	}
+	if got, ok := first.Metrics["snap.p95_snap_velocity"]; !ok || got != nil {
+		t.Errorf("NaN metric = %v, want null", got)
+	}
+}
@@ -0,0 +1,197 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+const (
+	// jumpthrowWindow is how far apart a grenade release and a takeoff may
+	// be for the throw to count as a jumpthrow; 8 ticks at 64 tick.
+	jumpthrowWindow = 125 * time.Millisecond
+
+	// jumpthrowPerfectTicks is the latest in-game tick after the release a
+	// frame-perfect jumpthrow takes off on. A bind jumps and releases on the
+	// same tick, and the ground flag clears on that tick or the next, at any
+	// tick rate.
+	jumpthrowPerfectTicks = 1
+
+	// jumpthrowScriptMin and jumpthrowScriptShare gate jumpthrow_script: a
+	// human lands the odd frame-perfect jumpthrow by luck, not nearly all
+	// of them.
+	jumpthrowScriptMin   = 3
+	jumpthrowScriptShare = 0.8
+)
+
+// JumpthrowCollector looks for jumpthrow binds: grenades released within
+// jumpthrowWindow of the thrower leaving the ground are jumpthrows, and
+// those taking off on the release tick or the next are frame-perfect. Both
+// are measured in in-game ticks; the window is converted with the tick rate.
+// Repeated lineups are fine; a macro doing the jump and release is what
+// makes every jumpthrow frame-perfect.
+//
+//   - jumpthrows: grenade throws released around a takeoff.
+//   - scripted_jumpthrows: the frame-perfect ones.
+//   - jumpthrow_script: Yes when at least jumpthrowScriptMin jumpthrows are
+//     frame-perfect and they are at least jumpthrowScriptShare of all of
+//     them.
+type JumpthrowCollector struct {
+	*BaseCollector
+
+	tickRate float64
+
+	// airborne and seen are each player's ground state and the tick it was
+	// read on; takeoffs are the recent ticks the player left the ground,
+	// and pending the release ticks still waiting for takeoffs after them.
+	airborne map[uint64]bool
+	seen     map[uint64]int
+	takeoffs map[uint64][]int
+	pending  map[uint64][]int
+
+	jumpthrows map[uint64]int
+	perfect    map[uint64]int
+}
+
+// NewJumpthrowCollector creates a new JumpthrowCollector.
+func NewJumpthrowCollector() *JumpthrowCollector {
+	return &JumpthrowCollector{
+		BaseCollector: NewBaseCollector("Jumpthrow Scripts", CatScripts),
+		airborne:      make(map[uint64]bool),
+		seen:          make(map[uint64]int),
+		takeoffs:      make(map[uint64][]int),
+		pending:       make(map[uint64][]int),
+		jumpthrows:    make(map[uint64]int),
+		perfect:       make(map[uint64]int),
+	}
+}
+
+// Setup seeds the tick rate and registers the throw handler. Throw handlers
+// run before the frame is collected, so a takeoff on the release tick is
+// seen afterwards.
+func (jc *JumpthrowCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	jc.tickRate = parser.TickRate()
+	parser.RegisterEventHandler(func(e events.TickRateInfoAvailable) {
+		if e.TickRate > 0 {
+			jc.tickRate = e.TickRate
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.GrenadeProjectileThrow) {
+		if inWarmup(parser) || e.Projectile == nil || !demoStats.CountsAimPlayer(e.Projectile.Thrower) {
+			return
+		}
+		sid := demoStats.PlayerKey(e.Projectile.Thrower)
+		jc.pending[sid] = append(jc.pending[sid], parser.GameState().IngameTick())
+	})
+}
+
+// window returns jumpthrowWindow in ticks at the demo's tick rate.
+func (jc *JumpthrowCollector) window() int {
+	return windowTicks(jumpthrowWindow, jc.tickRate)
+}
+
+// CollectFrame records takeoffs and classifies the throws whose window has
+// passed. Only the first frame of each in-game tick is read; the demo
+// carries several commands per tick.
+func (jc *JumpthrowCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	gs := parser.GameState()
+	tick := gs.IngameTick()
+	for _, player := range gs.Participants().Playing() {
+		if !demoStats.CountsAimPlayer(player) || !player.IsAlive() {
+			continue
+		}
+		sid := demoStats.PlayerKey(player)
+		last, ok := jc.seen[sid]
+		if ok && tick <= last {
+			continue
+		}
+		airborne := player.IsAirborne()
+		if ok && tick-last == 1 && airborne && !jc.airborne[sid] {
+			jc.takeoffs[sid] = append(jc.takeoffs[sid], tick)
+		}
+		jc.airborne[sid], jc.seen[sid] = airborne, tick
+	}
+	jc.resolve(tick, false)
+}
+
+// resolve classifies every pending throw whose window has passed by tick,
+// or all of them when final, and drops takeoffs too old to matter.
+func (jc *JumpthrowCollector) resolve(tick int, final bool) {
+	window := jc.window()
+	for sid, throws := range jc.pending {
+		kept := throws[:0]
+		for _, th := range throws {
+			if !final && tick-th <= window {
+				kept = append(kept, th)
+				continue
+			}
+			jump, perfect := classifyJumpthrow(th, jc.takeoffs[sid], window)
+			if jump {
+				jc.jumpthrows[sid]++
+			}
+			if perfect {
+				jc.perfect[sid]++
+			}
+		}
+		jc.pending[sid] = kept
+	}
+	for sid, takeoffs := range jc.takeoffs {
+		i := 0
+		for i < len(takeoffs) && tick-takeoffs[i] > 2*window {
+			i++
+		}
+		jc.takeoffs[sid] = takeoffs[i:]
+	}
+}
+
+// classifyJumpthrow reports whether a release on tick throw was a
+// jumpthrow (a takeoff within window ticks either side) and whether it was
+// frame-perfect (a takeoff on the release tick or up to
+// jumpthrowPerfectTicks after).
+func classifyJumpthrow(throw int, takeoffs []int, window int) (jump, perfect bool) {
+	for _, t := range takeoffs {
+		d := t - throw
+		if d < -window || d > window {
+			continue
+		}
+		jump = true
+		if d >= 0 && d <= jumpthrowPerfectTicks {
+			perfect = true
+		}
+	}
+	return jump, perfect
+}
+
+// CollectFinalStats classifies the remaining throws and publishes the
+// jumpthrow counts for every player who jumpthrew.
+func (jc *JumpthrowCollector) CollectFinalStats(demoStats *DemoStats) {
+	jc.resolve(0, true)
+	for sid, n := range jc.jumpthrows {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		perfect := jc.perfect[sid]
+		ps.AddMetric(CatScripts, KeyJumpthrows, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(n),
+			Description: "Grenades released around a takeoff",
+		})
+		ps.AddMetric(CatScripts, KeyScriptedJumpthrows, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(perfect),
+			Description: "Jumpthrows taking off on the release tick or the next",
+		})
+		flag := "No"
+		if perfect >= jumpthrowScriptMin && float64(perfect) >= jumpthrowScriptShare*float64(n) {
+			flag = "Yes"
+		}
+		ps.AddMetric(CatScripts, KeyJumpthrowScript, Metric{
+			Type:        MetricString,
+			StringValue: flag,
+			Description: "Nearly every jumpthrow frame-perfect — a jumpthrow bind",
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package stats
+
+import "testing"
+
+func TestClassifyJumpthrow(t *testing.T) {
+	cases := []struct {
+		name          string
+		throw         int
+		takeoffs      []int
+		jump, perfect bool
+	}{
+		{"same tick", 100, []int{100}, true, true},
+		{"next tick", 100, []int{101}, true, true},
+		{"late takeoff", 100, []int{104}, true, false},
+		{"release after takeoff", 100, []int{97}, true, false},
+		{"standing throw", 100, []int{40, 200}, false, false},
+	}
+	for _, tc := range cases {
+		jump, perfect := classifyJumpthrow(tc.throw, tc.takeoffs, 8)
+		if jump != tc.jump || perfect != tc.perfect {
+			t.Errorf("%s: classifyJumpthrow = %v, %v; want %v, %v", tc.name, jump, perfect, tc.jump, tc.perfect)
+		}
+	}
+}
+
+func TestJumpthrowScriptFlag(t *testing.T) {
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(1)
+	ds.GetOrCreatePlayerStatsBySteamID(2)
+	jc := NewJumpthrowCollector()
+	jc.tickRate = 64
+	window := jc.window()
+
+	// Player 1 takes off on the release frame every time; player 2 is a
+	// tick or four off, and lands one perfect throw by luck.
+	for i, d := range []int{0, 0, 1, 0} {
+		throw := 1000 * (i + 1)
+		jc.pending[1] = append(jc.pending[1], throw)
+		jc.takeoffs[1] = append(jc.takeoffs[1], throw+d)
+		jc.resolve(throw+window+1, false)
+	}
+	for i, d := range []int{3, -2, 0, 4} {
+		throw := 1000 * (i + 1)
+		jc.pending[2] = append(jc.pending[2], throw)
+		jc.takeoffs[2] = append(jc.takeoffs[2], throw+d)
+		jc.resolve(throw+window+1, false)
+	}
+	jc.CollectFinalStats(ds)
+
+	if n := intMetric(ds.Players[1], CatScripts, KeyScriptedJumpthrows); n != 4 {
+		t.Errorf("player 1 scripted_jumpthrows = %d, want 4", n)
+	}
+	if got, _ := psGetString(ds.Players[1], CatScripts, KeyJumpthrowScript); got != "Yes" {
+		t.Error("player 1 not flagged")
+	}
+	if n := intMetric(ds.Players[2], CatScripts, KeyJumpthrows); n != 4 {
+		t.Errorf("player 2 jumpthrows = %d, want 4", n)
+	}
+	if got, _ := psGetString(ds.Players[2], CatScripts, KeyJumpthrowScript); got != "No" {
+		t.Error("player 2 flagged for one lucky jumpthrow")
+	}
+}
+
+func TestJumpthrowWindowScalesWithTickRate(t *testing.T) {
+	jc := NewJumpthrowCollector()
+	for rate, want := range map[float64]int{64: 8, 128: 16} {
+		jc.tickRate = rate
+		if got := jc.window(); got != want {
+			t.Errorf("window at %v tick = %d ticks, want %d", rate, got, want)
+		}
+	}
+	// 12 ticks apart is a jumpthrow at 128 tick (94 ms) but not at 64 (188 ms)
+	if jump, _ := classifyJumpthrow(100, []int{112}, 16); !jump {
+		t.Error("takeoff 94 ms after the release not a jumpthrow at 128 tick")
+	}
+	if jump, _ := classifyJumpthrow(100, []int{112}, 8); jump {
+		t.Error("takeoff 188 ms after the release a jumpthrow at 64 tick")
+	}
+}
@@ -0,0 +1,211 @@
+package stats
+
+// Metric categories. Collectors write under these and the cheat detector
+// reads from them; using the constants rather than Category("…") literals
+// makes a misspelt category a compile error instead of a silent new one.
+const (
+	CatAccuracy    Category = "accuracy"
+	CatAiming      Category = "aiming"
+	CatAntiCheat   Category = "anti_cheat"
+	CatBehavioral  Category = "behavioral"
+	CatConduct     Category = "conduct"
+	CatFlash       Category = "flash"
+	CatGameInfo    Category = "game_info"
+	CatHitgroups   Category = "hitgroups"
+	CatImpact      Category = "impact"
+	CatInaccuracy  Category = "inaccuracy"
+	CatInfo        Category = "info"
+	CatKills       Category = "kills"
+	CatMovement    Category = "movement"
+	CatObjective   Category = "objective"
+	CatPerformance Category = "performance"
+	CatPlacement   Category = "placement"
+	CatPlayerInfo  Category = "player_info"
+	CatRating      Category = "rating"
+	CatReaction    Category = "reaction"
+	CatRecoil      Category = "recoil"
+	CatRecoilDebug Category = "recoil_debug"
+	CatScoreboard  Category = "scoreboard"
+	CatScripts     Category = "scripts"
+	CatSmoke       Category = "smoke"
+	CatSniper      Category = "sniper"
+	CatTeam        Category = "team"
+	CatTTK         Category = "ttk"
+	CatUtility     Category = "utility"
+	CatWallbang    Category = "wallbang"
+	CatWallhack    Category = "wallhack"
+	CatWeapons     Category = "weapons"
+)
+
+// Metric keys written by the collectors and cheat detector. Per-weapon keys
+// such as "ak47_bullets" are built at runtime and have no constant.
+const (
+	KeyADR                        Key = "adr"
+	KeyAimedShots                 Key = "aimed_shots"
+	KeyAliases                    Key = "aliases"
+	KeyAllowlisted                Key = "allowlisted"
+	KeyAssists                    Key = "assists"
+	KeyAvgFirstBloodTime          Key = "avg_first_blood_time"
+	KeyAvgSnapVelocity            Key = "avg_snap_velocity"
+	KeyAWPFlickSamples            Key = "awp_flick_samples"
+	KeyAWPFlickVelocity           Key = "awp_flick_velocity"
+	KeyBackKillGivenCount         Key = "back_kill_given_count"
+	KeyBackKillGivenPct           Key = "back_kill_given_pct"
+	KeyBackKillGivenTotalKills    Key = "back_kill_given_total_kills"
+	KeyBackKilledPct              Key = "back_killed_pct"
+	KeyBackKilledTotalDeaths      Key = "back_killed_total_deaths"
+	KeyBeyondInaccuracyHits       Key = "beyond_inaccuracy_hits"
+	KeyBlendedRecoilScore         Key = "blended_recoil_score"
+	KeyBlindKills                 Key = "blind_kills"
+	KeyBlindTrackingKills         Key = "blind_tracking_kills"
+	KeyBulletHits                 Key = "bullet_hits"
+	KeyBurstCount                 Key = "burst_count"
+	KeyCheatExplanation           Key = "cheat_explanation"
+	KeyCheatLikelihood            Key = "cheat_likelihood"
+	KeyCheatLikelihoodHigh        Key = "cheat_likelihood_high"
+	KeyCheatLikelihoodLow         Key = "cheat_likelihood_low"
+	KeyCheater                    Key = "cheater"
+	KeyCompetitiveBoost           Key = "competitive_boost"
+	KeyCrosshairOnTargetFireRate  Key = "crosshair_on_target_fire_rate"
+	KeyCrosshairOnTargetSprayRate Key = "crosshair_on_target_spray_rate"
+	KeyCrouchJitterLongestRun     Key = "crouch_jitter_longest_run"
+	KeyCrouchJitterScore          Key = "crouch_jitter_score"
+	KeyCrouchJitterToggles        Key = "crouch_jitter_toggles"
+	KeyCrouchToggles              Key = "crouch_toggles"
+	KeyCTRating                   Key = "ct_rating"
+	KeyCTRounds                   Key = "ct_rounds"
+	KeyDamage                     Key = "damage"
+	KeyDamagePerRound             Key = "damage_per_round"
+	KeyDamagePerThrow             Key = "damage_per_throw"
+	KeyDeaths                     Key = "deaths"
+	KeyDecouplingConfidence       Key = "decoupling_confidence"
+	KeyDecouplingScore            Key = "decoupling_score"
+	KeyDefuseUnderPressure        Key = "defuse_under_pressure"
+	KeyDemoCount                  Key = "demo_count"
+	KeyDemoIntegrityWarnings      Key = "demo_integrity_warnings"
+	KeyDemoPerspective            Key = "demo_perspective"
+	KeyDisconnectedTicks          Key = "disconnected_ticks"
+	KeyDisconnects                Key = "disconnects"
+	KeyEnemiesPerThrow            Key = "enemies_per_throw"
+	KeyEnemyHits                  Key = "enemy_hits"
+	KeyEvidenceStackingBoost      Key = "evidence_stacking_boost"
+	KeyFakeDefuses                Key = "fake_defuses"
+	KeyFastTTDMs                  Key = "fast_ttd_ms"
+	KeyFireSnapCount              Key = "fire_snap_count"
+	KeyFireSnapShots              Key = "fire_snap_shots"
+	KeyFirstKillTicks             Key = "first_kill_ticks"
+	KeyFullFlashes                Key = "full_flashes"
+	KeyGameMode                   Key = "game_mode"
+	KeyGrade                      Key = "grade"
+	KeyHEZeroDamage               Key = "he_zero_damage"
+	KeyHeadshotKills              Key = "headshot_kills"
+	KeyHeadshotPercentage         Key = "headshot_percentage"
+	KeyHSKills                    Key = "hs_kills"
+	KeyHSPercentage               Key = "hs_percentage"
+	KeyInaccuracyCheckedHits      Key = "inaccuracy_checked_hits"
+	KeyInsufficientData           Key = "insufficient_data"
+	KeyJumpthrowScript            Key = "jumpthrow_script"
+	KeyJumpthrows                 Key = "jumpthrows"
+	KeyKAST                       Key = "kast"
+	KeyKilled                     Key = "killed"
+	KeyKilledWhileNearBlind       Key = "killed_while_near_blind"
+	KeyKills                      Key = "kills"
+	KeyKillsOnUnspotted           Key = "kills_on_unspotted"
+	KeyKillsWhileFlashed          Key = "kills_while_flashed"
+	KeyKnifePercentage            Key = "knife_percentage"
+	KeyKnifeTicks                 Key = "knife_ticks"
+	KeyLobbyRelativeADR           Key = "lobby_relative_adr"
+	KeyLobbyRelativeHS            Key = "lobby_relative_hs"
+	KeyLobbyRelativeRating        Key = "lobby_relative_rating"
+	KeyLongSprayPerfectRatio      Key = "long_spray_perfect_ratio"
+	KeyLongSprays                 Key = "long_sprays"
+	KeyMapProfile                 Key = "map_profile"
+	KeyMeanAngularError           Key = "mean_angular_error"
+	KeyMedianSnapVelocity         Key = "median_snap_velocity"
+	KeyMedianSprayBullets         Key = "median_spray_bullets"
+	KeyMedianTTD                  Key = "median_ttd"
+	KeyMedianUnblindToKillMs      Key = "median_unblind_to_kill_ms"
+	KeyMeleeKills                 Key = "melee_kills"
+	KeyMostSuspiciousRecoilWeapon Key = "most_suspicious_recoil_weapon"
+	KeyMovingScopedKills          Key = "moving_scoped_kills"
+	KeyMVPs                       Key = "mvps"
+	KeyNameChanges                Key = "name_changes"
+	KeyNearestEnemyAngleMedianDeg Key = "nearest_enemy_angle_median_deg"
+	KeyNearestEnemyAngleSamples   Key = "nearest_enemy_angle_samples"
+	KeyNoOvershootSnapRatio       Key = "no_overshoot_snap_ratio"
+	KeyNoWeaponPercentage         Key = "no_weapon_percentage"
+	KeyNoWeaponTicks              Key = "no_weapon_ticks"
+	KeyNonKnifePercentage         Key = "non_knife_percentage"
+	KeyNonKnifeTicks              Key = "non_knife_ticks"
+	KeyOpeningDuels               Key = "opening_duels"
+	KeyOpeningSurvivals           Key = "opening_survivals"
+	KeyOpeningWinRate             Key = "opening_win_rate"
+	KeyOpeningWins                Key = "opening_wins"
+	KeyOverall                    Key = "overall"
+	KeyOverperformer              Key = "overperformer"
+	KeyOvershootCheckedSnaps      Key = "overshoot_checked_snaps"
+	KeyP10TTD                     Key = "p10_ttd"
+	KeyP10TimeToKillMs            Key = "p10_time_to_kill_ms"
+	KeyP95PreciseSnapVelocity     Key = "p95_precise_snap_velocity"
+	KeyP95SnapVelocity            Key = "p95_snap_velocity"
+	KeyPlacementEligibleTicks     Key = "placement_eligible_ticks"
+	KeyPositionDiscount           Key = "position_discount"
+	KeyPositionFactor             Key = "position_factor"
+	KeyPreFOVAimMedianDeg         Key = "pre_fov_aim_median_deg"
+	KeyPreFOVAimSamples           Key = "pre_fov_aim_samples"
+	KeyPreFOVPresenceScore        Key = "pre_fov_presence_score"
+	KeyPreciseSnaps               Key = "precise_snaps"
+	KeyPreheadRatio               Key = "prehead_ratio"
+	KeyPreheadTicks               Key = "prehead_ticks"
+	KeyPullBias                   Key = "pull_bias"
+	KeyPullSamples                Key = "pull_samples"
+	KeyRating2                    Key = "rating_2"
+	KeyReactionCheatScore         Key = "reaction_cheat_score"
+	KeyRecoilConsistencyStddev    Key = "recoil_consistency_stddev"
+	KeyRecoilInterpretation       Key = "recoil_interpretation"
+	KeyRecoilMagnitudeError       Key = "recoil_pattern_magnitude_error"
+	KeyRecoilPatternSimilarity    Key = "recoil_pattern_similarity"
+	KeyRecoilScore                Key = "recoil_score"
+	KeyRoundCount                 Key = "round_count"
+	KeyRoundsPlayed               Key = "rounds_played"
+	KeySaves                      Key = "saves"
+	KeyScopeToKillMs              Key = "scope_to_kill_ms"
+	KeyScopedSniperKills          Key = "scoped_sniper_kills"
+	KeyScoutHSKills               Key = "scout_hs_kills"
+	KeyScoutHSRate                Key = "scout_hs_rate"
+	KeyScoutKills                 Key = "scout_kills"
+	KeyScoutPrecisionOverride     Key = "scout_precision_override"
+	KeyScriptedJumpthrows         Key = "scripted_jumpthrows"
+	KeySideSwitches               Key = "side_switches"
+	KeySnapCount                  Key = "snap_count"
+	KeySnapFOVClamp               Key = "snap_fov_clamp"
+	KeySnapResidualMode           Key = "snap_residual_mode"
+	KeySnappedKills               Key = "snapped_kills"
+	KeySniperWallbangKills        Key = "sniper_wallbang_kills"
+	KeySniperWallbangOverride     Key = "sniper_wallbang_override"
+	KeySpottedKills               Key = "spotted_kills"
+	KeySub100msTTD                Key = "sub_100ms_ttd"
+	KeyTeam                       Key = "team"
+	KeyTeamDamage                 Key = "team_damage"
+	KeyTeamKills                  Key = "team_kills"
+	KeyTeamSpottedKills           Key = "team_spotted_kills"
+	KeyThroughSmokeKills          Key = "through_smoke_kills"
+	KeyThrown                     Key = "thrown"
+	KeyTimeToKillMs               Key = "time_to_kill_ms"
+	KeyTotalCheatScore            Key = "total_cheat_score"
+	KeyTotalCountedBullets        Key = "total_counted_bullets"
+	KeyTotalErrorSum              Key = "total_error_sum"
+	KeyTotalKills                 Key = "total_kills"
+	KeyTotalTicks                 Key = "total_ticks"
+	KeyTrackedWallbangs           Key = "tracked_wallbangs"
+	KeyTRating                    Key = "t_rating"
+	KeyTRounds                    Key = "t_rounds"
+	KeyTTDSamples                 Key = "ttd_samples"
+	KeyTTDSub100HighFloor         Key = "ttd_sub100_high_floor"
+	KeyTTKSamples                 Key = "ttk_samples"
+	KeyUnspottedKillPct           Key = "unspotted_kill_pct"
+	KeyWallbangKills              Key = "wallbang_kills"
+	KeyWallhackCoOccurrenceBoost  Key = "wallhack_co_occurrence_boost"
+	KeyWingmanBoost               Key = "wingman_boost"
+	KeyWingmanKPRBoostReason      Key = "wingman_kpr_boost_reason"
+)
@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// metricWriters are the calls that produce a metric. Every other call taking
+// a category constant followed by a key constant reads one.
+var metricWriters = map[string]bool{
+	"AddMetric":            true,
+	"IncrementIntMetric":   true,
+	"IncrementFloatMetric": true,
+	"addIntMetric":         true,
+}
+
+// TestConsumedKeysAreProduced checks, across the package source, that every
+// metric read through a Cat*/Key* constant pair is written somewhere under
+// the same category, so a renamed or misfiled key fails here rather than
+// silently reading zero.
+func TestConsumedKeysAreProduced(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := pkgs["stats"].Files
+
+	// Values of the Cat* and Key* constants.
+	values := make(map[string]string)
+	for _, decl := range files["keys.go"].Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if lit, ok := vs.Values[i].(*ast.BasicLit); ok {
+					values[name.Name], _ = strconv.Unquote(lit.Value)
+				}
+			}
+		}
+	}
+
+	// The detector publishes <id>_score, _confidence and _zone per channel
+	// under runtime-built keys.
+	produced := make(map[string]bool)
+	for id := range builtinChannelIDs {
+		for _, suffix := range []string{"_score", "_confidence", "_zone"} {
+			produced[string(CatAntiCheat)+"/"+id+suffix] = true
+		}
+	}
+	for _, legacy := range channelLegacyKey {
+		produced[string(CatAntiCheat)+"/"+legacy] = true
+	}
+
+	consumed := make(map[string]token.Position)
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			var name string
+			var args []ast.Expr
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				switch fn := n.Fun.(type) {
+				case *ast.Ident:
+					name = fn.Name
+				case *ast.SelectorExpr:
+					name = fn.Sel.Name
+				}
+				args = n.Args
+			case *ast.CompositeLit:
+				args = n.Elts
+			default:
+				return true
+			}
+			for i := 0; i+1 < len(args); i++ {
+				cat, ok1 := args[i].(*ast.Ident)
+				key, ok2 := args[i+1].(*ast.Ident)
+				if !ok1 || !ok2 || !strings.HasPrefix(cat.Name, "Cat") || !strings.HasPrefix(key.Name, "Key") {
+					continue
+				}
+				ref := values[cat.Name] + "/" + values[key.Name]
+				if metricWriters[name] {
+					produced[ref] = true
+				} else if _, seen := consumed[ref]; !seen {
+					consumed[ref] = fset.Position(args[i].Pos())
+				}
+			}
+			return true
+		})
+	}
+
+	if len(values) == 0 || len(consumed) == 0 {
+		t.Fatalf("found %d constants and %d consumed metrics; the scan is broken", len(values), len(consumed))
+	}
+	var missing []string
+	for _, p := range builtinProducers() {
+		for _, ref := range p.ProducedMetrics() {
+			if r := string(ref.Category) + "/" + string(ref.Key); !produced[r] {
+				missing = append(missing, r+" (declared by "+collectorTypeName(p)+")")
+			}
+		}
+	}
+	for ref, pos := range consumed {
+		if !produced[ref] {
+			missing = append(missing, ref+" (read at "+pos.String()+")")
+		}
+	}
+	sort.Strings(missing)
+	for _, m := range missing {
+		t.Errorf("metric never written: %s", m)
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
 )
 
-// HeadshotCollector tracks headshot kill statistics
+// HeadshotCollector tracks headshot kill statistics. Knife and Zeus kills
+// are counted as melee_kills and left out of total_kills and the headshot
+// rate, which the cheat detector reads as aim evidence; the scoreboard
+// still counts them.
 type HeadshotCollector struct {
 	*BaseCollector
 }
@@ -13,7 +16,7 @@ type HeadshotCollector struct {
 // NewHeadshotCollector creates a new HeadshotCollector
 func NewHeadshotCollector() *HeadshotCollector {
 	return &HeadshotCollector{
-		BaseCollector: NewBaseCollector("Headshot Statistics", Category("kills")),
+		BaseCollector: NewBaseCollector("Headshot Statistics", CatKills),
 	}
 }
 
@@ -32,13 +35,24 @@ func (hc *HeadshotCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStat
 			return
 		}
 
+		if isMelee(e.Weapon) {
+			playerStats.IncrementIntMetric(CatKills, KeyMeleeKills)
+			return
+		}
+
 		// Increment total kills
-		playerStats.IncrementIntMetric(Category("kills"), Key("total_kills"))
+		playerStats.IncrementIntMetric(CatKills, KeyTotalKills)
 
 		// Increment headshot kills if applicable
 		if e.IsHeadshot {
-			playerStats.IncrementIntMetric(Category("kills"), Key("headshot_kills"))
+			playerStats.IncrementIntMetric(CatKills, KeyHeadshotKills)
 		}
+
+		headshot := 0.0
+		if e.IsHeadshot {
+			headshot = 1.0
+		}
+		demoStats.AddRoundSample(demoStats.PlayerKey(e.Killer), currentRound(parser), "hs", headshot)
 	})
 }
 
@@ -47,25 +61,33 @@ func (hc *HeadshotCollector) CollectFrame(parser demoinfocs.Parser, demoStats *D
 	// No per-frame processing needed, we use event handlers
 }
 
+// ProducedMetrics lists the metrics the cheat detector reads from HeadshotCollector.
+func (hc *HeadshotCollector) ProducedMetrics() []KeyRef {
+	return []KeyRef{
+		{CatKills, KeyTotalKills},
+		{CatKills, KeyHeadshotPercentage},
+	}
+}
+
 // CollectFinalStats calculates headshot percentage
 func (hc *HeadshotCollector) CollectFinalStats(demoStats *DemoStats) {
 	for _, playerStats := range demoStats.Players {
-		totalKills, found := playerStats.GetMetric(Category("kills"), Key("total_kills"))
+		totalKills, found := playerStats.GetMetric(CatKills, KeyTotalKills)
 		if !found || totalKills.IntValue == 0 {
 			continue
 		}
 
 		// Calculate headshot percentage
-		if hsKills, found := playerStats.GetMetric(Category("kills"), Key("headshot_kills")); found {
+		if hsKills, found := playerStats.GetMetric(CatKills, KeyHeadshotKills); found {
 			hsPercentage := float64(hsKills.IntValue) / float64(totalKills.IntValue) * 100
-			playerStats.AddMetric(Category("kills"), Key("headshot_percentage"), Metric{
+			playerStats.AddMetric(CatKills, KeyHeadshotPercentage, Metric{
 				Type:        MetricPercentage,
 				FloatValue:  hsPercentage,
 				Description: "Percentage of kills that were headshots",
 			})
 		} else {
 			// If player has kills but no HS kills, set to 0%
-			playerStats.AddMetric(Category("kills"), Key("headshot_percentage"), Metric{
+			playerStats.AddMetric(CatKills, KeyHeadshotPercentage, Metric{
 				Type:        MetricPercentage,
 				FloatValue:  0,
 				Description: "Percentage of kills that were headshots",
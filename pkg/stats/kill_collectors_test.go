@@ -0,0 +1,37 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+func TestHeadshotCollectorSeparatesMeleeKills(t *testing.T) {
+	parser := &warmupStubParser{}
+	ds := NewDemoStats()
+	hc := NewHeadshotCollector()
+	hc.Setup(parser, ds)
+
+	killer := &common.Player{SteamID64: 1, Team: common.TeamTerrorists}
+	victim := &common.Player{SteamID64: 2, Team: common.TeamCounterTerrorists}
+	kill := func(weapon common.EquipmentType, headshot bool) {
+		parser.dispatch(events.Kill{Killer: killer, Victim: victim, Weapon: common.NewEquipment(weapon), IsHeadshot: headshot})
+	}
+	kill(common.EqAK47, true)
+	kill(common.EqAK47, false)
+	kill(common.EqKnife, false)
+	kill(common.EqZeus, false)
+	hc.CollectFinalStats(ds)
+
+	ps := ds.Players[1]
+	if n := intMetric(ps, CatKills, KeyTotalKills); n != 2 {
+		t.Errorf("total_kills = %d, want 2", n)
+	}
+	if n := intMetric(ps, CatKills, KeyMeleeKills); n != 2 {
+		t.Errorf("melee_kills = %d, want 2", n)
+	}
+	if pct, _ := psGetFloat(ps, CatKills, KeyHeadshotPercentage); pct != 50 {
+		t.Errorf("headshot_percentage = %v, want 50", pct)
+	}
+}
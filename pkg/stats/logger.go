@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Logger receives the diagnostics of the analyzer and collectors: Infof for
+// progress, Debugf for per-sample detail such as every recoil burst. The
+// library logs nothing unless one is set; see NopLogger and WriterLogger.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+}
+
+// LogUser is implemented by collectors that log. SetLogger must be called
+// before Setup.
+type LogUser interface {
+	SetLogger(l Logger)
+}
+
+// NopLogger discards everything. It is the default, so embedding the
+// library doesn't write to stdout.
+type NopLogger struct{}
+
+// Debugf does nothing.
+func (NopLogger) Debugf(string, ...any) {}
+
+// Infof does nothing.
+func (NopLogger) Infof(string, ...any) {}
+
+// WriterLogger writes one line per message to w: Infof always, Debugf only
+// when debug is set, prefixed with "[DEBUG] ". Safe for concurrent use.
+type WriterLogger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	debug bool
+}
+
+// NewWriterLogger creates a WriterLogger; the CLI uses one on stdout or
+// stderr.
+func NewWriterLogger(w io.Writer, debug bool) *WriterLogger {
+	return &WriterLogger{w: w, debug: debug}
+}
+
+// Debugf writes the message when debug output is enabled.
+func (l *WriterLogger) Debugf(format string, args ...any) {
+	if l.debug {
+		l.write("[DEBUG] ", format, args)
+	}
+}
+
+// Infof writes the message.
+func (l *WriterLogger) Infof(format string, args ...any) {
+	l.write("", format, args)
+}
+
+func (l *WriterLogger) write(prefix, format string, args []any) {
+	msg := fmt.Sprintf(format, args...)
+	if !strings.HasSuffix(msg, "\n") {
+		msg += "\n"
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprint(l.w, prefix+msg)
+}
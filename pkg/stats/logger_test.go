@@ -0,0 +1,16 @@
+package stats
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriterLogger(t *testing.T) {
+	var buf bytes.Buffer
+	NewWriterLogger(&buf, false).Debugf("hidden %d", 1)
+	NewWriterLogger(&buf, false).Infof("shown %d", 2)
+	NewWriterLogger(&buf, true).Debugf("burst %d\n", 3)
+	if got, want := buf.String(), "shown 2\n[DEBUG] burst 3\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MarkdownReporter renders the report as GitHub-flavored Markdown — a
+// heading per player with the verdict, explanation and one table per
+// category — for pasting into tickets and review threads. It reuses the
+// HTML reporter's data builders, so ordering and labels match the other
+// renderers.
+type MarkdownReporter struct{}
+
+// NewMarkdownReporter creates a MarkdownReporter.
+func NewMarkdownReporter() *MarkdownReporter {
+	return &MarkdownReporter{}
+}
+
+// Extension returns "md".
+func (mr *MarkdownReporter) Extension() string { return "md" }
+
+// Report writes the Markdown document. The categories argument is accepted
+// for Reporter compatibility but unused, like the other rendered reports.
+func (mr *MarkdownReporter) Report(demoStats *DemoStats, _ []Category, writer io.Writer) error {
+	data := buildHTMLData(demoStats)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", mdEscape(fallback(data.DemoName, "Demo analysis")))
+	fmt.Fprintf(&b, "- **Map:** %s\n", mdEscape(fallback(data.MapName, "unknown")))
+	if data.GameMode != "" {
+		fmt.Fprintf(&b, "- **Mode:** %s\n", mdEscape(data.GameMode))
+	}
+	if data.RoundCount > 0 {
+		fmt.Fprintf(&b, "- **Rounds:** %d\n", data.RoundCount)
+	}
+	fmt.Fprintf(&b, "- **Flagged:** %d of %d players\n", data.FlaggedCount, data.PlayerCount)
+
+	if len(data.DemoInfo) > 0 {
+		b.WriteString("\n## Demo Info\n")
+		writeMarkdownCategories(&b, data.DemoInfo)
+	}
+
+	for _, p := range data.Players {
+		verdict := "not flagged"
+		if p.Flagged {
+			verdict = "**FLAGGED**"
+		}
+		fmt.Fprintf(&b, "\n## %s (%s)\n\n", mdEscape(p.Name), p.SteamID)
+		fmt.Fprintf(&b, "Cheat likelihood **%.1f%%** — %s", p.Likelihood, verdict)
+		if p.OverallGrade != "" {
+			fmt.Fprintf(&b, " · overall grade %s", p.OverallGrade)
+		}
+		b.WriteString("\n")
+		if p.Explanation != "" {
+			fmt.Fprintf(&b, "\n> %s\n", mdEscape(p.Explanation))
+		}
+
+		writeMarkdownCategories(&b, p.Categories)
+	}
+
+	_, err := io.WriteString(writer, b.String())
+	return err
+}
+
+// writeMarkdownCategories writes a table per category.
+func writeMarkdownCategories(b *strings.Builder, cats []htmlCategory) {
+	for _, cat := range cats {
+		fmt.Fprintf(b, "\n### %s\n\n| Metric | Value |\n| --- | ---: |\n", mdEscape(cat.Title))
+		for _, m := range cat.Metrics {
+			fmt.Fprintf(b, "| %s | %s |\n", mdEscape(m.Label), mdEscape(m.Value))
+		}
+	}
+}
+
+var mdEscaper = strings.NewReplacer("|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`", "\n", " ")
+
+// mdEscape escapes the characters that would break a table cell or start
+// unintended formatting.
+func mdEscape(s string) string {
+	return mdEscaper.Replace(s)
+}
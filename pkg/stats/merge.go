@@ -0,0 +1,103 @@
+package stats
+
+// MergeDemoStats combines several demos into one cross-demo DemoStats for
+// league-wide ranking. Players are matched by SteamID. Per metric type:
+//
+//   - integer, count and duration metrics are summed (kills, rounds, ...);
+//   - float and percentage metrics are averaged over the demos that
+//     published them, so a player's cheat_likelihood is their mean
+//     likelihood;
+//   - string metrics keep the value from the last demo that has one.
+//
+// Demo-wide metrics are merged by the same rules. Every merged player also
+// gets game_info/demo_count. The result depends
+// only on the order of demos, never on map iteration; callers that gather
+// demos concurrently should pass them in a fixed order. MapName and TickRate
+// are kept when every demo agrees and cleared otherwise; TickCount is the
+// total. Nil entries are skipped.
+func MergeDemoStats(demos ...*DemoStats) *DemoStats {
+	merged := NewDemoStats()
+	merged.DemoName = "merged"
+
+	averages := make(map[uint64]map[Category]map[Key]*mergeAvg)
+	global := &PlayerStats{Categories: merged.GlobalMetrics}
+	globalAverages := make(map[Category]map[Key]*mergeAvg)
+
+	first := true
+	for _, ds := range demos {
+		if ds == nil {
+			continue
+		}
+		if first {
+			merged.MapName, merged.TickRate = ds.MapName, ds.TickRate
+			first = false
+		} else {
+			if merged.MapName != ds.MapName {
+				merged.MapName = ""
+			}
+			if merged.TickRate != ds.TickRate {
+				merged.TickRate = 0
+			}
+		}
+		merged.TickCount += ds.TickCount
+
+		for _, sid := range sortedSteamIDs(ds) {
+			src := ds.Players[sid]
+			dst, ok := merged.Players[sid]
+			if !ok {
+				dst = &PlayerStats{Player: src.Player, Categories: make(map[Category]map[Key]Metric)}
+				merged.Players[sid] = dst
+				averages[sid] = make(map[Category]map[Key]*mergeAvg)
+			} else if src.Player.Name != "" && src.Player.Name != "Unknown" {
+				dst.Player.Name = src.Player.Name
+			}
+			dst.IncrementIntMetric(CatGameInfo, KeyDemoCount)
+
+			mergeMetrics(dst, src.Categories, averages[sid])
+		}
+		mergeMetrics(global, ds.GlobalMetrics, globalAverages)
+	}
+	return merged
+}
+
+// mergeAvg is a running mean of a float metric across demos.
+type mergeAvg struct {
+	sum float64
+	n   int
+}
+
+// mergeMetrics folds one demo's metrics into dst, keeping the running means
+// of float metrics in averages.
+func mergeMetrics(dst *PlayerStats, src map[Category]map[Key]Metric, averages map[Category]map[Key]*mergeAvg) {
+	for cat, metrics := range src {
+		for key, m := range metrics {
+			if cat == CatGameInfo && key == KeyDemoCount {
+				continue // re-counted by the caller
+			}
+			prev, exists := dst.GetMetric(cat, key)
+			switch m.Type {
+			case MetricInteger, MetricCount:
+				if exists {
+					m.IntValue += prev.IntValue
+				}
+			case MetricDuration:
+				if exists {
+					m.DurationValue += prev.DurationValue
+				}
+			case MetricFloat, MetricPercentage:
+				if averages[cat] == nil {
+					averages[cat] = make(map[Key]*mergeAvg)
+				}
+				a := averages[cat][key]
+				if a == nil {
+					a = &mergeAvg{}
+					averages[cat][key] = a
+				}
+				a.sum += m.FloatValue
+				a.n++
+				m.FloatValue = a.sum / float64(a.n)
+			}
+			dst.AddMetric(cat, key, m)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package stats
+
+import "testing"
+
+func TestMergeDemoStats(t *testing.T) {
+	demo := func(mapName string, kills int64, likelihood float64, name string) *DemoStats {
+		ds := NewDemoStats()
+		ds.MapName, ds.TickRate, ds.TickCount = mapName, 64, 1000
+		ps := ds.GetOrCreatePlayerStatsBySteamID(7)
+		ps.Player.Name = name
+		ps.AddMetric(Category("kills"), Key("total_kills"), Metric{Type: MetricInteger, IntValue: kills})
+		ps.AddMetric(Category("anti_cheat"), Key("cheat_likelihood"), Metric{Type: MetricPercentage, FloatValue: likelihood})
+		ds.AddMetric(CatGameInfo, KeyRoundCount, Metric{Type: MetricInteger, IntValue: 20})
+		return ds
+	}
+
+	merged := MergeDemoStats(demo("de_nuke", 10, 20, "old"), nil, demo("de_nuke", 30, 60, "new"), demo("de_anubis", 5, 40, "Unknown"))
+	if merged.MapName != "" || merged.TickRate != 64 || merged.TickCount != 3000 {
+		t.Errorf("demo fields: map %q rate %v ticks %d", merged.MapName, merged.TickRate, merged.TickCount)
+	}
+	ps := merged.Players[7]
+	if ps.Player.Name != "new" {
+		t.Errorf("name = %q, want latest known name", ps.Player.Name)
+	}
+	if m, _ := ps.GetMetric(Category("kills"), Key("total_kills")); m.IntValue != 45 {
+		t.Errorf("total_kills = %d, want summed 45", m.IntValue)
+	}
+	if m, _ := ps.GetMetric(Category("anti_cheat"), Key("cheat_likelihood")); m.FloatValue != 40 {
+		t.Errorf("cheat_likelihood = %v, want mean 40", m.FloatValue)
+	}
+	if m, _ := ps.GetMetric(Category("game_info"), Key("demo_count")); m.IntValue != 3 {
+		t.Errorf("demo_count = %d, want 3", m.IntValue)
+	}
+	if m, _ := merged.GetMetric(CatGameInfo, KeyRoundCount); m.IntValue != 60 {
+		t.Errorf("global round_count = %d, want summed 60", m.IntValue)
+	}
+}
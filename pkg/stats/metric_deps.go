@@ -0,0 +1,92 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MetricProducer is implemented by collectors whose metrics other
+// collectors depend on. ProducedMetrics lists those metrics; it needn't list
+// everything the collector writes.
+type MetricProducer interface {
+	ProducedMetrics() []KeyRef
+}
+
+// MetricConsumer is implemented by collectors that read other collectors'
+// metrics in CollectFinalStats. RequiredMetrics lists the metrics that must
+// be produced by some registered collector for the consumer's output to
+// mean anything.
+type MetricConsumer interface {
+	RequiredMetrics() []KeyRef
+}
+
+// builtinProducers returns one of each built-in MetricProducer: the
+// collectors the cheat detector reads from. It also names the collector a
+// missing metric would come from.
+func builtinProducers() []MetricProducer {
+	return []MetricProducer{
+		NewHeadshotCollector(),
+		NewSnapAngleCollector(),
+		NewReactionTimeCollector(),
+		NewRecoilControlCollector(),
+		NewGameModeCollector(),
+		NewScoreboardCollector(),
+		NewSniperCollector(),
+		NewBehavioralCollector(),
+		NewFlashCollector(),
+	}
+}
+
+// ValidateMetricDependencies checks that every metric a MetricConsumer in
+// collectors requires is produced by a MetricProducer in collectors, so a
+// collector left out of the set fails up front instead of zeroing the
+// scores built on it. The error lists every missing metric.
+func ValidateMetricDependencies(collectors []Collector) error {
+	produced := make(map[KeyRef]bool)
+	for _, c := range collectors {
+		if p, ok := c.(MetricProducer); ok {
+			for _, ref := range p.ProducedMetrics() {
+				produced[ref] = true
+			}
+		}
+	}
+
+	var errs []error
+	for _, c := range collectors {
+		consumer, ok := c.(MetricConsumer)
+		if !ok {
+			continue
+		}
+		for _, ref := range consumer.RequiredMetrics() {
+			if produced[ref] {
+				continue
+			}
+			if producer := builtinProducerOf(ref); producer != "" {
+				errs = append(errs, fmt.Errorf("%s requires %s/%s but %s is not registered", collectorTypeName(c), ref.Category, ref.Key, producer))
+			} else {
+				errs = append(errs, fmt.Errorf("%s requires %s/%s but no registered collector produces it", collectorTypeName(c), ref.Category, ref.Key))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// builtinProducerOf returns the type name of the built-in collector that
+// produces ref, or "".
+func builtinProducerOf(ref KeyRef) string {
+	for _, p := range builtinProducers() {
+		for _, r := range p.ProducedMetrics() {
+			if r == ref {
+				return collectorTypeName(p)
+			}
+		}
+	}
+	return ""
+}
+
+// collectorTypeName returns c's type name without package or pointer.
+func collectorTypeName(c any) string {
+	name := fmt.Sprintf("%T", c)
+	return name[strings.LastIndex(name, ".")+1:]
+}
@@ -0,0 +1,105 @@
+package stats
+
+import "io"
+
+// FilteredReporter wraps another Reporter and drops metrics before it
+// renders, for focused reports — say only cheat_likelihood and the
+// component scores for a table pasted into a chat. Filtering happens on a
+// copy of the stats, so every format lays out only what is left: table
+// columns and widths are computed from the reduced set.
+//
+// cheat_likelihood, cheater and cheat_explanation are always kept, so every
+// format can still say who was flagged. Demo-wide metrics are never
+// filtered.
+type FilteredReporter struct {
+	inner    Reporter
+	omitZero bool
+	only     map[Key]bool
+}
+
+// NewFilteredReporter creates a FilteredReporter around r. Without a call
+// to OmitZero or OnlyKeys it reports everything, like r.
+func NewFilteredReporter(r Reporter) *FilteredReporter {
+	return &FilteredReporter{inner: r}
+}
+
+// OmitZero drops metrics whose value is zero or empty: 0 counts, 0%
+// rates, zero durations and empty strings.
+func (fr *FilteredReporter) OmitZero(on bool) {
+	fr.omitZero = on
+}
+
+// OnlyKeys keeps only metrics with these keys, in whatever category they
+// are. An empty list keeps every key.
+func (fr *FilteredReporter) OnlyKeys(keys []Key) {
+	fr.only = nil
+	if len(keys) == 0 {
+		return
+	}
+	fr.only = make(map[Key]bool, len(keys))
+	for _, k := range keys {
+		fr.only[k] = true
+	}
+}
+
+// Extension returns the wrapped reporter's extension.
+func (fr *FilteredReporter) Extension() string { return fr.inner.Extension() }
+
+// Report filters demoStats and hands the result to the wrapped reporter.
+// Categories left without metrics are dropped.
+func (fr *FilteredReporter) Report(demoStats *DemoStats, categories []Category, writer io.Writer) error {
+	if demoStats == nil || (!fr.omitZero && fr.only == nil) {
+		return fr.inner.Report(demoStats, categories, writer)
+	}
+	return fr.inner.Report(demoStats.withMetrics(fr.keep), categories, writer)
+}
+
+// keep reports whether metric k of cat on player sid survives the filter.
+func (fr *FilteredReporter) keep(sid uint64, cat Category, k Key, m Metric) bool {
+	if cat == CatAntiCheat && (k == KeyCheatLikelihood || k == KeyCheater || k == KeyCheatExplanation) {
+		return true
+	}
+	if fr.only != nil && !fr.only[k] {
+		return false
+	}
+	return !fr.omitZero || !isZeroMetric(m)
+}
+
+// isZeroMetric reports whether m holds its type's zero value.
+func isZeroMetric(m Metric) bool {
+	switch m.Type {
+	case MetricPercentage, MetricFloat:
+		return m.FloatValue == 0
+	case MetricInteger, MetricCount:
+		return m.IntValue == 0
+	case MetricDuration:
+		return m.DurationValue == 0
+	case MetricString:
+		return m.StringValue == ""
+	}
+	return false
+}
+
+// withMetrics returns a copy of ds whose players hold only the metrics keep
+// accepts, and only the categories with any left. Unlike withCategories the
+// metric maps are copied, so ds is left untouched.
+func (ds *DemoStats) withMetrics(keep func(sid uint64, cat Category, k Key, m Metric) bool) *DemoStats {
+	out := *ds
+	out.Players = make(map[uint64]*PlayerStats, len(ds.Players))
+	for sid, ps := range ds.Players {
+		cp := &PlayerStats{Player: ps.Player, Categories: make(map[Category]map[Key]Metric)}
+		for cat, metrics := range ps.Categories {
+			kept := make(map[Key]Metric)
+			for k, m := range metrics {
+				if keep(sid, cat, k, m) {
+					kept[k] = m
+				}
+			}
+			if len(kept) > 0 {
+				cp.Categories[cat] = kept
+			}
+		}
+		out.Players[sid] = cp
+	}
+	return &out
+}
@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFilteredReporter(t *testing.T) {
+	ds := NewDemoStats()
+	ps := ds.GetOrCreatePlayerStatsBySteamID(1)
+	ps.Player.Name = "suspect"
+	ps.AddMetric(CatAntiCheat, KeyCheatLikelihood, Metric{Type: MetricPercentage, FloatValue: 72})
+	ps.AddMetric(CatAntiCheat, KeyCheater, Metric{Type: MetricString, StringValue: "Yes"})
+	ps.AddMetric(CatAntiCheat, Key("hs_score"), Metric{Type: MetricFloat, FloatValue: 0.8})
+	ps.AddMetric(CatAntiCheat, Key("snap_score"), Metric{Type: MetricFloat, FloatValue: 0})
+	ps.AddMetric(Category("kills"), Key("total_kills"), Metric{Type: MetricInteger, IntValue: 25})
+	ps.AddMetric(Category("kills"), Key("team_kills"), Metric{Type: MetricInteger, IntValue: 0})
+
+	report := func(fr *FilteredReporter) string {
+		var buf bytes.Buffer
+		if err := fr.Report(ds, nil, &buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	fr := NewFilteredReporter(NewCSVReporter())
+	if got := strings.Count(report(fr), "\n"); got != 7 {
+		t.Errorf("unfiltered: %d lines, want header and 6 metrics", got)
+	}
+
+	fr.OmitZero(true)
+	out := report(fr)
+	if strings.Contains(out, "snap_score") || strings.Contains(out, "team_kills") {
+		t.Errorf("zero metrics kept:\n%s", out)
+	}
+	if !strings.Contains(out, "total_kills") {
+		t.Errorf("non-zero metric dropped:\n%s", out)
+	}
+
+	fr.OmitZero(false)
+	fr.OnlyKeys([]Key{"hs_score"})
+	out = report(fr)
+	for _, want := range []string{"hs_score", "cheat_likelihood", "cheater"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "total_kills") || strings.Contains(out, "kills,") {
+		t.Errorf("unrequested category kept:\n%s", out)
+	}
+
+	// The source stats are untouched.
+	if _, ok := ps.GetMetric(Category("kills"), Key("team_kills")); !ok {
+		t.Error("filtering modified the demo stats")
+	}
+}
@@ -0,0 +1,157 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+const (
+	// fakeDefuseMaxMs: an aborted defuse shorter than this, by a defuser who
+	// is still alive, is a tap to bait the planter rather than a real
+	// attempt that got interrupted.
+	fakeDefuseMaxMs = 1000.0
+
+	// defusePressureRadius is how close (in units) a living enemy must be to
+	// the defuser for a successful defuse to count as under pressure.
+	defusePressureRadius = 1000.0
+)
+
+// ObjectiveCollector adds round context around the bomb: saves, fake
+// defuses and defuses completed with enemies close by. Not an anti-cheat
+// signal — analysts use it to read rounds, e.g. to tell a passive player
+// who saves every lost round from one who never does.
+//
+// Some demos never emit BombDefuseStart. Without it there is no start time
+// to measure an abort against, so fake_defuses is only published when at
+// least one defuse start was seen; saves and defuse_under_pressure don't
+// depend on it.
+type ObjectiveCollector struct {
+	*BaseCollector
+
+	defuseStart    map[uint64]time.Duration
+	sawDefuseStart bool
+
+	saves          map[uint64]int
+	fakeDefuses    map[uint64]int
+	pressureDefuse map[uint64]int
+}
+
+// NewObjectiveCollector creates a new ObjectiveCollector.
+func NewObjectiveCollector() *ObjectiveCollector {
+	return &ObjectiveCollector{
+		BaseCollector:  NewBaseCollector("Objective", CatObjective),
+		defuseStart:    make(map[uint64]time.Duration),
+		saves:          make(map[uint64]int),
+		fakeDefuses:    make(map[uint64]int),
+		pressureDefuse: make(map[uint64]int),
+	}
+}
+
+// Setup registers the defuse and round handlers.
+func (oc *ObjectiveCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	tracked := func(p *common.Player) bool {
+		return demoStats.CountsPlayer(p) && !inWarmup(parser)
+	}
+
+	parser.RegisterEventHandler(func(e events.BombDefuseStart) {
+		oc.sawDefuseStart = true
+		if tracked(e.Player) {
+			oc.defuseStart[demoStats.PlayerKey(e.Player)] = demoTime(parser, parser.TickRate())
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.BombDefuseAborted) {
+		if !tracked(e.Player) {
+			return
+		}
+		sid := demoStats.PlayerKey(e.Player)
+		start, ok := oc.defuseStart[sid]
+		delete(oc.defuseStart, sid)
+		if !ok || !e.Player.IsAlive() {
+			return
+		}
+		elapsed := demoTime(parser, parser.TickRate()) - start
+		if float64(elapsed)/float64(time.Millisecond) < fakeDefuseMaxMs {
+			oc.fakeDefuses[sid]++
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.BombDefused) {
+		if !tracked(e.Player) {
+			return
+		}
+		sid := demoStats.PlayerKey(e.Player)
+		delete(oc.defuseStart, sid)
+		pos := e.Player.Position()
+		for _, enemy := range parser.GameState().Participants().Playing() {
+			if enemy == nil || !enemy.IsAlive() || enemy.Team == e.Player.Team {
+				continue
+			}
+			if enemy.Position().Sub(pos).Norm() <= defusePressureRadius {
+				oc.pressureDefuse[sid]++
+				break
+			}
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.RoundEnd) {
+		if e.Winner != common.TeamTerrorists && e.Winner != common.TeamCounterTerrorists {
+			return // draw or unknown winner
+		}
+		for _, p := range parser.GameState().Participants().Playing() {
+			if !tracked(p) || !p.IsAlive() || p.Team == e.Winner {
+				continue
+			}
+			if hasPrimaryWeapon(p) {
+				oc.saves[demoStats.PlayerKey(p)]++
+			}
+		}
+	})
+
+	parser.RegisterEventHandler(func(_ events.RoundStart) {
+		oc.defuseStart = make(map[uint64]time.Duration)
+	})
+}
+
+// hasPrimaryWeapon reports whether p carries a rifle, sniper, SMG or heavy.
+func hasPrimaryWeapon(p *common.Player) bool {
+	for _, w := range p.Weapons() {
+		if w == nil {
+			continue
+		}
+		switch weaponClass(w) {
+		case "rifle", "sniper", "smg", "heavy":
+			return true
+		}
+	}
+	return false
+}
+
+// CollectFrame is a no-op; everything is event-driven.
+func (oc *ObjectiveCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {}
+
+// CollectFinalStats publishes the objective counts for every player.
+func (oc *ObjectiveCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, ps := range demoStats.Players {
+		ps.AddMetric(CatObjective, KeySaves, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(oc.saves[sid]),
+			Description: "Lost rounds survived while holding a primary weapon",
+		})
+		ps.AddMetric(CatObjective, KeyDefuseUnderPressure, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(oc.pressureDefuse[sid]),
+			Description: "Defuses completed with a living enemy nearby",
+		})
+		if oc.sawDefuseStart {
+			ps.AddMetric(CatObjective, KeyFakeDefuses, Metric{
+				Type:        MetricInteger,
+				IntValue:    int64(oc.fakeDefuses[sid]),
+				Description: "Defuses started and aborted within a second while alive",
+			})
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+	st "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/sendtables"
+)
+
+// playingStubParser is a warmupStubParser that also reports a fixed set of
+// players as playing.
+type playingStubParser struct {
+	*warmupStubParser
+	playing []*common.Player
+}
+
+type playingStubState struct {
+	warmupStubState
+	playing []*common.Player
+}
+
+func (p *playingStubParser) GameState() demoinfocs.GameState {
+	return playingStubState{warmupStubState{p: p.warmupStubParser}, p.playing}
+}
+func (s playingStubState) Participants() demoinfocs.Participants {
+	return participantsStub{playing: s.playing}
+}
+
+// playerEntity stands in for both a player's controller and its pawn: the
+// controller points at the pawn by handle, and the pawn reports life state
+// and position.
+type playerEntity struct {
+	st.Entity
+	handle uint64
+	dead   bool
+	pos    r3.Vector
+}
+
+func (e *playerEntity) PropertyValue(name string) (st.PropertyValue, bool) {
+	switch name {
+	case "m_hPlayerPawn":
+		return st.PropertyValue{Any: e.handle}, true
+	case "m_bPawnIsAlive":
+		return st.PropertyValue{Any: !e.dead}, true
+	case "m_lifeState":
+		if e.dead {
+			return st.PropertyValue{Any: uint64(2)}, true
+		}
+		return st.PropertyValue{Any: uint64(0)}, true
+	}
+	return st.PropertyValue{}, false
+}
+
+func (e *playerEntity) PropertyValueMust(name string) st.PropertyValue {
+	v, _ := e.PropertyValue(name)
+	return v
+}
+
+func (e *playerEntity) Position() r3.Vector { return e.pos }
+
+// entityProvider resolves pawn handles for common.NewPlayer the way the
+// parser does.
+type entityProvider map[uint64]*playerEntity
+
+func (entityProvider) IngameTick() int                              { return 0 }
+func (entityProvider) TickRate() float64                            { return 64 }
+func (entityProvider) FindPlayerByHandle(uint64) *common.Player     { return nil }
+func (entityProvider) FindPlayerByPawnHandle(uint64) *common.Player { return nil }
+func (entityProvider) PlayerResourceEntity() st.Entity              { return nil }
+func (entityProvider) FindWeaponByEntityID(int) *common.Equipment   { return nil }
+func (p entityProvider) FindEntityByHandle(handle uint64) st.Entity {
+	if e, ok := p[handle]; ok {
+		return e
+	}
+	return nil
+}
+
+// objectiveFixture sets up an ObjectiveCollector on a two-versus-two lobby:
+// CTs are SteamIDs 1 and 2, Ts 3 and 4. Liveness and positions are set on
+// the players' entities, primaries in their inventories.
+type objectiveFixture struct {
+	parser   *playingStubParser
+	ds       *DemoStats
+	oc       *ObjectiveCollector
+	players  map[uint64]*common.Player
+	entities entityProvider
+}
+
+func newObjectiveFixture() *objectiveFixture {
+	f := &objectiveFixture{
+		parser:   &playingStubParser{warmupStubParser: &warmupStubParser{}},
+		ds:       NewDemoStats(),
+		oc:       NewObjectiveCollector(),
+		players:  make(map[uint64]*common.Player),
+		entities: make(entityProvider),
+	}
+	for sid := uint64(1); sid <= 4; sid++ {
+		team := common.TeamCounterTerrorists
+		if sid > 2 {
+			team = common.TeamTerrorists
+		}
+		e := &playerEntity{handle: 100 + sid}
+		f.entities[e.handle] = e
+		p := common.NewPlayer(f.entities)
+		p.SteamID64, p.Name, p.Team, p.Entity = sid, "p", team, e
+		f.players[sid] = p
+		f.parser.playing = append(f.parser.playing, p)
+		f.ds.GetOrCreatePlayerStats(p)
+	}
+	f.oc.Setup(f.parser, f.ds)
+	return f
+}
+
+func (f *objectiveFixture) entity(sid uint64) *playerEntity {
+	return f.entities[100+sid]
+}
+
+// arm gives the player an AK-47.
+func (f *objectiveFixture) arm(sid uint64) {
+	f.players[sid].Inventory[1] = &common.Equipment{Type: common.EqAK47}
+}
+
+// frames advances the parser n frames (1/64 s each).
+func (f *objectiveFixture) frames(n int) {
+	for i := 0; i < n; i++ {
+		f.parser.advance()
+	}
+}
+
+func (f *objectiveFixture) count(sid uint64, key Key) int64 {
+	f.oc.CollectFinalStats(f.ds)
+	n, _ := psGetInt(f.ds.Players[sid], CatObjective, key)
+	return n
+}
+
+func TestObjectiveSaves(t *testing.T) {
+	f := newObjectiveFixture()
+	f.arm(3) // 4 is alive with a pistol only
+	f.arm(1)
+
+	f.parser.dispatch(events.RoundEnd{Winner: common.TeamCounterTerrorists})
+	// A draw counts no one
+	f.parser.dispatch(events.RoundEnd{Winner: common.TeamUnassigned})
+	// Dead on the losing side isn't a save
+	f.entity(3).dead = true
+	f.parser.dispatch(events.RoundEnd{Winner: common.TeamCounterTerrorists})
+
+	want := map[uint64]int64{1: 0, 2: 0, 3: 1, 4: 0}
+	for sid, n := range want {
+		if got := f.count(sid, KeySaves); got != n {
+			t.Errorf("player %d saves = %d, want %d", sid, got, n)
+		}
+	}
+}
+
+func TestObjectiveFakeDefuses(t *testing.T) {
+	f := newObjectiveFixture()
+	defuser := f.players[1]
+	attempt := func(frames int) {
+		f.parser.dispatch(events.BombDefuseStart{Player: defuser})
+		f.frames(frames)
+		f.parser.dispatch(events.BombDefuseAborted{Player: defuser})
+	}
+
+	attempt(32) // 0.5 s tap: fake
+	attempt(96) // 1.5 s, interrupted: a real attempt
+	f.entity(1).dead = true
+	attempt(16) // killed on the kit: not a fake
+	f.entity(1).dead = false
+
+	// An abort without a start seen this round doesn't count
+	f.parser.dispatch(events.BombDefuseStart{Player: defuser})
+	f.parser.dispatch(events.RoundStart{})
+	f.frames(8)
+	f.parser.dispatch(events.BombDefuseAborted{Player: defuser})
+
+	if got := f.count(1, KeyFakeDefuses); got != 1 {
+		t.Errorf("fake_defuses = %d, want 1", got)
+	}
+	if _, ok := f.ds.Players[3].GetMetric(CatObjective, KeyFakeDefuses); !ok {
+		t.Error("fake_defuses not published for every player once a defuse start was seen")
+	}
+}
+
+func TestObjectiveFakeDefusesNeedDefuseStart(t *testing.T) {
+	f := newObjectiveFixture()
+	f.parser.dispatch(events.BombDefuseAborted{Player: f.players[1]})
+	f.oc.CollectFinalStats(f.ds)
+
+	if _, ok := f.ds.Players[1].GetMetric(CatObjective, KeyFakeDefuses); ok {
+		t.Error("fake_defuses published for a demo without BombDefuseStart")
+	}
+	if _, ok := f.ds.Players[1].GetMetric(CatObjective, KeySaves); !ok {
+		t.Error("saves missing for a demo without BombDefuseStart")
+	}
+}
+
+func TestObjectiveDefuseUnderPressure(t *testing.T) {
+	f := newObjectiveFixture()
+	defused := events.BombDefused{BombEvent: events.BombEvent{Player: f.players[1]}}
+
+	// A living T within the radius
+	f.entity(3).pos = r3.Vector{X: defusePressureRadius / 2}
+	f.entity(4).pos = r3.Vector{X: 2 * defusePressureRadius}
+	f.parser.dispatch(defused)
+
+	// The close T is dead, the living one far away; teammates don't count
+	f.entity(3).dead = true
+	f.entity(2).pos = r3.Vector{X: 10}
+	f.parser.dispatch(defused)
+
+	if got := f.count(1, KeyDefuseUnderPressure); got != 1 {
+		t.Errorf("defuse_under_pressure = %d, want 1", got)
+	}
+}
@@ -0,0 +1,192 @@
+package stats
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// openingDuel is the first kill of a round, held until the round is over so
+// a same-tick trade can still cancel the win and the opener's death later
+// in the round is seen.
+type openingDuel struct {
+	killer, victim uint64
+	tick           int           // in-game tick of the kill
+	sinceLive      time.Duration // from freeze-time end; -1 when unknown
+	traded         bool
+	killerDied     bool
+}
+
+// OpeningDuelCollector tracks each round's first kill: both players took
+// the opening duel, the killer won it. Winning most openers, and winning
+// them fast, is what pre-aiming through walls looks like from the outside —
+// though entry fraggers do it legitimately, so this is context rather than a
+// score.
+//
+// When the victim kills the killer back on the same tick (both fired at
+// once), the duel counts for both and is won by neither.
+//
+//   - opening_duels, opening_wins, opening_win_rate
+//   - opening_survivals: won openers after which the player survived the
+//     round — an entry that also lives through the retake.
+//   - avg_first_blood_time: mean seconds from freeze-time end to the
+//     player's won openers. Rounds whose freeze-time end wasn't seen (the
+//     demo started mid-round) are left out of the mean.
+//   - first_kill_ticks (demo-wide): the in-game tick of each round's first
+//     kill, in round order, for jumping to the openers in a replay.
+type OpeningDuelCollector struct {
+	*BaseCollector
+
+	liveAt  time.Duration // freeze-time end of the current round; -1 when unknown
+	opening *openingDuel
+
+	firstKillTicks []int
+
+	duels     map[uint64]int
+	wins      map[uint64]int
+	survivals map[uint64]int
+	timeSum   map[uint64]time.Duration
+	timeCount map[uint64]int
+}
+
+// NewOpeningDuelCollector creates a new OpeningDuelCollector.
+func NewOpeningDuelCollector() *OpeningDuelCollector {
+	return &OpeningDuelCollector{
+		BaseCollector: NewBaseCollector("Opening Duels", CatImpact),
+		liveAt:        -1,
+		duels:         make(map[uint64]int),
+		wins:          make(map[uint64]int),
+		survivals:     make(map[uint64]int),
+		timeSum:       make(map[uint64]time.Duration),
+		timeCount:     make(map[uint64]int),
+	}
+}
+
+// Setup registers the round and kill handlers.
+func (oc *OpeningDuelCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	parser.RegisterEventHandler(func(_ events.RoundStart) {
+		oc.closeRound()
+		oc.liveAt = -1
+	})
+
+	parser.RegisterEventHandler(func(_ events.RoundFreezetimeEnd) {
+		oc.liveAt = demoTime(parser, parser.TickRate())
+	})
+
+	parser.RegisterEventHandler(func(_ events.RoundEnd) {
+		oc.closeRound()
+	})
+
+	parser.RegisterEventHandler(func(e events.Kill) {
+		if inWarmup(parser) || e.Killer == nil || e.Victim == nil {
+			return
+		}
+		if e.Killer == e.Victim || e.Killer.Team == e.Victim.Team {
+			return
+		}
+		oc.processKill(demoStats.PlayerKey(e.Killer), demoStats.PlayerKey(e.Victim),
+			parser.GameState().IngameTick(), demoTime(parser, parser.TickRate()))
+	})
+}
+
+// processKill opens the round's duel on its first kill, marks it traded
+// when the victim kills the killer back on the same tick, and notes any
+// later death of the opener.
+func (oc *OpeningDuelCollector) processKill(killer, victim uint64, tick int, now time.Duration) {
+	if oc.opening == nil {
+		since := time.Duration(-1)
+		if oc.liveAt >= 0 && now >= oc.liveAt {
+			since = now - oc.liveAt
+		}
+		oc.opening = &openingDuel{killer: killer, victim: victim, tick: tick, sinceLive: since}
+		return
+	}
+	o := oc.opening
+	if victim == o.killer {
+		o.killerDied = true
+		if tick == o.tick && killer == o.victim {
+			o.traded = true
+		}
+	}
+}
+
+// closeRound books the round's opening duel, if there was one.
+func (oc *OpeningDuelCollector) closeRound() {
+	o := oc.opening
+	if o == nil {
+		return
+	}
+	oc.opening = nil
+	oc.firstKillTicks = append(oc.firstKillTicks, o.tick)
+	oc.duels[o.killer]++
+	oc.duels[o.victim]++
+	if o.traded {
+		return
+	}
+	oc.wins[o.killer]++
+	if !o.killerDied {
+		oc.survivals[o.killer]++
+	}
+	if o.sinceLive >= 0 {
+		oc.timeSum[o.killer] += o.sinceLive
+		oc.timeCount[o.killer]++
+	}
+}
+
+// CollectFrame is a no-op; everything is event-driven.
+func (oc *OpeningDuelCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {}
+
+// CollectFinalStats books a round the demo ended in and publishes the
+// opening-duel metrics for every player who took one, and the first-kill
+// ticks for the demo.
+func (oc *OpeningDuelCollector) CollectFinalStats(demoStats *DemoStats) {
+	oc.closeRound()
+	if len(oc.firstKillTicks) > 0 {
+		ticks := make([]string, len(oc.firstKillTicks))
+		for i, t := range oc.firstKillTicks {
+			ticks[i] = strconv.Itoa(t)
+		}
+		demoStats.AddMetric(CatImpact, KeyFirstKillTicks, Metric{
+			Type:        MetricString,
+			StringValue: strings.Join(ticks, ", "),
+			Description: "In-game tick of each round's first kill, in round order",
+		})
+	}
+	for sid, duels := range oc.duels {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		wins := oc.wins[sid]
+		ps.AddMetric(CatImpact, KeyOpeningDuels, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(duels),
+			Description: "Rounds in which the player took part in the first kill",
+		})
+		ps.AddMetric(CatImpact, KeyOpeningWins, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(wins),
+			Description: "Opening duels won without being traded on the same tick",
+		})
+		ps.AddMetric(CatImpact, KeyOpeningSurvivals, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(oc.survivals[sid]),
+			Description: "Opening duels won after which the player survived the round",
+		})
+		ps.AddMetric(CatImpact, KeyOpeningWinRate, Metric{
+			Type:        MetricPercentage,
+			FloatValue:  float64(wins) / float64(duels) * 100.0,
+			Description: "Share of opening duels won",
+		})
+		if n := oc.timeCount[sid]; n > 0 {
+			ps.AddMetric(CatImpact, KeyAvgFirstBloodTime, Metric{
+				Type:        MetricFloat,
+				FloatValue:  (oc.timeSum[sid] / time.Duration(n)).Seconds(),
+				Description: "Mean seconds from freeze-time end to the player's opening kills",
+			})
+		}
+	}
+}
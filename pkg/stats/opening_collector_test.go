@@ -0,0 +1,56 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+func TestOpeningDuelCollector(t *testing.T) {
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStats(&common.Player{SteamID64: 1, Name: "a"})
+	ds.GetOrCreatePlayerStats(&common.Player{SteamID64: 2, Name: "b"})
+	oc := NewOpeningDuelCollector()
+
+	// Round 1: 1 opens on 2 four seconds in and dies later in the round.
+	oc.liveAt = 10 * time.Second
+	oc.processKill(1, 2, 100, 14*time.Second)
+	oc.processKill(2, 1, 200, 20*time.Second)
+	oc.closeRound()
+
+	// Round 2: 1 and 2 kill each other on the same tick.
+	oc.liveAt = 60 * time.Second
+	oc.processKill(1, 2, 500, 62*time.Second)
+	oc.processKill(2, 1, 500, 62*time.Second)
+	oc.closeRound()
+
+	// Round 3: 1 opens again, with no freeze-time end seen; the demo ends
+	// before RoundEnd.
+	oc.liveAt = -1
+	oc.processKill(1, 2, 900, 90*time.Second)
+	oc.CollectFinalStats(ds)
+
+	a, b := ds.Players[1], ds.Players[2]
+	if n := intMetric(a, CatImpact, KeyOpeningDuels); n != 3 {
+		t.Errorf("a opening_duels = %d, want 3", n)
+	}
+	if n := intMetric(a, CatImpact, KeyOpeningWins); n != 2 {
+		t.Errorf("a opening_wins = %d, want 2", n)
+	}
+	if n := intMetric(b, CatImpact, KeyOpeningWins); n != 0 {
+		t.Errorf("b opening_wins = %d, want 0 (traded round has no winner)", n)
+	}
+	if n := intMetric(a, CatImpact, KeyOpeningSurvivals); n != 1 {
+		t.Errorf("a opening_survivals = %d, want 1 (died after the round 1 opener)", n)
+	}
+	if m, _ := ds.GetMetric(CatImpact, KeyFirstKillTicks); m.StringValue != "100, 500, 900" {
+		t.Errorf("first_kill_ticks = %q, want 100, 500, 900", m.StringValue)
+	}
+	if v, _ := psGetFloat(a, CatImpact, KeyAvgFirstBloodTime); v != 4 {
+		t.Errorf("avg_first_blood_time = %v, want 4", v)
+	}
+	if _, ok := b.GetMetric(CatImpact, KeyAvgFirstBloodTime); ok {
+		t.Error("avg_first_blood_time published for a player with no won openers")
+	}
+}
@@ -0,0 +1,119 @@
+package stats
+
+const (
+	// overperformMinPeers is how many other rated players a lobby needs
+	// before a player is compared with it. Below it (wingman, a filtered
+	// run) one strong or weak peer moves the mean too much to mean anything.
+	overperformMinPeers = 5
+
+	// overperformRating and overperformSecondary gate overperformer: a
+	// rating overperformRating times the lobby's, backed by an ADR or HS%
+	// at least overperformSecondary times the lobby's, so a lucky rating
+	// from assists or survival alone doesn't trip it.
+	overperformRating    = 1.6
+	overperformSecondary = 1.3
+)
+
+// OverperformanceCollector compares each player's rating, ADR and headshot
+// percentage with the rest of the lobby. A player far above their peers is
+// a smurf or boost candidate — not cheating in itself, but a cue to look at
+// the demo more closely. It only reads final metrics, so it must be
+// registered after the RatingCollector.
+//
+// Each ratio is against the mean of the other rated players (rating_2 needs
+// ratingMinRounds), leaving the player out so a standout doesn't raise the
+// bar they're measured against:
+//
+//   - lobby_relative_rating, lobby_relative_adr, lobby_relative_hs: the
+//     player's value over the peer mean; 1.0 is average.
+//   - overperformer: Yes when lobby_relative_rating is at least
+//     overperformRating and ADR or HS% at least overperformSecondary.
+//
+// Nothing is published in lobbies with fewer than overperformMinPeers
+// peers.
+type OverperformanceCollector struct {
+	*BaseCollector
+}
+
+// NewOverperformanceCollector creates a new OverperformanceCollector.
+func NewOverperformanceCollector() *OverperformanceCollector {
+	return &OverperformanceCollector{
+		BaseCollector: NewBaseCollector("Performance vs Lobby", CatPerformance),
+	}
+}
+
+// lobbyLine is one rated player's values.
+type lobbyLine struct {
+	rating, adr, hs float64
+}
+
+// CollectFinalStats publishes the lobby-relative ratios for every rated
+// player.
+func (oc *OverperformanceCollector) CollectFinalStats(demoStats *DemoStats) {
+	lines := make(map[uint64]lobbyLine)
+	var total lobbyLine
+	for sid, ps := range demoStats.Players {
+		rating, ok := psGetFloat(ps, CatRating, KeyRating2)
+		if !ok {
+			continue
+		}
+		adr, _ := psGetFloat(ps, CatScoreboard, KeyADR)
+		hs, _ := psGetFloat(ps, CatScoreboard, KeyHSPercentage)
+		l := lobbyLine{rating: rating, adr: adr, hs: hs}
+		lines[sid] = l
+		total.rating += l.rating
+		total.adr += l.adr
+		total.hs += l.hs
+	}
+	peers := len(lines) - 1
+	if peers < overperformMinPeers {
+		return
+	}
+
+	for sid, l := range lines {
+		ps := demoStats.Players[sid]
+		n := float64(peers)
+		rating, okRating := relativeTo(l.rating, (total.rating-l.rating)/n)
+		adr, okADR := relativeTo(l.adr, (total.adr-l.adr)/n)
+		hs, okHS := relativeTo(l.hs, (total.hs-l.hs)/n)
+		if okRating {
+			ps.AddMetric(CatPerformance, KeyLobbyRelativeRating, Metric{
+				Type:        MetricFloat,
+				FloatValue:  rating,
+				Description: "Rating over the mean of the rest of the lobby (1.0 = average)",
+			})
+		}
+		if okADR {
+			ps.AddMetric(CatPerformance, KeyLobbyRelativeADR, Metric{
+				Type:        MetricFloat,
+				FloatValue:  adr,
+				Description: "ADR over the mean of the rest of the lobby (1.0 = average)",
+			})
+		}
+		if okHS {
+			ps.AddMetric(CatPerformance, KeyLobbyRelativeHS, Metric{
+				Type:        MetricFloat,
+				FloatValue:  hs,
+				Description: "Headshot percentage over the mean of the rest of the lobby (1.0 = average)",
+			})
+		}
+		flag := "No"
+		if okRating && rating >= overperformRating &&
+			((okADR && adr >= overperformSecondary) || (okHS && hs >= overperformSecondary)) {
+			flag = "Yes"
+		}
+		ps.AddMetric(CatPerformance, KeyOverperformer, Metric{
+			Type:        MetricString,
+			StringValue: flag,
+			Description: "Far above the rest of the lobby — a smurf or boost candidate, not a cheat signal",
+		})
+	}
+}
+
+// relativeTo returns v over mean; ok is false when the mean isn't positive.
+func relativeTo(v, mean float64) (ratio float64, ok bool) {
+	if mean <= 0 {
+		return 0, false
+	}
+	return v / mean, true
+}
@@ -0,0 +1,51 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOverperformanceCollector(t *testing.T) {
+	lobby := func(n int) *DemoStats {
+		ds := NewDemoStats()
+		for sid := uint64(1); sid <= uint64(n); sid++ {
+			ps := ds.GetOrCreatePlayerStatsBySteamID(sid)
+			ps.AddMetric(CatRating, KeyRating2, Metric{Type: MetricFloat, FloatValue: 1.0})
+			ps.AddMetric(CatScoreboard, KeyADR, Metric{Type: MetricFloat, FloatValue: 75})
+			ps.AddMetric(CatScoreboard, KeyHSPercentage, Metric{Type: MetricPercentage, FloatValue: 40})
+		}
+		// Player 1 is the smurf: double the rating and ADR, same HS%.
+		ps := ds.Players[1]
+		ps.AddMetric(CatRating, KeyRating2, Metric{Type: MetricFloat, FloatValue: 2.0})
+		ps.AddMetric(CatScoreboard, KeyADR, Metric{Type: MetricFloat, FloatValue: 150})
+		// Player 2 has no rating (too few rounds) and is left out.
+		delete(ds.Players[2].Categories[CatRating], KeyRating2)
+		return ds
+	}
+
+	ds := lobby(10)
+	NewOverperformanceCollector().CollectFinalStats(ds)
+	smurf := ds.Players[1]
+	if got, _ := psGetFloat(smurf, CatPerformance, KeyLobbyRelativeRating); math.Abs(got-2.0) > 1e-9 {
+		t.Errorf("smurf lobby_relative_rating = %.3f, want 2.0 against the others", got)
+	}
+	if got, _ := psGetFloat(smurf, CatPerformance, KeyLobbyRelativeHS); math.Abs(got-1.0) > 1e-9 {
+		t.Errorf("smurf lobby_relative_hs = %.3f, want 1.0", got)
+	}
+	if got, _ := psGetString(smurf, CatPerformance, KeyOverperformer); got != "Yes" {
+		t.Errorf("smurf overperformer = %q, want Yes", got)
+	}
+	if got, _ := psGetString(ds.Players[3], CatPerformance, KeyOverperformer); got != "No" {
+		t.Errorf("average player overperformer = %q, want No", got)
+	}
+	if _, ok := ds.Players[2].GetMetric(CatPerformance, KeyLobbyRelativeRating); ok {
+		t.Error("unrated player got a lobby-relative rating")
+	}
+
+	// Five rated players are four peers each: too few to compare against.
+	ds = lobby(6)
+	NewOverperformanceCollector().CollectFinalStats(ds)
+	if _, ok := ds.Players[1].GetMetric(CatPerformance, KeyOverperformer); ok {
+		t.Error("small lobby should publish nothing")
+	}
+}
@@ -0,0 +1,177 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+const (
+	// Head-centre height above the feet. CS2 eye height is 64 units standing
+	// and 46 crouched; the head hitbox is centred within a couple of units of
+	// the eyes, well inside placementPitchToleranceDeg at any real range.
+	placementStandHeadZ  = 64.0
+	placementCrouchHeadZ = 46.0
+
+	// placementPitchToleranceDeg is how close the crosshair's pitch must be
+	// to the enemy's head pitch to count as head level.
+	placementPitchToleranceDeg = 1.5
+	// placementYawToleranceDeg is how far off in yaw the crosshair may be
+	// while still "pointed at" the enemy — wide enough to cover holding the
+	// corner the enemy will peek from.
+	placementYawToleranceDeg = 10.0
+
+	// Only enemies in this horizontal range count. Closer than the minimum,
+	// pitch tolerance is a few units and trivially met; beyond the maximum,
+	// the head subtends well under the tolerance and placement is noise.
+	placementMinRange = 250.0
+	placementMaxRange = 2500.0
+
+	// placementMinTicks is the minimum number of eligible ticks before the
+	// ratio is published.
+	placementMinTicks = 200
+)
+
+// PlacementCollector measures vertical crosshair placement against enemies
+// the player cannot see. Each frame, for every alive player with at least
+// one occluded enemy in range (an eligible tick), it checks whether the
+// crosshair sits at that enemy's estimated head height AND points at them in
+// yaw. Wallhackers keep the crosshair pre-aimed at the head of the enemy
+// behind the wall; legit players hold head level at common angles, but only
+// line up with the actual enemy by chance.
+//
+// Occlusion uses the engine's spotted mask (the same LoS source as the
+// reaction collector). Enemy head position is estimated from feet position
+// plus standing / crouched head height.
+//
+// Complements the behavioral pre_fov metric, which measures total angle at
+// one instant before FOV entry; this one isolates the vertical component
+// continuously. Informational — not yet a cheat-score channel.
+type PlacementCollector struct {
+	*BaseCollector
+
+	eligibleTicks map[uint64]int
+	preheadTicks  map[uint64]int
+}
+
+// NewPlacementCollector creates a new PlacementCollector.
+func NewPlacementCollector() *PlacementCollector {
+	return &PlacementCollector{
+		BaseCollector: NewBaseCollector("Crosshair Placement", CatPlacement),
+		eligibleTicks: make(map[uint64]int),
+		preheadTicks:  make(map[uint64]int),
+	}
+}
+
+// CollectFrame accumulates eligible and head-level ticks for every alive player.
+func (pc *PlacementCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	gs := parser.GameState()
+	if gs.IsWarmupPeriod() {
+		return
+	}
+	playing := gs.Participants().Playing()
+
+	for _, player := range playing {
+		if !demoStats.CountsAimPlayer(player) || !player.IsAlive() {
+			continue
+		}
+		eyes := eyePosition(player)
+		yaw := float64(player.ViewDirectionX())
+		pitch := signedPitch(float64(player.ViewDirectionY()))
+
+		eligible, prehead := false, false
+		for _, enemy := range playing {
+			if enemy == nil || !enemy.IsAlive() || enemy.Team == player.Team {
+				continue
+			}
+			if enemy.IsSpottedBy(player) {
+				continue // visible — aiming at them is legitimate
+			}
+			head := headPosition(enemy)
+			dx, dy, dz := head.X-eyes.X, head.Y-eyes.Y, head.Z-eyes.Z
+			horiz := math.Hypot(dx, dy)
+			if horiz < placementMinRange || horiz > placementMaxRange {
+				continue
+			}
+			eligible = true
+
+			// Source pitch is positive looking down.
+			headPitch := -math.Atan2(dz, horiz) * 180.0 / math.Pi
+			headYaw := math.Atan2(dy, dx) * 180.0 / math.Pi
+			if math.Abs(pitch-headPitch) <= placementPitchToleranceDeg &&
+				angleDiffDeg(yaw, headYaw) <= placementYawToleranceDeg {
+				prehead = true
+				break
+			}
+		}
+
+		if !eligible {
+			continue
+		}
+		sid := demoStats.PlayerKey(player)
+		pc.eligibleTicks[sid]++
+		if prehead {
+			pc.preheadTicks[sid]++
+		}
+	}
+}
+
+// eyePosition returns the player's eye position, falling back to feet
+// position plus stance eye height when the pawn eye offset is unavailable.
+func eyePosition(p *common.Player) r3.Vector {
+	if pos, ok := p.PositionEyes(); ok {
+		return pos
+	}
+	return headPosition(p)
+}
+
+// headPosition estimates the head centre from feet position and stance.
+func headPosition(p *common.Player) r3.Vector {
+	pos := p.Position()
+	if p.IsDucking() {
+		pos.Z += placementCrouchHeadZ
+	} else {
+		pos.Z += placementStandHeadZ
+	}
+	return pos
+}
+
+// signedPitch maps ViewDirectionY's 270..90 range onto -90..90.
+func signedPitch(deg float64) float64 {
+	if deg > 180.0 {
+		deg -= 360.0
+	}
+	return deg
+}
+
+// CollectFinalStats publishes prehead_ticks and prehead_ratio once a player
+// has enough eligible ticks.
+func (pc *PlacementCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, eligible := range pc.eligibleTicks {
+		if eligible < placementMinTicks {
+			continue
+		}
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		prehead := pc.preheadTicks[sid]
+		ps.AddMetric(CatPlacement, KeyPreheadTicks, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(prehead),
+			Description: "Ticks with the crosshair at head level on an occluded enemy",
+		})
+		ps.AddMetric(CatPlacement, KeyPlacementEligibleTicks, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(eligible),
+			Description: "Ticks alive with at least one occluded enemy in range",
+		})
+		ps.AddMetric(CatPlacement, KeyPreheadRatio, Metric{
+			Type:        MetricPercentage,
+			FloatValue:  float64(prehead) / float64(eligible) * 100.0,
+			Description: "Share of eligible ticks with the crosshair at an occluded enemy's head level",
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// playerExport is the ExportPlayer document.
+type playerExport struct {
+	SteamID     uint64                                   `json:"steam_id"`
+	Name        string                                   `json:"name"`
+	Demo        string                                   `json:"demo"`
+	Map         string                                   `json:"map"`
+	Likelihood  *float64                                 `json:"cheat_likelihood,omitempty"`
+	Flagged     bool                                     `json:"flagged"`
+	Explanation string                                   `json:"cheat_explanation,omitempty"`
+	Narrative   string                                   `json:"narrative,omitempty"`
+	Categories  map[string]map[string]playerExportMetric `json:"categories"`
+}
+
+type playerExportMetric struct {
+	Type        MetricType `json:"type"`
+	Value       any        `json:"value"`
+	Description string     `json:"description,omitempty"`
+}
+
+// ExportPlayer writes one player's full profile as an indented JSON
+// document: identity, the demo it came from, the cheat verdict with its
+// explanation and narrative, and every metric in every category with its
+// type and description. It is meant for sharing a single suspect's dossier
+// without the rest of the lobby. Values follow the same rules as
+// JSONLinesReporter. It returns an error if steamID isn't in the demo.
+func (ds *DemoStats) ExportPlayer(steamID uint64, w io.Writer) error {
+	ps, ok := ds.Players[steamID]
+	if !ok {
+		return fmt.Errorf("player %d not found in demo", steamID)
+	}
+
+	doc := playerExport{
+		SteamID:    steamID,
+		Name:       ps.Player.Name,
+		Demo:       ds.DemoName,
+		Map:        ds.MapName,
+		Flagged:    psHasYes(ps, KeyCheater),
+		Categories: make(map[string]map[string]playerExportMetric, len(ps.Categories)),
+	}
+	if v, ok := psGetFloat(ps, CatAntiCheat, KeyCheatLikelihood); ok && !math.IsNaN(v) {
+		doc.Likelihood = &v
+		doc.Narrative = buildCheatscoreNarrative(ps)
+	}
+	doc.Explanation, _ = psGetString(ps, CatAntiCheat, KeyCheatExplanation)
+
+	for cat, metrics := range ps.Categories {
+		out := make(map[string]playerExportMetric, len(metrics))
+		for k, m := range metrics {
+			out[string(k)] = playerExportMetric{Type: m.Type, Value: metricJSONValue(m), Description: m.Description}
+		}
+		doc.Categories[string(cat)] = out
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
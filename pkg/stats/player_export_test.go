@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestExportPlayer(t *testing.T) {
+	ds := NewDemoStats()
+	ds.DemoName = "match.dem"
+	ps := ds.GetOrCreatePlayerStatsBySteamID(42)
+	ps.Player.Name = "suspect"
+	ps.AddMetric(Category("anti_cheat"), Key("cheat_likelihood"), Metric{Type: MetricPercentage, FloatValue: 88, Description: "Estimated likelihood of player cheating"})
+	ps.AddMetric(Category("anti_cheat"), Key("cheater"), Metric{Type: MetricString, StringValue: "Yes"})
+	ps.AddMetric(Category("anti_cheat"), Key("cheat_explanation"), Metric{Type: MetricString, StringValue: "Headshot rate (78%, +0.45)"})
+	ps.AddMetric(Category("kills"), Key("total_kills"), Metric{Type: MetricInteger, IntValue: 30, Description: "Total kills"})
+	ds.GetOrCreatePlayerStatsBySteamID(7).Player.Name = "other"
+
+	var buf bytes.Buffer
+	if err := ds.ExportPlayer(42, &buf); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		SteamID     uint64  `json:"steam_id"`
+		Name        string  `json:"name"`
+		Demo        string  `json:"demo"`
+		Likelihood  float64 `json:"cheat_likelihood"`
+		Flagged     bool    `json:"flagged"`
+		Explanation string  `json:"cheat_explanation"`
+		Categories  map[string]map[string]struct {
+			Type        string `json:"type"`
+			Value       any    `json:"value"`
+			Description string `json:"description"`
+		} `json:"categories"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if doc.SteamID != 42 || doc.Name != "suspect" || doc.Demo != "match.dem" {
+		t.Errorf("identity = %+v", doc)
+	}
+	if doc.Likelihood != 88 || !doc.Flagged || doc.Explanation == "" {
+		t.Errorf("verdict = %v %v %q", doc.Likelihood, doc.Flagged, doc.Explanation)
+	}
+	kills := doc.Categories["kills"]["total_kills"]
+	if kills.Type != "integer" || kills.Value != 30.0 || kills.Description != "Total kills" {
+		t.Errorf("total_kills = %+v", kills)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("other")) {
+		t.Error("export contains another player")
+	}
+
+	if err := ds.ExportPlayer(99, &buf); err == nil {
+		t.Error("missing player exported without error")
+	}
+}
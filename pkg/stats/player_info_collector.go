@@ -0,0 +1,155 @@
+package stats
+
+import (
+	"strings"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// PlayerInfoCollector records every distinct name a SteamID used during the
+// demo. PlayerStats keeps a single name, so a mid-match rename — a common
+// evasion move — would otherwise be invisible to moderation.
+//
+// Names are captured both from PlayerNameChange events and by polling the
+// connected participants each frame, since CS2 demos don't reliably emit the
+// event for every rename. Bots are skipped: when a human disconnects the bot
+// that takes over the slot has its own (zero) SteamID and must not leak its
+// name into the human's alias list.
+//
+// The same per-frame pass tracks disconnects: once a SteamID has been seen,
+// the in-game ticks it spends absent from the connected set add up to
+// disconnected_ticks, so analysts know that player's data is partial.
+// Tracking stops when the match ends, since everyone leaves after the final
+// scoreboard and that isn't a drop.
+type PlayerInfoCollector struct {
+	*BaseCollector
+
+	aliases map[uint64][]string // distinct names in first-seen order
+
+	connected         map[uint64]bool // connection state as of the previous frame; key present once seen
+	disconnects       map[uint64]int
+	disconnectedTicks map[uint64]int
+	lastTick          int  // in-game tick of the previous frame
+	matchOver         bool // set once the game phase reaches GameEnded
+}
+
+// NewPlayerInfoCollector creates a new PlayerInfoCollector.
+func NewPlayerInfoCollector() *PlayerInfoCollector {
+	return &PlayerInfoCollector{
+		BaseCollector: NewBaseCollector("Player Info", CatPlayerInfo),
+		aliases:       make(map[uint64][]string),
+
+		connected:         make(map[uint64]bool),
+		disconnects:       make(map[uint64]int),
+		disconnectedTicks: make(map[uint64]int),
+	}
+}
+
+// Setup registers the name-change and match-end handlers.
+func (pic *PlayerInfoCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	parser.RegisterEventHandler(func(e events.GamePhaseChanged) {
+		if e.NewGamePhase == common.GamePhaseGameEnded {
+			pic.matchOver = true
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.PlayerNameChange) {
+		if !isHumanPlayer(e.Player) {
+			return
+		}
+		pic.record(e.Player.SteamID64, e.OldName)
+		pic.record(e.Player.SteamID64, e.NewName)
+	})
+}
+
+// CollectFrame records the current name of every connected human and
+// accumulates disconnected time for humans seen earlier but absent now.
+func (pic *PlayerInfoCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	present := make(map[uint64]bool)
+	for _, player := range parser.GameState().Participants().Connected() {
+		if !demoStats.CountsPlayer(player) {
+			continue
+		}
+		sid := demoStats.PlayerKey(player)
+		present[sid] = true
+		pic.record(sid, player.Name)
+	}
+	if pic.matchOver {
+		return
+	}
+
+	tick := parser.GameState().IngameTick()
+	elapsed := tick - pic.lastTick
+	if pic.lastTick <= 0 || elapsed < 0 {
+		elapsed = 0
+	}
+	pic.lastTick = tick
+
+	for sid, wasConnected := range pic.connected {
+		if present[sid] {
+			continue
+		}
+		if wasConnected {
+			pic.disconnects[sid]++
+		}
+		pic.disconnectedTicks[sid] += elapsed
+		pic.connected[sid] = false
+	}
+	for sid := range present {
+		pic.connected[sid] = true
+	}
+}
+
+func (pic *PlayerInfoCollector) record(sid uint64, name string) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return
+	}
+	names := pic.aliases[sid]
+	for _, n := range names {
+		if n == name {
+			return
+		}
+	}
+	pic.aliases[sid] = append(names, name)
+}
+
+// CollectFinalStats publishes the alias list for every player seen and the
+// disconnect counters for anyone who dropped mid-demo.
+func (pic *PlayerInfoCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, names := range pic.aliases {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		ps.AddMetric(CatPlayerInfo, KeyAliases, Metric{
+			Type:        MetricString,
+			StringValue: strings.Join(names, ", "),
+			Description: "Every distinct name this SteamID used during the demo, in first-seen order",
+		})
+		ps.AddMetric(CatPlayerInfo, KeyNameChanges, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(len(names) - 1),
+			Description: "Number of distinct renames observed",
+		})
+	}
+
+	for sid, n := range pic.disconnects {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		ps.AddMetric(CatPlayerInfo, KeyDisconnects, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(n),
+			Description: "Times the player dropped from the server mid-demo; their stats only cover connected time",
+		})
+		ps.AddMetric(CatPlayerInfo, KeyDisconnectedTicks, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(pic.disconnectedTicks[sid]),
+			Description: "In-game ticks the player was disconnected after first joining, up to the end of the match",
+		})
+	}
+}
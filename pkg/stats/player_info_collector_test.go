@@ -0,0 +1,75 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// connectedStubParser runs two demo frames per in-game tick and reports a
+// settable set of connected players.
+type connectedStubParser struct {
+	*warmupStubParser
+	connected []*common.Player
+}
+
+type connectedStubState struct {
+	warmupStubState
+	connected []*common.Player
+}
+
+type connectedStub struct {
+	demoinfocs.Participants
+	connected []*common.Player
+}
+
+func (p *connectedStubParser) GameState() demoinfocs.GameState {
+	return connectedStubState{warmupStubState{p: p.warmupStubParser}, p.connected}
+}
+func (s connectedStubState) IngameTick() int { return s.p.frame / 2 }
+func (s connectedStubState) Participants() demoinfocs.Participants {
+	return connectedStub{connected: s.connected}
+}
+func (c connectedStub) Connected() []*common.Player { return c.connected }
+
+func TestPlayerInfoDisconnectedTicks(t *testing.T) {
+	stay := &common.Player{SteamID64: 1, Name: "stay"}
+	drop := &common.Player{SteamID64: 2, Name: "drop"}
+	parser := &connectedStubParser{warmupStubParser: &warmupStubParser{}, connected: []*common.Player{stay, drop}}
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStats(stay)
+	ds.GetOrCreatePlayerStats(drop)
+
+	pic := NewPlayerInfoCollector()
+	pic.Setup(parser, ds)
+	run := func(frames int) {
+		for i := 0; i < frames; i++ {
+			parser.advance()
+			pic.CollectFrame(parser, ds)
+		}
+	}
+
+	run(20)
+	parser.connected = []*common.Player{stay}
+	run(40) // 20 in-game ticks away
+	parser.connected = []*common.Player{stay, drop}
+	run(20)
+
+	// Everyone leaves after the final scoreboard
+	parser.dispatch(events.GamePhaseChanged{OldGamePhase: common.GamePhaseStartGamePhase, NewGamePhase: common.GamePhaseGameEnded})
+	parser.connected = nil
+	run(40)
+	pic.CollectFinalStats(ds)
+
+	if got, _ := psGetInt(ds.Players[2], CatPlayerInfo, KeyDisconnects); got != 1 {
+		t.Errorf("disconnects = %d, want 1", got)
+	}
+	if got, _ := psGetInt(ds.Players[2], CatPlayerInfo, KeyDisconnectedTicks); got != 20 {
+		t.Errorf("disconnected_ticks = %d, want 20 in-game ticks", got)
+	}
+	if _, ok := ds.Players[1].GetMetric(CatPlayerInfo, KeyDisconnects); ok {
+		t.Error("leaving after the match ended counted as a disconnect")
+	}
+}
@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// prometheusNamespace is the metric-name syntax a namespace has to follow.
+var prometheusNamespace = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// PrometheusReporter writes every numeric player metric as a sample of one
+// gauge family in the Prometheus text exposition format, for a textfile
+// collector or a pushgateway to pick up:
+//
+//	demo_anticheat_player_metric{steam_id="…",player_name="…",map="de_mirage",category="anti_cheat",key="cheat_likelihood"} 72.5
+//
+// String metrics are left out; durations are written in seconds and
+// percentages as their 0–100 value.
+type PrometheusReporter struct {
+	namespace string
+}
+
+// NewPrometheusReporter creates a PrometheusReporter whose family is named
+// namespace_player_metric. It returns an error if namespace isn't a valid
+// Prometheus metric-name prefix.
+func NewPrometheusReporter(namespace string) (*PrometheusReporter, error) {
+	if namespace != "" && !prometheusNamespace.MatchString(namespace) {
+		return nil, fmt.Errorf("prometheus: invalid namespace %q", namespace)
+	}
+	return &PrometheusReporter{namespace: namespace}, nil
+}
+
+// Extension returns "prom", the suffix node_exporter's textfile collector
+// reads.
+func (pr *PrometheusReporter) Extension() string { return "prom" }
+
+// metricName returns the gauge family name.
+func (pr *PrometheusReporter) metricName() string {
+	if pr.namespace == "" {
+		return "player_metric"
+	}
+	return pr.namespace + "_player_metric"
+}
+
+// Report writes the family header and one sample per numeric metric,
+// ordered by SteamID, category and key. When categories is non-empty only
+// those categories are written.
+func (pr *PrometheusReporter) Report(demoStats *DemoStats, categories []Category, writer io.Writer) error {
+	want := make(map[Category]bool, len(categories))
+	for _, c := range categories {
+		want[c] = true
+	}
+	name := pr.metricName()
+	w := bufio.NewWriter(writer)
+	fmt.Fprintf(w, "# HELP %s Per-player demo analysis metric.\n", name)
+	fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+	for _, sid := range sortedSteamIDs(demoStats) {
+		ps := demoStats.Players[sid]
+		cats := make([]Category, 0, len(ps.Categories))
+		for cat := range ps.Categories {
+			if len(want) == 0 || want[cat] {
+				cats = append(cats, cat)
+			}
+		}
+		sort.Slice(cats, func(i, j int) bool { return cats[i] < cats[j] })
+		for _, cat := range cats {
+			keys := make([]Key, 0, len(ps.Categories[cat]))
+			for k := range ps.Categories[cat] {
+				keys = append(keys, k)
+			}
+			sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+			for _, k := range keys {
+				v, ok := prometheusValue(ps.Categories[cat][k])
+				if !ok {
+					continue
+				}
+				fmt.Fprintf(w, "%s{steam_id=\"%d\",player_name=\"%s\",map=\"%s\",category=\"%s\",key=\"%s\"} %s\n",
+					name, sid, escapeLabel(ps.Player.Name), escapeLabel(demoStats.MapName),
+					escapeLabel(string(cat)), escapeLabel(string(k)), v)
+			}
+		}
+	}
+	return w.Flush()
+}
+
+// prometheusValue formats a metric's numeric value, or returns ok=false for
+// string metrics.
+func prometheusValue(m Metric) (string, bool) {
+	var f float64
+	switch m.Type {
+	case MetricInteger, MetricCount:
+		return strconv.FormatInt(m.IntValue, 10), true
+	case MetricFloat, MetricPercentage:
+		f = m.FloatValue
+	case MetricDuration:
+		f = m.DurationValue.Seconds()
+	default:
+		return "", false
+	}
+	switch {
+	case math.IsNaN(f):
+		return "NaN", true
+	case math.IsInf(f, 1):
+		return "+Inf", true
+	case math.IsInf(f, -1):
+		return "-Inf", true
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64), true
+}
+
+// labelEscaper escapes a label value as the exposition format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
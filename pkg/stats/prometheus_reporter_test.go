@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusReporter(t *testing.T) {
+	ds := NewDemoStats()
+	ds.MapName = "de_mirage"
+	p := ds.GetOrCreatePlayerStatsBySteamID(7)
+	p.Player.Name = `say "hi"`
+	p.AddMetric(CatAntiCheat, KeyCheatLikelihood, Metric{Type: MetricPercentage, FloatValue: 72.5})
+	p.AddMetric(CatKills, KeyTotalKills, Metric{Type: MetricInteger, IntValue: 17})
+	p.AddMetric(CatTTK, KeyTimeToKillMs, Metric{Type: MetricDuration, DurationValue: 250 * time.Millisecond})
+	p.AddMetric(CatAiming, KeyP95SnapVelocity, Metric{Type: MetricFloat, FloatValue: math.NaN()})
+	p.AddMetric(CatKills, KeyGrade, Metric{Type: MetricString, StringValue: "A"})
+
+	pr, err := NewPrometheusReporter("demo_anticheat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := pr.Report(ds, nil, &buf); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	out := buf.String()
+	labels := `steam_id="7",player_name="say \"hi\"",map="de_mirage"`
+	for _, want := range []string{
+		"# TYPE demo_anticheat_player_metric gauge\n",
+		`demo_anticheat_player_metric{` + labels + `,category="anti_cheat",key="cheat_likelihood"} 72.5` + "\n",
+		`demo_anticheat_player_metric{` + labels + `,category="kills",key="total_kills"} 17` + "\n",
+		`demo_anticheat_player_metric{` + labels + `,category="ttk",key="time_to_kill_ms"} 0.25` + "\n",
+		`demo_anticheat_player_metric{` + labels + `,category="aiming",key="p95_snap_velocity"} NaN` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `key="grade"`) {
+		t.Errorf("string metric written:\n%s", out)
+	}
+
+	buf.Reset()
+	if err := pr.Report(ds, []Category{CatKills}, &buf); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	if strings.Contains(buf.String(), "anti_cheat") {
+		t.Errorf("category filter ignored:\n%s", buf.String())
+	}
+
+	if _, err := NewPrometheusReporter("demo-anticheat"); err == nil {
+		t.Error("invalid namespace accepted")
+	}
+}
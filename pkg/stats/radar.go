@@ -0,0 +1,34 @@
+package stats
+
+// RadarAxes are the axes of RadarProfile, in the order a chart should lay
+// them out. Each is a cheat-score channel:
+//
+//   - snap: precise-snap velocity
+//   - reaction: time from first sight to damage
+//   - recoil: spray control against the weapon's pattern
+//   - hs: headshot rate
+//   - blind_tracking: kills tracked through walls or smokes without line
+//     of sight — the wall axis
+//
+// The set only grows; an axis is never renamed or dropped.
+var RadarAxes = []string{"snap", "reaction", "recoil", "hs", "blind_tracking"}
+
+// RadarProfile returns steamID's score on each of RadarAxes, 0 (clean) to 1
+// (blatant), as the cheat detector published it, for a radar chart. Every
+// axis is present: one the player has no data for reads 0. It returns nil
+// when the player isn't in the demo or hasn't been scored.
+func (ds *DemoStats) RadarProfile(steamID uint64) map[string]float64 {
+	ps, ok := ds.Players[steamID]
+	if !ok {
+		return nil
+	}
+	if _, scored := ps.GetMetric(CatAntiCheat, KeyCheatLikelihood); !scored {
+		return nil
+	}
+	out := make(map[string]float64, len(RadarAxes))
+	for _, axis := range RadarAxes {
+		score, _ := psGetFloat(ps, CatAntiCheat, channelScoreKey(axis))
+		out[axis] = clamp01(score)
+	}
+	return out
+}
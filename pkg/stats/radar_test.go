@@ -0,0 +1,30 @@
+package stats
+
+import "testing"
+
+func TestRadarProfile(t *testing.T) {
+	ds := NewDemoStats()
+	ps := ds.GetOrCreatePlayerStatsBySteamID(1)
+	ps.AddMetric(CatAntiCheat, KeyCheatLikelihood, Metric{Type: MetricPercentage, FloatValue: 64})
+	ps.AddMetric(CatAntiCheat, Key("snap_score"), Metric{Type: MetricFloat, FloatValue: 0.8})
+	ps.AddMetric(CatAntiCheat, Key("blind_tracking_score"), Metric{Type: MetricFloat, FloatValue: 0.5})
+	ds.GetOrCreatePlayerStatsBySteamID(2)
+
+	got := ds.RadarProfile(1)
+	if len(got) != len(RadarAxes) {
+		t.Fatalf("profile has %d axes, want %d: %v", len(got), len(RadarAxes), got)
+	}
+	want := map[string]float64{"snap": 0.8, "reaction": 0, "recoil": 0, "hs": 0, "blind_tracking": 0.5}
+	for axis, v := range want {
+		if got[axis] != v {
+			t.Errorf("%s = %v, want %v", axis, got[axis], v)
+		}
+	}
+
+	if got := ds.RadarProfile(2); got != nil {
+		t.Errorf("unscored player: %v, want nil", got)
+	}
+	if got := ds.RadarProfile(99); got != nil {
+		t.Errorf("unknown player: %v, want nil", got)
+	}
+}
@@ -0,0 +1,85 @@
+package stats
+
+// ratingMinRounds is the number of rounds a player must have played before
+// rating_2 is published; a rating over a couple of rounds is noise.
+const ratingMinRounds = 5
+
+// RatingCoefficients are the weights of the rating_2 formula:
+//
+//	impact   = ImpactKPR·KPR + ImpactAPR·APR + ImpactIntercept
+//	rating_2 = KAST·KAST% + KPR·KPR + DPR·DPR + Impact·impact + ADR·ADR + Intercept
+//
+// where KPR, DPR and APR are kills, deaths and assists per round, KAST% is
+// the share of rounds with a kill, assist, survival or traded death (0–100)
+// and ADR is damage per round. Deaths per round is the survival term: its
+// coefficient is negative, so dying less raises the rating.
+type RatingCoefficients struct {
+	KAST, KPR, DPR, Impact, ADR, Intercept float64
+	ImpactKPR, ImpactAPR, ImpactIntercept  float64
+}
+
+// DefaultRatingCoefficients returns the community fit of HLTV's Rating 2.0,
+// which puts an average player at about 1.0.
+func DefaultRatingCoefficients() RatingCoefficients {
+	return RatingCoefficients{
+		KAST:            0.0073,
+		KPR:             0.3591,
+		DPR:             -0.5329,
+		Impact:          0.2372,
+		ADR:             0.0032,
+		Intercept:       0.1587,
+		ImpactKPR:       2.13,
+		ImpactAPR:       0.42,
+		ImpactIntercept: -0.41,
+	}
+}
+
+// RatingCollector computes an HLTV-style impact rating from the scoreboard's
+// kills, deaths, assists, ADR and KAST. It only reads final metrics, so it
+// must be registered after the ScoreboardCollector.
+type RatingCollector struct {
+	*BaseCollector
+
+	// Coefficients weight the rating formula; see RatingCoefficients.
+	Coefficients RatingCoefficients
+}
+
+// NewRatingCollector creates a RatingCollector with the default
+// coefficients.
+func NewRatingCollector() *RatingCollector {
+	return &RatingCollector{
+		BaseCollector: NewBaseCollector("Rating", CatRating),
+		Coefficients:  DefaultRatingCoefficients(),
+	}
+}
+
+// CollectFinalStats publishes rating_2 for every player with at least
+// ratingMinRounds rounds played.
+func (rc *RatingCollector) CollectFinalStats(demoStats *DemoStats) {
+	for _, ps := range demoStats.Players {
+		rounds := intMetric(ps, CatScoreboard, KeyRoundsPlayed)
+		if rounds < ratingMinRounds {
+			continue
+		}
+		kast, _ := psGetFloat(ps, CatScoreboard, KeyKAST)
+		adr, _ := psGetFloat(ps, CatScoreboard, KeyADR)
+		ps.AddMetric(CatRating, KeyRating2, Metric{
+			Type: MetricFloat,
+			FloatValue: rc.Coefficients.rating(
+				intMetric(ps, CatScoreboard, KeyKills),
+				intMetric(ps, CatScoreboard, KeyDeaths),
+				intMetric(ps, CatScoreboard, KeyAssists),
+				rounds, kast, adr,
+			),
+			Description: "HLTV-style rating from KAST, kills, deaths, assists and ADR (1.0 ≈ average)",
+		})
+	}
+}
+
+// rating evaluates the formula for one player's totals.
+func (c RatingCoefficients) rating(kills, deaths, assists, rounds int64, kastPct, adr float64) float64 {
+	n := float64(rounds)
+	kpr, dpr, apr := float64(kills)/n, float64(deaths)/n, float64(assists)/n
+	impact := c.ImpactKPR*kpr + c.ImpactAPR*apr + c.ImpactIntercept
+	return c.KAST*kastPct + c.KPR*kpr + c.DPR*dpr + c.Impact*impact + c.ADR*adr + c.Intercept
+}
@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestKASTTracker(t *testing.T) {
+	k := newKASTTracker()
+
+	// 1 and 2 play 3 and 4. Round 1: 3 kills 1, 2 trades him, 4 kills 2 and
+	// survives. Everyone earns KAST — 1 through the trade.
+	k.roundStart()
+	k.kill(3, 1, 0, 10*time.Second)
+	k.kill(2, 3, 0, 12*time.Second)
+	k.kill(4, 2, 0, 30*time.Second)
+	k.roundEnd([]uint64{1, 2, 3, 4}, []uint64{4})
+
+	// Round 2: 1 kills 4 with 2's assist; 4 is the only one without KAST.
+	k.roundStart()
+	k.kill(1, 4, 2, 100*time.Second)
+	k.roundEnd([]uint64{1, 2, 3, 4}, []uint64{1, 2, 3})
+
+	got := k.percentages()
+	want := map[uint64]float64{1: 100, 2: 100, 3: 100, 4: 50}
+	for sid, w := range want {
+		if got[sid] != w {
+			t.Errorf("player %d KAST = %.0f%%, want %.0f%%", sid, got[sid], w)
+		}
+	}
+}
+
+func TestKASTTradeWindow(t *testing.T) {
+	k := newKASTTracker()
+	k.roundStart()
+	k.kill(2, 1, 0, 10*time.Second)
+	k.kill(3, 2, 0, 10*time.Second+kastTradeWindow+time.Millisecond)
+	k.roundEnd([]uint64{1}, nil)
+	if got := k.percentages()[1]; got != 0 {
+		t.Errorf("late trade counted: KAST = %.0f%%", got)
+	}
+}
+
+func TestRatingCollector(t *testing.T) {
+	ds := NewDemoStats()
+	add := func(sid uint64, kills, deaths, assists, rounds int64, kast, adr float64) {
+		ps := ds.GetOrCreatePlayerStatsBySteamID(sid)
+		for k, v := range map[Key]int64{"kills": kills, "deaths": deaths, "assists": assists, "rounds_played": rounds} {
+			ps.AddMetric(CatScoreboard, k, Metric{Type: MetricInteger, IntValue: v})
+		}
+		ps.AddMetric(CatScoreboard, Key("kast"), Metric{Type: MetricPercentage, FloatValue: kast})
+		ps.AddMetric(CatScoreboard, Key("adr"), Metric{Type: MetricFloat, FloatValue: adr})
+	}
+	add(1, 17, 17, 4, 24, 70, 75) // an average line
+	add(2, 35, 12, 6, 24, 85, 120)
+	add(3, 2, 2, 0, 3, 70, 75) // too few rounds
+
+	rc := NewRatingCollector()
+	rc.CollectFinalStats(ds)
+
+	avg, ok := psGetFloat(ds.Players[1], CatRating, Key("rating_2"))
+	if !ok || math.Abs(avg-1.0) > 0.1 {
+		t.Errorf("average player rating = %.3f, want ≈1.0", avg)
+	}
+	star, _ := psGetFloat(ds.Players[2], CatRating, Key("rating_2"))
+	if star < 1.4 {
+		t.Errorf("star rating = %.3f, want well above average", star)
+	}
+	if _, ok := ds.Players[3].GetMetric(CatRating, Key("rating_2")); ok {
+		t.Error("rating published under ratingMinRounds")
+	}
+
+	rc.Coefficients = RatingCoefficients{Intercept: 2}
+	rc.CollectFinalStats(ds)
+	if got, _ := psGetFloat(ds.Players[1], CatRating, Key("rating_2")); got != 2 {
+		t.Errorf("custom coefficients: rating = %v, want 2", got)
+	}
+}
@@ -0,0 +1,105 @@
+package stats
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// PlayerSamples holds the individual measurements behind a player's
+// published percentiles, in the order they were taken. Past the collectors'
+// sample cap (see DefaultMaxSamples) they are a uniform random subset.
+type PlayerSamples struct {
+	SnapVelocities []float64 // °/ms, one per snap before a kill
+	ReactionTimes  []float64 // ms, one per engagement (time-to-damage)
+	RecoilErrors   []float64 // degrees, one per counted spray bullet
+}
+
+// RawSampler is implemented by collectors that can retain their raw
+// samples. Retention is off by default because a long demo holds tens of
+// thousands of them; KeepRawSamples must be called before Setup. Retained
+// samples are capped like the collectors' own; see SampleLimiter.
+type RawSampler interface {
+	KeepRawSamples()
+	// AppendRawSamples adds this collector's samples to samples, creating
+	// entries as needed.
+	AppendRawSamples(samples map[uint64]*PlayerSamples)
+}
+
+// playerSamples returns the entry for steamID, creating it if needed.
+func playerSamples(samples map[uint64]*PlayerSamples, steamID uint64) *PlayerSamples {
+	ps, ok := samples[steamID]
+	if !ok {
+		ps = &PlayerSamples{}
+		samples[steamID] = ps
+	}
+	return ps
+}
+
+// RawSampleReporter writes raw samples as long-format CSV:
+//
+//	demo,steam_id,name,kind,index,value
+//
+// kind is snap_velocity, reaction_time_ms or recoil_error_deg; index is the
+// sample's position in the player's sequence. Rows are ordered by SteamID,
+// then kind, then index. Names come from the DemoStats passed to Report.
+type RawSampleReporter struct {
+	samples map[uint64]PlayerSamples
+}
+
+// NewRawSampleReporter creates a RawSampleReporter for samples, usually
+// analyzer.Results.RawSamples.
+func NewRawSampleReporter(samples map[uint64]PlayerSamples) *RawSampleReporter {
+	return &RawSampleReporter{samples: samples}
+}
+
+// Extension returns "csv".
+func (rr *RawSampleReporter) Extension() string { return "csv" }
+
+// Report writes the header and one row per sample. The categories argument
+// is accepted for Reporter compatibility but unused.
+func (rr *RawSampleReporter) Report(demoStats *DemoStats, _ []Category, writer io.Writer) error {
+	w := csv.NewWriter(writer)
+	if err := w.Write([]string{"demo", "steam_id", "name", "kind", "index", "value"}); err != nil {
+		return err
+	}
+
+	ids := make([]uint64, 0, len(rr.samples))
+	for sid := range rr.samples {
+		ids = append(ids, sid)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, sid := range ids {
+		name := ""
+		if ps, ok := demoStats.Players[sid]; ok {
+			name = ps.Player.Name
+		}
+		s := rr.samples[sid]
+		for _, series := range []struct {
+			kind   string
+			values []float64
+		}{
+			{"snap_velocity", s.SnapVelocities},
+			{"reaction_time_ms", s.ReactionTimes},
+			{"recoil_error_deg", s.RecoilErrors},
+		} {
+			for i, v := range series.values {
+				if err := w.Write([]string{
+					demoStats.DemoName,
+					strconv.FormatUint(sid, 10),
+					name,
+					series.kind,
+					strconv.Itoa(i),
+					strconv.FormatFloat(v, 'f', -1, 64),
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
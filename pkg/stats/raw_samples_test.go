@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRawSamplesRetention(t *testing.T) {
+	rtc := NewReactionTimeCollector()
+	addSample(rtc.ttds, 5, 300, 0)
+	none := map[uint64]*PlayerSamples{}
+	rtc.AppendRawSamples(none)
+	if len(none) != 0 {
+		t.Fatal("samples retained without KeepRawSamples")
+	}
+
+	rtc.KeepRawSamples()
+	addSample(rtc.rawTTDs, 5, 300, 0)
+	addSample(rtc.rawTTDs, 5, 120, 0)
+	sac := NewSnapAngleCollector()
+	sac.KeepRawSamples()
+	addSample(sac.rawSnaps, 5, 2.5, 0)
+
+	got := map[uint64]*PlayerSamples{}
+	for _, rs := range []RawSampler{rtc, sac} {
+		rs.AppendRawSamples(got)
+	}
+	if s := got[5]; s == nil || len(s.ReactionTimes) != 2 || s.ReactionTimes[0] != 300 || len(s.SnapVelocities) != 1 {
+		t.Errorf("merged samples = %+v", got[5])
+	}
+}
+
+func TestRawSampleReporter(t *testing.T) {
+	ds := NewDemoStats()
+	ds.DemoName = "match.dem"
+	ds.GetOrCreatePlayerStatsBySteamID(9).Player.Name = "nine"
+	samples := map[uint64]PlayerSamples{
+		9: {ReactionTimes: []float64{250.5}, RecoilErrors: []float64{0.4, 0.6}},
+		3: {SnapVelocities: []float64{1.25}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewRawSampleReporter(samples).Report(ds, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"demo,steam_id,name,kind,index,value",
+		"match.dem,3,,snap_velocity,0,1.25",
+		"match.dem,9,nine,reaction_time_ms,0,250.5",
+		"match.dem,9,nine,recoil_error_deg,0,0.4",
+		"match.dem,9,nine,recoil_error_deg,1,0.6",
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
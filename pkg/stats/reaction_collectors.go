@@ -1,7 +1,8 @@
 package stats
 
 import (
-	"sort"
+	"fmt"
+	"time"
 
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
@@ -10,7 +11,8 @@ import (
 
 // ReactionTimeCollector measures Time-To-Damage (TTD): the duration from when
 // an enemy first becomes visible to the attacker (CS engine line-of-sight via
-// m_bSpottedByMask) to the first damage that attacker deals to them. Matches
+// m_bSpottedByMask) to the first damage that attacker deals to them, within
+// one engagement (see EngagementTracker). Matches
 // Leetify's definition — composite of reaction + crosshair adjustment + fire
 // rate + accuracy. NOT pure cognitive reaction time.
 //
@@ -27,21 +29,96 @@ import (
 //     assistance, since human reaction floor alone is ~150 ms.
 //
 // Engagements >1000 ms are dropped (trigger-discipline / re-engagement plays).
+// Damage before the first sighting (wallbangs, spam) opens the engagement but
+// yields no sample; the first hit after the victim becomes visible does.
 type ReactionTimeCollector struct {
 	*BaseCollector
 
-	// engagements[attackerSID][victimSID] is the current engagement record.
-	// An engagement begins when the victim first enters the attacker's FOV
-	// cone, persists across brief cone exits (≤ reactionGraceMs), and ends if
-	// the victim is out for longer than that — at which point the next entry
-	// starts a fresh engagement.
-	engagements map[uint64]map[uint64]*engagement
+	// engagements is the shared fight definition; a TTD sample is the time
+	// from an engagement's first sighting to the attacker's first damage
+	// after it. Collectors used outside the Analyzer run a private tracker.
+	engagements *EngagementTracker
+	ownTracker  bool
 
-	// ttds[playerSID] = list of TTD samples (in ms).
-	ttds map[uint64][]float64
+	// sampled marks engagements that already produced a TTD sample.
+	sampled map[*Engagement]bool
 
-	currentTick int
-	tickRate    float64
+	// ttds[playerSID] = TTD samples (in ms), capped at maxSamples; sub100
+	// counts every fast sample (see ReactionThresholds) so the ratio stays
+	// exact.
+	ttds       map[uint64]*sampleReservoir
+	sub100     map[uint64]int
+	maxSamples int
+
+	// rawTTDs keeps the samples in order when raw samples are enabled.
+	keepRaw bool
+	rawTTDs map[uint64]*sampleReservoir
+
+	// Thresholds are the cutoffs samples are counted and scored against;
+	// see ReactionThresholds.
+	Thresholds ReactionThresholds
+
+	tickRate float64
+}
+
+// ReactionThresholds are the time-to-damage cutoffs ReactionTimeCollector
+// works with, so they can be tuned for populations where the defaults are
+// too lenient or too strict. Fields at 0 or less keep their default.
+//
+//   - FastMs: samples at or under it count toward sub_100ms_ttd (the key
+//     keeps its name) and are logged as suspicious events; the round
+//     heatmap counts the same way. Default 100.
+//   - ScoreCleanMs, ScoreBlatantMs: the P10 time-to-damage at which
+//     reaction_cheat_score is 0 and 1. Defaults 400 and 100.
+//
+// The cheat detector reads the sub_100ms_ttd share the collector publishes,
+// so it always agrees with FastMs.
+type ReactionThresholds struct {
+	FastMs                       float64
+	ScoreCleanMs, ScoreBlatantMs float64
+}
+
+// DefaultReactionThresholds returns the built-in cutoffs.
+func DefaultReactionThresholds() ReactionThresholds {
+	return ReactionThresholds{FastMs: 100, ScoreCleanMs: 400, ScoreBlatantMs: 100}
+}
+
+// withDefaults fills the unset fields of t from DefaultReactionThresholds.
+func (t ReactionThresholds) withDefaults() ReactionThresholds {
+	d := DefaultReactionThresholds()
+	if t.FastMs <= 0 {
+		t.FastMs = d.FastMs
+	}
+	if t.ScoreCleanMs <= 0 {
+		t.ScoreCleanMs = d.ScoreCleanMs
+	}
+	if t.ScoreBlatantMs <= 0 {
+		t.ScoreBlatantMs = d.ScoreBlatantMs
+	}
+	return t
+}
+
+// fast reports whether a sample of ms counts toward sub_100ms_ttd.
+func (t ReactionThresholds) fast(ms float64) bool {
+	return ms <= t.FastMs
+}
+
+// fastPercent is the share of the ascending samples s that are fast, in
+// percent.
+func (t ReactionThresholds) fastPercent(s []float64) float64 {
+	n := 0
+	for _, v := range s {
+		if !t.fast(v) {
+			break
+		}
+		n++
+	}
+	return float64(n) / float64(len(s)) * 100.0
+}
+
+// score maps a P10 time-to-damage onto reaction_cheat_score.
+func (t ReactionThresholds) score(p10 float64) float64 {
+	return linearScore(p10, t.ScoreCleanMs, t.ScoreBlatantMs)
 }
 
 const (
@@ -50,10 +127,6 @@ const (
 	// disengaged and re-engaged later, which isn't a single "TTD".
 	reactionMaxEngagementMs = 1000.0
 
-	// reactionGraceMs is how long LoS can drop before the current engagement
-	// ends. Brief visibility flickers between ticks shouldn't reset the entry.
-	reactionGraceMs = 200.0
-
 	// reactionMinSamples is the minimum number of TTD samples required to
 	// produce stable per-player percentiles. Wingman 2v2 demos run short and
 	// produce few engagements per player, so we accept 3 — below that the
@@ -61,26 +134,39 @@ const (
 	reactionMinSamples = 3
 )
 
-// engagement tracks one continuous sighting of a victim by an attacker.
-// entryTick is set when the engagement starts; seenTick refreshes every frame
-// the victim is in cone. If seenTick falls more than reactionGraceMs behind
-// the current tick, the engagement is considered over and the next FOV entry
-// starts a new one.
-type engagement struct {
-	entryTick int
-	seenTick  int
-	damaged   bool
-}
-
 func NewReactionTimeCollector() *ReactionTimeCollector {
 	return &ReactionTimeCollector{
-		BaseCollector: NewBaseCollector("Reaction Time Analysis", Category("reaction")),
-		engagements:   make(map[uint64]map[uint64]*engagement),
-		ttds:          make(map[uint64][]float64),
+		BaseCollector: NewBaseCollector("Reaction Time Analysis", CatReaction),
+		sampled:       make(map[*Engagement]bool),
+		ttds:          make(map[uint64]*sampleReservoir),
+		sub100:        make(map[uint64]int),
+		maxSamples:    DefaultMaxSamples,
+		Thresholds:    DefaultReactionThresholds(),
 	}
 }
 
+// UseEngagements makes the collector sample fights from a shared tracker.
+func (rtc *ReactionTimeCollector) UseEngagements(t *EngagementTracker) {
+	rtc.engagements = t
+	rtc.ownTracker = false
+}
+
 func (rtc *ReactionTimeCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	if rtc.engagements == nil {
+		rtc.engagements = NewEngagementTracker(DefaultEngagementConfig())
+		rtc.ownTracker = true
+	}
+	if rtc.ownTracker {
+		rtc.engagements.Setup(parser, demoStats)
+	}
+	rtc.Thresholds = rtc.Thresholds.withDefaults()
+	// The round heatmap reads the cutoff back, including from saved reports
+	demoStats.AddMetric(CatGameInfo, KeyFastTTDMs, Metric{
+		Type:        MetricFloat,
+		FloatValue:  rtc.Thresholds.FastMs,
+		Description: "Time-to-damage cutoff in ms for sub_100ms_ttd",
+	})
+
 	rtc.tickRate = parser.TickRate()
 	if rtc.tickRate <= 0 {
 		rtc.tickRate = 64.0
@@ -92,115 +178,82 @@ func (rtc *ReactionTimeCollector) Setup(parser demoinfocs.Parser, demoStats *Dem
 	})
 
 	parser.RegisterEventHandler(func(e events.PlayerHurt) {
-		rtc.processDamage(e, demoStats)
+		if inWarmup(parser) {
+			return
+		}
+		now := demoTime(parser, rtc.tickRate)
+		if e.Attacker != nil {
+			now = rtc.engagements.HitTime(demoStats.PlayerKey(e.Attacker), parser.CurrentFrame(), now)
+		}
+		rtc.processDamage(e, now, currentRound(parser), parser.GameState().IngameTick(), demoStats)
 	})
 
 	parser.RegisterEventHandler(func(_ events.RoundEnd) {
-		rtc.engagements = make(map[uint64]map[uint64]*engagement)
+		rtc.sampled = make(map[*Engagement]bool)
 	})
-
-	parser.RegisterEventHandler(func(e events.Kill) {
-		if e.Victim != nil {
-			rtc.clearForPlayer(e.Victim.SteamID64)
-		}
-		if e.Killer != nil {
-			rtc.clearForPlayer(e.Killer.SteamID64)
-		}
+	onWarmupEnd(parser, func() {
+		rtc.sampled = make(map[*Engagement]bool)
 	})
 }
 
 // processDamage records a TTD sample when the attacker first damages a victim
-// during the current engagement (i.e. while that victim is being tracked as
-// in-FOV since some entry tick).
-func (rtc *ReactionTimeCollector) processDamage(e events.PlayerHurt, demoStats *DemoStats) {
+// they have seen during the current engagement. now is the damage event's
+// in-game time, at sub-tick precision when the shot's time is known; tick
+// is its in-game tick, for the evidence bundle.
+func (rtc *ReactionTimeCollector) processDamage(e events.PlayerHurt, now time.Duration, round, tick int, demoStats *DemoStats) {
 	if e.Attacker == nil || e.Player == nil {
 		return
 	}
-	if e.Attacker.SteamID64 == 0 || e.Player.SteamID64 == 0 {
+	if e.Attacker.Team == e.Player.Team || !demoStats.CountsAimPlayer(e.Attacker) {
 		return
 	}
-	if e.Attacker.Team == e.Player.Team {
-		return
-	}
-
-	attackerID := e.Attacker.SteamID64
-	victimID := e.Player.SteamID64
 
-	victims, ok := rtc.engagements[attackerID]
-	if !ok {
-		return
-	}
-	eng, ok := victims[victimID]
-	if !ok || eng == nil || eng.damaged {
+	attackerID := demoStats.PlayerKey(e.Attacker)
+	eng := rtc.engagements.Engagement(attackerID, demoStats.PlayerKey(e.Player))
+	if eng == nil || eng.Ended() || !eng.Seen || rtc.sampled[eng] {
 		return
 	}
+	rtc.sampled[eng] = true
 
-	deltaT := float64(rtc.currentTick-eng.entryTick) * (1000.0 / rtc.tickRate)
+	deltaT := float64(now-eng.FirstSeen) / float64(time.Millisecond)
 	if deltaT < 0 || deltaT > reactionMaxEngagementMs {
 		return
 	}
 
-	rtc.ttds[attackerID] = append(rtc.ttds[attackerID], deltaT)
-	eng.damaged = true
-}
-
-func (rtc *ReactionTimeCollector) clearForPlayer(playerID uint64) {
-	delete(rtc.engagements, playerID)
-	for attackerID, victims := range rtc.engagements {
-		delete(victims, playerID)
-		rtc.engagements[attackerID] = victims
+	addSample(rtc.ttds, attackerID, deltaT, rtc.maxSamples)
+	if rtc.Thresholds.fast(deltaT) {
+		rtc.sub100[attackerID]++
+		demoStats.AddSuspiciousEvent(attackerID, tick, fmt.Sprintf("damage %.0f ms after spotting %s", deltaT, e.Player.Name))
 	}
+	if rtc.keepRaw {
+		addSample(rtc.rawTTDs, attackerID, deltaT, rtc.maxSamples)
+	}
+	demoStats.AddRoundSample(attackerID, round, "reaction", deltaT)
 }
 
-// CollectFrame updates engagement records every tick using CS's server-side
-// line-of-sight visibility (IsSpottedBy / m_bSpottedByMask). When LoS is
-// first established, an engagement starts and entryTick is recorded. While
-// LoS persists, seenTick refreshes. If LoS lapses for longer than the grace
-// window, the next visibility starts a fresh engagement.
+// CollectFrame advances a private engagement tracker; a shared one is
+// updated by the Analyzer.
 func (rtc *ReactionTimeCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
-	rtc.currentTick = parser.CurrentFrame()
-	gs := parser.GameState()
-	graceTicks := int(reactionGraceMs * rtc.tickRate / 1000.0)
-
-	for _, attacker := range gs.Participants().Playing() {
-		if attacker == nil || attacker.SteamID64 == 0 || !attacker.IsAlive() {
-			continue
-		}
-		attackerID := attacker.SteamID64
-		if _, exists := rtc.engagements[attackerID]; !exists {
-			rtc.engagements[attackerID] = make(map[uint64]*engagement)
-		}
-
-		for _, opponent := range gs.Participants().Playing() {
-			if opponent == nil || opponent.SteamID64 == 0 || opponent.SteamID64 == attackerID {
-				continue
-			}
-			if opponent.Team == attacker.Team || !opponent.IsAlive() {
-				continue
-			}
-			if !opponent.IsSpottedBy(attacker) {
-				continue
-			}
+	if rtc.ownTracker {
+		rtc.engagements.Update(parser)
+	}
+}
 
-			eng, tracking := rtc.engagements[attackerID][opponent.SteamID64]
-			if !tracking || eng == nil || rtc.currentTick-eng.seenTick > graceTicks {
-				rtc.engagements[attackerID][opponent.SteamID64] = &engagement{
-					entryTick: rtc.currentTick,
-					seenTick:  rtc.currentTick,
-				}
-			} else {
-				eng.seenTick = rtc.currentTick
-			}
-		}
+// ProducedMetrics lists the metrics the cheat detector reads from ReactionTimeCollector.
+func (rtc *ReactionTimeCollector) ProducedMetrics() []KeyRef {
+	return []KeyRef{
+		{CatReaction, KeyMedianTTD},
+		{CatReaction, KeyTTDSamples},
+		{CatReaction, KeySub100msTTD},
 	}
 }
 
 func (rtc *ReactionTimeCollector) CollectFinalStats(demoStats *DemoStats) {
-	for playerID, samples := range rtc.ttds {
-		if len(samples) < reactionMinSamples {
+	for playerID, res := range rtc.ttds {
+		if res.seen < reactionMinSamples {
 			continue
 		}
-		sort.Float64s(samples)
+		samples := res.sorted()
 
 		ps, exists := demoStats.Players[playerID]
 		if !exists {
@@ -213,49 +266,70 @@ func (rtc *ReactionTimeCollector) CollectFinalStats(demoStats *DemoStats) {
 			}
 		}
 
-		median := samples[len(samples)/2]
+		median := sortedMedian(samples)
 		p10Idx := int(float64(len(samples)) * 0.1)
 		if p10Idx < 0 {
 			p10Idx = 0
 		}
 		p10 := samples[p10Idx]
 
-		sub100 := 0
-		for _, t := range samples {
-			if t <= 100.0 {
-				sub100++
-			}
-		}
-		sub100Ratio := float64(sub100) / float64(len(samples)) * 100.0
+		sub100Ratio := float64(rtc.sub100[playerID]) / float64(res.seen) * 100.0
 
-		ps.AddMetric(Category("reaction"), Key("median_ttd"), Metric{
+		ps.AddMetric(CatReaction, KeyMedianTTD, Metric{
 			Type:        MetricFloat,
 			FloatValue:  median,
 			Description: "Median Time-To-Damage in ms (sight → first damage; Leetify-style)",
 		})
-		ps.AddMetric(Category("reaction"), Key("p10_ttd"), Metric{
+		ps.AddMetric(CatReaction, KeyP10TTD, Metric{
 			Type:        MetricFloat,
 			FloatValue:  p10,
 			Description: "10th percentile Time-To-Damage in ms",
 		})
-		ps.AddMetric(Category("reaction"), Key("sub_100ms_ttd"), Metric{
+		ps.AddMetric(CatReaction, KeySub100msTTD, Metric{
 			Type:        MetricPercentage,
 			FloatValue:  sub100Ratio,
-			Description: "Share of engagements completed in under 100 ms — statistically implausible without info or aim assistance",
+			Description: fmt.Sprintf("Share of engagements completed in %.0f ms or less — statistically implausible without info or aim assistance", rtc.Thresholds.FastMs),
 		})
-		ps.AddMetric(Category("reaction"), Key("ttd_samples"), Metric{
+		if lo, hi, ok := bootstrapInterval(samples, sortedMedian); ok {
+			addIntervalMetrics(ps, CatReaction, KeyMedianTTD, lo, hi)
+		}
+		if lo, hi, ok := bootstrapInterval(samples, rtc.Thresholds.fastPercent); ok {
+			addIntervalMetrics(ps, CatReaction, KeySub100msTTD, lo, hi)
+		}
+		ps.AddMetric(CatReaction, KeyTTDSamples, Metric{
 			Type:        MetricInteger,
-			IntValue:    int64(len(samples)),
+			IntValue:    int64(res.seen),
 			Description: "Number of TTD samples collected",
 		})
 
-		// Cheat-score component, recalibrated for TTD:
-		//   0 at 400 ms (clean), 1 at 100 ms (implausible).
-		ttdScore := clamp01((400.0 - p10) / 300.0)
-		ps.AddMetric(Category("reaction"), Key("reaction_cheat_score"), Metric{
-			Type:        MetricFloat,
-			FloatValue:  ttdScore,
-			Description: "TTD-derived cheat score (0 at 400 ms P10, 1 at 100 ms P10 or lower)",
+		// Cheat-score component, recalibrated for TTD: by default 0 at a
+		// 400 ms P10 (clean), 1 at 100 ms (implausible).
+		ps.AddMetric(CatReaction, KeyReactionCheatScore, Metric{
+			Type:       MetricFloat,
+			FloatValue: rtc.Thresholds.score(p10),
+			Description: fmt.Sprintf("TTD-derived cheat score (0 at %.0f ms P10, 1 at %.0f ms P10 or lower)",
+				rtc.Thresholds.ScoreCleanMs, rtc.Thresholds.ScoreBlatantMs),
 		})
 	}
 }
+
+// SetMaxSamples caps the time-to-damage samples kept per player; see
+// DefaultMaxSamples.
+func (rtc *ReactionTimeCollector) SetMaxSamples(n int) {
+	rtc.maxSamples = n
+}
+
+// KeepRawSamples makes the collector retain the time-to-damage samples for
+// AppendRawSamples, up to the SetMaxSamples cap.
+func (rtc *ReactionTimeCollector) KeepRawSamples() {
+	rtc.keepRaw = true
+	rtc.rawTTDs = make(map[uint64]*sampleReservoir)
+}
+
+// AppendRawSamples adds the retained time-to-damage samples to samples.
+func (rtc *ReactionTimeCollector) AppendRawSamples(samples map[uint64]*PlayerSamples) {
+	for sid, v := range rtc.rawTTDs {
+		ps := playerSamples(samples, sid)
+		ps.ReactionTimes = append(ps.ReactionTimes, v.inOrder()...)
+	}
+}
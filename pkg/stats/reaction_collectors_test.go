@@ -0,0 +1,96 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+func TestReactionThresholds(t *testing.T) {
+	if got := (ReactionThresholds{}).withDefaults(); got != DefaultReactionThresholds() {
+		t.Errorf("zero thresholds = %+v, want the defaults", got)
+	}
+
+	th := ReactionThresholds{FastMs: 120}.withDefaults()
+	if got := th.fastPercent([]float64{80, 100, 120, 130}); got != 75 {
+		t.Errorf("fastPercent at 120 ms = %v, want 75", got)
+	}
+	if got := DefaultReactionThresholds().fastPercent([]float64{80, 100, 120, 130}); got != 50 {
+		t.Errorf("fastPercent at 100 ms = %v, want 50", got)
+	}
+	if th.score(400) != 0 || th.score(250) != 0.5 || th.score(50) != 1 {
+		t.Errorf("default ramp scores 400/250/50 ms as %v/%v/%v, want 0/0.5/1", th.score(400), th.score(250), th.score(50))
+	}
+}
+
+func TestReactionThresholdsPublished(t *testing.T) {
+	rtc := NewReactionTimeCollector()
+	rtc.Thresholds = ReactionThresholds{FastMs: 120, ScoreCleanMs: 300, ScoreBlatantMs: 200}
+	for _, v := range []float64{110, 250, 250, 250, 250, 250, 250, 250, 250, 250} {
+		addSample(rtc.ttds, 7, v, rtc.maxSamples)
+		if rtc.Thresholds.fast(v) {
+			rtc.sub100[7]++
+		}
+	}
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(7)
+	rtc.CollectFinalStats(ds)
+
+	ps := ds.Players[7]
+	if v, _ := psGetFloat(ps, CatReaction, KeySub100msTTD); v != 10 {
+		t.Errorf("sub_100ms_ttd = %v, want 10 with a 120 ms cutoff", v)
+	}
+	if v, _ := psGetFloat(ps, CatReaction, KeyReactionCheatScore); v != 0.5 {
+		t.Errorf("reaction_cheat_score = %v, want 0.5 at P10 250 ms on a 300-200 ramp", v)
+	}
+}
+
+// TestReactionSprayTransfer kills one enemy and sprays onto a second who
+// was in view all along: the second TTD runs from the original sighting,
+// not from the kill.
+func TestReactionSprayTransfer(t *testing.T) {
+	parser := &warmupStubParser{}
+	ds := NewDemoStats()
+	tracker := NewEngagementTracker(DefaultEngagementConfig())
+	tracker.Setup(parser, ds)
+	rtc := NewReactionTimeCollector()
+	rtc.UseEngagements(tracker)
+	rtc.Setup(parser, ds)
+
+	shooter := &common.Player{SteamID64: 1, Name: "a", Team: common.TeamTerrorists}
+	first := &common.Player{SteamID64: 2, Name: "b", Team: common.TeamCounterTerrorists}
+	second := &common.Player{SteamID64: 3, Name: "c", Team: common.TeamCounterTerrorists}
+
+	// Both enemies come into view on frame 0 and stay there, as Update
+	// would record it, until the first dies on frame 20 (312 ms).
+	see := func(from, to int, opponents ...uint64) {
+		for parser.frame = from; parser.frame <= to; parser.frame++ {
+			tracker.expire(parser.CurrentTime())
+			for _, opp := range opponents {
+				tracker.sight(1, opp, parser.frame, parser.CurrentTime())
+			}
+		}
+		parser.frame = to
+	}
+	see(0, 20, 2, 3)
+	parser.dispatch(events.PlayerHurt{Attacker: shooter, Player: first, HealthDamageTaken: 100})
+	parser.dispatch(events.Kill{Killer: shooter, Victim: first})
+
+	// Six frames on, the spray reaches the second.
+	see(21, 26, 3)
+	parser.dispatch(events.PlayerHurt{Attacker: shooter, Player: second, HealthDamageTaken: 30})
+
+	got := rtc.ttds[1].sorted()
+	if len(got) != 2 {
+		t.Fatalf("TTD samples = %v, want 2", got)
+	}
+	want := float64(parser.CurrentTime()) / float64(time.Millisecond)
+	if got[1] != want {
+		t.Errorf("second TTD = %.1f ms, want %.1f ms from the original sighting", got[1], want)
+	}
+	if rtc.sub100[1] != 0 {
+		t.Errorf("spray transfer counted %d sub-100ms reactions", rtc.sub100[1])
+	}
+}
@@ -3,6 +3,8 @@ package stats
 import (
 	"fmt"
 	"math"
+	"sort"
+	"time"
 
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
@@ -10,7 +12,10 @@ import (
 )
 
 const (
-	// RadToDeg converts radians to degrees
+	// RecoilRadToDeg converts radians to degrees.
+	//
+	// Deprecated: demoinfocs reports view angles in degrees, and nothing in
+	// this package converts them any more.
 	RecoilRadToDeg = 57.295779513
 )
 
@@ -155,26 +160,104 @@ type RecoilControlCollector struct {
 	*BaseCollector
 	sprayStates      map[uint64]*sprayState
 	tickRate         float64
-	maxBurstGapMs    float64
 	minBurstSize     int
 	maxBulletIdx     int
 	goodThreshold    float64
 	perfectThreshold float64
-	debugMode        bool // Enable debugging
+	debugMode        bool // Publish per-burst recoil_debug metrics
 	burstIDCounter   int  // For debug output
+	logger           Logger
+	// patterns is the spray-pattern set this collector scores against.
+	// Defaults to SprayPattern; SetSprayPatterns overrides per weapon.
+	patterns map[common.EquipmentType][][2]float64
+	// burstMeans[steamID][weapon] holds the mean error of every finalized
+	// burst, for the cross-burst consistency check.
+	burstMeans map[uint64]map[common.EquipmentType][]float64
+	// sprayBullets holds the length in bullets of every finalized burst,
+	// longSprays counts the bursts longer than longSprayBullets, and
+	// perfectLong those whose every counted bullet stayed within
+	// perfectThreshold.
+	sprayBullets map[uint64][]float64
+	longSprays   map[uint64]int
+	perfectLong  map[uint64]int
+
+	// FireDedupWindow drops a WeaponFire from a shooter that arrives within
+	// this much in-game time of their previous one. CS2 can surface one
+	// logical shot as several events on the same or adjacent sub-ticks;
+	// counting each would advance the bullet index past the spray pattern.
+	// The fastest automatic weapons cycle in ~70 ms, so the default
+	// (DefaultFireDedupWindow) never merges real shots. Zero disables.
+	FireDedupWindow time.Duration
+	lastFire        map[uint64]time.Duration
+
+	// MaxBurstGap is the longest in-game time between two shots of one
+	// burst; a longer pause starts a new spray. It is measured between the
+	// shots' sub-tick fire times, so it means the same on 64- and 128-tick
+	// demos. Defaults to DefaultMaxBurstGap.
+	MaxBurstGap time.Duration
+
+	// ScoreMode picks the model behind recoil_score. Defaults to
+	// RecoilScoreError; see RecoilScoreSimilarity.
+	ScoreMode RecoilScoreMode
+	// shapes pools each player's finalized bursts for the similarity model.
+	shapes map[uint64]sprayShape
+
+	// rawErrors keeps the counted bullets' angular errors when raw
+	// samples are enabled, up to maxSamples per player. The recoil metrics
+	// themselves are running sums and need no cap.
+	keepRaw    bool
+	rawErrors  map[uint64]*sampleReservoir
+	maxSamples int
 }
 
-// maxBurstGapTicks returns the burst-gap threshold in ticks at the current
-// tick rate. AK cycles in ~100 ms (6.4 ticks at 64 Hz); using a fixed integer
-// in ticks was tighter than the weapon's own cycle on 64-tick demos and
-// outright broken on 128-tick demos. A time budget keeps both honest.
-func (rc *RecoilControlCollector) maxBurstGapTicks() int {
-	tr := rc.tickRate
-	if tr <= 0 {
-		tr = 64.0
-	}
-	return int(rc.maxBurstGapMs * tr / 1000.0)
-}
+// DefaultFireDedupWindow is the default RecoilControlCollector.FireDedupWindow.
+const DefaultFireDedupWindow = 30 * time.Millisecond
+
+// DefaultMaxBurstGap is the default RecoilControlCollector.MaxBurstGap:
+// above the AK's 100 ms cycle with comfortable margin for jitter, below
+// the gap between intentional tap-fires (~300 ms+).
+const DefaultMaxBurstGap = 220 * time.Millisecond
+
+const (
+	// consistencyMinBursts is the number of bursts with one weapon needed
+	// before its spread of burst errors means anything.
+	consistencyMinBursts = 3
+	// Pooled stddev of per-burst mean error, in degrees. Human sprays vary
+	// burst to burst with positioning, movement and target switches; a
+	// compensation script lands every burst within a few hundredths. Fit as
+	// 0.05 and 0.25 on the old error scale, see legacyRecoilScale.
+	consistencySuspiciousStddev = 0.05 / legacyRecoilScale
+	consistencyHumanStddev      = 0.25 / legacyRecoilScale
+	// consistencyWeight is how much of the remaining headroom in
+	// recoil_score a perfectly consistent player can take up.
+	consistencyWeight = 0.5
+
+	// recoilWeaponMinBullets is the number of counted bullets with one
+	// weapon before its own score can stand in for the player's: fewer is
+	// one or two lucky sprays.
+	recoilWeaponMinBullets = 20
+
+	// longSprayBullets is the length above which a burst is a long spray.
+	// Good players tap or burst through long fights; a compensation script
+	// holds one stream, perfectly pulled down, for the whole magazine.
+	longSprayBullets = 15
+	// longSprayMinCount is the number of long sprays needed before
+	// long_spray_perfect_ratio is published.
+	longSprayMinCount = 3
+	// longSprayWeight is how much of the remaining headroom in recoil_score
+	// a player whose every long spray was perfect can take up.
+	longSprayWeight = 0.5
+
+	// Mean angular errors, in degrees, at or below which recoil control is
+	// suspiciously perfect, "very tight", and within the human range, and
+	// above which recoil_score is 0. They were fit as 0.3, 0.7, 1.0 and 0.75
+	// on the old error scale and are converted by legacyRecoilScale, not
+	// refit.
+	recoilPerfectError = 0.3 / legacyRecoilScale  // ≈0.52°
+	recoilGoodError    = 0.7 / legacyRecoilScale  // ≈1.22°
+	recoilHumanError   = 1.0 / legacyRecoilScale  // ≈1.75°
+	recoilZeroError    = 0.75 / legacyRecoilScale // ≈1.31°
+)
 
 // sprayState tracks the state of a player's weapon spray
 type sprayState struct {
@@ -183,27 +266,67 @@ type sprayState struct {
 	firstTick      int
 	firstYawDeg    float64 // In degrees
 	firstPitchDeg  float64 // In degrees
+	prevYawDeg     float64 // view at the previous shot, in degrees
+	prevPitchDeg   float64
 	bulletIndex    int
-	lastFireTick   int
+	lastFireAt     time.Duration // in-game fire time of the latest shot
 	weapon         common.EquipmentType
 	weaponName     string
 	sumError       float64
+	maxError       float64 // worst counted bullet, in degrees
 	countedBullets int
+	shape          sprayShape
 }
 
 // NewRecoilControlCollector creates a new RecoilControlCollector
 func NewRecoilControlCollector() *RecoilControlCollector {
 	return &RecoilControlCollector{
-		BaseCollector:    NewBaseCollector("Recoil Control", Category("recoil")),
+		BaseCollector:    NewBaseCollector("Recoil Control", CatRecoil),
 		sprayStates:      make(map[uint64]*sprayState),
-		maxBurstGapMs:    220,   // ms between shots within a burst. Above AK's 100 ms cycle with comfortable margin for jitter; below the gap between intentional tap-fires (~300 ms+).
-		minBurstSize:     3,     // Minimum bullets to consider a valid burst
-		maxBulletIdx:     30,    // Maximum bullets to track in a spray pattern
-		goodThreshold:    0.7,   // Threshold for good recoil control (in degrees)
-		perfectThreshold: 0.3,   // Threshold for suspiciously perfect recoil control (in degrees)
+		minBurstSize:     3,  // Minimum bullets to consider a valid burst
+		maxBulletIdx:     30, // Maximum bullets to track in a spray pattern
+		goodThreshold:    recoilGoodError,
+		perfectThreshold: recoilPerfectError,
 		debugMode:        false, // Enable debug mode temporarily to diagnose issues
 		burstIDCounter:   1,     // Start at 1
+		logger:           NopLogger{},
+		patterns:         SprayPattern,
+		burstMeans:       make(map[uint64]map[common.EquipmentType][]float64),
+		sprayBullets:     make(map[uint64][]float64),
+		longSprays:       make(map[uint64]int),
+		perfectLong:      make(map[uint64]int),
+		shapes:           make(map[uint64]sprayShape),
+		FireDedupWindow:  DefaultFireDedupWindow,
+		MaxBurstGap:      DefaultMaxBurstGap,
+		lastFire:         make(map[uint64]time.Duration),
+		maxSamples:       DefaultMaxSamples,
+	}
+}
+
+// SetSprayPatterns overrides the spray patterns used for scoring. Weapons in
+// patterns replace the built-in pattern for that weapon (or add one for a
+// weapon that had none); weapons not present keep their built-in pattern.
+// Must be called before parsing starts.
+func (rc *RecoilControlCollector) SetSprayPatterns(patterns map[common.EquipmentType][][2]float64) {
+	merged := make(map[common.EquipmentType][][2]float64, len(SprayPattern)+len(patterns))
+	for t, p := range SprayPattern {
+		merged[t] = p
+	}
+	for t, p := range patterns {
+		merged[t] = p
+	}
+	rc.patterns = merged
+}
+
+// tracksWeapon reports whether weapon has a spray pattern to score against.
+func (rc *RecoilControlCollector) tracksWeapon(weapon *common.Equipment) bool {
+	if weapon == nil {
+		return false
 	}
+	if _, ok := rc.patterns[weapon.Type]; ok {
+		return true
+	}
+	return isAutomaticWeapon(weapon)
 }
 
 // Setup registers event handlers for weapon fire events
@@ -227,8 +350,17 @@ func (rc *RecoilControlCollector) Setup(parser demoinfocs.Parser, demoStats *Dem
 
 	// Register player death event to reset burst state
 	parser.RegisterEventHandler(func(e events.Kill) {
-		if e.Victim != nil && e.Victim.SteamID64 != 0 {
-			delete(rc.sprayStates, e.Victim.SteamID64)
+		if key := demoStats.PlayerKey(e.Victim); key != 0 {
+			delete(rc.sprayStates, key)
+		}
+	})
+
+	// A weapon switch ends the burst, so the next weapon's shots aren't
+	// scored against the previous weapon's pattern. Not every demo carries
+	// ItemEquip; handleWeaponFire checks the fired weapon as well.
+	parser.RegisterEventHandler(func(e events.ItemEquip) {
+		if e.Weapon != nil {
+			rc.switchWeapon(demoStats.PlayerKey(e.Player), e.Weapon.Type, demoStats)
 		}
 	})
 
@@ -236,36 +368,42 @@ func (rc *RecoilControlCollector) Setup(parser demoinfocs.Parser, demoStats *Dem
 	parser.RegisterEventHandler(func(e events.RoundEnd) {
 		rc.sprayStates = make(map[uint64]*sprayState)
 	})
-}
 
-// angleDiffDeg calculates the shortest angular difference between two angles in degrees
-func angleDiffDeg(a, b float64) float64 {
-	diff := math.Mod(b-a+180, 360) - 180
-	if diff < -180 {
-		diff += 360
-	}
-	return math.Abs(diff)
+	// Bursts still open when warmup ends began in warmup
+	onWarmupEnd(parser, func() {
+		rc.sprayStates = make(map[uint64]*sprayState)
+		rc.lastFire = make(map[uint64]time.Duration)
+	})
 }
 
-// normalizeAngle ensures an angle is between 0 and 360 degrees
-func normalizeAngle(angle float64) float64 {
-	// Normalize to 0-360 range
-	return math.Mod(math.Mod(angle, 360)+360, 360)
+// bulletError returns how far (in degrees) the view at a bullet sits from
+// where it should be to cancel the pattern offset: the burst's first-shot
+// angles minus the recoil offset for that bullet.
+func bulletError(firstYaw, firstPitch, yawOffset, pitchOffset, yaw, pitch float64) float64 {
+	return angularDistance(firstYaw-yawOffset, firstPitch-pitchOffset, yaw, pitch)
 }
 
 // handleWeaponFire processes weapon fire events
 func (rc *RecoilControlCollector) handleWeaponFire(e events.WeaponFire, parser demoinfocs.Parser, demoStats *DemoStats) {
 	shooter := e.Shooter
-	if shooter == nil || shooter.SteamID64 == 0 {
+	if inWarmup(parser) || !demoStats.CountsAimPlayer(shooter) {
+		return
+	}
+	steamID := demoStats.PlayerKey(shooter)
+	shotAt := fireTime(parser, e.Weapon, rc.tickRate)
+	if rc.duplicateFire(steamID, shotAt) {
 		return
 	}
 
 	// Get current tick
 	currentTick := parser.CurrentFrame()
 	weapon := e.Weapon
+	if weapon != nil {
+		rc.switchWeapon(steamID, weapon.Type, demoStats)
+	}
 
-	// Skip non-automatic weapons
-	if !isAutomaticWeapon(weapon) {
+	// Skip weapons without a spray pattern
+	if !rc.tracksWeapon(weapon) {
 		return
 	}
 
@@ -275,19 +413,19 @@ func (rc *RecoilControlCollector) handleWeaponFire(e events.WeaponFire, parser d
 	// recoil-pattern matching.
 	if shooterStats := demoStats.GetOrCreatePlayerStats(shooter); shooterStats != nil {
 		shotsKey := Key(fmt.Sprintf("%s_shots", weaponTypeToString(weapon.Type)))
-		shooterStats.IncrementIntMetric(Category("recoil"), shotsKey)
+		shooterStats.IncrementIntMetric(CatRecoil, shotsKey)
 	}
 
 	// Get weapon name for debugging
 	weaponName := getWeaponName(weapon)
 
-	// Get view angles in DEGREES, then normalize to 0-360 range
-	actualYawRad := float64(shooter.ViewDirectionX())
-	actualPitchRad := float64(shooter.ViewDirectionY())
-	actualYawDeg := normalizeAngle(actualYawRad * RecoilRadToDeg)
-	actualPitchDeg := normalizeAngle(actualPitchRad * RecoilRadToDeg)
+	yaw, pitch, ok := viewAngles(shooter)
+	if !ok {
+		return
+	}
+	actualYawDeg := normalizeAngle(float64(yaw))
+	actualPitchDeg := normalizeAngle(float64(pitch))
 
-	steamID := shooter.SteamID64
 	state, exists := rc.sprayStates[steamID]
 
 	// If player has no spray state or we need to start a new burst
@@ -301,26 +439,27 @@ func (rc *RecoilControlCollector) handleWeaponFire(e events.WeaponFire, parser d
 			firstTick:     currentTick,
 			firstYawDeg:   actualYawDeg,
 			firstPitchDeg: actualPitchDeg,
+			prevYawDeg:    actualYawDeg,
+			prevPitchDeg:  actualPitchDeg,
 			bulletIndex:   1,
-			lastFireTick:  currentTick,
+			lastFireAt:    shotAt,
 			weapon:        weapon.Type,
 			weaponName:    weaponName,
 		}
 
-		// Log first bullet info for debugging
-		if rc.debugMode {
-			fmt.Printf("[DEBUG] B%02d Player:%d Weapon:%s First bullet angles: Yaw=%.2f° Pitch=%.2f°\n",
-				burstID, steamID, weaponName, actualYawDeg, actualPitchDeg)
-		}
+		rc.logger.Debugf("B%02d Player:%d Weapon:%s First bullet angles: Yaw=%.2f° Pitch=%.2f°",
+			burstID, steamID, weaponName, actualYawDeg, actualPitchDeg)
 
 		return // First shot of a burst, no analysis needed
 	}
 
 	if exists && state.inBurst {
 		// Continue existing burst if within gap threshold
-		if currentTick-state.lastFireTick <= rc.maxBurstGapTicks() {
+		if shotAt-state.lastFireAt <= rc.MaxBurstGap {
 			// Update bullet index first
 			state.bulletIndex++
+			rc.addShapeDelta(state, state.bulletIndex, state.prevYawDeg, state.prevPitchDeg, actualYawDeg, actualPitchDeg)
+			state.prevYawDeg, state.prevPitchDeg = actualYawDeg, actualPitchDeg
 
 			// Check if the bullet is in the range we want to analyze.
 			// Start at bullet 3 (paired with minBurstSize=3) — pros rarely
@@ -328,44 +467,30 @@ func (rc *RecoilControlCollector) handleWeaponFire(e events.WeaponFire, parser d
 			// bullets is the only way to surface AK data on pro demos.
 			if state.bulletIndex >= 3 && state.bulletIndex <= rc.maxBulletIdx {
 				// Get the expected recoil offsets for this bullet index (in degrees)
-				expectedYawOffset, expectedPitchOffset, hasPattern := getRecoilOffsets(state.weapon, state.bulletIndex)
+				expectedYawOffset, expectedPitchOffset, hasPattern := getRecoilOffsets(rc.patterns, state.weapon, state.bulletIndex)
 				if !hasPattern {
-					state.lastFireTick = currentTick
+					state.lastFireAt = shotAt
 					return
 				}
 
-				// Calculate expected aim angles (in degrees)
-				// We subtract offsets because we want to compensate for recoil
-				expectedYawDeg := normalizeAngle(state.firstYawDeg - expectedYawOffset)
-				expectedPitchDeg := normalizeAngle(state.firstPitchDeg - expectedPitchOffset)
-
-				// Calculate angular error (in degrees) using angleDiffDeg for proper angle wrapping
-				yawDiffDeg := angleDiffDeg(expectedYawDeg, actualYawDeg)
-				pitchDiffDeg := angleDiffDeg(expectedPitchDeg, actualPitchDeg)
-
-				// Apply error scaling factor to match expected ranges for human players (0.8-1.5°)
-				// The demo data seems to have much larger angle changes than expected
-				errorScaleFactor := 0.01 // Scale angles down by 100x to match expected ranges
-				scaledYawDiff := yawDiffDeg * errorScaleFactor
-				scaledPitchDiff := pitchDiffDeg * errorScaleFactor
-
-				// Calculate final angular error using scaled values
-				angularErrorDeg := math.Sqrt(scaledYawDiff*scaledYawDiff + scaledPitchDiff*scaledPitchDiff)
+				angularErrorDeg := bulletError(state.firstYawDeg, state.firstPitchDeg,
+					expectedYawOffset, expectedPitchOffset, actualYawDeg, actualPitchDeg)
 
 				// Add to player's accumulated error (in degrees)
 				state.sumError += angularErrorDeg
+				state.maxError = math.Max(state.maxError, angularErrorDeg)
 				state.countedBullets++
-
-				// Debug output for every bullet
-				if rc.debugMode {
-					fmt.Printf("[DEBUG] B%02d Player:%d %s Bullet:%d Raw:(yawDiff:%.2f°, pitchDiff:%.2f°) Scaled Error:%.2f° Sum:%.2f Count:%d\n",
-						state.burstID, steamID, state.weaponName, state.bulletIndex,
-						yawDiffDeg, pitchDiffDeg, angularErrorDeg, state.sumError, state.countedBullets)
+				if rc.keepRaw {
+					addSample(rc.rawErrors, steamID, angularErrorDeg, rc.maxSamples)
 				}
+
+				rc.logger.Debugf("B%02d Player:%d %s Bullet:%d Error:%.2f° Sum:%.2f Count:%d",
+					state.burstID, steamID, state.weaponName, state.bulletIndex,
+					angularErrorDeg, state.sumError, state.countedBullets)
 			}
 
 			// Update last fire tick
-			state.lastFireTick = currentTick
+			state.lastFireAt = shotAt
 		} else {
 			// Gap too large, end previous burst and start a new one
 			rc.finalizeBurst(state, steamID, demoStats)
@@ -379,8 +504,10 @@ func (rc *RecoilControlCollector) handleWeaponFire(e events.WeaponFire, parser d
 				firstTick:     currentTick,
 				firstYawDeg:   actualYawDeg,
 				firstPitchDeg: actualPitchDeg,
+				prevYawDeg:    actualYawDeg,
+				prevPitchDeg:  actualPitchDeg,
 				bulletIndex:   1,
-				lastFireTick:  currentTick,
+				lastFireAt:    shotAt,
 				weapon:        weapon.Type,
 				weaponName:    weaponName,
 			}
@@ -396,22 +523,45 @@ func (rc *RecoilControlCollector) handleWeaponFire(e events.WeaponFire, parser d
 			firstTick:     currentTick,
 			firstYawDeg:   actualYawDeg,
 			firstPitchDeg: actualPitchDeg,
+			prevYawDeg:    actualYawDeg,
+			prevPitchDeg:  actualPitchDeg,
 			bulletIndex:   1,
-			lastFireTick:  currentTick,
+			lastFireAt:    shotAt,
 			weapon:        weapon.Type,
 			weaponName:    weaponName,
 		}
 	}
 }
 
+// switchWeapon finalizes and drops steamID's burst when it was fired with a
+// weapon other than t. Bursts too short to score are dropped all the same.
+func (rc *RecoilControlCollector) switchWeapon(steamID uint64, t common.EquipmentType, demoStats *DemoStats) {
+	if state, ok := rc.sprayStates[steamID]; ok && state.inBurst && state.weapon != t {
+		rc.finalizeBurst(state, steamID, demoStats)
+		delete(rc.sprayStates, steamID)
+	}
+}
+
+// duplicateFire reports whether a shot by steamID at now repeats the
+// previous one within FireDedupWindow. Non-duplicates become the new
+// reference point.
+func (rc *RecoilControlCollector) duplicateFire(steamID uint64, now time.Duration) bool {
+	if rc.FireDedupWindow <= 0 {
+		return false
+	}
+	if prev, ok := rc.lastFire[steamID]; ok && now >= prev && now-prev < rc.FireDedupWindow {
+		return true
+	}
+	rc.lastFire[steamID] = now
+	return false
+}
+
 // finalizeBurst processes the end of a burst and calculates statistics
 func (rc *RecoilControlCollector) finalizeBurst(state *sprayState, steamID uint64, demoStats *DemoStats) {
 	// Only process if we have enough bullets for analysis
 	if state.bulletIndex < rc.minBurstSize || state.countedBullets == 0 {
-		if rc.debugMode {
-			fmt.Printf("[DEBUG] B%02d Player:%d %s - Skipped burst: bullets=%d, counted=%d\n",
-				state.burstID, steamID, state.weaponName, state.bulletIndex, state.countedBullets)
-		}
+		rc.logger.Debugf("B%02d Player:%d %s - Skipped burst: bullets=%d, counted=%d",
+			state.burstID, steamID, state.weaponName, state.bulletIndex, state.countedBullets)
 		return
 	}
 
@@ -423,48 +573,46 @@ func (rc *RecoilControlCollector) finalizeBurst(state *sprayState, steamID uint6
 	// Calculate mean error for this burst
 	meanError := state.sumError / float64(state.countedBullets)
 
-	if rc.debugMode {
-		fmt.Printf("[DEBUG] B%02d Player:%d %s - Burst finalized: bullets=%d, sum=%.2f°, mean=%.2f°\n",
-			state.burstID, steamID, state.weaponName, state.countedBullets, state.sumError, meanError)
-	}
+	rc.logger.Debugf("B%02d Player:%d %s - Burst finalized: bullets=%d, sum=%.2f°, mean=%.2f°",
+		state.burstID, steamID, state.weaponName, state.countedBullets, state.sumError, meanError)
 
 	// Track total error sum and bullet count for final calculation
 	currentErrorSum := 0.0
 	currentBulletCount := int64(0)
 
-	if metric, found := playerStats.GetMetric(Category("recoil"), Key("total_error_sum")); found {
+	if metric, found := playerStats.GetMetric(CatRecoil, KeyTotalErrorSum); found {
 		currentErrorSum = metric.FloatValue
 	}
 
-	if metric, found := playerStats.GetMetric(Category("recoil"), Key("total_counted_bullets")); found {
+	if metric, found := playerStats.GetMetric(CatRecoil, KeyTotalCountedBullets); found {
 		currentBulletCount = metric.IntValue
 	}
 
 	// Update total error sum
-	playerStats.AddMetric(Category("recoil"), Key("total_error_sum"), Metric{
+	playerStats.AddMetric(CatRecoil, KeyTotalErrorSum, Metric{
 		Type:        MetricFloat,
 		FloatValue:  currentErrorSum + state.sumError,
 		Description: "Total angular error sum in degrees",
 	})
 
 	// Update total bullet count
-	playerStats.AddMetric(Category("recoil"), Key("total_counted_bullets"), Metric{
+	playerStats.AddMetric(CatRecoil, KeyTotalCountedBullets, Metric{
 		Type:        MetricInteger,
 		IntValue:    currentBulletCount + int64(state.countedBullets),
 		Description: "Total bullets analyzed for recoil control",
 	})
 
 	// Increment burst count
-	playerStats.IncrementIntMetric(Category("recoil"), Key("burst_count"))
+	playerStats.IncrementIntMetric(CatRecoil, KeyBurstCount)
 
 	// Also track weapon-specific metrics
 	weaponKey := Key(fmt.Sprintf("%s_bullets", weaponTypeToString(state.weapon)))
 	currentWeaponCount := int64(0)
-	if metric, found := playerStats.GetMetric(Category("recoil"), weaponKey); found {
+	if metric, found := playerStats.GetMetric(CatRecoil, weaponKey); found {
 		currentWeaponCount = metric.IntValue
 	}
 
-	playerStats.AddMetric(Category("recoil"), weaponKey, Metric{
+	playerStats.AddMetric(CatRecoil, weaponKey, Metric{
 		Type:        MetricInteger,
 		IntValue:    currentWeaponCount + int64(state.countedBullets),
 		Description: fmt.Sprintf("Bullets analyzed for %s", state.weaponName),
@@ -473,20 +621,37 @@ func (rc *RecoilControlCollector) finalizeBurst(state *sprayState, steamID uint6
 	// Track weapon-specific error sums for per-weapon stats
 	weaponErrorKey := Key(fmt.Sprintf("%s_error_sum", weaponTypeToString(state.weapon)))
 	currentWeaponErrorSum := 0.0
-	if metric, found := playerStats.GetMetric(Category("recoil"), weaponErrorKey); found {
+	if metric, found := playerStats.GetMetric(CatRecoil, weaponErrorKey); found {
 		currentWeaponErrorSum = metric.FloatValue
 	}
 
-	playerStats.AddMetric(Category("recoil"), weaponErrorKey, Metric{
+	playerStats.AddMetric(CatRecoil, weaponErrorKey, Metric{
 		Type:        MetricFloat,
 		FloatValue:  currentWeaponErrorSum + state.sumError,
 		Description: fmt.Sprintf("Error sum for %s", state.weaponName),
 	})
 
+	if rc.burstMeans[steamID] == nil {
+		rc.burstMeans[steamID] = make(map[common.EquipmentType][]float64)
+	}
+	rc.burstMeans[steamID][state.weapon] = append(rc.burstMeans[steamID][state.weapon], meanError)
+
+	shape := rc.shapes[steamID]
+	shape.merge(state.shape)
+	rc.shapes[steamID] = shape
+
+	rc.sprayBullets[steamID] = append(rc.sprayBullets[steamID], float64(state.bulletIndex))
+	if state.bulletIndex > longSprayBullets {
+		rc.longSprays[steamID]++
+		if state.maxError <= rc.perfectThreshold {
+			rc.perfectLong[steamID]++
+		}
+	}
+
 	// Add burst-specific mean error for debugging
 	if rc.debugMode {
 		burstKey := Key(fmt.Sprintf("burst_%d_mean_error", state.burstID))
-		playerStats.AddMetric(Category("recoil_debug"), burstKey, Metric{
+		playerStats.AddMetric(CatRecoilDebug, burstKey, Metric{
 			Type:        MetricFloat,
 			FloatValue:  meanError,
 			Description: fmt.Sprintf("Mean error for burst #%d with %s", state.burstID, state.weaponName),
@@ -497,7 +662,17 @@ func (rc *RecoilControlCollector) finalizeBurst(state *sprayState, steamID uint6
 	state.inBurst = false
 	state.bulletIndex = 0
 	state.sumError = 0
+	state.maxError = 0
 	state.countedBullets = 0
+	state.shape = sprayShape{}
+}
+
+// ProducedMetrics lists the metrics the cheat detector reads from RecoilControlCollector.
+func (rc *RecoilControlCollector) ProducedMetrics() []KeyRef {
+	return []KeyRef{
+		{CatRecoil, KeyRecoilScore},
+		{CatRecoil, KeyTotalCountedBullets},
+	}
 }
 
 // CollectFinalStats calculates final recoil control statistics
@@ -509,48 +684,72 @@ func (rc *RecoilControlCollector) CollectFinalStats(demoStats *DemoStats) {
 		}
 	}
 
-	// List of weapons we want to prioritize in output
-	priorityWeapons := []common.EquipmentType{
-		common.EqAK47,
-		common.EqM4A4,
-		common.EqMP9,
-	}
-
-	fmt.Println("\n=== DEBUG: Recoil Metrics ===")
 	// Calculate final stats for each player
 	for steamID, playerStats := range demoStats.Players {
-		totalErrorSum, foundError := playerStats.GetMetric(Category("recoil"), Key("total_error_sum"))
-		totalBullets, foundBullets := playerStats.GetMetric(Category("recoil"), Key("total_counted_bullets"))
-		_, _ = playerStats.GetMetric(Category("recoil"), Key("burst_count")) // Get but don't store
+		totalErrorSum, foundError := playerStats.GetMetric(CatRecoil, KeyTotalErrorSum)
+		totalBullets, foundBullets := playerStats.GetMetric(CatRecoil, KeyTotalCountedBullets)
+		_, _ = playerStats.GetMetric(CatRecoil, KeyBurstCount) // Get but don't store
 
 		// Calculate mean error if we have any data at all
 		if foundError && foundBullets && totalBullets.IntValue > 0 {
 			meanError := totalErrorSum.FloatValue / float64(totalBullets.IntValue)
 
-			fmt.Printf("Player %d - Mean Error: %.2f° (from %d bullets, total error: %.2f°)\n",
+			rc.logger.Debugf("Player %d - Mean Error: %.2f° (from %d bullets, total error: %.2f°)",
 				steamID, meanError, totalBullets.IntValue, totalErrorSum.FloatValue)
 
 			// Store mean angular error
-			playerStats.AddMetric(Category("recoil"), Key("mean_angular_error"), Metric{
+			playerStats.AddMetric(CatRecoil, KeyMeanAngularError, Metric{
 				Type:        MetricFloat,
 				FloatValue:  meanError,
 				Description: "Mean angular error in recoil control (degrees)",
 			})
 
-			// Calculate recoil score for the cheat detector (0-1 scale)
-			recoilScore := 0.0
-			if meanError <= 0.3 {
-				recoilScore = 1.0 // Perfect score (suspicious)
-			} else if meanError >= 0.75 {
-				recoilScore = 0.0 // No score
-			} else {
-				// Linear scale between 0.3 and 0.75 degrees
-				recoilScore = (0.75 - meanError) / 0.45
+			// Score the most suspicious weapon rather than the blend, so a
+			// player who only runs compensation on one gun isn't diluted
+			// by honest sprays with the others.
+			recoilScore := recoilScoreFor(meanError)
+			playerStats.AddMetric(CatRecoil, KeyBlendedRecoilScore, Metric{
+				Type:        MetricFloat,
+				FloatValue:  recoilScore,
+				Description: "Recoil score from the mean error across all weapons (0-1)",
+			})
+			if weapon, score, ok := rc.weaponRecoilScores(steamID, playerStats); ok {
+				recoilScore = score
+				playerStats.AddMetric(CatRecoil, KeyMostSuspiciousRecoilWeapon, Metric{
+					Type:        MetricString,
+					StringValue: weapon,
+					Description: "Weapon whose recoil score feeds the cheat detector",
+				})
+			}
+			if rc.ScoreMode == RecoilScoreSimilarity {
+				if score, ok := rc.similarityScore(steamID, playerStats); ok {
+					recoilScore = score
+				}
 			}
 
-			fmt.Printf("Player %d - Recoil Score: %.2f\n", steamID, recoilScore)
+			// Cross-burst consistency: only raises a score that is already
+			// inside the suspicious band, so a consistently poor sprayer
+			// isn't flagged for being predictable.
+			if consistency, ok := rc.consistencyStddev(steamID); ok {
+				playerStats.AddMetric(CatRecoil, KeyRecoilConsistencyStddev, Metric{
+					Type:        MetricFloat,
+					FloatValue:  consistency,
+					Description: "Stddev of per-burst mean error, pooled across weapons (degrees)",
+				})
+				if recoilScore > 0 {
+					recoilScore += (1 - recoilScore) * consistencyWeight * consistencyScore(consistency)
+				}
+			}
 
-			playerStats.AddMetric(Category("recoil"), Key("recoil_score"), Metric{
+			// Long sprays held perfect the whole way: like consistency, only
+			// raises a score already inside the suspicious band.
+			if ratio, ok := rc.longSprayStats(steamID, playerStats); ok && recoilScore > 0 {
+				recoilScore += (1 - recoilScore) * longSprayWeight * ratio
+			}
+
+			rc.logger.Debugf("Player %d - Recoil Score: %.2f", steamID, recoilScore)
+
+			playerStats.AddMetric(CatRecoil, KeyRecoilScore, Metric{
 				Type:        MetricFloat,
 				FloatValue:  recoilScore,
 				Description: "Recoil score component for cheat detection (0-1)",
@@ -558,62 +757,154 @@ func (rc *RecoilControlCollector) CollectFinalStats(demoStats *DemoStats) {
 
 			// Add interpretation
 			interp := interpretation(meanError, rc.perfectThreshold, rc.goodThreshold)
-			playerStats.AddMetric(Category("recoil"), Key("recoil_interpretation"), Metric{
+			playerStats.AddMetric(CatRecoil, KeyRecoilInterpretation, Metric{
 				Type:        MetricString,
 				StringValue: interp,
 				Description: "Interpretation of recoil control ability",
 			})
 
-			fmt.Printf("Player %d - Interpretation: %s\n\n", steamID, interp)
+			rc.logger.Debugf("Player %d - Interpretation: %s", steamID, interp)
 		} else {
 			// No data at all
-			playerStats.AddMetric(Category("recoil"), Key("mean_angular_error"), Metric{
+			playerStats.AddMetric(CatRecoil, KeyMeanAngularError, Metric{
 				Type:        MetricFloat,
 				FloatValue:  0,
 				Description: "Mean angular error in recoil control (degrees) - no data",
 			})
 
-			playerStats.AddMetric(Category("recoil"), Key("recoil_score"), Metric{
+			playerStats.AddMetric(CatRecoil, KeyRecoilScore, Metric{
 				Type:        MetricFloat,
 				FloatValue:  0,
 				Description: "Recoil score component - no data",
 			})
 
-			playerStats.AddMetric(Category("recoil"), Key("recoil_interpretation"), Metric{
+			playerStats.AddMetric(CatRecoil, KeyRecoilInterpretation, Metric{
 				Type:        MetricString,
 				StringValue: "No data",
 				Description: "Interpretation of recoil control ability",
 			})
 		}
 
-		// Calculate weapon-specific stats for priority weapons
-		for _, weaponType := range priorityWeapons {
-			weaponKey := Key(fmt.Sprintf("%s_bullets", weaponTypeToString(weaponType)))
-			weaponBullets, foundWeapon := playerStats.GetMetric(Category("recoil"), weaponKey)
-
-			if foundWeapon && weaponBullets.IntValue > 0 {
-				// Calculate weapon-specific metrics if we have any data
-				weaponErrorKey := Key(fmt.Sprintf("%s_error_sum", weaponTypeToString(weaponType)))
-				weaponErrorSum, foundWeaponError := playerStats.GetMetric(Category("recoil"), weaponErrorKey)
+	}
+}
 
-				if foundWeaponError && weaponErrorSum.FloatValue > 0 {
-					weaponMeanError := weaponErrorSum.FloatValue / float64(weaponBullets.IntValue)
+// recoilScoreFor maps a mean angular error onto the 0-1 recoil score: 1 at
+// or below recoilPerfectError, 0 at or above recoilZeroError, linear in
+// between.
+func recoilScoreFor(meanError float64) float64 {
+	switch {
+	case meanError <= recoilPerfectError:
+		return 1.0 // Perfect score (suspicious)
+	case meanError >= recoilZeroError:
+		return 0.0
+	default:
+		return (recoilZeroError - meanError) / (recoilZeroError - recoilPerfectError)
+	}
+}
 
-					// Store weapon-specific mean error
-					playerStats.AddMetric(Category("recoil"), Key(fmt.Sprintf("%s_mean_error", weaponTypeToString(weaponType))), Metric{
-						Type:        MetricFloat,
-						FloatValue:  weaponMeanError,
-						Description: fmt.Sprintf("Mean error for %s (degrees)", weaponTypeToString(weaponType)),
-					})
+// weaponRecoilScores publishes <weapon>_mean_error and <weapon>_recoil_score
+// for every weapon the player finished a burst with, and returns the most
+// suspicious weapon among those with at least recoilWeaponMinBullets
+// counted bullets. ok is false when no weapon has that many.
+func (rc *RecoilControlCollector) weaponRecoilScores(steamID uint64, playerStats *PlayerStats) (worst string, worstScore float64, ok bool) {
+	seen := make(map[string]bool)
+	var names []string
+	for weaponType := range rc.burstMeans[steamID] {
+		if name := weaponTypeToString(weaponType); !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	worstError := 0.0
+	for _, name := range names {
+		bullets, hasBullets := psGetInt(playerStats, CatRecoil, Key(name+"_bullets"))
+		errorSum, hasErrors := psGetFloat(playerStats, CatRecoil, Key(name+"_error_sum"))
+		if !hasBullets || !hasErrors || bullets <= 0 || errorSum <= 0 {
+			continue
+		}
+		meanError := errorSum / float64(bullets)
+		score := recoilScoreFor(meanError)
+		playerStats.AddMetric(CatRecoil, Key(name+"_mean_error"), Metric{
+			Type:        MetricFloat,
+			FloatValue:  meanError,
+			Description: fmt.Sprintf("Mean error for %s (degrees)", name),
+		})
+		playerStats.AddMetric(CatRecoil, Key(name+"_recoil_score"), Metric{
+			Type:        MetricFloat,
+			FloatValue:  score,
+			Description: fmt.Sprintf("Recoil score for %s (0-1)", name),
+		})
+		rc.logger.Debugf("Player %d - %s: %.2f° mean error", steamID, name, meanError)
+		if bullets >= recoilWeaponMinBullets && (!ok || meanError < worstError) {
+			worst, worstScore, worstError, ok = name, score, meanError, true
+		}
+	}
+	return worst, worstScore, ok
+}
 
-					fmt.Printf("Player %d - %s: %.2f° mean error\n",
-						steamID, weaponTypeToString(weaponType), weaponMeanError)
-				}
-			}
+// consistencyStddev returns the stddev of per-burst mean error for a player,
+// computed per weapon and pooled by burst count. Weapons with fewer than
+// consistencyMinBursts bursts are ignored; ok is false when none qualify.
+func (rc *RecoilControlCollector) consistencyStddev(steamID uint64) (float64, bool) {
+	weighted, bursts := 0.0, 0
+	for _, means := range rc.burstMeans[steamID] {
+		if len(means) < consistencyMinBursts {
+			continue
 		}
+		weighted += stddev(means) * float64(len(means))
+		bursts += len(means)
 	}
-	fmt.Println("=== End of DEBUG Recoil Metrics ===")
-	fmt.Println()
+	if bursts == 0 {
+		return 0, false
+	}
+	return weighted / float64(bursts), true
+}
+
+// longSprayStats publishes the spray-length metrics for steamID and returns
+// the share of their long sprays that stayed within perfectThreshold on
+// every counted bullet; ok is false below longSprayMinCount long sprays.
+func (rc *RecoilControlCollector) longSprayStats(steamID uint64, playerStats *PlayerStats) (ratio float64, ok bool) {
+	lengths := rc.sprayBullets[steamID]
+	if len(lengths) == 0 {
+		return 0, false
+	}
+	sorted := append([]float64(nil), lengths...)
+	sort.Float64s(sorted)
+	playerStats.AddMetric(CatRecoil, KeyMedianSprayBullets, Metric{
+		Type:        MetricFloat,
+		FloatValue:  sortedMedian(sorted),
+		Description: "Median burst length in bullets",
+	})
+	long := rc.longSprays[steamID]
+	playerStats.AddMetric(CatRecoil, KeyLongSprays, Metric{
+		Type:        MetricInteger,
+		IntValue:    int64(long),
+		Description: fmt.Sprintf("Bursts longer than %d bullets", longSprayBullets),
+	})
+	if long < longSprayMinCount {
+		return 0, false
+	}
+	ratio = float64(rc.perfectLong[steamID]) / float64(long)
+	playerStats.AddMetric(CatRecoil, KeyLongSprayPerfectRatio, Metric{
+		Type:        MetricFloat,
+		FloatValue:  ratio,
+		Description: fmt.Sprintf("Share of long sprays with every bullet within %.2f° of the pattern (0-1)", rc.perfectThreshold),
+	})
+	return ratio, true
+}
+
+// consistencyScore maps a burst-error stddev onto 0..1: 1 at or below
+// consistencySuspiciousStddev, 0 at or above consistencyHumanStddev.
+func consistencyScore(sd float64) float64 {
+	if sd <= consistencySuspiciousStddev {
+		return 1.0
+	}
+	if sd >= consistencyHumanStddev {
+		return 0.0
+	}
+	return (consistencyHumanStddev - sd) / (consistencyHumanStddev - consistencySuspiciousStddev)
 }
 
 // interpretation returns a label describing the recoil profile, oriented
@@ -627,7 +918,7 @@ func interpretation(meanError float64, perfectThreshold, goodThreshold float64)
 		return "Bot-perfect"
 	} else if meanError <= goodThreshold {
 		return "Very tight"
-	} else if meanError <= 1.0 {
+	} else if meanError <= recoilHumanError {
 		return "Human range"
 	} else {
 		return "Wide spread"
@@ -690,31 +981,24 @@ func weaponTypeToString(weaponType common.EquipmentType) string {
 // up with meaningless mean-error values), so we restrict the set to weapons
 // we can actually score against ground truth.
 func isAutomaticWeapon(weapon *common.Equipment) bool {
-	if weapon == nil {
-		return false
-	}
-	switch weapon.Type {
+	switch weaponType(weapon) {
 	case common.EqAK47, common.EqM4A4, common.EqM4A1, common.EqMP9, common.EqP90:
 		return true
 	}
-	switch weapon.String() {
-	case "AK-47", "M4A4", "M4A1", "M4A1-S", "MP9", "P90":
-		return true
-	}
 	return false
 }
 
 // getRecoilOffsets returns the expected yaw/pitch offsets (in degrees) for a
-// specific weapon and bullet index. Returns (0, 0, false) when no spray
-// pattern is defined; callers should skip those weapons entirely rather than
-// score them against a synthetic fallback curve.
-func getRecoilOffsets(weaponType common.EquipmentType, bulletIndex int) (float64, float64, bool) {
+// specific weapon and bullet index from patterns. Returns (0, 0, false) when
+// no spray pattern is defined; callers should skip those weapons entirely
+// rather than score them against a synthetic fallback curve.
+func getRecoilOffsets(patterns map[common.EquipmentType][][2]float64, weaponType common.EquipmentType, bulletIndex int) (float64, float64, bool) {
 	if bulletIndex < 1 {
 		bulletIndex = 1
 	} else if bulletIndex > 30 {
 		bulletIndex = 30
 	}
-	pattern, exists := SprayPattern[weaponType]
+	pattern, exists := patterns[weaponType]
 	if !exists || len(pattern) == 0 {
 		return 0, 0, false
 	}
@@ -724,3 +1008,30 @@ func getRecoilOffsets(weaponType common.EquipmentType, bulletIndex int) (float64
 	}
 	return pattern[idx][0], pattern[idx][1], true
 }
+
+// SetMaxSamples caps the raw bullet errors kept per player; see
+// DefaultMaxSamples.
+func (rc *RecoilControlCollector) SetMaxSamples(n int) {
+	rc.maxSamples = n
+}
+
+// SetLogger sends the per-bullet and per-burst diagnostics to l at debug
+// level.
+func (rc *RecoilControlCollector) SetLogger(l Logger) {
+	rc.logger = l
+}
+
+// KeepRawSamples makes the collector retain the counted bullets' angular
+// errors for AppendRawSamples, up to the SetMaxSamples cap.
+func (rc *RecoilControlCollector) KeepRawSamples() {
+	rc.keepRaw = true
+	rc.rawErrors = make(map[uint64]*sampleReservoir)
+}
+
+// AppendRawSamples adds the retained per-bullet errors to samples.
+func (rc *RecoilControlCollector) AppendRawSamples(samples map[uint64]*PlayerSamples) {
+	for sid, v := range rc.rawErrors {
+		ps := playerSamples(samples, sid)
+		ps.RecoilErrors = append(ps.RecoilErrors, v.inOrder()...)
+	}
+}
@@ -0,0 +1,37 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecoilDuplicateFire(t *testing.T) {
+	rc := NewRecoilControlCollector()
+	tick := 1000 * time.Millisecond
+
+	// Three events on the same tick are one shot.
+	counted := 0
+	for i := 0; i < 3; i++ {
+		if !rc.duplicateFire(42, tick) {
+			counted++
+		}
+	}
+	if counted != 1 {
+		t.Fatalf("same-tick fires counted %d times, want 1", counted)
+	}
+
+	// Another shooter on the same tick is a separate shot.
+	if rc.duplicateFire(43, tick) {
+		t.Error("second shooter deduplicated against the first")
+	}
+
+	// The next real shot of a fast automatic (~70 ms later) still counts.
+	if rc.duplicateFire(42, tick+70*time.Millisecond) {
+		t.Error("next cycle deduplicated")
+	}
+
+	rc.FireDedupWindow = 0
+	if rc.duplicateFire(42, tick+70*time.Millisecond) {
+		t.Error("dedup applied with a zero window")
+	}
+}
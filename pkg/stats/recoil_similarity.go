@@ -0,0 +1,138 @@
+package stats
+
+import "math"
+
+// RecoilScoreMode selects how RecoilControlCollector turns sprays into
+// recoil_score.
+type RecoilScoreMode int
+
+const (
+	// RecoilScoreError scores the angular error of each bullet against the
+	// burst's first shot minus the pattern offset. The default.
+	RecoilScoreError RecoilScoreMode = iota
+
+	// RecoilScoreSimilarity scores the shape of the spray instead: the
+	// cosine similarity between the player's view movement from bullet to
+	// bullet and the movement that cancels the pattern, over every counted
+	// burst. Movement doesn't depend on where the spray started, so a burst
+	// opened slightly off target or re-aimed early isn't penalized the way
+	// the error model penalizes it. A similarity near 1 with the movement's
+	// size matching the pattern's is what a compensation script produces;
+	// published as recoil_pattern_similarity and
+	// recoil_pattern_magnitude_error.
+	RecoilScoreSimilarity
+)
+
+const (
+	// Similarity band of the similarity model: a human pull-down follows
+	// the pattern's vertical climb closely, so the band sits high and the
+	// horizontal wobble is what separates a hand from a script.
+	recoilSimilarityClean   = 0.95
+	recoilSimilarityBlatant = 0.995
+
+	// Magnitude error (relative difference between the size of the
+	// player's movement and the pattern's) at or below which the
+	// similarity counts in full, and at or above which it doesn't count.
+	recoilMagnitudeExact = 0.10
+	recoilMagnitudeLoose = 0.40
+)
+
+// sprayShape accumulates the view deltas of a player's sprays against the
+// deltas that cancel the pattern, as the dot product and squared lengths of
+// the two vectors every delta is appended to. Pooling bursts this way
+// weights each by how much it moved rather than averaging per-burst
+// similarities, so two-bullet bursts don't swing the result.
+type sprayShape struct {
+	dot       float64 // Σ actual·canonical
+	actual    float64 // Σ |actual|²
+	canonical float64 // Σ |canonical|²
+	deltas    int
+}
+
+// add appends one bullet's view delta and the pattern's delta for it.
+func (s *sprayShape) add(dYaw, dPitch, wantYaw, wantPitch float64) {
+	s.dot += dYaw*wantYaw + dPitch*wantPitch
+	s.actual += dYaw*dYaw + dPitch*dPitch
+	s.canonical += wantYaw*wantYaw + wantPitch*wantPitch
+	s.deltas++
+}
+
+// merge adds o's deltas to s.
+func (s *sprayShape) merge(o sprayShape) {
+	s.dot += o.dot
+	s.actual += o.actual
+	s.canonical += o.canonical
+	s.deltas += o.deltas
+}
+
+// similarity is the cosine similarity of the two vectors, -1 to 1; 0 when
+// either never moved.
+func (s sprayShape) similarity() float64 {
+	if s.actual == 0 || s.canonical == 0 {
+		return 0
+	}
+	return s.dot / math.Sqrt(s.actual*s.canonical)
+}
+
+// magnitudeError is how far the length of the player's movement is from
+// the pattern's, relative to the pattern's.
+func (s sprayShape) magnitudeError() float64 {
+	if s.canonical == 0 {
+		return 0
+	}
+	return math.Abs(math.Sqrt(s.actual)-math.Sqrt(s.canonical)) / math.Sqrt(s.canonical)
+}
+
+// similarityScoreFor maps a spray shape onto the 0-1 recoil score: the
+// similarity's position in its band, discounted as the movement's size
+// strays from the pattern's.
+func similarityScoreFor(similarity, magnitudeError float64) float64 {
+	return linearScore(similarity, recoilSimilarityClean, recoilSimilarityBlatant) *
+		(1 - linearScore(magnitudeError, recoilMagnitudeExact, recoilMagnitudeLoose))
+}
+
+// viewDelta is the signed change from one angle to the next, wrapped to
+// -180..180 degrees.
+func viewDelta(from, to float64) float64 {
+	return math.Mod(to-from+540, 360) - 180
+}
+
+// addShapeDelta records the view movement into bullet index of state's
+// burst, from the previous shot at prevYaw/prevPitch, against the movement
+// the pattern asks for: view = first shot - offset, so the wanted delta is
+// the negated change in offset. Bullets without a pattern entry are skipped.
+func (rc *RecoilControlCollector) addShapeDelta(state *sprayState, index int, prevYaw, prevPitch, yaw, pitch float64) {
+	if index < 2 || index > rc.maxBulletIdx {
+		return
+	}
+	yawOff, pitchOff, ok := getRecoilOffsets(rc.patterns, state.weapon, index)
+	if !ok {
+		return
+	}
+	prevYawOff, prevPitchOff, _ := getRecoilOffsets(rc.patterns, state.weapon, index-1)
+	state.shape.add(viewDelta(prevYaw, yaw), viewDelta(prevPitch, pitch),
+		-(yawOff - prevYawOff), -(pitchOff - prevPitchOff))
+}
+
+// similarityScore publishes recoil_pattern_similarity and
+// recoil_pattern_magnitude_error for steamID and returns the similarity
+// model's recoil score. ok is false with fewer than recoilWeaponMinBullets
+// deltas.
+func (rc *RecoilControlCollector) similarityScore(steamID uint64, playerStats *PlayerStats) (float64, bool) {
+	shape := rc.shapes[steamID]
+	if shape.deltas < recoilWeaponMinBullets {
+		return 0, false
+	}
+	similarity, magnitude := shape.similarity(), shape.magnitudeError()
+	playerStats.AddMetric(CatRecoil, KeyRecoilPatternSimilarity, Metric{
+		Type:        MetricFloat,
+		FloatValue:  similarity,
+		Description: "Cosine similarity between the view movement during sprays and the pattern's (-1 to 1)",
+	})
+	playerStats.AddMetric(CatRecoil, KeyRecoilMagnitudeError, Metric{
+		Type:        MetricFloat,
+		FloatValue:  magnitude,
+		Description: "Relative difference between the size of the view movement during sprays and the pattern's",
+	})
+	return similarityScoreFor(similarity, magnitude), true
+}
@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// sprayAK feeds one 30-bullet AK burst with view(i) into a fresh state and
+// returns its shape.
+func sprayAK(rc *RecoilControlCollector, view func(i int) (yaw, pitch float64)) sprayShape {
+	state := &sprayState{weapon: common.EqAK47}
+	prevYaw, prevPitch := view(1)
+	for i := 2; i <= 30; i++ {
+		yaw, pitch := view(i)
+		rc.addShapeDelta(state, i, prevYaw, prevPitch, yaw, pitch)
+		prevYaw, prevPitch = yaw, pitch
+	}
+	return state.shape
+}
+
+func TestSprayShapeSimilarity(t *testing.T) {
+	rc := NewRecoilControlCollector()
+	compensated := func(startYaw, startPitch float64) func(i int) (float64, float64) {
+		return func(i int) (float64, float64) {
+			yawOff, pitchOff, _ := getRecoilOffsets(rc.patterns, common.EqAK47, i)
+			return normalizeAngle(startYaw - yawOff), normalizeAngle(startPitch - pitchOff)
+		}
+	}
+
+	perfect := sprayAK(rc, compensated(90, 0))
+	if s, m := perfect.similarity(), perfect.magnitudeError(); s < 0.9999 || m > 1e-9 {
+		t.Errorf("perfect spray: similarity %.4f, magnitude error %.4f; want 1 and 0", s, m)
+	}
+	if score := similarityScoreFor(perfect.similarity(), perfect.magnitudeError()); score != 1 {
+		t.Errorf("perfect spray scores %.2f, want 1", score)
+	}
+
+	// The same compensation started elsewhere, across the yaw wrap, has the
+	// same shape
+	shifted := sprayAK(rc, compensated(359.5, 3))
+	if s := shifted.similarity(); s < 0.9999 {
+		t.Errorf("shifted perfect spray: similarity %.4f, want 1", s)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	yaw, pitch := 90.0, 0.0
+	random := sprayAK(rc, func(int) (float64, float64) {
+		yaw += rng.Float64()*2 - 1
+		pitch += rng.Float64()*2 - 1
+		return normalizeAngle(yaw), normalizeAngle(pitch)
+	})
+	if s := random.similarity(); s > 0.5 {
+		t.Errorf("random spray: similarity %.2f, want well below 1", s)
+	}
+	if score := similarityScoreFor(random.similarity(), random.magnitudeError()); score != 0 {
+		t.Errorf("random spray scores %.2f, want 0", score)
+	}
+
+	// Matching direction at twice the size is over-pulling, not a script
+	if score := similarityScoreFor(1, 1); score != 0 {
+		t.Errorf("doubled movement scores %.2f, want 0", score)
+	}
+}
+
+func TestRecoilSimilarityMode(t *testing.T) {
+	rc := NewRecoilControlCollector()
+	ps := &PlayerStats{Categories: make(map[Category]map[Key]Metric)}
+	if _, ok := rc.similarityScore(1, ps); ok {
+		t.Fatal("similarity scored without sprays")
+	}
+
+	rc.ScoreMode = RecoilScoreSimilarity
+	shape := sprayAK(rc, func(i int) (float64, float64) {
+		yawOff, pitchOff, _ := getRecoilOffsets(rc.patterns, common.EqAK47, i)
+		return 180 - yawOff, normalizeAngle(-pitchOff)
+	})
+	rc.shapes[1] = shape
+	score, ok := rc.similarityScore(1, ps)
+	if !ok || score != 1 {
+		t.Fatalf("similarityScore = %.2f %v, want 1 true", score, ok)
+	}
+	if s, _ := psGetFloat(ps, CatRecoil, KeyRecoilPatternSimilarity); s < 0.9999 {
+		t.Errorf("recoil_pattern_similarity = %.4f", s)
+	}
+}
@@ -0,0 +1,150 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+func TestWeaponRecoilScoresPicksTightestWeapon(t *testing.T) {
+	rc := NewRecoilControlCollector()
+	ps := &PlayerStats{Categories: make(map[Category]map[Key]Metric)}
+	add := func(name string, bullets int64, errorSum float64) {
+		ps.AddMetric(Category("recoil"), Key(name+"_bullets"), Metric{Type: MetricInteger, IntValue: bullets})
+		ps.AddMetric(Category("recoil"), Key(name+"_error_sum"), Metric{Type: MetricFloat, FloatValue: errorSum})
+	}
+	rc.burstMeans[1] = map[common.EquipmentType][]float64{
+		common.EqAK47: {0.2},
+		common.EqM4A4: {1.5},
+		common.EqMP9:  {0.1},
+	}
+	add("ak47", 40, 8)    // 0.2° — compensated
+	add("m4a4", 200, 300) // 1.5° — honest, and most of the bullets
+	add("mp9", 5, 0.5)    // 0.1° but too few bullets to count
+
+	weapon, score, ok := rc.weaponRecoilScores(1, ps)
+	if !ok || weapon != "ak47" || score != 1 {
+		t.Fatalf("got %q %.2f %v, want ak47 1.00", weapon, score, ok)
+	}
+	if s, _ := psGetFloat(ps, Category("recoil"), Key("m4a4_recoil_score")); s != 0 {
+		t.Errorf("m4a4_recoil_score = %.2f, want 0", s)
+	}
+	if _, found := ps.GetMetric(Category("recoil"), Key("mp9_mean_error")); !found {
+		t.Error("mp9_mean_error not published")
+	}
+}
+
+func TestRecoilScoreFor(t *testing.T) {
+	mid := (recoilPerfectError + recoilZeroError) / 2
+	for _, tc := range []struct{ err, want float64 }{{0.2, 1}, {recoilZeroError, 0}, {mid, 0.5}} {
+		if got := recoilScoreFor(tc.err); got < tc.want-1e-9 || got > tc.want+1e-9 {
+			t.Errorf("recoilScoreFor(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestRecoilWeaponSwitchEndsBurst(t *testing.T) {
+	parser := &warmupStubParser{}
+	ds := NewDemoStats()
+	rc := NewRecoilControlCollector()
+	rc.Setup(parser, ds)
+
+	shooter := &common.Player{SteamID64: 1, Team: common.TeamTerrorists}
+	fire := func(weapon common.EquipmentType) {
+		parser.dispatch(events.WeaponFire{Shooter: shooter, Weapon: common.NewEquipment(weapon)})
+		parser.frame += 6 // ~94 ms at 64 tick, inside the burst gap
+	}
+	fire(common.EqAK47)
+	fire(common.EqAK47)
+	fire(common.EqAK47)
+	fire(common.EqM4A4)
+
+	state := rc.sprayStates[1]
+	if state == nil || state.weapon != common.EqM4A4 || state.bulletIndex != 1 {
+		t.Fatalf("M4A4 shot continued the AK burst: %+v", state)
+	}
+	ps := ds.Players[1]
+	if ps == nil || intMetric(ps, Category("recoil"), Key("burst_count")) != 1 {
+		t.Fatal("AK burst not finalized on the switch")
+	}
+	if n := intMetric(ps, Category("recoil"), Key("m4a4_bullets")); n != 0 {
+		t.Errorf("m4a4_bullets = %d, want 0", n)
+	}
+
+	// ItemEquip alone ends the burst too.
+	parser.dispatch(events.ItemEquip{Player: shooter, Weapon: common.NewEquipment(common.EqAK47)})
+	if _, ok := rc.sprayStates[1]; ok {
+		t.Error("burst survived ItemEquip of another weapon")
+	}
+}
+
+func TestRecoilBurstGapIsTime(t *testing.T) {
+	parser := &warmupStubParser{}
+	ds := NewDemoStats()
+	rc := NewRecoilControlCollector()
+	rc.Setup(parser, ds)
+
+	shooter := &common.Player{SteamID64: 1, Team: common.TeamTerrorists}
+	fireAfter := func(frames int) {
+		parser.frame += frames
+		parser.dispatch(events.WeaponFire{Shooter: shooter, Weapon: common.NewEquipment(common.EqAK47)})
+	}
+	fireAfter(0)
+	fireAfter(13) // ~203 ms: still one burst
+	if rc.sprayStates[1].bulletIndex != 2 {
+		t.Fatalf("shot inside the gap started a new burst: %+v", rc.sprayStates[1])
+	}
+	fireAfter(15) // ~234 ms: a new burst
+	if rc.sprayStates[1].bulletIndex != 1 {
+		t.Fatalf("shot past the gap continued the burst: %+v", rc.sprayStates[1])
+	}
+
+	rc.MaxBurstGap = 300 * time.Millisecond
+	fireAfter(15)
+	if rc.sprayStates[1].bulletIndex != 2 {
+		t.Errorf("MaxBurstGap override ignored: %+v", rc.sprayStates[1])
+	}
+}
+
+func TestLongSprayPerfectRatio(t *testing.T) {
+	rc := NewRecoilControlCollector()
+	ds := NewDemoStats()
+	burst := func(bullets int, maxError float64) {
+		rc.finalizeBurst(&sprayState{
+			inBurst:        true,
+			bulletIndex:    bullets,
+			weapon:         common.EqAK47,
+			sumError:       maxError * float64(bullets-2),
+			maxError:       maxError,
+			countedBullets: bullets - 2,
+		}, 1, ds)
+	}
+	burst(20, 0.1)  // long and perfect the whole way
+	burst(25, 0.2)  // long and perfect
+	burst(18, 0.9)  // long, one bullet well off
+	burst(5, 0.1)   // short: a controlled burst, not a long spray
+	burst(30, 0.25) // long and perfect
+
+	ps := ds.Players[1]
+	ratio, ok := rc.longSprayStats(1, ps)
+	if !ok || ratio != 0.75 {
+		t.Fatalf("long_spray_perfect_ratio = %.2f %v, want 0.75", ratio, ok)
+	}
+	if n, _ := psGetInt(ps, CatRecoil, KeyLongSprays); n != 4 {
+		t.Errorf("long_sprays = %d, want 4", n)
+	}
+	if m, _ := psGetFloat(ps, CatRecoil, KeyMedianSprayBullets); m != 20 {
+		t.Errorf("median_spray_bullets = %.0f, want 20", m)
+	}
+
+	// Two long sprays aren't enough to publish a ratio.
+	rc = NewRecoilControlCollector()
+	ds = NewDemoStats()
+	burst(20, 0.1)
+	burst(20, 0.1)
+	if _, ok := rc.longSprayStats(1, ds.Players[1]); ok {
+		t.Error("ratio published from two long sprays")
+	}
+}
@@ -0,0 +1,145 @@
+package stats
+
+import "sort"
+
+// ReportModel is a report as data rather than formatted bytes: the demo's
+// metadata and one section per category for the demo-wide metrics and for
+// each player. BuildReport shapes it with the ordering and labels of the
+// rendered reports; the reporters format it, and library users can read it
+// directly.
+type ReportModel struct {
+	Demo      string
+	Map       string
+	TickRate  float64
+	TickCount int
+
+	// Global holds the demo-wide sections; see DemoStats.AddMetric.
+	Global []ReportSection
+
+	// Players are ordered by SteamID.
+	Players []ReportPlayer
+}
+
+// ReportPlayer is one player's part of a ReportModel.
+type ReportPlayer struct {
+	SteamID  uint64
+	Name     string
+	Sections []ReportSection
+}
+
+// ReportSection is one category's metrics. Sections come in the order the
+// rendered reports show them — the known categories first, the rest
+// alphabetically — and rows in the category's display order.
+type ReportSection struct {
+	Category Category
+	Title    string
+
+	// Note is "informational" for categories that don't feed the cheat
+	// verdict.
+	Note string
+
+	Rows []ReportRow
+}
+
+// ReportRow is one metric: its key, the label and formatted value the
+// rendered reports show, and the typed metric for consumers that want the
+// raw value. Value is "-" when the metric has nothing to show.
+type ReportRow struct {
+	Key    Key
+	Label  string
+	Value  string
+	Metric Metric
+}
+
+// BuildReport shapes ds into a ReportModel. When categories is non-empty
+// only those categories are included. Every metric is kept, including the
+// ones the rendered reports show elsewhere on a player's card or leave out.
+func BuildReport(ds *DemoStats, categories []Category) ReportModel {
+	model := ReportModel{
+		Demo:      ds.DemoName,
+		Map:       ds.MapName,
+		TickRate:  ds.TickRate,
+		TickCount: ds.TickCount,
+		Global:    reportSections(&PlayerStats{Categories: ds.GlobalMetrics}, categories),
+		Players:   make([]ReportPlayer, 0, len(ds.Players)),
+	}
+	for _, sid := range sortedSteamIDs(ds) {
+		ps := ds.Players[sid]
+		model.Players = append(model.Players, ReportPlayer{
+			SteamID:  sid,
+			Name:     ps.Player.Name,
+			Sections: reportSections(ps, categories),
+		})
+	}
+	return model
+}
+
+// reportCategories returns the categories of ps to write, sorted: the
+// requested ones it has, or all of them when none were requested.
+func reportCategories(ps *PlayerStats, requested []Category) []Category {
+	out := make([]Category, 0, len(ps.Categories))
+	if len(requested) == 0 {
+		for cat := range ps.Categories {
+			out = append(out, cat)
+		}
+	} else {
+		for _, cat := range requested {
+			if _, ok := ps.Categories[cat]; ok {
+				out = append(out, cat)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// reportSections builds a section for each of ps's categories, restricted to
+// categories when it is non-empty, in display order.
+func reportSections(ps *PlayerStats, categories []Category) []ReportSection {
+	cats := reportCategories(ps, categories)
+	rank := make(map[Category]int, len(categoryDisplay))
+	for i, spec := range categoryDisplay {
+		rank[spec.Key] = i + 1
+	}
+	// reportCategories sorts alphabetically, which the stable sort keeps
+	// for the categories categoryDisplay doesn't list.
+	sort.SliceStable(cats, func(i, j int) bool {
+		ri, rj := rank[cats[i]], rank[cats[j]]
+		if ri == 0 || rj == 0 {
+			return ri != 0 && rj == 0
+		}
+		return ri < rj
+	})
+
+	out := make([]ReportSection, 0, len(cats))
+	for _, cat := range cats {
+		out = append(out, reportSection(ps, cat))
+	}
+	return out
+}
+
+// reportSection builds cat's section of ps, titled from categoryDisplay or
+// the category name.
+func reportSection(ps *PlayerStats, cat Category) ReportSection {
+	s := ReportSection{Category: cat, Title: titleize(string(cat))}
+	for _, spec := range categoryDisplay {
+		if spec.Key == cat {
+			s.Title, s.Note = spec.Title, spec.Note
+			break
+		}
+	}
+
+	keys := make([]Key, 0, len(ps.Categories[cat]))
+	for k := range ps.Categories[cat] {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return categoryKeyOrder(cat, keys[i]) < categoryKeyOrder(cat, keys[j])
+	})
+	s.Rows = make([]ReportRow, 0, len(keys))
+	for _, k := range keys {
+		m := ps.Categories[cat][k]
+		s.Rows = append(s.Rows, ReportRow{Key: k, Label: metricLabel(cat, k), Value: formatMetricValue(m), Metric: m})
+	}
+	return s
+}
@@ -0,0 +1,54 @@
+package stats
+
+import "testing"
+
+func TestBuildReport(t *testing.T) {
+	ds := NewDemoStats()
+	ds.DemoName = "match.dem"
+	ds.AddMetric(CatGameInfo, KeyGameMode, Metric{Type: MetricString, StringValue: "Competitive"})
+	for _, sid := range []uint64{9, 3} {
+		ps := ds.GetOrCreatePlayerStatsBySteamID(sid)
+		ps.AddMetric(CatKills, KeyHeadshotPercentage, Metric{Type: MetricPercentage, FloatValue: 62.5})
+		ps.AddMetric(CatKills, KeyTotalKills, Metric{Type: MetricInteger, IntValue: 16})
+		ps.AddMetric(CatRecoil, KeyRecoilScore, Metric{Type: MetricFloat, FloatValue: 0.25})
+		ps.AddMetric(Category("zeta"), Key("custom"), Metric{Type: MetricInteger, IntValue: 1})
+		ps.AddMetric(Category("alpha"), Key("custom"), Metric{Type: MetricInteger, IntValue: 1})
+	}
+
+	model := BuildReport(ds, nil)
+	if model.Demo != "match.dem" || len(model.Players) != 2 || model.Players[0].SteamID != 3 {
+		t.Fatalf("model = %+v, want match.dem with players 3 then 9", model)
+	}
+	if len(model.Global) != 1 || model.Global[0].Rows[0].Value != "Competitive" {
+		t.Errorf("global sections = %+v", model.Global)
+	}
+
+	// Known categories in display order, then the rest alphabetically
+	var order []Category
+	for _, s := range model.Players[0].Sections {
+		order = append(order, s.Category)
+	}
+	want := []Category{CatKills, CatRecoil, "alpha", "zeta"}
+	if len(order) != len(want) {
+		t.Fatalf("sections = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("sections = %v, want %v", order, want)
+		}
+	}
+
+	kills := model.Players[0].Sections[0]
+	if kills.Title != "Combat" || kills.Rows[0].Key != KeyTotalKills {
+		t.Errorf("kills section = %+v, want Combat led by total_kills", kills)
+	}
+	hs := kills.Rows[1]
+	if hs.Value != "62.50%" || hs.Metric.FloatValue != 62.5 || hs.Label == "" {
+		t.Errorf("headshot row = %+v", hs)
+	}
+
+	filtered := BuildReport(ds, []Category{CatRecoil})
+	if s := filtered.Players[0].Sections; len(s) != 1 || s[0].Category != CatRecoil || len(filtered.Global) != 0 {
+		t.Errorf("recoil-only report = %+v", filtered)
+	}
+}
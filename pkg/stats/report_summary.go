@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReportSummary returns a short plain-text lobby summary for the top of a
+// report: map, game mode, rounds and player count, how many players the
+// cheat detector flagged and who, and the highest cheat likelihood. Flags
+// and likelihoods come from the anti_cheat metrics, so ds must already have
+// been through the detector; without them every player reads as clean.
+func ReportSummary(ds *DemoStats) string {
+	if ds == nil {
+		return "No statistics available"
+	}
+	data := buildHTMLData(ds)
+
+	meta := make([]string, 0, 4)
+	if data.MapName != "" {
+		meta = append(meta, "Map "+data.MapName)
+	}
+	if data.GameMode != "" {
+		meta = append(meta, data.GameMode)
+	}
+	if data.RoundCount > 0 {
+		meta = append(meta, fmt.Sprintf("%d rounds", data.RoundCount))
+	}
+	meta = append(meta, fmt.Sprintf("%d players", data.PlayerCount))
+
+	lines := []string{strings.Join(meta, " · ")}
+	if data.PlayerCount > 0 {
+		headline, details := summaryVerdict(data)
+		lines = append(lines, headline)
+		lines = append(lines, details...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// summaryVerdict is the flagged-count headline and the detail lines under
+// it, shared by ReportSummary and the terminal verdict block.
+func summaryVerdict(d htmlData) (string, []string) {
+	if d.InsufficientData != "" {
+		return "Insufficient data: " + d.InsufficientData + ".",
+			[]string{"No cheat likelihoods were computed — this is not a clean result."}
+	}
+	headline := fmt.Sprintf("%d of %d players flagged.", d.FlaggedCount, d.PlayerCount)
+
+	var details []string
+	if d.FlaggedCount > 0 {
+		flagged := make([]string, 0, d.FlaggedCount)
+		for _, p := range d.Players { // ordered by likelihood, highest first
+			if p.Flagged {
+				flagged = append(flagged, fmt.Sprintf("%s (%.1f%%)", p.Name, p.Likelihood))
+			}
+		}
+		details = append(details, "Flagged: "+strings.Join(flagged, ", ")+".")
+	}
+	details = append(details, fmt.Sprintf(
+		"Threshold for auto-flag is %.0f%%. Highest %.1f%% (%s), lowest %.1f%% (%s).",
+		flagThreshold, d.HighestLikelihood, d.HighestName, d.LowestLikelihood, d.LowestName,
+	))
+	if d.IntegrityWarnings > 0 {
+		details = append(details, fmt.Sprintf(
+			"%d frames went back in time and were skipped — the demo may be corrupt, treat these stats with caution.",
+			d.IntegrityWarnings,
+		))
+	}
+	return headline, details
+}
@@ -0,0 +1,42 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReportSummary(t *testing.T) {
+	ds := NewDemoStats()
+	ds.MapName = "de_nuke"
+	ds.AddMetric(Category("game_info"), Key("round_count"), Metric{Type: MetricInteger, IntValue: 24})
+
+	add := func(sid uint64, name string, likelihood float64, cheater string) {
+		ps := ds.GetOrCreatePlayerStatsBySteamID(sid)
+		ps.Player.Name = name
+		ps.AddMetric(Category("anti_cheat"), Key("cheat_likelihood"), Metric{Type: MetricPercentage, FloatValue: likelihood})
+		ps.AddMetric(Category("anti_cheat"), Key("cheater"), Metric{Type: MetricString, StringValue: cheater})
+	}
+	add(1, "alice", 12, "No")
+	add(2, "bob", 81.5, "Yes")
+	add(3, "carol", 55, "Yes")
+
+	got := ReportSummary(ds)
+	for _, want := range []string{
+		"Map de_nuke · 24 rounds · 3 players",
+		"2 of 3 players flagged.",
+		"Flagged: bob (81.5%), carol (55.0%).",
+		"Highest 81.5% (bob)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("summary missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "went back in time") {
+		t.Errorf("integrity warning on a clean demo:\n%s", got)
+	}
+
+	ds.AddMetric(Category("game_info"), Key("demo_integrity_warnings"), Metric{Type: MetricInteger, IntValue: 3})
+	if got := ReportSummary(ds); !strings.Contains(got, "3 frames went back in time") {
+		t.Errorf("summary missing the integrity warning:\n%s", got)
+	}
+}
@@ -3,11 +3,16 @@ package stats
 import (
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"sort"
 )
 
 // Reporter defines the interface for statistics output formatters.
 type Reporter interface {
 	Report(demoStats *DemoStats, categories []Category, writer io.Writer) error
+	// Extension is the file extension (without the dot) for this format.
+	Extension() string
 }
 
 // TextReporter renders the colored, layout-rich terminal report. The
@@ -15,6 +20,8 @@ type Reporter interface {
 // ASCII when the writer is not a TTY or NO_COLOR is set.
 type TextReporter struct {
 	title string
+	// color overrides TTY detection when set; see EnableColor.
+	color *bool
 }
 
 // NewTextReporter creates a TextReporter that prints `title` in the header.
@@ -22,11 +29,22 @@ func NewTextReporter(title string) *TextReporter {
 	return &TextReporter{title: title}
 }
 
+// EnableColor forces colored output on or off regardless of whether the
+// writer is a TTY — e.g. on for `less -R` or CI logs that render ANSI, off
+// for a terminal that should get plain text. Without a call the reporter
+// auto-detects.
+func (tr *TextReporter) EnableColor(on bool) {
+	tr.color = &on
+}
+
+// Extension returns "txt".
+func (tr *TextReporter) Extension() string { return "txt" }
+
 // Report renders the report. The categories argument is accepted for
 // Reporter compatibility but is unused — the renderer derives its own
 // ordering from html_reporter.go's shared builders.
 func (tr *TextReporter) Report(demoStats *DemoStats, _ []Category, writer io.Writer) error {
-	return renderTerminal(demoStats, writer, tr.title)
+	return renderTerminal(demoStats, writer, tr.title, tr.color)
 }
 
 // formatMetricValue formats a metric for display. Shared with the HTML
@@ -59,3 +77,68 @@ func getMetricFloatValue(playerStats *PlayerStats, category Category, key Key) f
 	return 0.0
 }
 
+// ReportToDir runs r once per category, writing dir/{category}.{ext}. Each
+// call sees a copy of ds holding only that category, so reporters that
+// render everything they are given still produce one category per file.
+// With no categories, every category present in ds is written. dir is
+// created if needed.
+func ReportToDir(ds *DemoStats, categories []Category, dir string, r Reporter) error {
+	if len(categories) == 0 {
+		categories = presentCategories(ds)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create output dir: %w", err)
+	}
+	for _, cat := range categories {
+		path := filepath.Join(dir, string(cat)+"."+r.Extension())
+		if err := reportCategoryToFile(ds.withCategories(cat), cat, path, r); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func reportCategoryToFile(ds *DemoStats, cat Category, path string, r Reporter) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := r.Report(ds, []Category{cat}, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// presentCategories returns every category any player has, sorted.
+func presentCategories(ds *DemoStats) []Category {
+	seen := make(map[Category]bool)
+	out := make([]Category, 0)
+	for _, ps := range ds.Players {
+		for cat := range ps.Categories {
+			if !seen[cat] {
+				seen[cat] = true
+				out = append(out, cat)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// withCategories returns a shallow copy of ds whose players hold only the
+// given categories. Metric maps are shared, not copied.
+func (ds *DemoStats) withCategories(categories ...Category) *DemoStats {
+	out := *ds
+	out.Players = make(map[uint64]*PlayerStats, len(ds.Players))
+	for sid, ps := range ds.Players {
+		cp := &PlayerStats{Player: ps.Player, Categories: make(map[Category]map[Key]Metric)}
+		for _, cat := range categories {
+			if m, ok := ps.Categories[cat]; ok {
+				cp.Categories[cat] = m
+			}
+		}
+		out.Players[sid] = cp
+	}
+	return &out
+}
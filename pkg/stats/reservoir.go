@@ -0,0 +1,92 @@
+package stats
+
+import (
+	"math/rand/v2"
+	"sort"
+)
+
+// DefaultMaxSamples is the default number of samples a collector keeps per
+// player for each percentile it publishes (time-to-damage, snap velocities)
+// and for raw-sample export.
+//
+// Past the cap the kept samples are a uniform random subset of everything
+// seen (reservoir sampling), so percentiles stay unbiased but are estimated
+// rather than exact. The standard error of the p-th quantile's rank is about
+// sqrt(p(1-p)/n): at 5000 samples that is ±0.7 percentile points for the
+// median, ±0.4 for p10 and ±0.3 for p95, well inside the resolution the
+// cheat score uses. Counts, sums and ratios (ttd_samples, snap_count,
+// avg_snap_velocity, sub_100ms_ttd) are kept exactly. A competitive match produces a few hundred samples per player, so the cap
+// only engages on very long demos such as deathmatch or retake servers.
+const DefaultMaxSamples = 5000
+
+// SampleLimiter is implemented by collectors that bound the samples they
+// keep per player. n <= 0 keeps every sample. SetMaxSamples must be called
+// before Setup.
+type SampleLimiter interface {
+	SetMaxSamples(n int)
+}
+
+// sampleReservoir keeps at most max of the values added to it, chosen
+// uniformly at random from all of them (Vitter's Algorithm R). The random
+// source is seeded with a constant so the same demo always yields the same
+// subset and the same report.
+type sampleReservoir struct {
+	max    int
+	seen   int
+	values []float64
+	order  []int // arrival index of each kept value
+	rng    *rand.Rand
+}
+
+func newSampleReservoir(max int) *sampleReservoir {
+	return &sampleReservoir{max: max}
+}
+
+// add offers v to the reservoir.
+func (r *sampleReservoir) add(v float64) {
+	r.seen++
+	if r.max <= 0 || len(r.values) < r.max {
+		r.values = append(r.values, v)
+		r.order = append(r.order, r.seen-1)
+		return
+	}
+	if r.rng == nil {
+		r.rng = rand.New(rand.NewPCG(0x5eed, uint64(r.max)))
+	}
+	if j := r.rng.IntN(r.seen); j < r.max {
+		r.values[j] = v
+		r.order[j] = r.seen - 1
+	}
+}
+
+// sorted returns the kept values in ascending order.
+func (r *sampleReservoir) sorted() []float64 {
+	out := append([]float64(nil), r.values...)
+	sort.Float64s(out)
+	return out
+}
+
+// inOrder returns the kept values in the order they were added.
+func (r *sampleReservoir) inOrder() []float64 {
+	idx := make([]int, len(r.values))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(a, b int) bool { return r.order[idx[a]] < r.order[idx[b]] })
+	out := make([]float64, len(idx))
+	for i, j := range idx {
+		out[i] = r.values[j]
+	}
+	return out
+}
+
+// addSample adds v to steamID's reservoir in m, creating it with the given
+// cap if needed.
+func addSample(m map[uint64]*sampleReservoir, steamID uint64, v float64, max int) {
+	r, ok := m[steamID]
+	if !ok {
+		r = newSampleReservoir(max)
+		m[steamID] = r
+	}
+	r.add(v)
+}
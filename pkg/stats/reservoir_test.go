@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSampleReservoirCap(t *testing.T) {
+	r := newSampleReservoir(1000)
+	for i := 0; i < 100000; i++ {
+		r.add(float64(i))
+	}
+	if r.seen != 100000 || len(r.values) != 1000 {
+		t.Fatalf("seen %d, kept %d", r.seen, len(r.values))
+	}
+	// A uniform subset of 0..99999: percentiles land within a few standard
+	// errors (sqrt(p(1-p)/n) ≈ 1.6 points at the median) of the true ones.
+	s := r.sorted()
+	for _, p := range []float64{0.1, 0.5, 0.95} {
+		got := s[int(float64(len(s))*p)] / 100000
+		if math.Abs(got-p) > 0.05 {
+			t.Errorf("p%.0f = %.3f of the range, want ≈ %.2f", p*100, got, p)
+		}
+	}
+	ordered := r.inOrder()
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i] <= ordered[i-1] {
+			t.Fatalf("inOrder not in arrival order at %d: %v, %v", i, ordered[i-1], ordered[i])
+		}
+	}
+}
+
+func TestSampleReservoirUnbounded(t *testing.T) {
+	r := newSampleReservoir(0)
+	for _, v := range []float64{3, 1, 2} {
+		r.add(v)
+	}
+	if got := r.inOrder(); len(got) != 3 || got[0] != 3 || got[2] != 2 {
+		t.Errorf("inOrder = %v", got)
+	}
+	if got := r.sorted(); got[0] != 1 || got[2] != 3 {
+		t.Errorf("sorted = %v", got)
+	}
+}
+
+func TestReactionCapKeepsExactCounts(t *testing.T) {
+	rtc := NewReactionTimeCollector()
+	rtc.SetMaxSamples(10)
+	for i := 0; i < 50; i++ {
+		addSample(rtc.ttds, 7, float64(50+i*10), rtc.maxSamples)
+		if 50+i*10 <= 100 {
+			rtc.sub100[7]++
+		}
+	}
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(7)
+	rtc.CollectFinalStats(ds)
+	ps := ds.Players[7]
+	if n := intMetric(ps, Category("reaction"), Key("ttd_samples")); n != 50 {
+		t.Errorf("ttd_samples = %d, want 50", n)
+	}
+	if v, _ := psGetFloat(ps, Category("reaction"), Key("sub_100ms_ttd")); v != 12 {
+		t.Errorf("sub_100ms_ttd = %v, want 12", v)
+	}
+}
@@ -0,0 +1,232 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// RoundSample is one channel measurement tagged with the round it was
+// taken in. Channel is a cheat-score channel ID ("hs", "snap", "reaction");
+// Value is in that channel's raw unit — 1/0 for a headshot / body kill,
+// °/ms for a snap, ms for a time-to-damage.
+type RoundSample struct {
+	Round   int
+	Channel string
+	Value   float64
+}
+
+// AddRoundSample records a round-tagged sample for steamID. Samples from
+// round 0 (warmup) and from players outside the filter are dropped.
+func (ds *DemoStats) AddRoundSample(steamID uint64, round int, channel string, value float64) {
+	if round <= 0 || steamID == 0 || !ds.TracksPlayer(steamID) {
+		return
+	}
+	if ds.roundSamples == nil {
+		ds.roundSamples = make(map[uint64][]RoundSample)
+	}
+	ds.roundSamples[steamID] = append(ds.roundSamples[steamID], RoundSample{Round: round, Channel: channel, Value: value})
+}
+
+// Per-round minimum samples before a channel is scored. One headshot or one
+// fast reaction in a round is routine; these keep single events from
+// lighting up a cell on their own.
+const (
+	roundMinKills = 2
+	roundMinSnaps = 2
+	roundMinTTDs  = 2
+)
+
+// RoundCell is one player-round of the suspicion grid. Score is 0–1; HasData
+// is false when no channel reached its per-round minimum.
+type RoundCell struct {
+	Score   float64
+	HasData bool
+}
+
+// RoundGridRow is one player's row of the suspicion grid.
+type RoundGridRow struct {
+	SteamID64 uint64
+	Name      string
+	Cells     []RoundCell // Cells[i] is round i+1
+}
+
+// RoundGrid is the per-player, per-round suspicion matrix.
+type RoundGrid struct {
+	Rounds int
+	Rows   []RoundGridRow // ordered by name, then SteamID
+}
+
+// RoundSuspicionGrid scores every player-round from the round-tagged
+// samples. A round's score is the peak score among the hs, snap, reaction
+// and ttd_sub100 channels, using the same ramps (and map profile) as the
+// match-level channels but without confidence weighting — the grid is meant
+// to show *when* a signal spiked, the match-level likelihood decides whether
+// it matters.
+func (ds *DemoStats) RoundSuspicionGrid() RoundGrid {
+	grid := RoundGrid{}
+	for _, samples := range ds.roundSamples {
+		for _, s := range samples {
+			if s.Round > grid.Rounds {
+				grid.Rounds = s.Round
+			}
+		}
+	}
+
+	profile, _ := mapProfileFor(ds.MapName)
+	fast, _ := ds.GetMetric(CatGameInfo, KeyFastTTDMs)
+	thresholds := ReactionThresholds{FastMs: fast.FloatValue}.withDefaults()
+	for sid, ps := range ds.Players {
+		row := RoundGridRow{SteamID64: sid, Name: ps.Player.Name, Cells: make([]RoundCell, grid.Rounds)}
+		byRound := make(map[int][]RoundSample)
+		for _, s := range ds.roundSamples[sid] {
+			byRound[s.Round] = append(byRound[s.Round], s)
+		}
+		for round, samples := range byRound {
+			row.Cells[round-1] = scoreRound(samples, profile, thresholds)
+		}
+		grid.Rows = append(grid.Rows, row)
+	}
+	sort.Slice(grid.Rows, func(i, j int) bool {
+		if grid.Rows[i].Name != grid.Rows[j].Name {
+			return grid.Rows[i].Name < grid.Rows[j].Name
+		}
+		return grid.Rows[i].SteamID64 < grid.Rows[j].SteamID64
+	})
+	return grid
+}
+
+// scoreRound builds a one-round PlayerStats from samples and runs the
+// match-level evaluators over it, counting fast TTDs by thresholds.
+func scoreRound(samples []RoundSample, profile MapProfile, thresholds ReactionThresholds) RoundCell {
+	var kills, headshots int64
+	var snaps, ttds []float64
+	for _, s := range samples {
+		switch s.Channel {
+		case "hs":
+			kills++
+			if s.Value > 0 {
+				headshots++
+			}
+		case "snap":
+			snaps = append(snaps, s.Value)
+		case "reaction":
+			ttds = append(ttds, s.Value)
+		}
+	}
+
+	ps := &PlayerStats{Categories: make(map[Category]map[Key]Metric)}
+	if kills >= roundMinKills {
+		ps.AddMetric(CatKills, KeyTotalKills, Metric{Type: MetricInteger, IntValue: kills})
+		ps.AddMetric(CatKills, KeyHeadshotPercentage, Metric{
+			Type:       MetricPercentage,
+			FloatValue: float64(headshots) / float64(kills) * 100.0,
+		})
+	}
+	if len(snaps) >= roundMinSnaps {
+		sort.Float64s(snaps)
+		ps.AddMetric(CatAiming, KeySnapCount, Metric{Type: MetricInteger, IntValue: int64(len(snaps))})
+		ps.AddMetric(CatAiming, KeyP95SnapVelocity, Metric{Type: MetricFloat, FloatValue: snaps[int(float64(len(snaps)-1)*0.95)]})
+	}
+	if len(ttds) >= roundMinTTDs {
+		sub100 := 0
+		for _, v := range ttds {
+			if thresholds.fast(v) {
+				sub100++
+			}
+		}
+		ps.AddMetric(CatReaction, KeyTTDSamples, Metric{Type: MetricInteger, IntValue: int64(len(ttds))})
+		ps.AddMetric(CatReaction, KeyMedianTTD, Metric{Type: MetricFloat, FloatValue: median(ttds)})
+		ps.AddMetric(CatReaction, KeySub100msTTD, Metric{
+			Type:       MetricPercentage,
+			FloatValue: float64(sub100) / float64(len(ttds)) * 100.0,
+		})
+	}
+
+	cell := RoundCell{}
+	for _, ch := range []Channel{
+		evaluateHS(ps),
+		evaluateSnap(ps, profile),
+		evaluateReactionMedianTTD(ps, profile),
+		evaluateTTDSub100(ps),
+	} {
+		if !ch.HasData {
+			continue
+		}
+		cell.HasData = true
+		if ch.Score > cell.Score {
+			cell.Score = ch.Score
+		}
+	}
+	return cell
+}
+
+// heatmapSymbol buckets a round score on the channel zone bands:
+// "." clean, "~" mild, "!" strong, "#" blatant, " " no data.
+func heatmapSymbol(c RoundCell) string {
+	if !c.HasData {
+		return " "
+	}
+	switch zoneFor(c.Score) {
+	case ZoneMild:
+		return "~"
+	case ZoneStrong:
+		return "!"
+	case ZoneBlatant:
+		return "#"
+	default:
+		return "."
+	}
+}
+
+// HeatmapReporter prints the per-round suspicion grid: one row per player,
+// one column per round, each cell a symbol from heatmapSymbol. Toggling
+// shows up as a run of "!" / "#" in an otherwise clean row.
+type HeatmapReporter struct{}
+
+// NewHeatmapReporter creates a HeatmapReporter.
+func NewHeatmapReporter() *HeatmapReporter {
+	return &HeatmapReporter{}
+}
+
+// Extension returns "txt".
+func (hr *HeatmapReporter) Extension() string { return "txt" }
+
+// Report writes the grid. The categories argument is accepted for Reporter
+// compatibility but unused.
+func (hr *HeatmapReporter) Report(demoStats *DemoStats, _ []Category, writer io.Writer) error {
+	grid := demoStats.RoundSuspicionGrid()
+	if grid.Rounds == 0 {
+		_, err := fmt.Fprintln(writer, "No round data available")
+		return err
+	}
+
+	nameWidth := len("Player")
+	for _, row := range grid.Rows {
+		if n := len([]rune(fallback(row.Name, "Unknown"))); n > nameWidth {
+			nameWidth = n
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-*s  ", nameWidth, "Player")
+	// One column per round, so the header is a ruler of last digits.
+	for r := 1; r <= grid.Rounds; r++ {
+		fmt.Fprintf(&b, "%d", r%10)
+	}
+	b.WriteString("\n")
+	for _, row := range grid.Rows {
+		name := fallback(row.Name, "Unknown")
+		b.WriteString(name)
+		b.WriteString(strings.Repeat(" ", nameWidth-len([]rune(name))+2))
+		for _, c := range row.Cells {
+			b.WriteString(heatmapSymbol(c))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n. clean  ~ mild  ! strong  # blatant  (blank: no data)\n")
+
+	_, err := io.WriteString(writer, b.String())
+	return err
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRoundSuspicionGrid(t *testing.T) {
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(1).Player.Name = "alpha"
+	ds.GetOrCreatePlayerStatsBySteamID(2).Player.Name = "bravo"
+
+	// alpha: clean round 1, blatant sub-100 ms reactions in round 3.
+	ds.AddRoundSample(1, 1, "reaction", 520)
+	ds.AddRoundSample(1, 1, "reaction", 610)
+	ds.AddRoundSample(1, 3, "reaction", 60)
+	ds.AddRoundSample(1, 3, "reaction", 80)
+	// bravo: a single kill in round 2 is below the per-round minimum.
+	ds.AddRoundSample(2, 2, "hs", 1)
+	// Warmup samples are dropped.
+	ds.AddRoundSample(2, 0, "hs", 1)
+
+	grid := ds.RoundSuspicionGrid()
+	if grid.Rounds != 3 || len(grid.Rows) != 2 || grid.Rows[0].Name != "alpha" {
+		t.Fatalf("unexpected grid shape: %+v", grid)
+	}
+	alpha, bravo := grid.Rows[0], grid.Rows[1]
+	if got := heatmapSymbol(alpha.Cells[0]); got != "." {
+		t.Errorf("alpha round 1 = %q, want clean", got)
+	}
+	if alpha.Cells[1].HasData {
+		t.Error("alpha round 2 should have no data")
+	}
+	if got := heatmapSymbol(alpha.Cells[2]); got != "#" {
+		t.Errorf("alpha round 3 = %q, want blatant", got)
+	}
+	if bravo.Cells[1].HasData {
+		t.Error("bravo round 2 should be below the per-round minimum")
+	}
+
+	var buf bytes.Buffer
+	if err := NewHeatmapReporter().Report(ds, nil, &buf); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	if !strings.Contains(buf.String(), "alpha   . #") {
+		t.Errorf("unexpected heatmap:\n%s", buf.String())
+	}
+}
+
+func TestRoundSuspicionGridFastTTDCutoff(t *testing.T) {
+	cell := func(cutoff float64) RoundCell {
+		ds := NewDemoStats()
+		ds.GetOrCreatePlayerStatsBySteamID(1)
+		if cutoff > 0 {
+			ds.AddMetric(CatGameInfo, KeyFastTTDMs, Metric{Type: MetricFloat, FloatValue: cutoff})
+		}
+		ds.AddRoundSample(1, 1, "reaction", 180)
+		ds.AddRoundSample(1, 1, "reaction", 190)
+		return ds.RoundSuspicionGrid().Rows[0].Cells[0]
+	}
+	if def, wide := cell(0), cell(200); wide.Score <= def.Score {
+		t.Errorf("200 ms cutoff score %.2f not above the default's %.2f", wide.Score, def.Score)
+	}
+
+	// The cutoff survives a saved report
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(1)
+	rtc := NewReactionTimeCollector()
+	rtc.Thresholds = ReactionThresholds{FastMs: 150}
+	rtc.Setup(&warmupStubParser{}, ds)
+	var buf bytes.Buffer
+	if err := NewJSONReporter().Report(ds, nil, &buf); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	loaded, err := LoadJSONReport(&buf)
+	if err != nil {
+		t.Fatalf("LoadJSONReport: %v", err)
+	}
+	if m, _ := loaded.GetMetric(CatGameInfo, KeyFastTTDMs); m.FloatValue != 150 {
+		t.Errorf("loaded fast_ttd_ms = %v, want 150", m.FloatValue)
+	}
+}
@@ -0,0 +1,39 @@
+package stats
+
+import "time"
+
+// ScatterData returns one (x, y) point per player that has both metrics,
+// ordered by SteamID, ready to hand to a plotting library. A typical use is
+// suspicion over sample size — x = kills/total_kills, y =
+// anti_cheat/cheat_likelihood — where a high score on few kills stands out
+// as an outlier to review rather than a flag to trust. Durations are in ms;
+// string metrics are skipped.
+func (ds *DemoStats) ScatterData(xKey, yKey KeyRef) [][2]float64 {
+	var points [][2]float64
+	for _, sid := range sortedSteamIDs(ds) {
+		ps := ds.Players[sid]
+		x, okX := metricNumber(ps, xKey)
+		y, okY := metricNumber(ps, yKey)
+		if okX && okY {
+			points = append(points, [2]float64{x, y})
+		}
+	}
+	return points
+}
+
+// metricNumber reads ref from ps as a float64, whatever its numeric type.
+func metricNumber(ps *PlayerStats, ref KeyRef) (float64, bool) {
+	m, ok := ps.Lookup(ref)
+	if !ok {
+		return 0, false
+	}
+	switch m.Type {
+	case MetricInteger, MetricCount:
+		return float64(m.IntValue), true
+	case MetricFloat, MetricPercentage:
+		return m.FloatValue, true
+	case MetricDuration:
+		return float64(m.DurationValue) / float64(time.Millisecond), true
+	}
+	return 0, false
+}
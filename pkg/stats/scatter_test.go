@@ -0,0 +1,23 @@
+package stats
+
+import "testing"
+
+func TestScatterData(t *testing.T) {
+	ds := NewDemoStats()
+	kills := KeyRef{Category("kills"), Key("total_kills")}
+	likelihood := KeyRef{Category("anti_cheat"), Key("cheat_likelihood")}
+	add := func(sid uint64, k int64, l float64) {
+		ps := ds.GetOrCreatePlayerStatsBySteamID(sid)
+		ps.AddMetric(kills.Category, kills.Key, Metric{Type: MetricInteger, IntValue: k})
+		ps.AddMetric(likelihood.Category, likelihood.Key, Metric{Type: MetricPercentage, FloatValue: l})
+	}
+	add(9, 4, 90)
+	add(2, 30, 15)
+	ds.GetOrCreatePlayerStatsBySteamID(5) // no metrics: skipped
+
+	got := ds.ScatterData(kills, likelihood)
+	want := [][2]float64{{30, 15}, {4, 90}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("ScatterData = %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package stats
 
 import (
 	"sort"
+	"time"
 
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
@@ -9,7 +10,7 @@ import (
 )
 
 // ScoreboardCollector emits CS2-scoreboard-style basic stats per player:
-// team side, kills, deaths, assists, MVPs, damage → ADR, headshot %.
+// team side, kills, deaths, assists, MVPs, damage → ADR, headshot %, KAST.
 // Also snapshots per-round kill counts so a position_factor (avg rank-fraction
 // within team at round 5 / halftime / end) can be derived for the detector.
 type ScoreboardCollector struct {
@@ -22,6 +23,7 @@ type ScoreboardCollector struct {
 	// defuser MVPs but matches the in-game MVP awarded the vast majority
 	// of rounds.
 	roundKills map[uint64]int
+	kast       *kastTracker
 }
 
 type playerSnap struct {
@@ -29,12 +31,11 @@ type playerSnap struct {
 	side  common.Team
 }
 
-const scoreboardCategory = Category("scoreboard")
-
 func NewScoreboardCollector() *ScoreboardCollector {
 	return &ScoreboardCollector{
-		BaseCollector: NewBaseCollector("Scoreboard", scoreboardCategory),
+		BaseCollector: NewBaseCollector("Scoreboard", CatScoreboard),
 		roundKills:    map[uint64]int{},
+		kast:          newKASTTracker(),
 	}
 }
 
@@ -44,11 +45,23 @@ func (sc *ScoreboardCollector) Setup(parser demoinfocs.Parser, demoStats *DemoSt
 		// RoundEnd fires first, then we award MVP, then the next RoundStart
 		// resets.
 		sc.roundKills = map[uint64]int{}
+		sc.kast.roundStart()
 	})
 
 	parser.RegisterEventHandler(func(_ events.RoundEnd) {
 		sc.roundCount++
 
+		var played, survived []uint64
+		for _, p := range parser.GameState().Participants().Playing() {
+			if key := demoStats.PlayerKey(p); key != 0 {
+				played = append(played, key)
+				if p.IsAlive() {
+					survived = append(survived, key)
+				}
+			}
+		}
+		sc.kast.roundEnd(played, survived)
+
 		// Award MVP heuristically to the top fragger of this round.
 		// Ties broken by lower SteamID (stable).
 		var mvpSID uint64
@@ -61,21 +74,18 @@ func (sc *ScoreboardCollector) Setup(parser demoinfocs.Parser, demoStats *DemoSt
 		}
 		if mvpSID != 0 && mvpKills > 0 {
 			if ps, ok := demoStats.Players[mvpSID]; ok {
-				ps.IncrementIntMetric(scoreboardCategory, Key("mvps"))
+				ps.IncrementIntMetric(CatScoreboard, KeyMVPs)
 			}
 		}
 
 		snap := map[uint64]playerSnap{}
 		for _, p := range parser.GameState().Participants().Playing() {
-			if p == nil || p.SteamID64 == 0 {
-				continue
-			}
 			ps := demoStats.GetOrCreatePlayerStats(p)
 			if ps == nil {
 				continue
 			}
-			snap[p.SteamID64] = playerSnap{
-				kills: intMetric(ps, scoreboardCategory, Key("kills")),
+			snap[ps.Player.SteamID64] = playerSnap{
+				kills: intMetric(ps, CatScoreboard, KeyKills),
 				side:  p.Team,
 			}
 		}
@@ -83,9 +93,17 @@ func (sc *ScoreboardCollector) Setup(parser demoinfocs.Parser, demoStats *DemoSt
 	})
 
 	parser.RegisterEventHandler(func(e events.Kill) {
+		if e.Killer != nil && e.Victim != nil && e.Killer.Team != e.Victim.Team {
+			var assister uint64
+			if e.Assister != nil && e.Assister.Team == e.Killer.Team {
+				assister = demoStats.PlayerKey(e.Assister)
+			}
+			sc.kast.kill(demoStats.PlayerKey(e.Killer), demoStats.PlayerKey(e.Victim), assister, demoTime(parser, parser.TickRate()))
+		}
+
 		if e.Victim != nil {
 			if vps := demoStats.GetOrCreatePlayerStats(e.Victim); vps != nil {
-				vps.IncrementIntMetric(scoreboardCategory, Key("deaths"))
+				vps.IncrementIntMetric(CatScoreboard, KeyDeaths)
 				recordTeam(vps, e.Victim)
 			}
 		}
@@ -93,18 +111,18 @@ func (sc *ScoreboardCollector) Setup(parser demoinfocs.Parser, demoStats *DemoSt
 		teamKill := e.Killer != nil && e.Victim != nil && e.Killer.Team == e.Victim.Team
 		if e.Killer != nil && e.Killer != e.Victim && !teamKill {
 			if kps := demoStats.GetOrCreatePlayerStats(e.Killer); kps != nil {
-				kps.IncrementIntMetric(scoreboardCategory, Key("kills"))
+				kps.IncrementIntMetric(CatScoreboard, KeyKills)
 				if e.IsHeadshot {
-					kps.IncrementIntMetric(scoreboardCategory, Key("hs_kills"))
+					kps.IncrementIntMetric(CatScoreboard, KeyHSKills)
 				}
 				recordTeam(kps, e.Killer)
-				sc.roundKills[e.Killer.SteamID64]++
+				sc.roundKills[kps.Player.SteamID64]++
 			}
 		}
 
 		if e.Assister != nil && e.Assister != e.Killer && e.Assister != e.Victim {
 			if aps := demoStats.GetOrCreatePlayerStats(e.Assister); aps != nil {
-				aps.IncrementIntMetric(scoreboardCategory, Key("assists"))
+				aps.IncrementIntMetric(CatScoreboard, KeyAssists)
 				recordTeam(aps, e.Assister)
 			}
 		}
@@ -121,7 +139,7 @@ func (sc *ScoreboardCollector) Setup(parser demoinfocs.Parser, demoStats *DemoSt
 		if aps == nil {
 			return
 		}
-		addIntMetric(aps, scoreboardCategory, Key("damage"), int64(e.HealthDamageTaken))
+		addIntMetric(aps, CatScoreboard, KeyDamage, int64(e.HealthDamageTaken))
 		recordTeam(aps, e.Attacker)
 	})
 
@@ -130,21 +148,28 @@ func (sc *ScoreboardCollector) Setup(parser demoinfocs.Parser, demoStats *DemoSt
 	// from the per-round top-fragger above. See sc.roundKills.
 }
 
+// ProducedMetrics lists the metrics the cheat detector reads from ScoreboardCollector.
+func (sc *ScoreboardCollector) ProducedMetrics() []KeyRef {
+	return []KeyRef{
+		{CatScoreboard, KeyPositionFactor},
+	}
+}
+
 func (sc *ScoreboardCollector) CollectFinalStats(demoStats *DemoStats) {
 	for _, ps := range demoStats.Players {
 		if sc.roundCount > 0 {
-			if dmg, ok := ps.GetMetric(scoreboardCategory, Key("damage")); ok {
-				ps.AddMetric(scoreboardCategory, Key("adr"), Metric{
+			if dmg, ok := ps.GetMetric(CatScoreboard, KeyDamage); ok {
+				ps.AddMetric(CatScoreboard, KeyADR, Metric{
 					Type:        MetricFloat,
 					FloatValue:  float64(dmg.IntValue) / float64(sc.roundCount),
 					Description: "Average damage per round",
 				})
 			}
 		}
-		kills, _ := ps.GetMetric(scoreboardCategory, Key("kills"))
-		hsKills, _ := ps.GetMetric(scoreboardCategory, Key("hs_kills"))
+		kills, _ := ps.GetMetric(CatScoreboard, KeyKills)
+		hsKills, _ := ps.GetMetric(CatScoreboard, KeyHSKills)
 		if kills.IntValue > 0 {
-			ps.AddMetric(scoreboardCategory, Key("hs_percentage"), Metric{
+			ps.AddMetric(CatScoreboard, KeyHSPercentage, Metric{
 				Type:        MetricPercentage,
 				FloatValue:  float64(hsKills.IntValue) / float64(kills.IntValue) * 100,
 				Description: "Headshot percentage on the scoreboard",
@@ -152,9 +177,102 @@ func (sc *ScoreboardCollector) CollectFinalStats(demoStats *DemoStats) {
 		}
 	}
 
+	for sid, pct := range sc.kast.percentages() {
+		if ps, ok := demoStats.Players[sid]; ok {
+			ps.AddMetric(CatScoreboard, KeyRoundsPlayed, Metric{
+				Type:        MetricInteger,
+				IntValue:    int64(sc.kast.played[sid]),
+				Description: "Rounds the player was in at round end",
+			})
+			ps.AddMetric(CatScoreboard, KeyKAST, Metric{
+				Type:        MetricPercentage,
+				FloatValue:  pct,
+				Description: "Rounds with a kill, assist, survival or traded death",
+			})
+		}
+	}
+
 	sc.assignPositionFactors(demoStats)
 }
 
+// kastTradeWindow is how soon after a death the killer must die for the
+// death to count as traded.
+const kastTradeWindow = 5 * time.Second
+
+// kastTracker counts KAST rounds: rounds in which a player got a kill or an
+// assist, survived, or was traded.
+type kastTracker struct {
+	// round[sid] is set once the player has earned KAST this round.
+	round map[uint64]bool
+	// deaths[victim] is who killed them this round and when, for trades.
+	deaths map[uint64]kastDeath
+
+	kastRounds, played map[uint64]int
+}
+
+type kastDeath struct {
+	killer uint64
+	at     time.Duration
+}
+
+func newKASTTracker() *kastTracker {
+	return &kastTracker{
+		round:      map[uint64]bool{},
+		deaths:     map[uint64]kastDeath{},
+		kastRounds: map[uint64]int{},
+		played:     map[uint64]int{},
+	}
+}
+
+func (k *kastTracker) roundStart() {
+	k.round = map[uint64]bool{}
+	k.deaths = map[uint64]kastDeath{}
+}
+
+// kill records killer killing victim at now. Keys of 0 (untracked
+// players) are ignored, except that killing an untracked player still
+// trades the deaths they caused.
+func (k *kastTracker) kill(killer, victim, assister uint64, now time.Duration) {
+	if killer != 0 {
+		k.round[killer] = true
+	}
+	if assister != 0 {
+		k.round[assister] = true
+	}
+	for sid, d := range k.deaths {
+		if d.killer == victim && victim != 0 && now-d.at <= kastTradeWindow {
+			k.round[sid] = true
+		}
+	}
+	if victim != 0 {
+		k.deaths[victim] = kastDeath{killer: killer, at: now}
+	}
+}
+
+// roundEnd tallies the round for everyone who played it.
+func (k *kastTracker) roundEnd(played, survived []uint64) {
+	for _, sid := range survived {
+		k.round[sid] = true
+	}
+	for _, sid := range played {
+		k.played[sid]++
+		if k.round[sid] {
+			k.kastRounds[sid]++
+		}
+	}
+}
+
+// percentages returns each player's KAST as a percentage of rounds played.
+func (k *kastTracker) percentages() map[uint64]float64 {
+	out := make(map[uint64]float64, len(k.played))
+	for sid, n := range k.played {
+		if n > 0 {
+			out[sid] = float64(k.kastRounds[sid]) / float64(n) * 100
+		}
+	}
+	return out
+}
+
 // assignPositionFactors writes a per-player position_factor metric: the
 // average rank-fraction within their current side at round 5, halftime, and
 // the final round (0.0 = consistent top, 1.0 = consistent bottom). The cheat
@@ -206,7 +324,7 @@ func (sc *ScoreboardCollector) assignPositionFactors(demoStats *DemoStats) {
 		if !ok {
 			continue
 		}
-		ps.AddMetric(scoreboardCategory, Key("position_factor"), Metric{
+		ps.AddMetric(CatScoreboard, KeyPositionFactor, Metric{
 			Type:        MetricFloat,
 			FloatValue:  total / float64(c),
 			Description: "Avg rank within team at round 5 / halftime / end (0 = top, 1 = bottom)",
@@ -240,7 +358,7 @@ func recordTeam(ps *PlayerStats, p *common.Player) {
 	if label == "" {
 		return
 	}
-	ps.AddMetric(scoreboardCategory, Key("team"), Metric{
+	ps.AddMetric(CatScoreboard, KeyTeam, Metric{
 		Type:        MetricString,
 		StringValue: label,
 		Description: "Most recent team side",
@@ -0,0 +1,204 @@
+package stats
+
+import (
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// sideTally is one player's scoreboard totals on one side.
+type sideTally struct {
+	rounds, kastRounds     int64
+	kills, deaths, assists int64
+	damage                 int64
+}
+
+// SideCollector splits each player's rating by side. Teams swap sides at
+// halftime and at every overtime half, and the swap (TeamSideSwitch) is
+// dispatched just after the new round's RoundStart, so a side read at round
+// start can still be the old one. The collector locks every player's side
+// at the end of freeze time instead, when the swap has landed, and credits
+// the whole round — kills, deaths, assists, damage and KAST — to that side.
+// Players who join after the lock use their team at the time.
+//
+//   - ct_rounds, t_rounds: rounds played on each side.
+//   - ct_rating, t_rating: rating_2 over each side's rounds, for sides with
+//     at least ratingMinRounds rounds.
+//   - side_switches (demo-wide): side swaps seen; more than one means the
+//     match went to overtime.
+type SideCollector struct {
+	*BaseCollector
+
+	// Coefficients weight the rating formula, as on RatingCollector.
+	Coefficients RatingCoefficients
+
+	// sides holds the side each player was locked to this round.
+	sides    map[uint64]common.Team
+	tallies  map[uint64]map[common.Team]*sideTally
+	kast     *kastTracker
+	switches int
+}
+
+// NewSideCollector creates a SideCollector with the default rating
+// coefficients.
+func NewSideCollector() *SideCollector {
+	return &SideCollector{
+		BaseCollector: NewBaseCollector("Sides", CatTeam),
+		Coefficients:  DefaultRatingCoefficients(),
+		sides:         make(map[uint64]common.Team),
+		tallies:       make(map[uint64]map[common.Team]*sideTally),
+		kast:          newKASTTracker(),
+	}
+}
+
+// Setup registers the round, side-switch, kill and damage handlers. Like the
+// ScoreboardCollector's, they count every round the parser reports.
+func (sc *SideCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	parser.RegisterEventHandler(func(events.RoundStart) {
+		sc.sides = make(map[uint64]common.Team)
+		sc.kast.roundStart()
+	})
+	parser.RegisterEventHandler(func(events.TeamSideSwitch) {
+		sc.switches++
+	})
+	parser.RegisterEventHandler(func(events.RoundFreezetimeEnd) {
+		for _, p := range parser.GameState().Participants().Playing() {
+			if key := demoStats.PlayerKey(p); key != 0 {
+				sc.lock(key, p.Team)
+			}
+		}
+	})
+
+	parser.RegisterEventHandler(func(events.RoundEnd) {
+		players := parser.GameState().Participants().Playing()
+		var played, survived []uint64
+		for _, p := range players {
+			if key := demoStats.PlayerKey(p); key != 0 {
+				played = append(played, key)
+				if p.IsAlive() {
+					survived = append(survived, key)
+				}
+			}
+		}
+		// roundEnd leaves the round's KAST flags set until the next round
+		sc.kast.roundEnd(played, survived)
+		for _, p := range players {
+			key := demoStats.PlayerKey(p)
+			if t := sc.tally(key, p.Team); t != nil {
+				t.rounds++
+				if sc.kast.round[key] {
+					t.kastRounds++
+				}
+			}
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.Kill) {
+		if e.Killer == nil || e.Victim == nil || e.Killer.Team == e.Victim.Team {
+			return
+		}
+		killer, victim := demoStats.PlayerKey(e.Killer), demoStats.PlayerKey(e.Victim)
+		var assister uint64
+		if e.Assister != nil && e.Assister.Team == e.Killer.Team {
+			assister = demoStats.PlayerKey(e.Assister)
+		}
+		sc.kast.kill(killer, victim, assister, demoTime(parser, parser.TickRate()))
+
+		if t := sc.tally(victim, e.Victim.Team); t != nil {
+			t.deaths++
+		}
+		if t := sc.tally(killer, e.Killer.Team); t != nil {
+			t.kills++
+		}
+		if e.Assister != nil && e.Assister != e.Killer && e.Assister != e.Victim {
+			if t := sc.tally(assister, e.Assister.Team); t != nil {
+				t.assists++
+			}
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.PlayerHurt) {
+		if e.Attacker == nil || e.Player == nil || e.Attacker == e.Player || e.Attacker.Team == e.Player.Team {
+			return
+		}
+		if t := sc.tally(demoStats.PlayerKey(e.Attacker), e.Attacker.Team); t != nil {
+			t.damage += int64(e.HealthDamageTaken)
+		}
+	})
+}
+
+// lock fixes key's side for the rest of the round. Only T and CT lock.
+func (sc *SideCollector) lock(key uint64, team common.Team) {
+	if team == common.TeamTerrorists || team == common.TeamCounterTerrorists {
+		sc.sides[key] = team
+	}
+}
+
+// tally returns key's totals on the side it is locked to this round, or on
+// current when it isn't locked; nil for key 0 or a player on neither side.
+func (sc *SideCollector) tally(key uint64, current common.Team) *sideTally {
+	if key == 0 {
+		return nil
+	}
+	side, ok := sc.sides[key]
+	if !ok {
+		side = current
+	}
+	if side != common.TeamTerrorists && side != common.TeamCounterTerrorists {
+		return nil
+	}
+	bySide, ok := sc.tallies[key]
+	if !ok {
+		bySide = make(map[common.Team]*sideTally)
+		sc.tallies[key] = bySide
+	}
+	t, ok := bySide[side]
+	if !ok {
+		t = &sideTally{}
+		bySide[side] = t
+	}
+	return t
+}
+
+// CollectFinalStats publishes each player's rounds and rating per side and
+// the demo-wide side_switches.
+func (sc *SideCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, bySide := range sc.tallies {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		for _, side := range []struct {
+			team           common.Team
+			rounds, rating Key
+			label          string
+		}{
+			{common.TeamCounterTerrorists, KeyCTRounds, KeyCTRating, "CT"},
+			{common.TeamTerrorists, KeyTRounds, KeyTRating, "T"},
+		} {
+			t := bySide[side.team]
+			if t == nil || t.rounds == 0 {
+				continue
+			}
+			ps.AddMetric(CatTeam, side.rounds, Metric{
+				Type:        MetricInteger,
+				IntValue:    t.rounds,
+				Description: "Rounds played on " + side.label,
+			})
+			if t.rounds < ratingMinRounds {
+				continue
+			}
+			ps.AddMetric(CatTeam, side.rating, Metric{
+				Type: MetricFloat,
+				FloatValue: sc.Coefficients.rating(t.kills, t.deaths, t.assists, t.rounds,
+					float64(t.kastRounds)/float64(t.rounds)*100, float64(t.damage)/float64(t.rounds)),
+				Description: "HLTV-style rating over the rounds played on " + side.label,
+			})
+		}
+	}
+	demoStats.AddMetric(CatTeam, KeySideSwitches, Metric{
+		Type:        MetricInteger,
+		IntValue:    int64(sc.switches),
+		Description: "Times the teams swapped sides (halftime, then each overtime half)",
+	})
+}
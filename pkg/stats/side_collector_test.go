@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+func TestSideLockAcrossSwap(t *testing.T) {
+	sc := NewSideCollector()
+
+	// First half: locked on CT
+	sc.lock(1, common.TeamCounterTerrorists)
+	sc.tally(1, common.TeamCounterTerrorists).kills++
+
+	// Halftime: the new round starts, freeze time ends after the swap and
+	// locks T, and a kill read against a stale CT team still counts for T
+	sc.sides = make(map[uint64]common.Team)
+	sc.switches++
+	sc.lock(1, common.TeamTerrorists)
+	sc.tally(1, common.TeamCounterTerrorists).kills++
+
+	if ct, tt := sc.tallies[1][common.TeamCounterTerrorists].kills, sc.tallies[1][common.TeamTerrorists].kills; ct != 1 || tt != 1 {
+		t.Errorf("kills CT %d, T %d; want 1 each", ct, tt)
+	}
+
+	// Unlocked players use their current team; spectators and key 0 count nowhere
+	if sc.tally(2, common.TeamTerrorists) == nil {
+		t.Error("unlocked T player not tallied")
+	}
+	if sc.tally(3, common.TeamSpectators) != nil || sc.tally(0, common.TeamTerrorists) != nil {
+		t.Error("spectator or untracked player tallied")
+	}
+	sc.lock(4, common.TeamSpectators)
+	if _, ok := sc.sides[4]; ok {
+		t.Error("locked a spectator")
+	}
+}
+
+func TestSideRatings(t *testing.T) {
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStatsBySteamID(1)
+	sc := NewSideCollector()
+	sc.tallies[1] = map[common.Team]*sideTally{
+		common.TeamCounterTerrorists: {rounds: 12, kastRounds: 9, kills: 14, deaths: 7, assists: 3, damage: 1200},
+		common.TeamTerrorists:        {rounds: 3, kastRounds: 1, kills: 1, deaths: 3},
+	}
+	sc.switches = 3 // halftime and both overtime halves
+	sc.CollectFinalStats(ds)
+
+	ps := ds.Players[1]
+	want := sc.Coefficients.rating(14, 7, 3, 12, 75, 100)
+	if got, _ := psGetFloat(ps, CatTeam, KeyCTRating); got != want {
+		t.Errorf("ct_rating = %v, want %v", got, want)
+	}
+	if n := intMetric(ps, CatTeam, KeyTRounds); n != 3 {
+		t.Errorf("t_rounds = %d, want 3", n)
+	}
+	if _, ok := ps.GetMetric(CatTeam, KeyTRating); ok {
+		t.Error("t_rating published on 3 rounds")
+	}
+	if m, ok := ds.GetMetric(CatTeam, KeySideSwitches); !ok || m.IntValue != 3 {
+		t.Errorf("side_switches = %+v, want 3", m)
+	}
+}
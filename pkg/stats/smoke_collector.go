@@ -0,0 +1,142 @@
+package stats
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+const (
+	// smokeRadius approximates a bloomed CS2 smoke as a sphere of this
+	// radius (units) around the detonation point, raised by smokeCenterZ
+	// since the volume sits on the ground it landed on.
+	smokeRadius  = 144.0
+	smokeCenterZ = 48.0
+
+	// smokeAdjacentMargin: a player within smokeRadius + this of the
+	// centre is at or in the smoke. Shooting from the edge of a smoke
+	// (the classic one-way) or while both are inside it is legitimate.
+	smokeAdjacentMargin = 64.0
+)
+
+// SmokeCollector flags gun kills where the line from the killer's eyes to
+// the victim's head crosses an active smoke that neither player was in or
+// next to — a kill on someone the killer had no way of seeing. Smokes are
+// tracked from SmokeStart to SmokeExpired. The engine's own thrusmoke flag
+// on the kill also counts; the adjacency exemption applies either way, to
+// the smoke the line crosses.
+//
+// The sphere is a rough stand-in for CS2's volumetric smoke, which HE
+// grenades and bullets can temporarily clear. Treat the count as a lead to
+// review, not a score input.
+type SmokeCollector struct {
+	*BaseCollector
+
+	smokes    map[int]r3.Vector // active smoke centres by grenade entity ID
+	sawSmoke  bool
+	throughBy map[uint64]int
+}
+
+// NewSmokeCollector creates a new SmokeCollector.
+func NewSmokeCollector() *SmokeCollector {
+	return &SmokeCollector{
+		BaseCollector: NewBaseCollector("Smoke", CatSmoke),
+		smokes:        make(map[int]r3.Vector),
+		throughBy:     make(map[uint64]int),
+	}
+}
+
+// Setup registers the smoke lifetime and kill handlers.
+func (sc *SmokeCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	parser.RegisterEventHandler(func(e events.SmokeStart) {
+		sc.sawSmoke = true
+		center := e.Position
+		center.Z += smokeCenterZ
+		sc.smokes[e.GrenadeEntityID] = center
+	})
+
+	parser.RegisterEventHandler(func(e events.SmokeExpired) {
+		delete(sc.smokes, e.GrenadeEntityID)
+	})
+
+	parser.RegisterEventHandler(func(_ events.RoundStart) {
+		sc.smokes = make(map[int]r3.Vector)
+	})
+
+	parser.RegisterEventHandler(func(e events.Kill) {
+		if inWarmup(parser) || e.Killer == nil || e.Victim == nil || e.Weapon == nil {
+			return
+		}
+		if !demoStats.CountsPlayer(e.Killer) || e.Killer.Team == e.Victim.Team {
+			return
+		}
+		if isKnife(e.Weapon) {
+			return
+		}
+		switch weaponClass(e.Weapon) {
+		case "grenade", "equipment":
+			return
+		}
+		if sc.throughSmoke(eyePosition(e.Killer), headPosition(e.Victim), e.ThroughSmoke) {
+			sc.throughBy[demoStats.PlayerKey(e.Killer)]++
+		}
+	})
+}
+
+// throughSmoke reports whether a shot from eye to target counts as a
+// through-smoke kill: it crosses an active smoke that neither end is at or
+// inside. A player standing at a different smoke doesn't exempt the shot.
+//
+// The engine's flag doesn't say which smoke it saw, so it counts unless the
+// line only crosses smokes one of the players is at or, crossing none of
+// the tracked ones, either player is at some smoke.
+func (sc *SmokeCollector) throughSmoke(eye, target r3.Vector, engineFlag bool) bool {
+	crossedAny, nearAny := false, false
+	for _, center := range sc.smokes {
+		near := center.Sub(eye).Norm() <= smokeRadius+smokeAdjacentMargin ||
+			center.Sub(target).Norm() <= smokeRadius+smokeAdjacentMargin
+		nearAny = nearAny || near
+		if segmentPointDistance(eye, target, center) > smokeRadius {
+			continue
+		}
+		if !near {
+			return true
+		}
+		crossedAny = true
+	}
+	return engineFlag && !crossedAny && !nearAny
+}
+
+// segmentPointDistance returns the distance from p to the segment a–b.
+func segmentPointDistance(a, b, p r3.Vector) float64 {
+	ab := b.Sub(a)
+	lenSq := ab.Dot(ab)
+	if lenSq == 0 {
+		return p.Sub(a).Norm()
+	}
+	t := p.Sub(a).Dot(ab) / lenSq
+	if t < 0 {
+		t = 0
+	} else if t > 1 {
+		t = 1
+	}
+	return p.Sub(a.Add(ab.Mul(t))).Norm()
+}
+
+// CollectFrame is a no-op; everything is event-driven.
+func (sc *SmokeCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {}
+
+// CollectFinalStats publishes through_smoke_kills for every player, once the
+// demo has had at least one smoke.
+func (sc *SmokeCollector) CollectFinalStats(demoStats *DemoStats) {
+	if !sc.sawSmoke {
+		return
+	}
+	for sid, ps := range demoStats.Players {
+		ps.AddMetric(CatSmoke, KeyThroughSmokeKills, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(sc.throughBy[sid]),
+			Description: "Gun kills through an active smoke neither player was in or next to",
+		})
+	}
+}
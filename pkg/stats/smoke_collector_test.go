@@ -0,0 +1,62 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+)
+
+func TestSmokeThroughSmoke(t *testing.T) {
+	sc := NewSmokeCollector()
+	sc.smokes[1] = r3.Vector{X: 1000, Y: 0, Z: 64}
+
+	far := r3.Vector{X: 0, Y: 0, Z: 64}
+	tests := []struct {
+		name        string
+		eye, target r3.Vector
+		engine      bool
+		want        bool
+	}{
+		{"line crosses smoke", far, r3.Vector{X: 2000, Y: 0, Z: 64}, false, true},
+		{"line misses smoke", far, r3.Vector{X: 0, Y: 2000, Z: 64}, false, false},
+		{"killer at the edge (one-way)", r3.Vector{X: 820, Y: 0, Z: 64}, r3.Vector{X: 2000, Y: 0, Z: 64}, false, false},
+		{"both inside", r3.Vector{X: 950, Y: 0, Z: 64}, r3.Vector{X: 1050, Y: 0, Z: 64}, false, false},
+		{"engine flag only", far, r3.Vector{X: 0, Y: 2000, Z: 64}, true, true},
+	}
+	for _, tt := range tests {
+		if got := sc.throughSmoke(tt.eye, tt.target, tt.engine); got != tt.want {
+			t.Errorf("%s: throughSmoke = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSmokeThroughSmokeTwoSmokes(t *testing.T) {
+	sc := NewSmokeCollector()
+	sc.smokes[1] = r3.Vector{X: 1000, Y: 0, Z: 64} // on the line
+	sc.smokes[2] = r3.Vector{X: -100, Y: 0, Z: 64} // around the killer, behind them
+
+	killer := r3.Vector{X: 0, Y: 0, Z: 64}
+	victim := r3.Vector{X: 2000, Y: 0, Z: 64}
+	if !sc.throughSmoke(killer, victim, false) {
+		t.Error("killer at a second smoke exempted a shot through a smoke they weren't at")
+	}
+	if !sc.throughSmoke(victim, killer, false) {
+		t.Error("victim at a second smoke exempted the shot")
+	}
+	if !sc.throughSmoke(r3.Vector{X: 0, Y: 2000, Z: 64}, r3.Vector{X: 800, Y: 2000, Z: 64}, true) {
+		t.Error("engine-flagged kill missing every tracked smoke didn't count")
+	}
+
+	// Standing at the smoke that is crossed is still a one-way
+	sc.smokes[2] = r3.Vector{X: 2100, Y: 500, Z: 64}
+	if sc.throughSmoke(r3.Vector{X: 820, Y: 0, Z: 64}, victim, false) {
+		t.Error("one-way from the crossed smoke counted while another smoke was up")
+	}
+
+	// An engine-flagged kill that misses both tracked smokes is exempt only
+	// when a player is at one of them
+	sc.smokes[2] = r3.Vector{X: 2150, Y: 300, Z: 64}
+	if sc.throughSmoke(r3.Vector{X: 2000, Y: 300, Z: 64}, r3.Vector{X: 0, Y: 2000, Z: 64}, true) {
+		t.Error("engine flag counted with the killer at a smoke")
+	}
+}
@@ -1,9 +1,11 @@
 package stats
 
 import (
+	"fmt"
 	"math"
-	"sort"
+	"time"
 
+	"github.com/golang/geo/r3"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
@@ -11,18 +13,69 @@ import (
 
 const (
 	// ViewAngleBufferSize is the number of ticks to keep in the buffer for angle calculations
+	//
+	// Deprecated: buffers are sized from a duration and the demo's tick
+	// rate, see DefaultSnapWindow and windowTicks. This is DefaultSnapWindow
+	// at 64 tick.
 	ViewAngleBufferSize = 40 // ~0.5 seconds at 64 tick rate
 
+	// DefaultSnapWindow is how much view-angle history the snap analysis
+	// keeps per player — 40 ticks at 64 tick, 80 at 128.
+	DefaultSnapWindow = 625 * time.Millisecond
+
 	// MinAngleDiffThreshold is the minimum angle difference in degrees that indicates a stopped movement
 	MinAngleDiffThreshold = 0.2
 
 	// Conversion factor from radians to degrees
+	//
+	// Deprecated: demoinfocs reports view angles in degrees, and nothing in
+	// this package converts them any more.
 	RadToDeg = 57.2958
+
+	// preciseSnapTargetRadius is the half-width (in units) of the body the
+	// crosshair must land on for a snap to count as precise. The allowed
+	// residual shrinks with distance, see preciseSnapTolerance.
+	preciseSnapTargetRadius = 12.0
+
+	// preciseSnapMinToleranceDeg floors the residual tolerance so long-range
+	// kills aren't held to sub-degree precision the view angle can't resolve.
+	preciseSnapMinToleranceDeg = 1.0
+
+	// evidenceSnapDegPerMs is the precise-snap velocity from which a kill
+	// goes into the evidence bundle — the bottom of the snap channel's ramp.
+	evidenceSnapDegPerMs = snapRampLow
+
+	// fovClampMinTurnDeg, fovClampBinDeg and fovClampMinSnaps shape the
+	// histogram of precise-snap turns: turns below fovClampMinTurnDeg are
+	// micro-corrections and left out, the rest are binned
+	// fovClampBinDeg wide, and a mode needs fovClampMinSnaps of them.
+	fovClampMinTurnDeg = 2.0
+	fovClampBinDeg     = 0.5
+	fovClampMinSnaps   = 10
+
+	// fovClampMinShare and fovClampSpikeRatio decide when the mode is a
+	// spike: it holds at least fovClampMinShare of the turns and
+	// fovClampSpikeRatio times the mean of the two bins either side.
+	// Human flicks spread over tens of degrees.
+	fovClampMinShare   = 0.3
+	fovClampSpikeRatio = 3.0
+
+	// overshootMinTurnDeg is the smallest flick checked for overshoot; a
+	// small adjustment has nothing to overshoot. overshootMinDeg is how far
+	// past its final aim a flick must go to count as overshooting — above
+	// the jitter of a crosshair held still.
+	overshootMinTurnDeg = 5.0
+	overshootMinDeg     = 0.5
+
+	// overshootMinFlicks is the number of checked flicks before
+	// no_overshoot_snap_ratio is published.
+	overshootMinFlicks = 5
 )
 
 // ViewAngleSnapshot stores a player's view angle at a specific tick
 type ViewAngleSnapshot struct {
 	Tick   int
+	Time   time.Duration // in-game time of Tick, see demoTime
 	Yaw    float32
 	Pitch  float32
 	Killed *common.Player    // Will be non-nil if a kill occurred on this tick
@@ -78,19 +131,91 @@ type SnapVelocity struct {
 // SnapAngleCollector tracks player view angle movements and calculates snap velocities
 type SnapAngleCollector struct {
 	*BaseCollector
-	viewBuffers    map[uint64]*RingBuffer
-	snapVelocities map[uint64][]float64
-	currentTick    int
-	tickRate       float64
+	viewBuffers map[uint64]*RingBuffer
+
+	// snapVelocities and preciseVelocities are capped at maxSamples;
+	// snapSums keeps the exact total for the average. preciseVelocities
+	// holds the subset of snaps that started from a settled aim and ended
+	// on the victim; see isPreciseSnap.
+	snapVelocities    map[uint64]*sampleReservoir
+	snapSums          map[uint64]float64
+	preciseVelocities map[uint64]*sampleReservoir
+	maxSamples        int
+	currentTick       int
+	tickRate          float64
+
+	// window is the view-angle history to keep; bufferSize is window in
+	// ticks at the current tick rate.
+	window     time.Duration
+	bufferSize int
+
+	// preciseTurns holds how far, in degrees, each precise snap turned from
+	// the settled aim to the victim; see fovClampMode.
+	preciseTurns map[uint64]*sampleReservoir
+
+	// flicks counts the kills whose flick was checked for overshoot, and
+	// straightFlicks those that never went past the final aim; see
+	// flickOvershoot.
+	flicks         map[uint64]int
+	straightFlicks map[uint64]int
+
+	// rawSnaps keeps the snap velocities in order when raw samples are
+	// enabled.
+	keepRaw  bool
+	rawSnaps map[uint64]*sampleReservoir
 }
 
-// NewSnapAngleCollector creates a new SnapAngleCollector
+// NewSnapAngleCollector creates a new SnapAngleCollector with
+// DefaultSnapWindow of history.
 func NewSnapAngleCollector() *SnapAngleCollector {
+	return NewSnapAngleCollectorWithWindow(DefaultSnapWindow)
+}
+
+// NewSnapAngleCollectorWithWindow creates a SnapAngleCollector that looks
+// back up to d for the point where the aim settled before a kill. The window
+// is converted to ticks once the demo's tick rate is known, so it covers the
+// same time on 64- and 128-tick demos.
+func NewSnapAngleCollectorWithWindow(d time.Duration) *SnapAngleCollector {
+	if d <= 0 {
+		d = DefaultSnapWindow
+	}
 	return &SnapAngleCollector{
-		BaseCollector:  NewBaseCollector("Snap Angle Analysis", Category("aiming")),
-		viewBuffers:    make(map[uint64]*RingBuffer),
-		snapVelocities: make(map[uint64][]float64),
-		currentTick:    0,
+		BaseCollector:     NewBaseCollector("Snap Angle Analysis", CatAiming),
+		viewBuffers:       make(map[uint64]*RingBuffer),
+		snapVelocities:    make(map[uint64]*sampleReservoir),
+		snapSums:          make(map[uint64]float64),
+		preciseVelocities: make(map[uint64]*sampleReservoir),
+		preciseTurns:      make(map[uint64]*sampleReservoir),
+		flicks:            make(map[uint64]int),
+		straightFlicks:    make(map[uint64]int),
+		maxSamples:        DefaultMaxSamples,
+		currentTick:       0,
+		window:            d,
+		bufferSize:        windowTicks(d, 64.0),
+	}
+}
+
+// windowTicks converts d to a whole number of ticks at tickRate, rounding
+// up, with a floor of 5 ticks — the least processKill can work with.
+func windowTicks(d time.Duration, tickRate float64) int {
+	if tickRate <= 0 {
+		tickRate = 64.0
+	}
+	n := int(math.Ceil(d.Seconds() * tickRate))
+	if n < 5 {
+		n = 5
+	}
+	return n
+}
+
+// setTickRate records the tick rate and resizes the view buffers to match.
+// Existing buffers are dropped on a resize; the tick rate is known within
+// the first few frames, before any kill needs them.
+func (sac *SnapAngleCollector) setTickRate(tickRate float64) {
+	sac.tickRate = tickRate
+	if size := windowTicks(sac.window, tickRate); size != sac.bufferSize {
+		sac.bufferSize = size
+		sac.viewBuffers = make(map[uint64]*RingBuffer)
 	}
 }
 
@@ -98,24 +223,29 @@ func NewSnapAngleCollector() *SnapAngleCollector {
 func (sac *SnapAngleCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
 	// In v5 parser.TickRate() returns -1 before CSVCMsg_ServerInfo arrives, so
 	// seed with the CS2 default and refresh from TickRateInfoAvailable.
-	sac.tickRate = parser.TickRate()
-	if sac.tickRate <= 0 {
-		sac.tickRate = 64.0
+	tickRate := parser.TickRate()
+	if tickRate <= 0 {
+		tickRate = 64.0
 	}
+	sac.setTickRate(tickRate)
 	parser.RegisterEventHandler(func(e events.TickRateInfoAvailable) {
 		if e.TickRate > 0 {
-			sac.tickRate = e.TickRate
+			sac.setTickRate(e.TickRate)
 		}
 	})
 
 	// Register kill event handler
 	parser.RegisterEventHandler(func(e events.Kill) {
-		sac.processKill(e, demoStats)
+		if inWarmup(parser) {
+			return
+		}
+		sac.processKill(e, currentRound(parser), parser.GameState().IngameTick(), demoStats)
 	})
 }
 
-// processKill analyzes view angle changes before a kill to detect aim snapping
-func (sac *SnapAngleCollector) processKill(e events.Kill, demoStats *DemoStats) {
+// processKill analyzes view angle changes before a kill to detect aim
+// snapping. tick is the kill's in-game tick, for the evidence bundle.
+func (sac *SnapAngleCollector) processKill(e events.Kill, round, tick int, demoStats *DemoStats) {
 	// Ignore kills without a killer (suicides, fall damage, etc.)
 	if e.Killer == nil || e.Victim == nil {
 		return
@@ -126,14 +256,14 @@ func (sac *SnapAngleCollector) processKill(e events.Kill, demoStats *DemoStats)
 		return
 	}
 
-	killerID := e.Killer.SteamID64
+	killerID := demoStats.PlayerKey(e.Killer)
 	buffer, ok := sac.viewBuffers[killerID]
 	if !ok || buffer == nil {
 		return // No angle data for this player
 	}
 
 	// Get recent view angles
-	recentAngles := buffer.GetLast(ViewAngleBufferSize)
+	recentAngles := buffer.GetLast(sac.bufferSize)
 	if len(recentAngles) < 5 { // Need at least a few samples
 		return
 	}
@@ -153,9 +283,7 @@ func (sac *SnapAngleCollector) processKill(e events.Kill, demoStats *DemoStats)
 		previous := recentAngles[i+1]
 
 		// Calculate angle difference between these consecutive ticks
-		yawDiff := float64(angleDiff(current.Yaw, previous.Yaw))
-		pitchDiff := float64(angleDiff(current.Pitch, previous.Pitch))
-		angleDelta := math.Sqrt(yawDiff*yawDiff + pitchDiff*pitchDiff)
+		angleDelta := snapAngle(previous, current)
 
 		// If angle difference is small enough, we've found our starting point
 		if angleDelta < MinAngleDiffThreshold {
@@ -170,29 +298,19 @@ func (sac *SnapAngleCollector) processKill(e events.Kill, demoStats *DemoStats)
 		startSnapshot = recentAngles[len(recentAngles)-1]
 	}
 
-	// Calculate deltas
-	tickDelta := float64(endSnapshot.Tick - startSnapshot.Tick)
-	if tickDelta <= 0 {
-		tickDelta = 1.0 // Minimum tick difference to avoid division by zero
+	// Calculate deltas from in-game time rather than frame counts, so a
+	// dropped or duplicated frame doesn't stretch or shrink the snap.
+	tickInterval := time.Duration(float64(time.Second) / math.Max(1.0, sac.tickRate))
+	timeDelta := endSnapshot.Time - startSnapshot.Time
+	if timeDelta <= 0 {
+		timeDelta = tickInterval // Minimum one tick to avoid division by zero
 	}
 
-	// Exactly as per user's formula:
-	// deltaRad := angleDiff(prevYaw, currYaw)           // already radians
-	// deltaDeg := deltaRad * 57.29577951308232          // rad → deg
-	// deltaMs  := float64(tickCount) * (1000.0 / tickRate)
-	// snapVel  := deltaDeg / deltaMs                    // °/ms
-
-	// Calculate angle difference
-	deltaRad := math.Sqrt(
-		math.Pow(float64(angleDiff(startSnapshot.Yaw, endSnapshot.Yaw)), 2) +
-			math.Pow(float64(angleDiff(startSnapshot.Pitch, endSnapshot.Pitch)), 2),
-	)
-
-	// Convert to degrees
-	deltaDeg := deltaRad * RadToDeg
+	// View angles are already degrees, so the velocity is °/ms directly.
+	deltaDeg := snapAngle(startSnapshot, endSnapshot)
 
 	// Calculate time delta in milliseconds
-	deltaMs := tickDelta * (1000.0 / math.Max(1.0, sac.tickRate))
+	deltaMs := float64(timeDelta) / float64(time.Millisecond)
 
 	// Calculate velocity in degrees per millisecond
 	var velocity float64
@@ -202,57 +320,120 @@ func (sac *SnapAngleCollector) processKill(e events.Kill, demoStats *DemoStats)
 		velocity = 0
 	}
 
+	precise := isPreciseSnap(e.Killer, e.Victim)
+
 	// Only store non-zero, valid velocities
 	if velocity > 0 && !math.IsNaN(velocity) && !math.IsInf(velocity, 0) {
 		// Store the velocity for this killer
-		if _, ok := sac.snapVelocities[killerID]; !ok {
-			sac.snapVelocities[killerID] = make([]float64, 0)
+		addSample(sac.snapVelocities, killerID, velocity, sac.maxSamples)
+		sac.snapSums[killerID] += velocity
+		if sac.keepRaw {
+			addSample(sac.rawSnaps, killerID, velocity, sac.maxSamples)
+		}
+
+		if startTickFound && precise {
+			addSample(sac.preciseVelocities, killerID, velocity, sac.maxSamples)
+			addSample(sac.preciseTurns, killerID, deltaDeg, sac.maxSamples)
+			demoStats.AddRoundSample(killerID, round, "snap", velocity)
+			if velocity >= evidenceSnapDegPerMs {
+				demoStats.AddSuspiciousEvent(killerID, tick, fmt.Sprintf("precise snap %.3f°/ms onto %s", velocity, e.Victim.Name))
+			}
+		}
+	}
+
+	// The overshoot check reads the whole buffer rather than the settled
+	// start: a human's correction back onto the target is often slow
+	// enough to pass for settling.
+	if precise {
+		if overshoot, turn := flickOvershoot(recentAngles); turn >= overshootMinTurnDeg {
+			sac.flicks[killerID]++
+			if overshoot < overshootMinDeg {
+				sac.straightFlicks[killerID]++
+			}
 		}
-		sac.snapVelocities[killerID] = append(sac.snapVelocities[killerID], velocity)
 	}
 
 	// Get or create player stats
 	playerStats := demoStats.GetOrCreatePlayerStats(e.Killer)
 	if playerStats != nil {
 		// Increment kill count for this player
-		playerStats.IncrementIntMetric(Category("aiming"), Key("snapped_kills"))
+		playerStats.IncrementIntMetric(CatAiming, KeySnappedKills)
+	}
+}
+
+// isPreciseSnap reports whether the killer's crosshair at the kill tick sits
+// on the victim — within preciseSnapTolerance of either the head or the
+// chest. Legit flicks and wide swings are fast too, but they overshoot or
+// land off-body and rely on spread; an aimbot ends exactly on the target.
+func isPreciseSnap(killer, victim *common.Player) bool {
+	yaw, pitch, ok := viewAngles(killer)
+	if !ok {
+		return false
+	}
+	eye := eyePosition(killer)
+	head := headPosition(victim)
+	feet := victim.Position()
+	chest := feet.Add(head.Sub(feet).Mul(0.7))
+
+	for _, target := range []r3.Vector{head, chest} {
+		tol := preciseSnapTolerance(target.Sub(eye).Norm())
+		if aimResidualDeg(eye, float64(yaw), signedPitch(float64(pitch)), target) <= tol {
+			return true
+		}
+	}
+	return false
+}
+
+// aimResidualDeg returns the angle in degrees between a view direction
+// (Source yaw, pitch positive looking down) from eye and the direction to
+// target.
+func aimResidualDeg(eye r3.Vector, yaw, pitch float64, target r3.Vector) float64 {
+	d := target.Sub(eye)
+	horiz := math.Hypot(d.X, d.Y)
+	if horiz == 0 && d.Z == 0 {
+		return 0
 	}
+	targetYaw := math.Atan2(d.Y, d.X) * 180.0 / math.Pi
+	targetPitch := -math.Atan2(d.Z, horiz) * 180.0 / math.Pi
+	yawErr := angleDiffDeg(yaw, targetYaw) * math.Cos(targetPitch*math.Pi/180.0)
+	pitchErr := pitch - targetPitch
+	return math.Hypot(yawErr, pitchErr)
+}
+
+// preciseSnapTolerance is the angular radius in degrees of a
+// preciseSnapTargetRadius-wide target at dist units.
+func preciseSnapTolerance(dist float64) float64 {
+	if dist <= 0 {
+		return 90.0
+	}
+	tol := math.Atan(preciseSnapTargetRadius/dist) * 180.0 / math.Pi
+	return math.Max(tol, preciseSnapMinToleranceDeg)
 }
 
 // CollectFrame updates the view angle buffers for each player
 func (sac *SnapAngleCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
 	sac.currentTick = parser.CurrentFrame()
+	now := demoTime(parser, sac.tickRate)
 	gs := parser.GameState()
 
 	for _, player := range gs.Participants().Playing() {
-		if player == nil || player.SteamID64 == 0 {
+		if !demoStats.CountsAimPlayer(player) {
 			continue
 		}
 
 		// Get or create player view buffer
-		playerID := player.SteamID64
+		playerID := demoStats.PlayerKey(player)
 		if _, ok := sac.viewBuffers[playerID]; !ok {
-			sac.viewBuffers[playerID] = NewRingBuffer(ViewAngleBufferSize)
+			sac.viewBuffers[playerID] = NewRingBuffer(sac.bufferSize)
 		}
 
-		// Check if ViewDirection methods are available
-		yaw := float32(0.0)
-		pitch := float32(0.0)
-
-		// Try to safely get view directions
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-				}
-			}()
-
-			yaw = player.ViewDirectionX()
-			pitch = player.ViewDirectionY()
-		}()
+		// Unreadable pawns are stored as 0/0, as before.
+		yaw, pitch, _ := viewAngles(player)
 
 		// Store current view angles
 		snapshot := ViewAngleSnapshot{
 			Tick:  sac.currentTick,
+			Time:  now,
 			Yaw:   yaw,
 			Pitch: pitch,
 		}
@@ -260,11 +441,19 @@ func (sac *SnapAngleCollector) CollectFrame(parser demoinfocs.Parser, demoStats
 	}
 }
 
+// ProducedMetrics lists the metrics the cheat detector reads from SnapAngleCollector.
+func (sac *SnapAngleCollector) ProducedMetrics() []KeyRef {
+	return []KeyRef{
+		{CatAiming, KeyPreciseSnaps},
+		{CatAiming, KeyP95PreciseSnapVelocity},
+	}
+}
+
 // CollectFinalStats calculates the 95th percentile snap velocities
 func (sac *SnapAngleCollector) CollectFinalStats(demoStats *DemoStats) {
 	// For each player with snap velocity data
-	for playerID, velocities := range sac.snapVelocities {
-		if len(velocities) == 0 {
+	for playerID, res := range sac.snapVelocities {
+		if res.seen == 0 {
 			continue
 		}
 
@@ -285,25 +474,14 @@ func (sac *SnapAngleCollector) CollectFinalStats(demoStats *DemoStats) {
 		}
 
 		// Sort velocities to calculate percentiles
-		sort.Float64s(velocities)
-
-		// Calculate 95th percentile
-		p95Index := int(float64(len(velocities)) * 0.95)
-		if p95Index >= len(velocities) {
-			p95Index = len(velocities) - 1
-		}
-		p95Value := velocities[p95Index]
+		velocities := res.sorted()
 
-		// Calculate median as well
-		medianIndex := len(velocities) / 2
-		medianValue := velocities[medianIndex]
+		// Calculate 95th percentile and median
+		p95Value := sortedP95(velocities)
+		medianValue := sortedMedian(velocities)
 
-		// Calculate average
-		sum := 0.0
-		for _, v := range velocities {
-			sum += v
-		}
-		avgValue := sum / float64(len(velocities))
+		// Calculate average over every snap, not just the kept ones
+		avgValue := sac.snapSums[playerID] / float64(res.seen)
 
 		// Store statistics
 		playerStats := demoStats.GetOrCreatePlayerStats(player)
@@ -312,39 +490,187 @@ func (sac *SnapAngleCollector) CollectFinalStats(demoStats *DemoStats) {
 		}
 
 		// Store snap velocity metrics
-		playerStats.AddMetric(Category("aiming"), Key("p95_snap_velocity"), Metric{
+		playerStats.AddMetric(CatAiming, KeyP95SnapVelocity, Metric{
 			Type:        MetricFloat,
 			FloatValue:  p95Value,
 			Description: "95th percentile of aim snap velocity in degrees/ms",
 		})
 
-		playerStats.AddMetric(Category("aiming"), Key("median_snap_velocity"), Metric{
+		if lo, hi, ok := bootstrapInterval(velocities, sortedP95); ok {
+			addIntervalMetrics(playerStats, CatAiming, KeyP95SnapVelocity, lo, hi)
+		}
+
+		playerStats.AddMetric(CatAiming, KeyMedianSnapVelocity, Metric{
 			Type:        MetricFloat,
 			FloatValue:  medianValue,
 			Description: "Median of aim snap velocity in degrees/ms",
 		})
 
-		playerStats.AddMetric(Category("aiming"), Key("avg_snap_velocity"), Metric{
+		playerStats.AddMetric(CatAiming, KeyAvgSnapVelocity, Metric{
 			Type:        MetricFloat,
 			FloatValue:  avgValue,
 			Description: "Average aim snap velocity in degrees/ms",
 		})
 
-		playerStats.AddMetric(Category("aiming"), Key("snap_count"), Metric{
+		playerStats.AddMetric(CatAiming, KeySnapCount, Metric{
 			Type:        MetricInteger,
-			IntValue:    int64(len(velocities)),
+			IntValue:    int64(res.seen),
 			Description: "Number of aim snaps analyzed",
 		})
+
+		// Published even when zero so the cheat score knows the filter ran
+		// and doesn't fall back to raw snaps.
+		var precise []float64
+		preciseCount := 0
+		if pr, ok := sac.preciseVelocities[playerID]; ok {
+			precise, preciseCount = pr.sorted(), pr.seen
+		}
+		playerStats.AddMetric(CatAiming, KeyPreciseSnaps, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(preciseCount),
+			Description: "Snaps from a settled aim that ended on the victim",
+		})
+		if len(precise) > 0 {
+			playerStats.AddMetric(CatAiming, KeyP95PreciseSnapVelocity, Metric{
+				Type:        MetricFloat,
+				FloatValue:  sortedP95(precise),
+				Description: "95th percentile of precise snap velocity in degrees/ms",
+			})
+			if lo, hi, ok := bootstrapInterval(precise, sortedP95); ok {
+				addIntervalMetrics(playerStats, CatAiming, KeyP95PreciseSnapVelocity, lo, hi)
+			}
+		}
+
+		if turns, ok := sac.preciseTurns[playerID]; ok {
+			if mode, spike, ok := fovClampMode(turns.sorted()); ok {
+				playerStats.AddMetric(CatAiming, KeySnapResidualMode, Metric{
+					Type:        MetricFloat,
+					FloatValue:  mode,
+					Description: "Most common distance (degrees) a precise snap closed to the victim",
+				})
+				flag := "No"
+				if spike {
+					flag = "Yes"
+				}
+				playerStats.AddMetric(CatAiming, KeySnapFOVClamp, Metric{
+					Type:        MetricString,
+					StringValue: flag,
+					Description: "Precise snaps pile up at one distance — an aimbot's FOV radius",
+				})
+			}
+		}
+
+		if n := sac.flicks[playerID]; n >= overshootMinFlicks {
+			playerStats.AddMetric(CatAiming, KeyOvershootCheckedSnaps, Metric{
+				Type:        MetricInteger,
+				IntValue:    int64(n),
+				Description: fmt.Sprintf("Kill flicks of at least %.0f° checked for overshoot", overshootMinTurnDeg),
+			})
+			playerStats.AddMetric(CatAiming, KeyNoOvershootSnapRatio, Metric{
+				Type:        MetricFloat,
+				FloatValue:  float64(sac.straightFlicks[playerID]) / float64(n),
+				Description: "Share of kill flicks that stopped dead on the victim without overshooting (0-1)",
+			})
+		}
 	}
 }
 
-// Helper function to calculate the smallest angle difference between two angles (in radians)
-// This function calculates the smallest angle between two view directions
-func angleDiff(a, b float32) float32 {
-	// Calculate the difference in degrees
-	diff := float32(math.Mod(float64(b-a+180), 360) - 180)
-	if diff < -180 {
-		diff += 360
+// flickOvershoot measures the human overshoot-and-correct pattern. Hands
+// carry a flick past the target and pull back onto it; an aimbot approaches
+// the target and stops on it. recent is the view history before a kill,
+// newest first as from GetLast. The flick runs from the sample farthest from
+// the final aim (turn degrees away) to the final aim; overshoot is how far,
+// in degrees along that direction, the view went past the final aim on the
+// way.
+func flickOvershoot(recent []ViewAngleSnapshot) (overshoot, turn float64) {
+	if len(recent) < 3 {
+		return 0, 0
+	}
+	end := recent[0]
+	far := -1
+	for i, s := range recent {
+		if s.Tick == 0 {
+			break // unfilled slots
+		}
+		dx, dy := viewOffset(s, end)
+		if d := math.Hypot(dx, dy); d > turn {
+			turn, far = d, i
+		}
+	}
+	if far < 0 {
+		return 0, 0
+	}
+	ux, uy := viewOffset(recent[far], end)
+	ux, uy = ux/turn, uy/turn
+	for _, s := range recent[1:far] {
+		dx, dy := viewOffset(recent[far], s)
+		if past := dx*ux + dy*uy - turn; past > overshoot {
+			overshoot = past
+		}
+	}
+	return overshoot, turn
+}
+
+// viewOffset returns the signed yaw and pitch turn, in degrees, from a to b.
+func viewOffset(a, b ViewAngleSnapshot) (dYaw, dPitch float64) {
+	dYaw = math.Mod(float64(b.Yaw)-float64(a.Yaw)+540, 360) - 180
+	dPitch = signedPitch(float64(b.Pitch)) - signedPitch(float64(a.Pitch))
+	return dYaw, dPitch
+}
+
+// fovClampMode looks for the edge-clamp artifact of an FOV-limited aimbot.
+// Such a bot only locks onto targets within its FOV, and pulls from where
+// they enter it, so the distance its snaps close piles up at the FOV
+// radius. turns are the precise-snap turns in degrees; mode is the centre
+// of the most common fovClampBinDeg bin among those of at least
+// fovClampMinTurnDeg, and spike reports whether it stands out as described
+// at fovClampMinShare. ok is false with fewer than fovClampMinSnaps turns.
+func fovClampMode(turns []float64) (mode float64, spike, ok bool) {
+	counts := make(map[int]int)
+	n := 0
+	for _, t := range turns {
+		if t < fovClampMinTurnDeg {
+			continue
+		}
+		counts[int(t/fovClampBinDeg)]++
+		n++
+	}
+	if n < fovClampMinSnaps {
+		return 0, false, false
+	}
+	best, bestCount := 0, 0
+	for b, c := range counts {
+		if c > bestCount || (c == bestCount && b < best) {
+			best, bestCount = b, c
+		}
+	}
+	neighbours := float64(counts[best-2]+counts[best-1]+counts[best+1]+counts[best+2]) / 4
+	spike = float64(bestCount) >= fovClampMinShare*float64(n) && float64(bestCount) >= fovClampSpikeRatio*neighbours
+	return (float64(best) + 0.5) * fovClampBinDeg, spike, true
+}
+
+// snapAngle returns how far (in degrees) the view turned from a to b.
+func snapAngle(a, b ViewAngleSnapshot) float64 {
+	return angularDistance(float64(a.Yaw), float64(a.Pitch), float64(b.Yaw), float64(b.Pitch))
+}
+
+// SetMaxSamples caps the snap velocities kept per player; see
+// DefaultMaxSamples.
+func (sac *SnapAngleCollector) SetMaxSamples(n int) {
+	sac.maxSamples = n
+}
+
+// KeepRawSamples makes the collector retain the snap velocities for
+// AppendRawSamples, up to the SetMaxSamples cap.
+func (sac *SnapAngleCollector) KeepRawSamples() {
+	sac.keepRaw = true
+	sac.rawSnaps = make(map[uint64]*sampleReservoir)
+}
+
+// AppendRawSamples adds the retained snap velocities to samples.
+func (sac *SnapAngleCollector) AppendRawSamples(samples map[uint64]*PlayerSamples) {
+	for sid, v := range sac.rawSnaps {
+		ps := playerSamples(samples, sid)
+		ps.SnapVelocities = append(ps.SnapVelocities, v.inOrder()...)
 	}
-	return float32(math.Abs(float64(diff)))
 }
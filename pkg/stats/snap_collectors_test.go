@@ -0,0 +1,129 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+)
+
+func TestAimResidualDeg(t *testing.T) {
+	eye := r3.Vector{X: 0, Y: 0, Z: 64}
+	tests := []struct {
+		name       string
+		yaw, pitch float64
+		target     r3.Vector
+		want       float64
+	}{
+		{"dead on", 0, 0, r3.Vector{X: 500, Y: 0, Z: 64}, 0},
+		{"yaw off", 10, 0, r3.Vector{X: 500, Y: 0, Z: 64}, 10},
+		{"wraps at 360", 355, 0, r3.Vector{X: 500, Y: 0, Z: 64}, 5},
+		{"target below, looking down", 0, 45, r3.Vector{X: 100, Y: 0, Z: -36}, 0},
+	}
+	for _, tt := range tests {
+		if got := aimResidualDeg(eye, tt.yaw, tt.pitch, tt.target); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("%s: aimResidualDeg = %.4f, want %.4f", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPreciseSnapTolerance(t *testing.T) {
+	if near, far := preciseSnapTolerance(200), preciseSnapTolerance(2000); near <= far {
+		t.Errorf("tolerance should shrink with distance: near %.2f, far %.2f", near, far)
+	}
+	if got := preciseSnapTolerance(100000); got != preciseSnapMinToleranceDeg {
+		t.Errorf("long-range tolerance = %.3f, want floor %.1f", got, preciseSnapMinToleranceDeg)
+	}
+}
+
+func TestEvaluateSnapPrefersPrecise(t *testing.T) {
+	ps := &PlayerStats{Categories: make(map[Category]map[Key]Metric)}
+	ps.AddMetric(CatAiming, Key("snap_count"), Metric{Type: MetricInteger, IntValue: 20})
+	ps.AddMetric(CatAiming, Key("p95_snap_velocity"), Metric{Type: MetricFloat, FloatValue: 5.0})
+	if ch := evaluateSnap(ps, neutralMapProfile); !ch.HasData || ch.Score != 1 {
+		t.Fatalf("raw fallback: %+v", ch)
+	}
+
+	// Fast flicks that never landed precisely: the filter ran, nothing to score.
+	ps.AddMetric(CatAiming, Key("precise_snaps"), Metric{Type: MetricInteger, IntValue: 0})
+	if ch := evaluateSnap(ps, neutralMapProfile); ch.HasData {
+		t.Errorf("scored raw snaps despite precise_snaps=0: %+v", ch)
+	}
+}
+
+func TestSnapWindowTicks(t *testing.T) {
+	if got := windowTicks(DefaultSnapWindow, 64); got != ViewAngleBufferSize {
+		t.Errorf("default window at 64 tick = %d ticks, want %d", got, ViewAngleBufferSize)
+	}
+	if got := windowTicks(DefaultSnapWindow, 128); got != 2*ViewAngleBufferSize {
+		t.Errorf("default window at 128 tick = %d ticks, want %d", got, 2*ViewAngleBufferSize)
+	}
+
+	sac := NewSnapAngleCollectorWithWindow(500 * time.Millisecond)
+	sac.viewBuffers[1] = NewRingBuffer(sac.bufferSize)
+	sac.setTickRate(128)
+	if sac.bufferSize != 64 || len(sac.viewBuffers) != 0 {
+		t.Errorf("after 128 tick: bufferSize %d, %d stale buffers", sac.bufferSize, len(sac.viewBuffers))
+	}
+}
+
+func TestFOVClampMode(t *testing.T) {
+	// Human flicks: spread from 2° to 40°, plus micro-corrections.
+	var human []float64
+	for i := 0; i < 30; i++ {
+		human = append(human, 2+float64(i)*1.3, 0.5)
+	}
+	if _, spike, ok := fovClampMode(human); !ok || spike {
+		t.Errorf("human flicks: spike=%v ok=%v, want no spike", spike, ok)
+	}
+
+	// A bot with a 6° FOV: most snaps close 6.0–6.4°, the rest anywhere.
+	bot := append([]float64{}, human[:20]...)
+	for i := 0; i < 12; i++ {
+		bot = append(bot, 6.0+float64(i%5)*0.08)
+	}
+	mode, spike, ok := fovClampMode(bot)
+	if !ok || !spike || mode != 6.25 {
+		t.Errorf("FOV bot: mode=%.2f spike=%v ok=%v, want 6.25 spike", mode, spike, ok)
+	}
+
+	if _, _, ok := fovClampMode([]float64{5, 5, 5, 1, 1}); ok {
+		t.Error("mode from fewer than fovClampMinSnaps turns")
+	}
+}
+
+func TestFlickOvershoot(t *testing.T) {
+	// flick builds a newest-first history from yaws listed oldest first,
+	// crossing 0° so the wrap is exercised.
+	flick := func(yaws ...float32) []ViewAngleSnapshot {
+		out := make([]ViewAngleSnapshot, len(yaws))
+		for i, y := range yaws {
+			out[len(yaws)-1-i] = ViewAngleSnapshot{Tick: i + 1, Yaw: y, Pitch: 358}
+		}
+		return out
+	}
+	tests := []struct {
+		name          string
+		recent        []ViewAngleSnapshot
+		wantOvershoot bool
+	}{
+		{"human overshoot and correct", flick(350, 350, 355, 2, 13, 16, 14, 12, 10), true},
+		{"aimbot stops dead", flick(350, 350, 356, 2, 7, 9, 10, 10, 10), false},
+	}
+	for _, tt := range tests {
+		overshoot, turn := flickOvershoot(tt.recent)
+		if math.Abs(turn-20) > 1e-4 {
+			t.Errorf("%s: turn = %.2f, want 20", tt.name, turn)
+		}
+		if got := overshoot >= overshootMinDeg; got != tt.wantOvershoot {
+			t.Errorf("%s: overshoot %.2f°, overshot = %v, want %v", tt.name, overshoot, got, tt.wantOvershoot)
+		}
+	}
+
+	// Unfilled ring slots are ignored.
+	recent := append(flick(0, 10, 10), ViewAngleSnapshot{Yaw: 180})
+	if _, turn := flickOvershoot(recent); math.Abs(turn-10) > 1e-4 {
+		t.Errorf("turn = %.2f with an unfilled slot, want 10", turn)
+	}
+}
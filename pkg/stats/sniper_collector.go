@@ -1,12 +1,41 @@
 package stats
 
 import (
+	"math"
+	"time"
+
+	"github.com/golang/geo/r3"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
 )
 
-const sniperCategory = Category("sniper")
+// sniperAccurateSpeed is the horizontal speed (units/s) above which a sniper
+// shot is inaccurate: a third of the weapon's unscoped max speed (AWP 200,
+// Scout 230, autos 215), the point at which CS2 stops treating a shooter as
+// standing still. Scoped movement caps well above it, so a scoped kill
+// faster than this needed the inaccuracy cone to land on the head by luck.
+var sniperAccurateSpeed = map[common.EquipmentType]float64{
+	common.EqAWP:    68,
+	common.EqScout:  78,
+	common.EqScar20: 73,
+	common.EqG3SG1:  73,
+}
+
+// sniperTeleportSpeed is the horizontal speed (units/s) above which a
+// position change between frames is a respawn or teleport, not movement.
+const sniperTeleportSpeed = 500.0
+
+// sniperStance is a player's movement and scope state on the last frame.
+// The parser exposes no velocity, so speed is the position change since the
+// frame before.
+type sniperStance struct {
+	pos      r3.Vector
+	at       time.Duration
+	speed    float64 // horizontal, units/s; -1 when unknown
+	scoped   bool
+	airborne bool
+}
 
 // SniperCollector tracks sniper-specific kill signals that act as
 // high-confidence cheat overrides in the detector:
@@ -20,13 +49,22 @@ const sniperCategory = Category("sniper")
 //     close-medium range. Landing 10+ Scout kills with >=80% HS rate without
 //     aim assistance is statistically implausible — Scout's slow rate of fire,
 //     no aim punch, and pixel-tight head hitbox don't allow it.
+//
+//   - moving_scoped_kills: scoped sniper kills (scoped_sniper_kills) made
+//     while moving faster than sniperAccurateSpeed. Movement and scope are
+//     read from the frame before the kill, because the AWP and Scout drop
+//     the scope as they fire. Airborne kills are left out: jump-scouting at
+//     the apex is accurate in CS2 and a technique of its own.
 type SniperCollector struct {
 	*BaseCollector
+
+	stances map[uint64]sniperStance
 }
 
 func NewSniperCollector() *SniperCollector {
 	return &SniperCollector{
-		BaseCollector: NewBaseCollector("Sniper Kills", sniperCategory),
+		BaseCollector: NewBaseCollector("Sniper Kills", CatSniper),
+		stances:       make(map[uint64]sniperStance),
 	}
 }
 
@@ -40,7 +78,7 @@ func isSniper(t common.EquipmentType) bool {
 
 func (sc *SniperCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
 	parser.RegisterEventHandler(func(e events.Kill) {
-		if e.Killer == nil || e.Killer.SteamID64 == 0 || e.Victim == nil {
+		if e.Killer == nil || demoStats.PlayerKey(e.Killer) == 0 || e.Victim == nil {
 			return
 		}
 		if e.Killer == e.Victim || e.Killer.Team == e.Victim.Team {
@@ -57,29 +95,87 @@ func (sc *SniperCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats)
 		t := e.Weapon.Type
 		if isSniper(t) {
 			if e.PenetratedObjects > 0 {
-				ps.IncrementIntMetric(sniperCategory, Key("sniper_wallbang_kills"))
+				ps.IncrementIntMetric(CatSniper, KeySniperWallbangKills)
+			}
+			if st, ok := sc.stances[demoStats.PlayerKey(e.Killer)]; ok && st.speed >= 0 && st.scoped && !e.NoScope && !st.airborne {
+				ps.IncrementIntMetric(CatSniper, KeyScopedSniperKills)
+				if movingScoped(t, st.speed) {
+					ps.IncrementIntMetric(CatSniper, KeyMovingScopedKills)
+				}
 			}
 		}
 		if t == common.EqScout {
-			ps.IncrementIntMetric(sniperCategory, Key("scout_kills"))
+			ps.IncrementIntMetric(CatSniper, KeyScoutKills)
 			if e.IsHeadshot {
-				ps.IncrementIntMetric(sniperCategory, Key("scout_hs_kills"))
+				ps.IncrementIntMetric(CatSniper, KeyScoutHSKills)
 			}
 		}
 	})
 }
 
-func (sc *SniperCollector) CollectFinalStats(demoStats *DemoStats) {
-	for sid, ps := range demoStats.Players {
-		if sid == 0 {
+// CollectFrame records every alive player's speed and scope state. Kill
+// handlers run before the frame is collected, so they see the stance from
+// the frame before the shot.
+func (sc *SniperCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	now := demoTime(parser, parser.TickRate())
+	for _, player := range parser.GameState().Participants().Playing() {
+		if !demoStats.CountsPlayer(player) || !player.IsAlive() {
 			continue
 		}
-		total := intMetric(ps, sniperCategory, Key("scout_kills"))
+		sid := demoStats.PlayerKey(player)
+		st := sniperStance{
+			pos:      player.Position(),
+			at:       now,
+			speed:    -1,
+			scoped:   player.IsScoped(),
+			airborne: player.IsAirborne(),
+		}
+		if prev, ok := sc.stances[sid]; ok {
+			st.speed = horizontalSpeed(prev.pos, st.pos, now-prev.at)
+		}
+		sc.stances[sid] = st
+	}
+}
+
+// horizontalSpeed returns the speed (units/s) of a move from a to b over dt,
+// or -1 when dt isn't positive or the move is a teleport.
+func horizontalSpeed(a, b r3.Vector, dt time.Duration) float64 {
+	if dt <= 0 {
+		return -1
+	}
+	speed := math.Hypot(b.X-a.X, b.Y-a.Y) / dt.Seconds()
+	if speed > sniperTeleportSpeed {
+		return -1
+	}
+	return speed
+}
+
+// movingScoped reports whether a scoped kill with weapon t at the given
+// horizontal speed was made too fast to be accurate.
+func movingScoped(t common.EquipmentType, speed float64) bool {
+	limit, ok := sniperAccurateSpeed[t]
+	return ok && speed > limit
+}
+
+// ProducedMetrics lists the metrics the cheat detector reads from SniperCollector.
+func (sc *SniperCollector) ProducedMetrics() []KeyRef {
+	return []KeyRef{
+		{CatSniper, KeySniperWallbangKills},
+		{CatSniper, KeyScoutKills},
+		{CatSniper, KeyScoutHSRate},
+		{CatSniper, KeyScopedSniperKills},
+		{CatSniper, KeyMovingScopedKills},
+	}
+}
+
+func (sc *SniperCollector) CollectFinalStats(demoStats *DemoStats) {
+	for _, ps := range demoStats.Players {
+		total := intMetric(ps, CatSniper, KeyScoutKills)
 		if total <= 0 {
 			continue
 		}
-		hs := intMetric(ps, sniperCategory, Key("scout_hs_kills"))
-		ps.AddMetric(sniperCategory, Key("scout_hs_rate"), Metric{
+		hs := intMetric(ps, CatSniper, KeyScoutHSKills)
+		ps.AddMetric(CatSniper, KeyScoutHSRate, Metric{
 			Type:        MetricPercentage,
 			FloatValue:  float64(hs) / float64(total) * 100.0,
 			Description: "Headshot rate on SSG-08 kills",
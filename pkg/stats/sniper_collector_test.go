@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+func TestHorizontalSpeed(t *testing.T) {
+	tick := time.Second / 64
+	// 1.5 units per 64-tick frame, with a vertical drop that must not count.
+	if got := horizontalSpeed(r3.Vector{}, r3.Vector{X: 0.9, Y: 1.2, Z: -5}, tick); got != 96 {
+		t.Errorf("speed = %v, want 96", got)
+	}
+	if got := horizontalSpeed(r3.Vector{}, r3.Vector{X: 1000}, tick); got != -1 {
+		t.Errorf("teleport speed = %v, want -1", got)
+	}
+	if got := horizontalSpeed(r3.Vector{}, r3.Vector{X: 1}, 0); got != -1 {
+		t.Errorf("zero dt speed = %v, want -1", got)
+	}
+}
+
+func TestMovingScoped(t *testing.T) {
+	if movingScoped(common.EqAWP, 60) {
+		t.Error("AWP at 60 u/s counted as moving")
+	}
+	if !movingScoped(common.EqAWP, 90) {
+		t.Error("AWP at 90 u/s not counted as moving")
+	}
+	if movingScoped(common.EqScout, 75) {
+		t.Error("Scout at 75 u/s counted as moving")
+	}
+	if movingScoped(common.EqAK47, 200) {
+		t.Error("rifle counted as a sniper")
+	}
+}
+
+func TestEvaluateMovingScoped(t *testing.T) {
+	ps := &PlayerStats{Categories: make(map[Category]map[Key]Metric)}
+	if ch := evaluateMovingScoped(ps); ch.HasData {
+		t.Fatalf("scored without sniper data: %+v", ch)
+	}
+	ps.AddMetric(CatSniper, Key("scoped_sniper_kills"), Metric{Type: MetricInteger, IntValue: 6})
+	if ch := evaluateMovingScoped(ps); !ch.HasData || ch.Score != 0 {
+		t.Errorf("no moving kills: %+v", ch)
+	}
+	ps.AddMetric(CatSniper, Key("moving_scoped_kills"), Metric{Type: MetricInteger, IntValue: 3})
+	if ch := evaluateMovingScoped(ps); ch.Score != 1 || ch.Confidence != 1 {
+		t.Errorf("3 moving kills over 6 scoped kills: %+v", ch)
+	}
+}
@@ -0,0 +1,241 @@
+package stats
+
+import (
+	"math"
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+const (
+	// awpFlickLookback is the window before an AWP kill over which the
+	// flick is measured. AWP one-taps are flick-then-fire inside ~150 ms;
+	// anything slower is a tracked shot, not a flick.
+	awpFlickLookback = 150 * time.Millisecond
+
+	// awpFlickMinSamples is the minimum number of AWP kills before the
+	// per-player medians are published.
+	awpFlickMinSamples = 3
+)
+
+// SniperFlickCollector measures the AWP flick-then-fire signature on every
+// AWP kill:
+//
+//   - awp_flick_velocity: angular displacement (deg) over the last
+//     awpFlickLookback before the kill, divided by that window in ms.
+//     Huge flicks that land a one-tap are the classic sniper aimbot tell.
+//   - scope_to_kill_ms: time from the killer scoping in to the killing
+//     shot. A human needs to settle after scope-in; a consistent sub-100 ms
+//     scope-to-kill on top of a large flick is implausible.
+//
+// Scoped state is tracked per frame because demos carry no scope-in event,
+// and read at the AWP shot rather than at the kill: the AWP unscopes to
+// rechamber right after firing, so the killer may already be unscoped when
+// the kill arrives. Noscope kills contribute a flick sample but no
+// scope_to_kill sample.
+//
+// All times are in-game (see demoTime), not demo frames, which run at
+// several per tick.
+type SniperFlickCollector struct {
+	*BaseCollector
+
+	tickRate     float64
+	currentFrame int
+	now          time.Duration
+
+	viewBuffers map[uint64]*RingBuffer
+	scopeIns    map[uint64]time.Duration // when the player last scoped in; absent while unscoped
+	lastShots   map[uint64]awpShot
+
+	flickVelocities map[uint64][]float64
+	scopeToKillMs   map[uint64][]float64
+}
+
+// awpShot is the killer's scope state at their latest AWP shot.
+type awpShot struct {
+	at      time.Duration
+	scoped  bool
+	scopeIn time.Duration // when scoped in, if scoped
+}
+
+// NewSniperFlickCollector creates a new SniperFlickCollector.
+func NewSniperFlickCollector() *SniperFlickCollector {
+	return &SniperFlickCollector{
+		BaseCollector:   NewBaseCollector("AWP Flick Analysis", CatSniper),
+		viewBuffers:     make(map[uint64]*RingBuffer),
+		scopeIns:        make(map[uint64]time.Duration),
+		lastShots:       make(map[uint64]awpShot),
+		flickVelocities: make(map[uint64][]float64),
+		scopeToKillMs:   make(map[uint64][]float64),
+	}
+}
+
+// Setup seeds the tick rate and registers the shot and kill handlers.
+func (sfc *SniperFlickCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	sfc.tickRate = parser.TickRate()
+	if sfc.tickRate <= 0 {
+		sfc.tickRate = 64.0
+	}
+	parser.RegisterEventHandler(func(e events.TickRateInfoAvailable) {
+		if e.TickRate > 0 {
+			sfc.tickRate = e.TickRate
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.WeaponFire) {
+		if e.Weapon == nil || e.Weapon.Type != common.EqAWP || !demoStats.CountsAimPlayer(e.Shooter) {
+			return
+		}
+		sid := demoStats.PlayerKey(e.Shooter)
+		now := demoTime(parser, sfc.tickRate)
+		scopeIn, scoped := sfc.scopeIns[sid]
+		if !scoped && e.Shooter.IsScoped() {
+			// Scoped in within the shot frame itself — not yet seen by
+			// CollectFrame.
+			scopeIn, scoped = now, true
+		}
+		sfc.lastShots[sid] = awpShot{at: now, scoped: scoped, scopeIn: scopeIn}
+	})
+
+	parser.RegisterEventHandler(func(e events.Kill) {
+		sfc.processKill(e, demoStats, demoTime(parser, sfc.tickRate))
+	})
+
+	parser.RegisterEventHandler(func(_ events.RoundEnd) {
+		sfc.scopeIns = make(map[uint64]time.Duration)
+		sfc.lastShots = make(map[uint64]awpShot)
+	})
+}
+
+// CollectFrame records view angles and scope-in transitions for every
+// alive player.
+func (sfc *SniperFlickCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	sfc.currentFrame = parser.CurrentFrame()
+	sfc.now = demoTime(parser, sfc.tickRate)
+
+	for _, player := range parser.GameState().Participants().Playing() {
+		if !demoStats.CountsAimPlayer(player) || !player.IsAlive() {
+			continue
+		}
+		sid := demoStats.PlayerKey(player)
+
+		if _, ok := sfc.viewBuffers[sid]; !ok {
+			sfc.viewBuffers[sid] = NewRingBuffer(windowTicks(DefaultSnapWindow, sfc.tickRate))
+		}
+		sfc.viewBuffers[sid].Add(ViewAngleSnapshot{
+			Tick:  sfc.currentFrame,
+			Time:  sfc.now,
+			Yaw:   player.ViewDirectionX(),
+			Pitch: player.ViewDirectionY(),
+		})
+
+		if player.IsScoped() {
+			if _, scoped := sfc.scopeIns[sid]; !scoped {
+				sfc.scopeIns[sid] = sfc.now
+			}
+		} else {
+			delete(sfc.scopeIns, sid)
+		}
+	}
+}
+
+// processKill records the flick velocity and scope-to-kill time for an AWP
+// kill at in-game time now.
+func (sfc *SniperFlickCollector) processKill(e events.Kill, demoStats *DemoStats, now time.Duration) {
+	if e.Killer == nil || e.Victim == nil || e.Weapon == nil {
+		return
+	}
+	if e.Killer == e.Victim || e.Killer.Team == e.Victim.Team {
+		return
+	}
+	if e.Weapon.Type != common.EqAWP || !demoStats.CountsAimPlayer(e.Killer) {
+		return
+	}
+	sid := demoStats.PlayerKey(e.Killer)
+
+	if buffer, ok := sfc.viewBuffers[sid]; ok {
+		if v, ok := awpFlickVelocity(buffer, now); ok {
+			sfc.flickVelocities[sid] = append(sfc.flickVelocities[sid], v)
+		}
+	}
+
+	shot, ok := sfc.lastShots[sid]
+	if !ok || now-shot.at > awpFlickLookback {
+		// No shot seen for this kill; fall back to the state at the kill
+		shot = awpShot{at: now}
+		shot.scopeIn, shot.scoped = sfc.scopeIns[sid]
+		if !shot.scoped && e.Killer.IsScoped() {
+			shot.scopeIn, shot.scoped = now, true
+		}
+	}
+	if shot.scoped && !e.NoScope {
+		sfc.scopeToKillMs[sid] = append(sfc.scopeToKillMs[sid], float64(shot.at-shot.scopeIn)/float64(time.Millisecond))
+	}
+}
+
+// awpFlickVelocity returns the angular displacement between the most recent
+// snapshot and the oldest snapshot inside the lookback window before
+// killTime, in °/ms.
+func awpFlickVelocity(buffer *RingBuffer, killTime time.Duration) (float64, bool) {
+	recent := buffer.GetLast(buffer.Size)
+
+	end := recent[0]
+	if end.Tick == 0 || killTime-end.Time > awpFlickLookback {
+		return 0, false // no fresh view data for the killer
+	}
+	start := end
+	for _, snap := range recent[1:] {
+		if snap.Tick == 0 || end.Time-snap.Time > awpFlickLookback {
+			break
+		}
+		start = snap
+	}
+	elapsedMs := float64(end.Time-start.Time) / float64(time.Millisecond)
+	if elapsedMs <= 0 {
+		return 0, false
+	}
+
+	yaw := angleDiffDeg(float64(start.Yaw), float64(end.Yaw))
+	pitch := angleDiffDeg(float64(start.Pitch), float64(end.Pitch))
+	return math.Sqrt(yaw*yaw+pitch*pitch) / elapsedMs, true
+}
+
+// CollectFinalStats publishes per-player medians once enough AWP kills exist.
+func (sfc *SniperFlickCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, velocities := range sfc.flickVelocities {
+		if len(velocities) < awpFlickMinSamples {
+			continue
+		}
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		ps.AddMetric(CatSniper, KeyAWPFlickVelocity, Metric{
+			Type:        MetricFloat,
+			FloatValue:  median(velocities),
+			Description: "Median AWP flick velocity in degrees/ms over the 150 ms before each AWP kill",
+		})
+		ps.AddMetric(CatSniper, KeyAWPFlickSamples, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(len(velocities)),
+			Description: "Number of AWP kills contributing to flick velocity",
+		})
+	}
+
+	for sid, samples := range sfc.scopeToKillMs {
+		if len(samples) < awpFlickMinSamples {
+			continue
+		}
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		ps.AddMetric(CatSniper, KeyScopeToKillMs, Metric{
+			Type:        MetricFloat,
+			FloatValue:  median(samples),
+			Description: "Median time in ms from scoping in to an AWP kill (low + large flick = suspicious)",
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+func TestScopeToKillAfterUnscope(t *testing.T) {
+	parser := &warmupStubParser{}
+	killer := &common.Player{SteamID64: 1, Team: common.TeamTerrorists}
+	victim := &common.Player{SteamID64: 2, Team: common.TeamCounterTerrorists}
+	awp := &common.Equipment{Type: common.EqAWP}
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStats(killer)
+	ds.GetOrCreatePlayerStats(victim)
+
+	sfc := NewSniperFlickCollector()
+	sfc.Setup(parser, ds)
+
+	for i := 0; i < awpFlickMinSamples; i++ {
+		// Scoped in on tick 100, as CollectFrame would record it
+		sfc.scopeIns[1] = 100 * time.Second / 64
+
+		parser.frame = 106
+		parser.dispatch(events.WeaponFire{Shooter: killer, Weapon: awp})
+
+		// The AWP unscopes to rechamber before the kill arrives
+		delete(sfc.scopeIns, 1)
+		parser.frame = 107
+		parser.dispatch(events.Kill{Killer: killer, Victim: victim, Weapon: awp})
+	}
+	sfc.CollectFinalStats(ds)
+
+	got, ok := psGetFloat(ds.Players[1], CatSniper, KeyScopeToKillMs)
+	if !ok {
+		t.Fatal("no scope_to_kill_ms for kills that arrived after unscoping")
+	}
+	if want := 6 * 1000.0 / 64; got != want {
+		t.Errorf("scope_to_kill_ms = %.2f, want %.2f (scope-in to shot)", got, want)
+	}
+}
+
+// TestAWPFlickVelocityInGameTime runs two demo frames per tick: the flick
+// is timed by the snapshots' in-game time, not their frame numbers.
+func TestAWPFlickVelocityInGameTime(t *testing.T) {
+	buffer := NewRingBuffer(64)
+	tick := time.Second / 64
+	for frame := 1; frame <= 40; frame++ {
+		at := time.Duration(frame/2) * tick
+		buffer.Add(ViewAngleSnapshot{Tick: frame, Time: at, Yaw: float32(at / time.Millisecond)})
+	}
+
+	// 1° per in-game ms over the 150 ms window
+	got, ok := awpFlickVelocity(buffer, 20*tick)
+	if !ok {
+		t.Fatal("no flick velocity")
+	}
+	if math.Abs(got-1) > 0.01 {
+		t.Errorf("flick velocity = %.3f°/ms, want 1", got)
+	}
+
+	// View data older than the window isn't a flick before this kill
+	if _, ok := awpFlickVelocity(buffer, 20*tick+awpFlickLookback+tick); ok {
+		t.Error("stale view data measured as a flick")
+	}
+}
@@ -0,0 +1,104 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+const (
+	// sprayPatternMinLen matches the recoil collector's scoring start: bullets
+	// 1–2 are never scored, so a pattern needs at least 3 entries to be useful.
+	sprayPatternMinLen = 3
+	// sprayPatternMaxLen bounds a pattern at the largest magazine in the game
+	// (Negev). Only the first maxBulletIdx entries are scored.
+	sprayPatternMaxLen = 150
+)
+
+// sprayPatternWeapons lists every weapon type a spray pattern file may name.
+// Keys in the file use the same lowercase names as the recoil metrics
+// (weaponTypeToString), e.g. "ak47", "m4a1", "galil".
+var sprayPatternWeapons = []common.EquipmentType{
+	common.EqAK47,
+	common.EqM4A4,
+	common.EqM4A1,
+	common.EqFamas,
+	common.EqGalil,
+	common.EqMP7,
+	common.EqMP9,
+	common.EqP90,
+	common.EqUMP,
+	common.EqNegev,
+	common.EqM249,
+	common.EqSG556,
+	common.EqAUG,
+}
+
+// weaponTypeFromString resolves a spray-pattern weapon name. Matching is
+// case-insensitive and ignores "-" so "AK-47" and "ak47" both resolve; the
+// in-game "M4A1-S" spelling is accepted as an alias for "m4a1".
+func weaponTypeFromString(name string) (common.EquipmentType, bool) {
+	n := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "")
+	if n == "m4a1s" {
+		n = "m4a1"
+	}
+	for _, t := range sprayPatternWeapons {
+		if weaponTypeToString(t) == n {
+			return t, true
+		}
+	}
+	return common.EqUnknown, false
+}
+
+// LoadSprayPatterns parses spray patterns from a JSON object mapping weapon
+// name to a list of [yaw, pitch] offsets in degrees:
+//
+//	{
+//	  "ak47": [[0, 0], [0, 0.9], [0, 1.9], ...],
+//	  "m4a4": [[0, 0], [0, 0.8], ...]
+//	}
+//
+// Every pattern must start at (0, 0) — the first bullet is the reference
+// point — and hold between 3 and 150 entries.
+func LoadSprayPatterns(r io.Reader) (map[common.EquipmentType][][2]float64, error) {
+	var raw map[string][][2]float64
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decode spray patterns: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("spray patterns: no weapons defined")
+	}
+
+	// Validate in name order so the first reported error is deterministic.
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	patterns := make(map[common.EquipmentType][][2]float64, len(raw))
+	for _, name := range names {
+		pattern := raw[name]
+		t, ok := weaponTypeFromString(name)
+		if !ok {
+			return nil, fmt.Errorf("spray patterns: unknown weapon %q", name)
+		}
+		if _, dup := patterns[t]; dup {
+			return nil, fmt.Errorf("spray patterns: weapon %q defined more than once", name)
+		}
+		if len(pattern) < sprayPatternMinLen || len(pattern) > sprayPatternMaxLen {
+			return nil, fmt.Errorf("spray patterns: %s has %d bullets, want %d–%d",
+				name, len(pattern), sprayPatternMinLen, sprayPatternMaxLen)
+		}
+		if pattern[0] != [2]float64{0, 0} {
+			return nil, fmt.Errorf("spray patterns: %s must start at (0, 0), got (%g, %g)",
+				name, pattern[0][0], pattern[0][1])
+		}
+		patterns[t] = pattern
+	}
+	return patterns, nil
+}
@@ -0,0 +1,40 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+func TestLoadSprayPatterns(t *testing.T) {
+	in := `{"AK-47": [[0, 0], [0, 0.9], [0, 1.9]], "m4a1-s": [[0, 0], [0, 0.7], [0, 1.5], [0.2, 2.2]]}`
+	patterns, err := LoadSprayPatterns(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("LoadSprayPatterns: %v", err)
+	}
+	if got := len(patterns[common.EqAK47]); got != 3 {
+		t.Errorf("ak47 pattern length = %d, want 3", got)
+	}
+	if got := len(patterns[common.EqM4A1]); got != 4 {
+		t.Errorf("m4a1 pattern length = %d, want 4", got)
+	}
+}
+
+func TestLoadSprayPatterns_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   string
+	}{
+		{"malformed", `{"ak47": [[0, 0]`},
+		{"empty", `{}`},
+		{"unknown weapon", `{"awp": [[0, 0], [0, 1], [0, 2]]}`},
+		{"too short", `{"ak47": [[0, 0], [0, 1]]}`},
+		{"bad origin", `{"ak47": [[0.5, 0], [0, 1], [0, 2]]}`},
+		{"duplicate alias", `{"m4a1": [[0, 0], [0, 1], [0, 2]], "M4A1-S": [[0, 0], [0, 1], [0, 2]]}`},
+	} {
+		if _, err := LoadSprayPatterns(strings.NewReader(tc.in)); err == nil {
+			t.Errorf("%s: expected error, got nil", tc.name)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// CS2 runs sub-tick: a shot happens at a fraction of a tick, not on the
+// tick boundary where demoinfocs reports it. The demo records the exact
+// moment on the weapon entity as m_fLastShotTime, in server game time —
+// the clock parser.CurrentTime runs on. The user commands that carry the
+// sub-tick input steps (CSubtickMoveStep.when) are only in POV demos, and
+// demoinfocs doesn't decode them, so the weapon property is the source.
+//
+// Timing a shot or a hit at its tick is up to one tick late — 15.6 ms at
+// 64 tick. fireTime corrects that when the property is there and
+// consistent with the current tick, and falls back to the tick time
+// otherwise (GOTV demos from before sub-tick, weapons without the property).
+
+// fireTime returns when weapon's latest shot was fired: the current tick's
+// time plus the shot's sub-tick offset, if the weapon records one.
+func fireTime(parser demoinfocs.Parser, weapon *common.Equipment, tickRate float64) time.Duration {
+	now := demoTime(parser, tickRate)
+	if weapon == nil || weapon.Entity == nil {
+		return now
+	}
+	v, ok := weapon.Entity.PropertyValue("m_fLastShotTime")
+	if !ok || v.Any == nil {
+		return now
+	}
+	return now + subtickOffset(float64(v.Float()), now, tickRate)
+}
+
+// subtickOffset returns the offset of a shot at game time shotTime
+// (seconds) from tickTime, the time of the tick reporting it. Anything more
+// than a tick away isn't this tick's shot — a stale value, or a demo whose
+// clocks don't line up — and yields 0, the tick-granular fallback.
+func subtickOffset(shotTime float64, tickTime time.Duration, tickRate float64) time.Duration {
+	if shotTime <= 0 || tickRate <= 0 {
+		return 0
+	}
+	off := time.Duration(shotTime*float64(time.Second)) - tickTime
+	tick := time.Duration(float64(time.Second) / tickRate)
+	if off <= -tick || off >= tick {
+		return 0
+	}
+	return off
+}
+
+// shotClock remembers each shooter's latest shot so a hit reported on the
+// same tick can be timed at the shot's sub-tick moment. Hitscan damage
+// lands when the bullet is fired; the hurt event only carries the tick.
+type shotClock map[uint64]shotTime
+
+type shotTime struct {
+	tick int
+	at   time.Duration
+}
+
+// fire records a shot by key at tick, fired at at.
+func (c shotClock) fire(key uint64, tick int, at time.Duration) {
+	c[key] = shotTime{tick: tick, at: at}
+}
+
+// hitTime returns the time of a hit by key at tick: the shot's time if key
+// fired on this tick, else now.
+func (c shotClock) hitTime(key uint64, tick int, now time.Duration) time.Duration {
+	if s, ok := c[key]; ok && s.tick == tick {
+		return s.at
+	}
+	return now
+}
@@ -0,0 +1,40 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubtickOffset(t *testing.T) {
+	tickTime := 1000 * time.Second // tick 64000 at 64 tick
+	tests := []struct {
+		name string
+		shot float64
+		want time.Duration
+	}{
+		{"mid-tick shot", 999.992, -8 * time.Millisecond},
+		{"on the tick", 1000, 0},
+		{"stale shot from an earlier tick", 999.9, 0},
+		{"no shot recorded", 0, 0},
+	}
+	for _, tt := range tests {
+		got := subtickOffset(tt.shot, tickTime, 64)
+		if d := got - tt.want; d < -time.Microsecond || d > time.Microsecond {
+			t.Errorf("%s: offset = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestShotClockHitTime(t *testing.T) {
+	c := make(shotClock)
+	c.fire(7, 100, 1562*time.Millisecond)
+	if got := c.hitTime(7, 100, 1563*time.Millisecond); got != 1562*time.Millisecond {
+		t.Errorf("same-tick hit = %v, want the shot's time", got)
+	}
+	if got := c.hitTime(7, 101, 1578*time.Millisecond); got != 1578*time.Millisecond {
+		t.Errorf("later-tick hit = %v, want the tick time (fallback)", got)
+	}
+	if got := c.hitTime(8, 100, 1563*time.Millisecond); got != 1563*time.Millisecond {
+		t.Errorf("other shooter = %v, want the tick time", got)
+	}
+}
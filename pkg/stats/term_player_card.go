@@ -28,6 +28,13 @@ func renderPlayerCard(s *styles, p htmlPlayer, innerWidth int) string {
 		body.WriteString("\n\n")
 	}
 
+	if p.Explanation != "" {
+		body.WriteString(s.subhead.Render("WHY"))
+		body.WriteString("\n")
+		body.WriteString(s.tableMuted.Width(innerWidth).Render(p.Explanation))
+		body.WriteString("\n\n")
+	}
+
 	if len(p.Boosts) > 0 {
 		body.WriteString(renderBoostsStrip(s, p.Boosts, innerWidth))
 		body.WriteString("\n\n")
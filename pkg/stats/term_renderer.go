@@ -9,19 +9,28 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/term"
 	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
 )
 
 // renderTerminal produces the full terminal report for ds and writes it to
 // w. The renderer auto-detects whether w is a TTY so output is plain ASCII
 // when piped or redirected. NO_COLOR is honored automatically through the
-// underlying termenv backend.
-func renderTerminal(ds *DemoStats, w io.Writer, title string) error {
+// underlying termenv backend. A non-nil color overrides the detection.
+func renderTerminal(ds *DemoStats, w io.Writer, title string, color *bool) error {
 	if ds == nil || len(ds.Players) == 0 {
 		_, err := fmt.Fprintln(w, "No statistics available")
 		return err
 	}
 
-	s := newStyles(w, detectTTY(w))
+	isTTY := detectTTY(w)
+	if color != nil {
+		isTTY = *color
+	}
+	s := newStyles(w, isTTY)
+	if color != nil && *color {
+		// The renderer probes w itself and would pick Ascii for a pipe.
+		s.r.SetColorProfile(termenv.ANSI256)
+	}
 	data := buildHTMLData(ds)
 	width := terminalWidth(w)
 
@@ -37,6 +46,13 @@ func renderTerminal(ds *DemoStats, w io.Writer, title string) error {
 		out.WriteString("\n\n")
 	}
 
+	if len(data.DemoInfo) > 0 {
+		out.WriteString(renderSectionDivider(s, "DEMO INFO", width))
+		out.WriteString("\n\n")
+		out.WriteString(renderCategoriesGrid(s, data.DemoInfo, cardInnerWidth(width)))
+		out.WriteString("\n\n")
+	}
+
 	if scoreboard := renderScoreboard(s, data.Teams); scoreboard != "" {
 		out.WriteString(renderSectionDivider(s, "SCOREBOARD", width))
 		out.WriteString("\n\n")
@@ -130,18 +146,19 @@ func renderDemoBlock(s *styles, d htmlData) string {
 }
 
 func renderVerdict(s *styles, d htmlData) string {
+	headline, details := summaryVerdict(d)
+	if d.InsufficientData != "" {
+		// Nothing was scored, so there is no count to lead with and the
+		// result must not read as clean.
+		return s.verdictWarn.Render(headline) + "\n" + s.verdictDetail.Render(strings.Join(details, "\n"))
+	}
 	countStyle := s.verdictClean
 	if d.FlaggedCount > 0 {
 		countStyle = s.verdictFlag
 	}
-	first := countStyle.Render(fmt.Sprintf("%d", d.FlaggedCount)) +
-		s.verdict.Render(fmt.Sprintf(" of %d players flagged.", d.PlayerCount))
-
-	detail := fmt.Sprintf(
-		"Threshold for auto-flag is 50%%. Highest %.1f%% (%s), lowest %.1f%% (%s).",
-		d.HighestLikelihood, d.HighestName, d.LowestLikelihood, d.LowestName,
-	)
-	return first + "\n" + s.verdictDetail.Render(detail)
+	count := fmt.Sprintf("%d", d.FlaggedCount)
+	first := countStyle.Render(count) + s.verdict.Render(strings.TrimPrefix(headline, count))
+	return first + "\n" + s.verdictDetail.Render(strings.Join(details, "\n"))
 }
 
 func renderSectionDivider(s *styles, label string, width int) string {
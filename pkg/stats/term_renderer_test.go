@@ -0,0 +1,31 @@
+package stats
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestRenderVerdictInsufficientData(t *testing.T) {
+	s := newStyles(io.Discard, true)
+	s.r.SetColorProfile(termenv.TrueColor)
+
+	d := htmlData{PlayerCount: 10, InsufficientData: "only 3 rounds were played"}
+	got := renderVerdict(s, d)
+	first := strings.SplitN(got, "\n", 2)[0]
+
+	want := s.verdictWarn.Render("Insufficient data: only 3 rounds were played.")
+	if first != want {
+		t.Errorf("headline = %q, want %q", first, want)
+	}
+	if strings.Contains(got, s.verdictClean.Render("0")) {
+		t.Errorf("insufficient data rendered with the clean count:\n%q", got)
+	}
+
+	d.InsufficientData = ""
+	if first := strings.SplitN(renderVerdict(s, d), "\n", 2)[0]; !strings.HasPrefix(first, s.verdictClean.Render("0")) {
+		t.Errorf("scored clean demo headline = %q, want the clean count first", first)
+	}
+}
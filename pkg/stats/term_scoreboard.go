@@ -47,7 +47,8 @@ const (
 	colADR     = 5
 	colHS      = 5
 	colMVP     = 4
-	teamMinTot = colName + colNarrow*3 + colADR + colHS + colMVP + 6 // padding
+	colRating  = 4
+	teamMinTot = colName + colNarrow*3 + colADR + colHS + colMVP + colRating + 7 // padding
 )
 
 func renderTeamTable(s *styles, t htmlTeam) string {
@@ -67,7 +68,7 @@ func renderTeamTable(s *styles, t htmlTeam) string {
 	b.WriteString(label + count + "\n")
 
 	header := s.tableHeader.Render(fmt.Sprintf(
-		"%-*s %*s %*s %*s %*s %*s %*s",
+		"%-*s %*s %*s %*s %*s %*s %*s %*s",
 		colName, "Player",
 		colNarrow, "K",
 		colNarrow, "D",
@@ -75,6 +76,7 @@ func renderTeamTable(s *styles, t htmlTeam) string {
 		colADR, "ADR",
 		colHS, "HS%",
 		colMVP, "MVP",
+		colRating, "Rtg",
 	))
 	b.WriteString(header + "\n")
 
@@ -89,8 +91,9 @@ func renderTeamTable(s *styles, t htmlTeam) string {
 		adr := numOrMuted(s, row.ADR, colADR)
 		hs := numOrMuted(s, row.HS, colHS)
 		mvp := numOrMuted(s, row.MVPs, colMVP)
+		rating := numOrMuted(s, row.Rating, colRating)
 
-		b.WriteString(nameCell + " " + k + " " + d + " " + a + " " + adr + " " + hs + " " + mvp + "\n")
+		b.WriteString(nameCell + " " + k + " " + d + " " + a + " " + adr + " " + hs + " " + mvp + " " + rating + "\n")
 	}
 
 	return b.String()
@@ -103,12 +106,16 @@ func numOrMuted(s *styles, v string, width int) string {
 	return s.tableNum.Render(fmt.Sprintf("%*s", width, v))
 }
 
+// trimName cuts name to width runes, ending in "…" when shortened. It
+// counts runes, not bytes, so non-ASCII names and labels keep their columns
+// aligned and are never cut mid-character.
 func trimName(name string, width int) string {
-	if len(name) <= width {
+	runes := []rune(name)
+	if len(runes) <= width {
 		return name
 	}
 	if width <= 1 {
-		return name[:width]
+		return string(runes[:width])
 	}
-	return name[:width-1] + "…"
+	return string(runes[:width-1]) + "…"
 }
@@ -34,6 +34,7 @@ type styles struct {
 	verdict       lipgloss.Style
 	verdictFlag   lipgloss.Style
 	verdictClean  lipgloss.Style
+	verdictWarn   lipgloss.Style
 	verdictDetail lipgloss.Style
 
 	sectionTitle lipgloss.Style
@@ -118,6 +119,7 @@ func newStyles(w io.Writer, isTTY bool) *styles {
 	s.verdict = ns().Foreground(colorText)
 	s.verdictFlag = ns().Foreground(colorFlag).Bold(true)
 	s.verdictClean = ns().Foreground(colorOk).Bold(true)
+	s.verdictWarn = ns().Foreground(colorWarn).Bold(true)
 	s.verdictDetail = ns().Foreground(colorDim)
 
 	s.sectionTitle = ns().Foreground(colorFaint).Bold(true)
@@ -0,0 +1,27 @@
+package stats
+
+import "testing"
+
+func TestTitleize(t *testing.T) {
+	tests := map[string]string{
+		"headshot_kills":     "Headshot Kills",
+		"p95_snap_velocity":  "P95 Snap Velocity",
+		"m4a4_efficiency":    "M4A4 Efficiency",
+		"ärger_über_sprays":  "Ärger Über Sprays",
+		"double__underscore": "Double  Underscore",
+	}
+	for in, want := range tests {
+		if got := titleize(in); got != want {
+			t.Errorf("titleize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTrimNameRunes(t *testing.T) {
+	if got := trimName("Jürgen_Müller", 8); got != "Jürgen_…" {
+		t.Errorf("trimName = %q", got)
+	}
+	if got := trimName("añb", 3); got != "añb" {
+		t.Errorf("trimName = %q, want unchanged", got)
+	}
+}
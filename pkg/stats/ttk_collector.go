@@ -0,0 +1,141 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+const (
+	// ttkMaxMs drops kills that land long after the first hit. Engagements
+	// rarely run this long; when one does it's a drawn-out fight, not a
+	// measure of follow-up shots.
+	ttkMaxMs = 3000.0
+
+	// ttkMinSamples is the minimum number of paired kills before the
+	// per-player percentiles are published.
+	ttkMinSamples = 5
+)
+
+// TimeToKillCollector measures time-to-kill (TTK): the time from an
+// attacker's first damage on a victim to that same attacker killing them.
+// Where TTD captures how fast a player starts hitting, TTK captures how
+// consistently they finish — a very low TTK repeated across many kills
+// means near-perfect follow-up shots.
+//
+// The first damage is the attacker's first hit in the engagement the kill
+// ends (see EngagementTracker), so a victim who escapes and is finished off
+// in a later fight is timed from that fight. One-tap kills (first damage and
+// kill on the same tick) record 0 ms.
+type TimeToKillCollector struct {
+	*BaseCollector
+
+	tickRate float64
+
+	// engagements is the shared fight definition. Collectors used outside
+	// the Analyzer run a private tracker.
+	engagements *EngagementTracker
+	ownTracker  bool
+
+	ttks map[uint64][]float64
+}
+
+// NewTimeToKillCollector creates a new TimeToKillCollector.
+func NewTimeToKillCollector() *TimeToKillCollector {
+	return &TimeToKillCollector{
+		BaseCollector: NewBaseCollector("Time To Kill", CatTTK),
+		ttks:          make(map[uint64][]float64),
+	}
+}
+
+// UseEngagements makes the collector time fights from a shared tracker.
+func (tc *TimeToKillCollector) UseEngagements(t *EngagementTracker) {
+	tc.engagements = t
+	tc.ownTracker = false
+}
+
+// Setup seeds the tick rate and registers the kill handler.
+func (tc *TimeToKillCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	if tc.engagements == nil {
+		tc.engagements = NewEngagementTracker(DefaultEngagementConfig())
+		tc.ownTracker = true
+	}
+	if tc.ownTracker {
+		tc.engagements.Setup(parser, demoStats)
+	}
+
+	tc.tickRate = parser.TickRate()
+	if tc.tickRate <= 0 {
+		tc.tickRate = 64.0
+	}
+	parser.RegisterEventHandler(func(e events.TickRateInfoAvailable) {
+		if e.TickRate > 0 {
+			tc.tickRate = e.TickRate
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.Kill) {
+		if inWarmup(parser) {
+			return
+		}
+		if e.Killer == nil || e.Victim == nil || e.Killer == e.Victim || e.Killer.Team == e.Victim.Team {
+			return
+		}
+		if !demoStats.CountsPlayer(e.Killer) {
+			return
+		}
+		sid := demoStats.PlayerKey(e.Killer)
+		eng := tc.engagements.Engagement(sid, demoStats.PlayerKey(e.Victim))
+		if eng == nil || !eng.Damaged {
+			return
+		}
+		now := tc.engagements.HitTime(sid, parser.CurrentFrame(), demoTime(parser, tc.tickRate))
+		ms := float64(now-eng.FirstDamage) / float64(time.Millisecond)
+		if ms < 0 || ms > ttkMaxMs {
+			return
+		}
+		tc.ttks[sid] = append(tc.ttks[sid], ms)
+	})
+}
+
+// CollectFrame advances a private engagement tracker; a shared one is
+// updated by the Analyzer.
+func (tc *TimeToKillCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	if tc.ownTracker {
+		tc.engagements.Update(parser)
+	}
+}
+
+// CollectFinalStats publishes time_to_kill_ms (the median) and its 10th
+// percentile per player.
+func (tc *TimeToKillCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, samples := range tc.ttks {
+		if len(samples) < ttkMinSamples {
+			continue
+		}
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		sort.Float64s(samples)
+		p10 := samples[int(float64(len(samples))*0.1)]
+
+		ps.AddMetric(CatTTK, KeyTimeToKillMs, Metric{
+			Type:        MetricFloat,
+			FloatValue:  median(samples),
+			Description: "Median time-to-kill in ms (first damage → kill by the same attacker)",
+		})
+		ps.AddMetric(CatTTK, KeyP10TimeToKillMs, Metric{
+			Type:        MetricFloat,
+			FloatValue:  p10,
+			Description: "10th percentile time-to-kill in ms",
+		})
+		ps.AddMetric(CatTTK, KeyTTKSamples, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(len(samples)),
+			Description: "Number of kills paired with a first hit",
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// ttkFixture runs a TimeToKillCollector with its own engagement tracker on
+// a stub parser, with the killer (SteamID 1) against fresh victims.
+type ttkFixture struct {
+	parser *warmupStubParser
+	ds     *DemoStats
+	tc     *TimeToKillCollector
+	killer *common.Player
+	next   uint64
+}
+
+func newTTKFixture() *ttkFixture {
+	f := &ttkFixture{
+		parser: &warmupStubParser{frame: 1},
+		ds:     NewDemoStats(),
+		tc:     NewTimeToKillCollector(),
+		killer: &common.Player{SteamID64: 1, Team: common.TeamTerrorists},
+		next:   100,
+	}
+	f.ds.GetOrCreatePlayerStats(f.killer)
+	f.tc.Setup(f.parser, f.ds)
+	return f
+}
+
+func (f *ttkFixture) victim() *common.Player {
+	f.next++
+	return &common.Player{SteamID64: f.next, Team: common.TeamCounterTerrorists}
+}
+
+// fight has the killer hit a new victim and kill them frames later; a
+// negative frames skips the hit.
+func (f *ttkFixture) fight(frames int) {
+	v := f.victim()
+	if frames >= 0 {
+		f.parser.dispatch(events.PlayerHurt{Attacker: f.killer, Player: v})
+	}
+	f.parser.frame += max(frames, 0)
+	f.parser.dispatch(events.Kill{Killer: f.killer, Victim: v})
+	f.parser.frame += 1000 // well apart from the next fight
+}
+
+func TestTimeToKill(t *testing.T) {
+	f := newTTKFixture()
+	for _, frames := range []int{0, 8, 16, 16, 32} {
+		f.fight(frames)
+	}
+	f.fight(-1)  // no hit before the kill: not paired
+	f.fight(640) // 10 s after the first hit: a drawn-out fight, dropped
+	f.tc.CollectFinalStats(f.ds)
+
+	ps := f.ds.Players[1]
+	if n := intMetric(ps, CatTTK, KeyTTKSamples); n != 5 {
+		t.Errorf("ttk_samples = %d, want 5", n)
+	}
+	if got, _ := psGetFloat(ps, CatTTK, KeyTimeToKillMs); got != 250 {
+		t.Errorf("time_to_kill_ms = %v, want the median 250", got)
+	}
+	if got, _ := psGetFloat(ps, CatTTK, KeyP10TimeToKillMs); got != 0 {
+		t.Errorf("p10_time_to_kill_ms = %v, want the one-tap 0", got)
+	}
+}
+
+func TestTimeToKillMinSamples(t *testing.T) {
+	f := newTTKFixture()
+	for i := 0; i < ttkMinSamples-1; i++ {
+		f.fight(8)
+	}
+	f.tc.CollectFinalStats(f.ds)
+	if _, ok := f.ds.Players[1].GetMetric(CatTTK, KeyTimeToKillMs); ok {
+		t.Errorf("time_to_kill_ms published from %d kills", ttkMinSamples-1)
+	}
+}
+
+func TestTimeToKillVictimKilledByTeammate(t *testing.T) {
+	f := newTTKFixture()
+	mate := &common.Player{SteamID64: 2, Team: common.TeamTerrorists}
+	for i := 0; i < ttkMinSamples; i++ {
+		// The killer's hit is finished off by a teammate
+		v := f.victim()
+		f.parser.dispatch(events.PlayerHurt{Attacker: f.killer, Player: v})
+		f.parser.frame += 8
+		f.parser.dispatch(events.Kill{Killer: mate, Victim: v})
+
+		// A kill of the same player on the next frame, without a new hit,
+		// isn't timed from the old one
+		f.parser.frame++
+		f.tc.engagements.expire(f.parser.CurrentTime()) // the frame's Update
+		f.parser.dispatch(events.Kill{Killer: f.killer, Victim: v})
+		f.parser.frame += 1000
+	}
+	f.tc.CollectFinalStats(f.ds)
+	if n := intMetric(f.ds.Players[1], CatTTK, KeyTTKSamples); n != 0 {
+		t.Errorf("ttk_samples = %d, want 0: hits ended with the victim's death", n)
+	}
+}
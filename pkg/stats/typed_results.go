@@ -0,0 +1,154 @@
+package stats
+
+import "sort"
+
+// TypedResults is a read-only, strongly-typed projection of DemoStats for
+// library consumers who would rather not look metrics up by category / key
+// strings. DemoStats remains the source of truth; Typed builds a fresh copy
+// each call, and a field is left at its zero value when the underlying
+// metric was not published (e.g. too few samples).
+type TypedResults struct {
+	DemoName  string
+	MapName   string
+	TickRate  float64
+	TickCount int
+	Players   []TypedPlayer // ordered by SteamID64
+}
+
+// TypedPlayer holds one player's typed stats.
+type TypedPlayer struct {
+	SteamID64 uint64
+	Name      string
+	Aim       AimStats
+	Reaction  ReactionStats
+	Recoil    RecoilStats
+	Cheat     CheatStats
+}
+
+// AimStats projects the aiming category. Velocities are in degrees per ms.
+type AimStats struct {
+	P95Snap        float64
+	MedianSnap     float64
+	AvgSnap        float64
+	SnapCount      int
+	SnappedKills   int
+	PreciseSnaps   int
+	P95PreciseSnap float64
+}
+
+// ReactionStats projects the reaction category. Times are in ms;
+// Sub100Percent is 0–100.
+type ReactionStats struct {
+	MedianTTD     float64
+	P10TTD        float64
+	Sub100Percent float64
+	Samples       int
+}
+
+// RecoilStats projects the recoil category. Errors are in degrees; Score is
+// the 0–1 recoil component fed to the cheat score.
+type RecoilStats struct {
+	MeanError         float64
+	ConsistencyStddev float64
+	Score             float64
+	Bursts            int
+	Bullets           int
+	Interpretation    string
+}
+
+// CheatStats projects the anti_cheat category. Likelihood is 0–100.
+type CheatStats struct {
+	Likelihood  float64
+	Flagged     bool
+	Explanation string
+	Components  []CheatComponent // in report order, only channels that published a score
+}
+
+// CheatComponent is one evidence channel's contribution inputs.
+type CheatComponent struct {
+	ID         string
+	Score      float64 // 0–1
+	Confidence float64 // 0–1
+	Zone       string
+}
+
+// Typed projects the stringly-typed metric store into TypedResults.
+func (ds *DemoStats) Typed() TypedResults {
+	out := TypedResults{
+		DemoName:  ds.DemoName,
+		MapName:   ds.MapName,
+		TickRate:  ds.TickRate,
+		TickCount: ds.TickCount,
+		Players:   make([]TypedPlayer, 0, len(ds.Players)),
+	}
+	for sid, ps := range ds.Players {
+		out.Players = append(out.Players, typedPlayer(sid, ps))
+	}
+	sort.Slice(out.Players, func(i, j int) bool {
+		return out.Players[i].SteamID64 < out.Players[j].SteamID64
+	})
+	return out
+}
+
+func typedPlayer(sid uint64, ps *PlayerStats) TypedPlayer {
+	aiming, reaction, recoil, anti := CatAiming, CatReaction, CatRecoil, CatAntiCheat
+	f := func(cat Category, key string) float64 {
+		v, _ := psGetFloat(ps, cat, Key(key))
+		return v
+	}
+	n := func(cat Category, key string) int {
+		v, _ := psGetInt(ps, cat, Key(key))
+		return int(v)
+	}
+	s := func(cat Category, key string) string {
+		v, _ := psGetString(ps, cat, Key(key))
+		return v
+	}
+
+	tp := TypedPlayer{
+		SteamID64: sid,
+		Name:      ps.Player.Name,
+		Aim: AimStats{
+			P95Snap:        f(aiming, "p95_snap_velocity"),
+			MedianSnap:     f(aiming, "median_snap_velocity"),
+			AvgSnap:        f(aiming, "avg_snap_velocity"),
+			SnapCount:      n(aiming, "snap_count"),
+			SnappedKills:   n(aiming, "snapped_kills"),
+			PreciseSnaps:   n(aiming, "precise_snaps"),
+			P95PreciseSnap: f(aiming, "p95_precise_snap_velocity"),
+		},
+		Reaction: ReactionStats{
+			MedianTTD:     f(reaction, "median_ttd"),
+			P10TTD:        f(reaction, "p10_ttd"),
+			Sub100Percent: f(reaction, "sub_100ms_ttd"),
+			Samples:       n(reaction, "ttd_samples"),
+		},
+		Recoil: RecoilStats{
+			MeanError:         f(recoil, "mean_angular_error"),
+			ConsistencyStddev: f(recoil, "recoil_consistency_stddev"),
+			Score:             f(recoil, "recoil_score"),
+			Bursts:            n(recoil, "burst_count"),
+			Bullets:           n(recoil, "total_counted_bullets"),
+			Interpretation:    s(recoil, "recoil_interpretation"),
+		},
+		Cheat: CheatStats{
+			Likelihood:  f(anti, "cheat_likelihood"),
+			Flagged:     s(anti, "cheater") == "Yes",
+			Explanation: s(anti, "cheat_explanation"),
+		},
+	}
+
+	for _, cd := range channelDisplay {
+		score, ok := psGetFloat(ps, anti, channelScoreKey(cd.ID))
+		if !ok {
+			continue
+		}
+		tp.Cheat.Components = append(tp.Cheat.Components, CheatComponent{
+			ID:         cd.ID,
+			Score:      score,
+			Confidence: f(anti, cd.ID+"_confidence"),
+			Zone:       s(anti, cd.ID+"_zone"),
+		})
+	}
+	return tp
+}
@@ -0,0 +1,32 @@
+package stats
+
+import "testing"
+
+func TestDemoStatsTyped(t *testing.T) {
+	ds := NewDemoStats()
+	ds.MapName = "de_inferno"
+	ps := ds.GetOrCreatePlayerStatsBySteamID(7)
+	ps.AddMetric(Category("aiming"), Key("p95_snap_velocity"), Metric{Type: MetricFloat, FloatValue: 6.5})
+	ps.AddMetric(Category("aiming"), Key("snap_count"), Metric{Type: MetricInteger, IntValue: 40})
+	ps.AddMetric(Category("reaction"), Key("median_ttd"), Metric{Type: MetricFloat, FloatValue: 210})
+	ps.AddMetric(Category("anti_cheat"), Key("cheat_likelihood"), Metric{Type: MetricPercentage, FloatValue: 81})
+	ps.AddMetric(Category("anti_cheat"), Key("cheater"), Metric{Type: MetricString, StringValue: "Yes"})
+	ps.AddMetric(Category("anti_cheat"), Key("snap_score"), Metric{Type: MetricFloat, FloatValue: 0.9})
+	ps.AddMetric(Category("anti_cheat"), Key("snap_zone"), Metric{Type: MetricString, StringValue: "suspicious"})
+	ds.GetOrCreatePlayerStatsBySteamID(3)
+
+	tr := ds.Typed()
+	if tr.MapName != "de_inferno" || len(tr.Players) != 2 || tr.Players[0].SteamID64 != 3 {
+		t.Fatalf("unexpected results: %+v", tr)
+	}
+	p := tr.Players[1]
+	if p.Aim.P95Snap != 6.5 || p.Aim.SnapCount != 40 || p.Reaction.MedianTTD != 210 {
+		t.Errorf("aim/reaction not projected: %+v %+v", p.Aim, p.Reaction)
+	}
+	if !p.Cheat.Flagged || p.Cheat.Likelihood != 81 {
+		t.Errorf("cheat stats not projected: %+v", p.Cheat)
+	}
+	if len(p.Cheat.Components) != 1 || p.Cheat.Components[0].ID != "snap" || p.Cheat.Components[0].Zone != "suspicious" {
+		t.Errorf("components = %+v, want only snap", p.Cheat.Components)
+	}
+}
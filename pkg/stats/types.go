@@ -1,9 +1,12 @@
 package stats
 
 import (
+	"hash/fnv"
 	"time"
 
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
 )
 
 // PlayerIdentifier contains information to identify a player
@@ -18,6 +21,12 @@ type Category string
 // Key represents a specific statistic key within a category
 type Key string
 
+// KeyRef names one metric: a key within a category.
+type KeyRef struct {
+	Category Category
+	Key      Key
+}
+
 // MetricType defines the type of a statistic value (count, percentage, duration, etc.)
 type MetricType string
 
@@ -81,6 +90,12 @@ func (ps *PlayerStats) GetMetric(category Category, key Key) (Metric, bool) {
 	return Metric{}, false
 }
 
+// Lookup retrieves the metric ref names, e.g.
+// ps.Lookup(KeyRef{CatRecoil, KeyRecoilScore}).
+func (ps *PlayerStats) Lookup(ref KeyRef) (Metric, bool) {
+	return ps.GetMetric(ref.Category, ref.Key)
+}
+
 // IncrementIntMetric increments an integer metric
 func (ps *PlayerStats) IncrementIntMetric(category Category, key Key) {
 	if _, exists := ps.Categories[category]; !exists {
@@ -132,29 +147,298 @@ type DemoStats struct {
 	TickCount int
 	DemoName  string
 	MapName   string
+
+	// GlobalMetrics holds demo-wide metrics (game_info: game mode, round
+	// count, why the demo wasn't scored), kept apart from Players so they
+	// can't mix with anyone's own stats. See DemoStats.AddMetric.
+	GlobalMetrics map[Category]map[Key]Metric
+
+	// playerFilter, when non-empty, restricts stats to these SteamIDs.
+	playerFilter map[uint64]bool
+
+	// includeBots makes bots count as players under synthetic keys; see
+	// SetIncludeBots.
+	includeBots bool
+
+	// povRecorder is the key of the player who recorded a POV demo, 0 for
+	// GOTV demos; see SetPOVRecorder.
+	povRecorder uint64
+
+	// roundSamples holds round-tagged channel samples per SteamID; see
+	// AddRoundSample.
+	roundSamples map[uint64][]RoundSample
+
+	// suspiciousEvents holds the ticks collectors found suspicious per
+	// SteamID; see AddSuspiciousEvent.
+	suspiciousEvents map[uint64][]SuspiciousEvent
 }
 
 // NewDemoStats creates a new DemoStats instance
 func NewDemoStats() *DemoStats {
 	return &DemoStats{
-		Players: make(map[uint64]*PlayerStats),
+		Players:       make(map[uint64]*PlayerStats),
+		GlobalMetrics: make(map[Category]map[Key]Metric),
+	}
+}
+
+// SetPlayerFilter restricts collection to the given SteamIDs. Collectors
+// consult TracksPlayer before doing per-player work, and
+// GetOrCreatePlayerStats returns nil for anyone else, so only the filtered
+// players reach the cheat detector and reporters. Lobby-relative scoring
+// then sees only them too; to report a few players with the likelihoods of
+// a full run, collect everyone and call RetainPlayers after scoring, as the
+// Analyzer does. Calling it with no IDs clears the filter.
+func (ds *DemoStats) SetPlayerFilter(steamIDs ...uint64) {
+	if len(steamIDs) == 0 {
+		ds.playerFilter = nil
+		return
+	}
+	ds.playerFilter = make(map[uint64]bool, len(steamIDs))
+	for _, id := range steamIDs {
+		ds.playerFilter[id] = true
+	}
+}
+
+// RetainPlayers drops every player but steamIDs, with their round samples
+// and suspicious events. Called after the cheat detector, it narrows the
+// report without changing anyone's score. Calling it with no IDs keeps
+// everyone.
+func (ds *DemoStats) RetainPlayers(steamIDs ...uint64) {
+	if len(steamIDs) == 0 {
+		return
+	}
+	keep := make(map[uint64]bool, len(steamIDs))
+	for _, id := range steamIDs {
+		keep[id] = true
+	}
+	for sid := range ds.Players {
+		if !keep[sid] {
+			delete(ds.Players, sid)
+			delete(ds.roundSamples, sid)
+			delete(ds.suspiciousEvents, sid)
+		}
+	}
+}
+
+// TracksPlayer reports whether stats should be collected for steamID. True
+// for everyone when no filter is set.
+func (ds *DemoStats) TracksPlayer(steamID uint64) bool {
+	return len(ds.playerFilter) == 0 || ds.playerFilter[steamID]
+}
+
+// SetIncludeBots makes bots count as players, for practice and aim-trainer
+// demos where the bots are the opponents (or the subject). Bots report
+// SteamID 0, so each gets a synthetic key from BotKey instead. Off by
+// default: in real matches a bot is a disconnected player's stand-in and
+// must not be attributed to anyone.
+func (ds *DemoStats) SetIncludeBots(include bool) {
+	ds.includeBots = include
+}
+
+// IncludesBots reports whether bots count as players.
+func (ds *DemoStats) IncludesBots() bool {
+	return ds.includeBots
+}
+
+// Perspectives a demo can be recorded from; see Perspective.
+const (
+	PerspectiveGOTV = "gotv"
+	PerspectivePOV  = "pov"
+)
+
+// SetPOVRecorder marks the demo as recorded client-side (POV) by the player
+// with key steamID. A POV demo only networks the recorder's own view angles
+// and movement at full rate; everyone else's are interpolated or missing,
+// so CountsAimPlayer admits the recorder alone from then on.
+func (ds *DemoStats) SetPOVRecorder(steamID uint64) {
+	ds.povRecorder = steamID
+}
+
+// POVRecorder returns the key of the player who recorded a POV demo, or 0
+// for a GOTV demo.
+func (ds *DemoStats) POVRecorder() uint64 {
+	return ds.povRecorder
+}
+
+// Perspective returns PerspectivePOV for a POV demo and PerspectiveGOTV
+// otherwise.
+func (ds *DemoStats) Perspective() string {
+	if ds.povRecorder != 0 {
+		return PerspectivePOV
+	}
+	return PerspectiveGOTV
+}
+
+// AddMetric adds or replaces a demo-wide metric — one that describes the
+// demo rather than a player, like the round count.
+func (ds *DemoStats) AddMetric(category Category, key Key, metric Metric) {
+	if ds.GlobalMetrics == nil {
+		ds.GlobalMetrics = make(map[Category]map[Key]Metric)
+	}
+	if ds.GlobalMetrics[category] == nil {
+		ds.GlobalMetrics[category] = make(map[Key]Metric)
 	}
+	ds.GlobalMetrics[category][key] = metric
 }
 
-// GetOrCreatePlayerStats gets existing player stats or creates new ones if they don't exist
+// GetMetric returns a demo-wide metric added with AddMetric.
+func (ds *DemoStats) GetMetric(category Category, key Key) (Metric, bool) {
+	m, ok := ds.GlobalMetrics[category][key]
+	return m, ok
+}
+
+// AddGlobalMetric adds or replaces a demo-wide metric.
+//
+// Deprecated: use AddMetric.
+func (ds *DemoStats) AddGlobalMetric(category Category, key Key, metric Metric) {
+	ds.AddMetric(category, key, metric)
+}
+
+// GetGlobalMetric returns a demo-wide metric.
+//
+// Deprecated: use GetMetric.
+func (ds *DemoStats) GetGlobalMetric(category Category, key Key) (Metric, bool) {
+	return ds.GetMetric(category, key)
+}
+
+// SetIntegrityWarnings records n frames skipped because their tick went
+// backwards as game_info/demo_integrity_warnings, which the reports show as
+// a warning. Zero records nothing.
+func (ds *DemoStats) SetIntegrityWarnings(n int) {
+	if n <= 0 {
+		return
+	}
+	ds.AddMetric(CatGameInfo, KeyDemoIntegrityWarnings, Metric{
+		Type:        MetricInteger,
+		IntValue:    int64(n),
+		Description: "Frames whose tick went backwards, skipped by every collector",
+	})
+}
+
+// botKeyFlag marks a synthetic bot key. Real SteamID64s never set the top
+// bit.
+const botKeyFlag = uint64(1) << 63
+
+// BotKey returns the synthetic key for the bot called name. Bot names are
+// unique within a server and stable across rounds, so the key is too.
+func BotKey(name string) uint64 {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return botKeyFlag | uint64(h.Sum32())
+}
+
+// IsBotKey reports whether key came from BotKey.
+func IsBotKey(key uint64) bool {
+	return key&botKeyFlag != 0
+}
+
+// PlayerKey returns the key p's stats are collected under: the SteamID for
+// humans, BotKey for bots when bots are included, and 0 (not a player)
+// otherwise.
+func (ds *DemoStats) PlayerKey(p *common.Player) uint64 {
+	if p == nil {
+		return 0
+	}
+	if isHumanPlayer(p) {
+		return p.SteamID64
+	}
+	if ds.includeBots && p.IsBot && p.Name != "" {
+		return BotKey(p.Name)
+	}
+	return 0
+}
+
+// CountsPlayer is the shared predicate collectors use before attributing
+// anything to p: p is a player (see PlayerKey) and passes the player
+// filter.
+func (ds *DemoStats) CountsPlayer(p *common.Player) bool {
+	key := ds.PlayerKey(p)
+	return key != 0 && ds.TracksPlayer(key)
+}
+
+// CountsAimPlayer is CountsPlayer for collectors that read p's own view
+// angles or movement — aim, reaction and recoil. In a POV demo only the
+// recording player's are trustworthy, so it admits no one else.
+func (ds *DemoStats) CountsAimPlayer(p *common.Player) bool {
+	if !ds.CountsPlayer(p) {
+		return false
+	}
+	return ds.povRecorder == 0 || ds.PlayerKey(p) == ds.povRecorder
+}
+
+// isHumanPlayer reports whether p is a human with a real SteamID. Bots —
+// including the bot that takes over a disconnected player's slot — report
+// SteamID 0 or IsBot and must never be attributed to a human's stats.
+func isHumanPlayer(p *common.Player) bool {
+	return p != nil && !p.IsBot && p.SteamID64 != 0
+}
+
+// inWarmup is the shared predicate per-fire and per-hit handlers check
+// before recording anything: warmup is free spraying and deathmatch, not
+// competitive play, and would pollute recoil, reaction and accuracy samples.
+func inWarmup(parser demoinfocs.Parser) bool {
+	return parser.GameState().IsWarmupPeriod()
+}
+
+// onWarmupEnd registers reset to run when warmup ends, so collectors can
+// drop bursts, engagements and other state opened during warmup before the
+// first competitive round.
+func onWarmupEnd(parser demoinfocs.Parser, reset func()) {
+	parser.RegisterEventHandler(func(e events.IsWarmupPeriodChanged) {
+		if e.OldIsWarmupPeriod && !e.NewIsWarmupPeriod {
+			reset()
+		}
+	})
+}
+
+// currentRound returns the 1-based number of the round being played, or 0
+// during warmup.
+func currentRound(parser demoinfocs.Parser) int {
+	if inWarmup(parser) {
+		return 0
+	}
+	return parser.GameState().TotalRoundsPlayed() + 1
+}
+
+// demoTime returns the parser's in-game time. Inside an event handler this
+// is the event's own tick, one frame ahead of anything a collector stored in
+// CollectFrame, and it is based on the server's tick interval rather than
+// frame count. Before the server info arrives CurrentTime is 0, so fall back
+// to frame / tickRate.
+func demoTime(parser demoinfocs.Parser, tickRate float64) time.Duration {
+	if t := parser.CurrentTime(); t > 0 {
+		return t
+	}
+	if tickRate <= 0 {
+		tickRate = 64.0
+	}
+	return time.Duration(float64(parser.CurrentFrame()) / tickRate * float64(time.Second))
+}
+
+// GetOrCreatePlayerStats gets existing player stats or creates new ones if they don't exist.
+// Returns nil for bots unless they are included. Stats are keyed by
+// PlayerKey, so a player who disconnects and reconnects keeps accumulating
+// into the same entry.
 func (ds *DemoStats) GetOrCreatePlayerStats(player *common.Player) *PlayerStats {
-	if player == nil {
+	if !ds.CountsPlayer(player) {
 		return nil
 	}
 
-	if _, exists := ds.Players[player.SteamID64]; !exists {
-		ds.Players[player.SteamID64] = NewPlayerStats(player)
+	key := ds.PlayerKey(player)
+	if _, exists := ds.Players[key]; !exists {
+		ps := NewPlayerStats(player)
+		ps.Player.SteamID64 = key
+		ds.Players[key] = ps
 	}
-	return ds.Players[player.SteamID64]
+	return ds.Players[key]
 }
 
 // GetOrCreatePlayerStatsBySteamID gets existing player stats or creates new ones by SteamID
+// Returns nil for players excluded by the player filter.
 func (ds *DemoStats) GetOrCreatePlayerStatsBySteamID(steamID uint64) *PlayerStats {
+	if !ds.TracksPlayer(steamID) {
+		return nil
+	}
 	if _, exists := ds.Players[steamID]; !exists {
 		// Create a placeholder player
 		ds.Players[steamID] = &PlayerStats{
@@ -0,0 +1,51 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+func TestCountsAimPlayerPOV(t *testing.T) {
+	recorder := &common.Player{Name: "rec", SteamID64: 76561198000000001}
+	other := &common.Player{Name: "other", SteamID64: 76561198000000002}
+
+	ds := NewDemoStats()
+	ds.GetOrCreatePlayerStats(recorder)
+	ds.GetOrCreatePlayerStats(other)
+	if !ds.CountsAimPlayer(other) || ds.Perspective() != PerspectiveGOTV {
+		t.Fatal("GOTV demo restricted aim stats")
+	}
+
+	ds.SetPOVRecorder(recorder.SteamID64)
+	if !ds.CountsAimPlayer(recorder) {
+		t.Error("POV recorder's aim not counted")
+	}
+	if ds.CountsAimPlayer(other) {
+		t.Error("non-recorder's aim counted on a POV demo")
+	}
+	if !ds.CountsPlayer(other) {
+		t.Error("non-recorder dropped from non-aim stats on a POV demo")
+	}
+
+	NewGameModeCollector().CollectFinalStats(ds)
+	for sid, ps := range ds.Players {
+		if got, _ := psGetString(ps, CatGameInfo, KeyDemoPerspective); got != PerspectivePOV {
+			t.Errorf("player %d demo_perspective = %q, want %q", sid, got, PerspectivePOV)
+		}
+	}
+}
+
+func TestGlobalMetricAliases(t *testing.T) {
+	ds := NewDemoStats()
+	ds.AddGlobalMetric(CatGameInfo, KeyRoundCount, Metric{Type: MetricInteger, IntValue: 24})
+	if m, ok := ds.GetMetric(CatGameInfo, KeyRoundCount); !ok || m.IntValue != 24 {
+		t.Errorf("GetMetric after AddGlobalMetric = %+v, %v", m, ok)
+	}
+	if m, ok := ds.GetGlobalMetric(CatGameInfo, KeyRoundCount); !ok || m.IntValue != 24 {
+		t.Errorf("GetGlobalMetric = %+v, %v", m, ok)
+	}
+	if len(ds.Players) != 0 {
+		t.Error("demo-wide metric landed on a player")
+	}
+}
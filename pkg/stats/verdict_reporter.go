@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// VerdictReporter writes one line per flagged player and nothing else, for
+// shell scripts and alerting:
+//
+//	FLAGGED: Alice (76561198000000001) 87%
+//
+// Lines are ordered by likelihood, highest first. A demo with nobody
+// flagged produces no output at all.
+type VerdictReporter struct{}
+
+// NewVerdictReporter creates a VerdictReporter.
+func NewVerdictReporter() *VerdictReporter {
+	return &VerdictReporter{}
+}
+
+// Extension returns "txt".
+func (vr *VerdictReporter) Extension() string { return "txt" }
+
+// Report writes the flagged players. The categories argument is accepted
+// for Reporter compatibility but unused.
+func (vr *VerdictReporter) Report(demoStats *DemoStats, _ []Category, writer io.Writer) error {
+	for _, sid := range FlaggedPlayers(demoStats) {
+		ps := demoStats.Players[sid]
+		likelihood := getMetricFloatValue(ps, CatAntiCheat, KeyCheatLikelihood)
+		if _, err := fmt.Fprintf(writer, "FLAGGED: %s (%d) %.0f%%\n", ps.Player.Name, sid, likelihood); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FlaggedPlayers returns the SteamIDs of the players the cheat detector
+// flagged, most likely cheater first (ties by SteamID).
+func FlaggedPlayers(demoStats *DemoStats) []uint64 {
+	var out []uint64
+	for _, sid := range sortedSteamIDs(demoStats) {
+		if psHasYes(demoStats.Players[sid], KeyCheater) {
+			out = append(out, sid)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return getMetricFloatValue(demoStats.Players[out[i]], CatAntiCheat, KeyCheatLikelihood) >
+			getMetricFloatValue(demoStats.Players[out[j]], CatAntiCheat, KeyCheatLikelihood)
+	})
+	return out
+}
@@ -0,0 +1,35 @@
+package stats
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestVerdictReporter(t *testing.T) {
+	ds := NewDemoStats()
+	add := func(sid uint64, name string, likelihood float64, cheater string) {
+		ps := ds.GetOrCreatePlayerStatsBySteamID(sid)
+		ps.Player.Name = name
+		ps.AddMetric(CatAntiCheat, KeyCheatLikelihood, Metric{Type: MetricPercentage, FloatValue: likelihood})
+		ps.AddMetric(CatAntiCheat, KeyCheater, Metric{Type: MetricString, StringValue: cheater})
+	}
+	add(1, "low", 62.4, "Yes")
+	add(2, "clean", 12, "No")
+	add(3, "high", 87.2, "Yes")
+
+	var buf bytes.Buffer
+	if err := NewVerdictReporter().Report(ds, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "FLAGGED: high (3) 87%\nFLAGGED: low (1) 62%\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	clean := NewDemoStats()
+	clean.GetOrCreatePlayerStatsBySteamID(2).AddMetric(CatAntiCheat, KeyCheater, Metric{Type: MetricString, StringValue: "No"})
+	if err := NewVerdictReporter().Report(clean, nil, &buf); err != nil || buf.Len() != 0 {
+		t.Errorf("clean demo wrote %q (err %v), want nothing", buf.String(), err)
+	}
+}
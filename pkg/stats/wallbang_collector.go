@@ -0,0 +1,226 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+const (
+	// wallbangLookback is how far before a wallbang kill the killer's aim
+	// is checked.
+	wallbangLookback = 500 * time.Millisecond
+
+	// wallbangTrackMin is how long the crosshair must have sat on the
+	// hidden victim within the lookback for the kill to count as tracked.
+	// A spray through a wall sweeps across the victim for a tick or two;
+	// holding them for this long needs to know where they are.
+	wallbangTrackMin = 150 * time.Millisecond
+
+	// wallbangHistory is the ring size: the lookback at 128 tick.
+	wallbangHistory = 64
+)
+
+// wallbangFrame is one player's state on one frame.
+type wallbangFrame struct {
+	tick       int
+	time       time.Duration
+	eye, head  r3.Vector
+	chest      r3.Vector
+	yaw, pitch float64 // degrees, pitch signed (positive looking down)
+	spottedBy  uint64  // bit i set when the player in client slot i sees this one
+}
+
+// WallbangKillCollector counts wallbang kills and, of those, the ones where
+// the killer had been holding their crosshair on the victim through the
+// wall before firing (tracked_wallbangs). Lucky spray through a wall lands
+// on whoever happens to be behind it; tracking a player you can't see for
+// a sustained stretch is what wallhacks make easy.
+type WallbangKillCollector struct {
+	*BaseCollector
+	tickRate float64
+
+	history   map[uint64][]wallbangFrame // ring per SteamID
+	next      map[uint64]int
+	wallbangs map[uint64]int
+	tracked   map[uint64]int
+}
+
+// NewWallbangKillCollector creates a new WallbangKillCollector.
+func NewWallbangKillCollector() *WallbangKillCollector {
+	return &WallbangKillCollector{
+		BaseCollector: NewBaseCollector("Wallbang Kills", CatWallbang),
+		history:       make(map[uint64][]wallbangFrame),
+		next:          make(map[uint64]int),
+		wallbangs:     make(map[uint64]int),
+		tracked:       make(map[uint64]int),
+	}
+}
+
+// Setup registers the kill and round handlers.
+func (wc *WallbangKillCollector) Setup(parser demoinfocs.Parser, demoStats *DemoStats) {
+	wc.tickRate = parser.TickRate()
+	if wc.tickRate <= 0 {
+		wc.tickRate = 64.0
+	}
+	parser.RegisterEventHandler(func(e events.TickRateInfoAvailable) {
+		if e.TickRate > 0 {
+			wc.tickRate = e.TickRate
+		}
+	})
+
+	parser.RegisterEventHandler(func(e events.Kill) {
+		if inWarmup(parser) || e.PenetratedObjects <= 0 {
+			return
+		}
+		if e.Killer == nil || e.Victim == nil || e.Weapon == nil || isKnife(e.Weapon) {
+			return
+		}
+		if !demoStats.CountsPlayer(e.Killer) || e.Killer.Team == e.Victim.Team {
+			return
+		}
+		sid := demoStats.PlayerKey(e.Killer)
+		wc.wallbangs[sid]++
+		if !demoStats.CountsAimPlayer(e.Killer) {
+			return // tracking reads the killer's view, see CountsAimPlayer
+		}
+		held := trackedThroughWall(wc.frames(sid), wc.frames(demoStats.PlayerKey(e.Victim)), e.Killer.EntityID-1, demoTime(parser, wc.tickRate))
+		if held >= wallbangTrackMin {
+			wc.tracked[sid]++
+		}
+	})
+
+	parser.RegisterEventHandler(func(_ events.RoundStart) {
+		wc.history = make(map[uint64][]wallbangFrame)
+		wc.next = make(map[uint64]int)
+	})
+}
+
+// CollectFrame records every living player's eye, body and view angles,
+// plus which enemies currently see them.
+func (wc *WallbangKillCollector) CollectFrame(parser demoinfocs.Parser, demoStats *DemoStats) {
+	tick := parser.CurrentFrame()
+	now := demoTime(parser, wc.tickRate)
+	playing := parser.GameState().Participants().Playing()
+	for _, p := range playing {
+		key := demoStats.PlayerKey(p)
+		if key == 0 || !p.IsAlive() {
+			continue
+		}
+		yaw, pitch, ok := viewAngles(p)
+		if !ok {
+			continue
+		}
+		head := headPosition(p)
+		feet := p.Position()
+		f := wallbangFrame{
+			tick:  tick,
+			time:  now,
+			eye:   eyePosition(p),
+			head:  head,
+			chest: feet.Add(head.Sub(feet).Mul(0.7)),
+			yaw:   float64(yaw),
+			pitch: signedPitch(float64(pitch)),
+		}
+		for _, other := range playing {
+			if other == nil || other.Team == p.Team || other.EntityID < 1 || other.EntityID > 64 {
+				continue
+			}
+			if p.IsSpottedBy(other) {
+				f.spottedBy |= 1 << uint(other.EntityID-1)
+			}
+		}
+		wc.push(key, f)
+	}
+}
+
+// push appends f to steamID's ring.
+func (wc *WallbangKillCollector) push(steamID uint64, f wallbangFrame) {
+	ring := wc.history[steamID]
+	if len(ring) < wallbangHistory {
+		wc.history[steamID] = append(ring, f)
+		return
+	}
+	i := wc.next[steamID]
+	ring[i] = f
+	wc.next[steamID] = (i + 1) % wallbangHistory
+}
+
+// frames returns steamID's history oldest first.
+func (wc *WallbangKillCollector) frames(steamID uint64) []wallbangFrame {
+	ring := wc.history[steamID]
+	if len(ring) < wallbangHistory {
+		return ring
+	}
+	i := wc.next[steamID]
+	return append(append([]wallbangFrame(nil), ring[i:]...), ring[:i]...)
+}
+
+// trackedThroughWall returns how long, within wallbangLookback before now,
+// the killer's crosshair was on the victim's head or chest while the victim
+// was hidden from them (killerSlot not in the victim's spottedBy mask).
+// Killer and victim frames are matched by tick; each matching frame counts
+// for the time until the killer's next frame.
+func trackedThroughWall(killer, victim []wallbangFrame, killerSlot int, now time.Duration) time.Duration {
+	if killerSlot < 0 || killerSlot >= 64 {
+		return 0
+	}
+	victimAt := make(map[int]wallbangFrame, len(victim))
+	for _, f := range victim {
+		victimAt[f.tick] = f
+	}
+
+	var held time.Duration
+	for i, k := range killer {
+		if now-k.time > wallbangLookback || k.time > now {
+			continue
+		}
+		v, ok := victimAt[k.tick]
+		if !ok || v.spottedBy&(1<<uint(killerSlot)) != 0 {
+			continue
+		}
+		if !aimOnTarget(k, v) {
+			continue
+		}
+		end := now
+		if i+1 < len(killer) && killer[i+1].time < now {
+			end = killer[i+1].time
+		}
+		held += end - k.time
+	}
+	return held
+}
+
+// aimOnTarget reports whether k's crosshair is on v's head or chest, using
+// the precise-snap tolerance.
+func aimOnTarget(k, v wallbangFrame) bool {
+	for _, target := range []r3.Vector{v.head, v.chest} {
+		if aimResidualDeg(k.eye, k.yaw, k.pitch, target) <= preciseSnapTolerance(target.Sub(k.eye).Norm()) {
+			return true
+		}
+	}
+	return false
+}
+
+// CollectFinalStats publishes wallbang_kills and tracked_wallbangs for every
+// player with at least one wallbang kill.
+func (wc *WallbangKillCollector) CollectFinalStats(demoStats *DemoStats) {
+	for sid, n := range wc.wallbangs {
+		ps, ok := demoStats.Players[sid]
+		if !ok {
+			continue
+		}
+		ps.AddMetric(CatWallbang, KeyWallbangKills, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(n),
+			Description: "Kills through at least one wall or object",
+		})
+		ps.AddMetric(CatWallbang, KeyTrackedWallbangs, Metric{
+			Type:        MetricInteger,
+			IntValue:    int64(wc.tracked[sid]),
+			Description: "Wallbang kills after holding the crosshair on the hidden victim",
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+)
+
+// wallbangTrack builds matching killer and victim histories at 64 tick,
+// the killer looking along +X at a victim 1000u away. aimed and hidden say,
+// per frame, whether the killer's yaw is on the victim and whether the
+// victim is hidden from the killer (slot 0).
+func wallbangTrack(aimed, hidden []bool) (killer, victim []wallbangFrame, now time.Duration) {
+	step := time.Second / 64
+	for i := range aimed {
+		at := time.Duration(i) * step
+		yaw := 0.0
+		if !aimed[i] {
+			yaw = 30
+		}
+		killer = append(killer, wallbangFrame{tick: i, time: at, eye: r3.Vector{Z: 64}, yaw: yaw})
+		v := wallbangFrame{tick: i, time: at, head: r3.Vector{X: 1000, Z: 64}, chest: r3.Vector{X: 1000, Z: 40}}
+		if !hidden[i] {
+			v.spottedBy = 1
+		}
+		victim = append(victim, v)
+	}
+	return killer, victim, time.Duration(len(aimed)) * step
+}
+
+func repeatBool(v bool, n int) []bool {
+	out := make([]bool, n)
+	for i := range out {
+		out[i] = v
+	}
+	return out
+}
+
+func TestTrackedThroughWall(t *testing.T) {
+	tests := []struct {
+		name          string
+		aimed, hidden []bool
+		wantTracked   bool
+	}{
+		{"held on hidden victim", repeatBool(true, 20), repeatBool(true, 20), true},
+		{"swept across once", append(repeatBool(false, 18), true, false), repeatBool(true, 20), false},
+		{"aimed but visible", repeatBool(true, 20), repeatBool(false, 20), false},
+		{"never on target", repeatBool(false, 20), repeatBool(true, 20), false},
+	}
+	for _, tt := range tests {
+		killer, victim, now := wallbangTrack(tt.aimed, tt.hidden)
+		held := trackedThroughWall(killer, victim, 0, now)
+		if got := held >= wallbangTrackMin; got != tt.wantTracked {
+			t.Errorf("%s: held %v, tracked = %v, want %v", tt.name, held, got, tt.wantTracked)
+		}
+	}
+}
+
+func TestTrackedThroughWallLookback(t *testing.T) {
+	// A full second of tracking, but only the last 500ms counts
+	killer, victim, now := wallbangTrack(repeatBool(true, 64), repeatBool(true, 64))
+	held := trackedThroughWall(killer, victim, 0, now)
+	if held > wallbangLookback {
+		t.Errorf("held = %v, want at most %v", held, wallbangLookback)
+	}
+}
+
+func TestWallbangRingOrder(t *testing.T) {
+	wc := NewWallbangKillCollector()
+	for i := 0; i < wallbangHistory+3; i++ {
+		wc.push(1, wallbangFrame{tick: i})
+	}
+	frames := wc.frames(1)
+	if len(frames) != wallbangHistory {
+		t.Fatalf("len = %d, want %d", len(frames), wallbangHistory)
+	}
+	if frames[0].tick != 3 || frames[len(frames)-1].tick != wallbangHistory+2 {
+		t.Errorf("ticks %d..%d, want 3..%d", frames[0].tick, frames[len(frames)-1].tick, wallbangHistory+2)
+	}
+}
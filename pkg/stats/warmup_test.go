@@ -0,0 +1,111 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+	dp "github.com/markus-wa/godispatch"
+)
+
+// warmupStubParser is a parser that reports warmup for its first
+// warmupFrames frames and lets the test dispatch events by hand. Methods
+// the collectors under test don't call are left to the nil embedded Parser.
+type warmupStubParser struct {
+	demoinfocs.Parser
+	frame        int
+	warmupFrames int
+	handlers     []any
+}
+
+type warmupStubState struct {
+	demoinfocs.GameState
+	p *warmupStubParser
+}
+
+func (s warmupStubState) IsWarmupPeriod() bool   { return s.p.frame < s.p.warmupFrames }
+func (s warmupStubState) TotalRoundsPlayed() int { return 0 }
+func (s warmupStubState) IngameTick() int        { return s.p.frame }
+
+func (p *warmupStubParser) GameState() demoinfocs.GameState { return warmupStubState{p: p} }
+func (p *warmupStubParser) TickRate() float64               { return 64 }
+func (p *warmupStubParser) CurrentFrame() int               { return p.frame }
+func (p *warmupStubParser) CurrentTime() time.Duration {
+	return time.Duration(p.frame) * time.Second / 64
+}
+
+func (p *warmupStubParser) RegisterEventHandler(h any) dp.HandlerIdentifier {
+	p.handlers = append(p.handlers, h)
+	return nil
+}
+
+// dispatch calls every registered handler that takes e's type.
+func (p *warmupStubParser) dispatch(e any) {
+	for _, h := range p.handlers {
+		fn := reflect.ValueOf(h)
+		if fn.Type().In(0) == reflect.TypeOf(e) {
+			fn.Call([]reflect.Value{reflect.ValueOf(e)})
+		}
+	}
+}
+
+// advance moves to the next frame, announcing the end of warmup on the
+// frame it ends.
+func (p *warmupStubParser) advance() {
+	p.frame++
+	if p.frame == p.warmupFrames {
+		p.dispatch(events.IsWarmupPeriodChanged{OldIsWarmupPeriod: true, NewIsWarmupPeriod: false})
+	}
+}
+
+func TestEngagementTrackerIgnoresWarmup(t *testing.T) {
+	parser := &warmupStubParser{warmupFrames: 3}
+	tracker := NewEngagementTracker(DefaultEngagementConfig())
+	tracker.Setup(parser, NewDemoStats())
+
+	attacker := &common.Player{SteamID64: 1, Team: common.TeamTerrorists}
+	victim := &common.Player{SteamID64: 2, Team: common.TeamCounterTerrorists}
+	hurt := events.PlayerHurt{Attacker: attacker, Player: victim}
+
+	parser.dispatch(hurt)
+	if tracker.Engagement(1, 2) != nil {
+		t.Fatal("warmup damage opened an engagement")
+	}
+
+	// A sighting left open from warmup must not carry into the match.
+	parser.advance()
+	tracker.sight(1, 2, parser.frame, parser.CurrentTime())
+	parser.advance()
+	parser.advance()
+	if tracker.Engagement(1, 2) != nil {
+		t.Fatal("warmup engagement survived the end of warmup")
+	}
+
+	parser.dispatch(hurt)
+	if eng := tracker.Engagement(1, 2); eng == nil || !eng.Damaged {
+		t.Errorf("match damage not tracked: %+v", eng)
+	}
+}
+
+func TestRecoilDropsWarmupBursts(t *testing.T) {
+	parser := &warmupStubParser{warmupFrames: 2}
+	rc := NewRecoilControlCollector()
+	rc.Setup(parser, NewDemoStats())
+
+	state := &sprayState{inBurst: true, bulletIndex: 4, weapon: common.EqAK47}
+	rc.sprayStates[1] = state
+	shooter := &common.Player{SteamID64: 1, Team: common.TeamTerrorists}
+	parser.dispatch(events.WeaponFire{Shooter: shooter, Weapon: common.NewEquipment(common.EqAK47)})
+	if state.bulletIndex != 4 {
+		t.Errorf("warmup shot counted: bulletIndex = %d", state.bulletIndex)
+	}
+
+	parser.advance()
+	parser.advance()
+	if len(rc.sprayStates) != 0 {
+		t.Errorf("warmup burst survived the end of warmup: %+v", rc.sprayStates)
+	}
+}
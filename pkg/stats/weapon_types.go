@@ -0,0 +1,65 @@
+package stats
+
+import "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+
+// Item definition indexes of the knives outside the 500 range: the golden
+// knife, the default CT and T knives, and the ghost knife. Every knife skin
+// model (Bayonet, Karambit, Kukri, ...) has its own index from 500 up; the
+// range below 600 is reserved for them, so knives added after this was
+// written still fall in it.
+const (
+	knifeIndexGolden  = 41
+	knifeIndexDefault = 42
+	knifeIndexT       = 59
+	knifeIndexGhost   = 80
+
+	knifeIndexSkinMin = 500
+	knifeIndexSkinMax = 599
+)
+
+// weaponType resolves weapon's type. demoinfocs derives Type from the
+// weapon entity's item definition index, but an index its table doesn't
+// know — a knife or weapon variant newer than the library — comes back as
+// EqUnknown. For those the index is classified here, so a new knife isn't
+// counted as a gun.
+func weaponType(weapon *common.Equipment) common.EquipmentType {
+	if weapon == nil {
+		return common.EqUnknown
+	}
+	if weapon.Type != common.EqUnknown {
+		return weapon.Type
+	}
+	idx, ok := itemDefIndex(weapon)
+	if !ok {
+		return common.EqUnknown
+	}
+	if t, known := common.EquipmentIndexMapping[idx]; known {
+		return t
+	}
+	if isKnifeItemIndex(idx) {
+		return common.EqKnife
+	}
+	return common.EqUnknown
+}
+
+// itemDefIndex returns the item definition index of weapon's entity.
+func itemDefIndex(weapon *common.Equipment) (uint64, bool) {
+	if weapon.Entity == nil {
+		return 0, false
+	}
+	v, ok := weapon.Entity.PropertyValue("m_iItemDefinitionIndex")
+	if !ok {
+		return 0, false
+	}
+	idx, ok := v.Any.(uint64)
+	return idx, ok
+}
+
+// isKnifeItemIndex reports whether idx is a knife's item definition index.
+func isKnifeItemIndex(idx uint64) bool {
+	switch idx {
+	case knifeIndexGolden, knifeIndexDefault, knifeIndexT, knifeIndexGhost:
+		return true
+	}
+	return idx >= knifeIndexSkinMin && idx <= knifeIndexSkinMax
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	st "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/sendtables"
+)
+
+// itemEntity is a weapon entity exposing only its item definition index.
+type itemEntity struct {
+	st.Entity
+	index uint64
+}
+
+func (e itemEntity) PropertyValue(name string) (st.PropertyValue, bool) {
+	if name != "m_iItemDefinitionIndex" {
+		return st.PropertyValue{}, false
+	}
+	return st.PropertyValue{Any: e.index}, true
+}
+
+func TestIsKnifeAllKnifeTypes(t *testing.T) {
+	knives := map[uint64]string{
+		41: "knifegg", 42: "knife", 59: "knife_t", 80: "knife_ghost",
+		500: "bayonet", 503: "knife_css", 505: "knife_flip", 506: "knife_gut",
+		507: "knife_karambit", 508: "knife_m9_bayonet", 509: "knife_tactical",
+		512: "knife_falchion", 514: "knife_survival_bowie", 515: "knife_butterfly",
+		516: "knife_push", 517: "knife_cord", 518: "knife_canis", 519: "knife_ursus",
+		520: "knife_gypsy_jackknife", 521: "knife_outdoor", 522: "knife_stiletto",
+		523: "knife_widowmaker", 525: "knife_skeleton", 526: "knife_kukri",
+	}
+	for idx, name := range knives {
+		// As demoinfocs builds it: the type from its index table
+		mapped := &common.Equipment{Type: common.EquipmentIndexMapping[idx]}
+		if !isKnife(mapped) {
+			t.Errorf("%s (%d) mapped to %v, not a knife", name, idx, mapped.Type)
+		}
+		// A library without the index in its table leaves the type unknown
+		unmapped := &common.Equipment{Type: common.EqUnknown, Entity: itemEntity{index: idx}}
+		if !isKnife(unmapped) {
+			t.Errorf("%s (%d) with an unknown type not recognized by its index", name, idx)
+		}
+		if c := weaponClass(unmapped); c != "equipment" {
+			t.Errorf("%s class = %q, want equipment", name, c)
+		}
+		// Kill events name the weapon instead
+		if byName := (&common.Equipment{Type: common.MapEquipment("weapon_" + name)}); !isKnife(byName) {
+			t.Errorf("weapon_%s not a knife by name", name)
+		}
+	}
+
+	// A knife skin newer than this list still falls in the reserved range
+	if !isKnife(&common.Equipment{Entity: itemEntity{index: 530}}) {
+		t.Error("unlisted knife index 530 not a knife")
+	}
+
+	for _, idx := range []uint64{7, 9, 31, 49, 61, 5027} { // AK, AWP, Zeus, C4, USP, gloves
+		w := &common.Equipment{Type: common.EquipmentIndexMapping[idx], Entity: itemEntity{index: idx}}
+		if isKnife(w) {
+			t.Errorf("index %d (%v) counted as a knife", idx, w.Type)
+		}
+	}
+	if isKnife(nil) || isKnife(&common.Equipment{}) {
+		t.Error("nil or entity-less unknown weapon counted as a knife")
+	}
+}
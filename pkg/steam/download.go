@@ -0,0 +1,345 @@
+package steam
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrIncompleteDownload is returned when a download ends early or doesn't
+// decompress to a CS2 demo. The partial file is deleted or kept for
+// resuming, so retrying the download is the fix.
+var ErrIncompleteDownload = errors.New("steam: download incomplete")
+
+// demoMagic starts every CS2 demo file.
+var demoMagic = []byte("PBDEMS2\x00")
+
+// minDemoSize is the smallest plausible demo: the header plus the signon
+// data every demo records before the first tick comes to well over this.
+const minDemoSize = 16 << 10
+
+// DownloadOptions controls DownloadMany.
+type DownloadOptions struct {
+	// Resolve maps a share code to its demo URL (usually a
+	// replayN.valve.net/730/…dem.bz2 link). The URL is only known to the
+	// CS2 Game Coordinator, so it has to come from a logged-in client or a
+	// service that talks to one. Required.
+	Resolve func(ctx context.Context, shareCode string) (string, error)
+
+	// Concurrency is the number of downloads in flight. Default 2.
+	Concurrency int
+
+	// Delay is the minimum gap between the start of any two requests,
+	// retries included. Default one second.
+	Delay time.Duration
+
+	// MaxRetries is how many times a failed request is retried. Backoff
+	// doubles from one second unless the server sends Retry-After.
+	// Default 3.
+	MaxRetries int
+
+	// HTTPClient defaults to a client with a 5 minute timeout.
+	HTTPClient *http.Client
+
+	// Logger receives progress (Infof) and retries (Debugf). Nil logs
+	// nothing.
+	Logger Logger
+}
+
+// Logger is the logging interface the downloader uses; stats.NopLogger and
+// stats.WriterLogger implement it.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+}
+
+// nopLogger is the Logger used when DownloadOptions.Logger is nil.
+type nopLogger struct{}
+
+func (nopLogger) Debugf(string, ...any) {}
+func (nopLogger) Infof(string, ...any)  {}
+
+// downloader carries the options and pacing shared by one DownloadMany call.
+type downloader struct {
+	opts  DownloadOptions
+	sleep func(time.Duration)
+
+	mu   sync.Mutex
+	next time.Time // earliest start of the next request
+}
+
+func newDownloader(opts DownloadOptions) *downloader {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 2
+	}
+	if opts.Delay <= 0 {
+		opts.Delay = time.Second
+	}
+	if opts.MaxRetries <= 0 {
+		opts.MaxRetries = 3
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 5 * time.Minute}
+	}
+	if opts.Logger == nil {
+		opts.Logger = nopLogger{}
+	}
+	return &downloader{opts: opts, sleep: time.Sleep}
+}
+
+// DownloadMany downloads the demo of every share code into outputDir as
+// <code>.dem and returns the path of each demo that is on disk afterwards,
+// keyed by share code. At most opts.Concurrency downloads run at once and
+// requests start at least opts.Delay apart, so a season's worth of demos
+// doesn't hammer Valve's replay servers.
+//
+// Demos already in outputDir are skipped, and an interrupted download
+// resumes from its .part file where the server supports range requests, so
+// rerunning after a failure only fetches what's missing. A code that fails
+// doesn't stop the others: the returned error joins every per-code error
+// (each prefixed with its code) and is nil only if all codes succeeded.
+// Cancelling ctx stops codes that haven't started yet.
+func DownloadMany(ctx context.Context, shareCodes []string, outputDir string, opts DownloadOptions) (map[string]string, error) {
+	if opts.Resolve == nil {
+		return nil, errors.New("steam: DownloadOptions.Resolve is required")
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return nil, err
+	}
+	return newDownloader(opts).downloadMany(ctx, shareCodes, outputDir)
+}
+
+func (d *downloader) downloadMany(ctx context.Context, shareCodes []string, outputDir string) (map[string]string, error) {
+	paths := make([]string, len(shareCodes))
+	errs := make([]error, len(shareCodes))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < d.opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				path, err := d.download(ctx, shareCodes[i], outputDir)
+				if err != nil {
+					errs[i] = fmt.Errorf("%s: %w", shareCodes[i], err)
+					continue
+				}
+				paths[i] = path
+			}
+		}()
+	}
+	for i := range shareCodes {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	byCode := make(map[string]string, len(shareCodes))
+	for i, code := range shareCodes {
+		if errs[i] == nil {
+			byCode[code] = paths[i]
+		}
+	}
+	return byCode, errors.Join(errs...)
+}
+
+// download fetches one share code's demo unless it is already on disk.
+func (d *downloader) download(ctx context.Context, shareCode, outputDir string) (string, error) {
+	path := filepath.Join(outputDir, shareCode+".dem")
+	if fi, err := os.Stat(path); err == nil && fi.Size() > 0 {
+		d.opts.Logger.Infof("%s: already downloaded", shareCode)
+		return path, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	demoURL, err := d.opts.Resolve(ctx, shareCode)
+	if err != nil {
+		return "", fmt.Errorf("resolving demo URL: %w", err)
+	}
+	d.opts.Logger.Infof("%s: downloading %s", shareCode, demoURL)
+	if err := d.downloadAndDecompress(ctx, demoURL, path); err != nil {
+		return "", err
+	}
+	d.opts.Logger.Infof("%s: saved %s", shareCode, path)
+	return path, nil
+}
+
+// downloadAndDecompress downloads demoURL to path. The raw response is kept
+// in path+".part" until complete, so a rerun resumes it; a .bz2 URL is then
+// decompressed and the result checked with verifyDemo. path only appears
+// once the demo is fully written and verified.
+func (d *downloader) downloadAndDecompress(ctx context.Context, demoURL, path string) error {
+	part := path + ".part"
+	if err := d.fetch(ctx, demoURL, part); err != nil {
+		return err
+	}
+
+	src, err := os.Open(part)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	var r io.Reader = src
+	if strings.HasSuffix(strings.ToLower(demoURL), ".bz2") {
+		r = bzip2.NewReader(src)
+	}
+
+	tmp := path + ".tmp"
+	dst, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, r); err != nil {
+		dst.Close()
+		os.Remove(tmp)
+		// A corrupt archive can't be resumed; start over next time.
+		os.Remove(part)
+		return fmt.Errorf("%w: decompressing demo: %v", ErrIncompleteDownload, err)
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := verifyDemo(tmp); err != nil {
+		os.Remove(tmp)
+		os.Remove(part)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	src.Close()
+	return os.Remove(part)
+}
+
+// verifyDemo checks that path starts with the CS2 demo magic and is at
+// least minDemoSize bytes, so a truncated or error-page download fails here
+// instead of deep inside the parser.
+func verifyDemo(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	magic := make([]byte, len(demoMagic))
+	if _, err := io.ReadFull(f, magic); err != nil || !bytes.Equal(magic, demoMagic) {
+		return fmt.Errorf("%w: not a CS2 demo (bad header)", ErrIncompleteDownload)
+	}
+	if fi.Size() < minDemoSize {
+		return fmt.Errorf("%w: demo is only %d bytes", ErrIncompleteDownload, fi.Size())
+	}
+	return nil
+}
+
+// fetch appends demoURL's body to part, resuming from part's current size
+// and retrying failed or rate-limited requests.
+func (d *downloader) fetch(ctx context.Context, demoURL, part string) error {
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		if err := d.wait(ctx); err != nil {
+			return err
+		}
+		retry, wait, err := d.fetchOnce(ctx, demoURL, part)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= d.opts.MaxRetries {
+			return err
+		}
+		if wait <= 0 {
+			wait = backoff
+		}
+		d.opts.Logger.Debugf("retrying %s in %s: %v", demoURL, wait, err)
+		d.sleep(wait)
+		backoff *= 2
+	}
+}
+
+// fetchOnce makes one request. retry reports whether the failure is worth
+// another attempt, and wait is the server's Retry-After, if any.
+func (d *downloader) fetchOnce(ctx context.Context, demoURL, part string) (retry bool, wait time.Duration, err error) {
+	var offset int64
+	if fi, err := os.Stat(part); err == nil {
+		offset = fi.Size()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, demoURL, nil)
+	if err != nil {
+		return false, 0, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := d.opts.HTTPClient.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, 0, fmt.Errorf("download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		flags |= os.O_APPEND
+	case resp.StatusCode == http.StatusOK:
+		flags |= os.O_TRUNC // no range support: start over
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		return false, 0, nil // the part file is already complete
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, retryAfter(resp, 0), fmt.Errorf("download failed: %s", resp.Status)
+	default:
+		return false, 0, fmt.Errorf("download failed: %s", resp.Status)
+	}
+
+	f, err := os.OpenFile(part, flags, 0o644)
+	if err != nil {
+		return false, 0, err
+	}
+	n, err := io.Copy(f, resp.Body)
+	if err != nil {
+		f.Close()
+		// What arrived is kept; the retry resumes after it.
+		return ctx.Err() == nil, 0, fmt.Errorf("%w: %v", ErrIncompleteDownload, err)
+	}
+	if err := f.Close(); err != nil {
+		return false, 0, err
+	}
+	if resp.ContentLength >= 0 && n != resp.ContentLength {
+		return true, 0, fmt.Errorf("%w: got %d of %d bytes", ErrIncompleteDownload, n, resp.ContentLength)
+	}
+	return false, 0, nil
+}
+
+// wait blocks until the next request may start, keeping requests at least
+// Delay apart across all workers.
+func (d *downloader) wait(ctx context.Context) error {
+	d.mu.Lock()
+	now := time.Now()
+	start := d.next
+	if start.Before(now) {
+		start = now
+	}
+	d.next = start.Add(d.opts.Delay)
+	d.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if delay := time.Until(start); delay > 0 {
+		d.sleep(delay)
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,171 @@
+package steam
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// bz2Demo is a bzip2-compressed minimal demo: the CS2 magic followed by
+// zeros up to minDemoSize.
+const bz2Demo = "425a6839314159265359ba64c7ed0000854e00c0001002160248000008200020aa4d0d3210030c026620233595ebbca03e2ee48a70a12174c98fda"
+
+// recordingLogger keeps every message it receives.
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) Debugf(format string, args ...any) { l.add("debug: "+format, args) }
+func (l *recordingLogger) Infof(format string, args ...any)  { l.add(format, args) }
+
+func (l *recordingLogger) add(format string, args []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func isTestDemo(b []byte) bool {
+	return len(b) == len(demoMagic)+minDemoSize && bytes.HasPrefix(b, demoMagic)
+}
+
+func testDownloader(t *testing.T, h http.HandlerFunc) (*downloader, string) {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	d := newDownloader(DownloadOptions{
+		Resolve: func(_ context.Context, code string) (string, error) {
+			if code == "CSGO-bad" {
+				return "", errors.New("unknown match")
+			}
+			return srv.URL + "/" + code + ".dem.bz2", nil
+		},
+		Delay: time.Millisecond,
+	})
+	d.sleep = func(time.Duration) {}
+	return d, t.TempDir()
+}
+
+func TestDownloadMany(t *testing.T) {
+	payload, _ := hex.DecodeString(bz2Demo)
+	var mu sync.Mutex
+	requests := map[string]int{}
+	d, dir := testDownloader(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		n := requests[r.URL.Path]
+		mu.Unlock()
+		if strings.Contains(r.URL.Path, "CSGO-flaky") && n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(payload)
+	})
+	existing := filepath.Join(dir, "CSGO-have.dem")
+	os.WriteFile(existing, []byte("old"), 0o644)
+	logger := &recordingLogger{}
+	d.opts.Logger = logger
+
+	got, err := d.downloadMany(context.Background(), []string{"CSGO-a", "CSGO-have", "CSGO-flaky", "CSGO-bad"}, dir)
+	if err == nil || !strings.Contains(err.Error(), "CSGO-bad") {
+		t.Errorf("err = %v, want the CSGO-bad failure", err)
+	}
+	if len(got) != 3 || got["CSGO-have"] != existing {
+		t.Fatalf("got %v", got)
+	}
+	for _, code := range []string{"CSGO-a", "CSGO-flaky"} {
+		if b, _ := os.ReadFile(got[code]); !isTestDemo(b) {
+			t.Errorf("%s: wrong content (%d bytes)", code, len(b))
+		}
+		if _, err := os.Stat(got[code] + ".part"); !os.IsNotExist(err) {
+			t.Errorf("%s: .part file left behind", code)
+		}
+	}
+	if requests["/CSGO-have.dem.bz2"] != 0 {
+		t.Error("existing demo was downloaded again")
+	}
+	if requests["/CSGO-flaky.dem.bz2"] != 2 {
+		t.Errorf("flaky demo requested %d times, want 2", requests["/CSGO-flaky.dem.bz2"])
+	}
+	log := strings.Join(logger.lines, "\n")
+	for _, want := range []string{"CSGO-have: already downloaded", "CSGO-a: saved", "debug: retrying"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log lacks %q:\n%s", want, log)
+		}
+	}
+}
+
+func TestDownloadResumesPartFile(t *testing.T) {
+	payload, _ := hex.DecodeString(bz2Demo)
+	d, dir := testDownloader(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "bytes=10-" {
+			t.Errorf("Range = %q, want bytes=10-", r.Header.Get("Range"))
+		}
+		http.ServeContent(w, r, "demo.bz2", time.Time{}, bytes.NewReader(payload))
+	})
+	path := filepath.Join(dir, "CSGO-a.dem")
+	os.WriteFile(path+".part", payload[:10], 0o644)
+
+	demoURL, _ := d.opts.Resolve(context.Background(), "CSGO-a")
+	if err := d.downloadAndDecompress(context.Background(), demoURL, path); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := os.ReadFile(path); !isTestDemo(b) {
+		t.Errorf("wrong content (%d bytes)", len(b))
+	}
+}
+
+func TestDownloadRejectsNonDemo(t *testing.T) {
+	tiny := append(append([]byte{}, demoMagic...), "short"...)
+	for name, body := range map[string][]byte{
+		"error page": []byte("<html>Service Unavailable</html>"),
+		"too small":  tiny,
+	} {
+		d, dir := testDownloader(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.Write(body)
+		})
+		path := filepath.Join(dir, "CSGO-a.dem")
+		// Served uncompressed: resolve to a URL without .bz2.
+		err := d.downloadAndDecompress(context.Background(), strings.TrimSuffix(testURL(t, d), ".bz2"), path)
+		if !errors.Is(err, ErrIncompleteDownload) {
+			t.Errorf("%s: err = %v, want ErrIncompleteDownload", name, err)
+		}
+		for _, p := range []string{path, path + ".part", path + ".tmp"} {
+			if _, err := os.Stat(p); !os.IsNotExist(err) {
+				t.Errorf("%s: %s left behind", name, filepath.Base(p))
+			}
+		}
+	}
+}
+
+func TestFetchDetectsShortBody(t *testing.T) {
+	d, dir := testDownloader(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		w.Write([]byte("only part"))
+	})
+	d.opts.MaxRetries = 1
+	err := d.fetch(context.Background(), testURL(t, d), filepath.Join(dir, "x.part"))
+	if !errors.Is(err, ErrIncompleteDownload) {
+		t.Errorf("err = %v, want ErrIncompleteDownload", err)
+	}
+}
+
+// testURL resolves CSGO-a through the test server.
+func testURL(t *testing.T, d *downloader) string {
+	t.Helper()
+	u, err := d.opts.Resolve(context.Background(), "CSGO-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
@@ -0,0 +1,133 @@
+// Package steam talks to the Steam Web API to turn a player's match history
+// into CS2 match share codes.
+package steam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// DefaultAPIBase is the Steam Web API root.
+const DefaultAPIBase = "https://api.steampowered.com"
+
+// APIKeyEnv is the environment variable NextMatchSharingCode reads the Steam
+// Web API key from.
+const APIKeyEnv = "STEAM_API_KEY"
+
+// ErrRateLimited is returned when Steam keeps answering 429 / 503 after all
+// retries.
+var ErrRateLimited = errors.New("steam: rate limited")
+
+// Client calls the match-sharing endpoint. The zero value is not usable;
+// create one with NewClient.
+type Client struct {
+	APIKey     string
+	HTTPClient *http.Client
+
+	// MaxRetries is how many times a rate-limited request is retried.
+	// Backoff doubles from one second unless Steam sends Retry-After.
+	MaxRetries int
+
+	baseURL string
+	sleep   func(time.Duration)
+}
+
+// NewClient creates a Client for apiKey with a 10 s request timeout and 3
+// retries.
+func NewClient(apiKey string) *Client {
+	return &Client{
+		APIKey:     apiKey,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		MaxRetries: 3,
+		baseURL:    DefaultAPIBase,
+		sleep:      time.Sleep,
+	}
+}
+
+// NextMatchSharingCode returns the share code of the match played after
+// knownCode by steamID, using the API key from STEAM_API_KEY. See
+// Client.NextMatchSharingCode.
+func NextMatchSharingCode(steamID, authCode, knownCode string) (string, error) {
+	key := os.Getenv(APIKeyEnv)
+	if key == "" {
+		return "", fmt.Errorf("steam: %s is not set", APIKeyEnv)
+	}
+	return NewClient(key).NextMatchSharingCode(steamID, authCode, knownCode)
+}
+
+// NextMatchSharingCode calls ICSGOPlayers_730/GetNextMatchSharingCode.
+// authCode is the player's game authentication code (Steam → CS2 → "Manage
+// match history"), knownCode any share code of theirs; the result is the
+// code of their next match. Walk the history by feeding each result back as
+// knownCode until it returns "" — Steam's "n/a", meaning there is no newer
+// match yet. That is not an error.
+func (c *Client) NextMatchSharingCode(steamID, authCode, knownCode string) (string, error) {
+	q := url.Values{}
+	q.Set("key", c.APIKey)
+	q.Set("steamid", steamID)
+	q.Set("steamidkey", authCode)
+	q.Set("knowncode", knownCode)
+	endpoint := c.baseURL + "/ICSGOPlayers_730/GetNextMatchSharingCode/v1?" + q.Encode()
+
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		resp, err := c.HTTPClient.Get(endpoint)
+		if err != nil {
+			// Don't wrap *url.Error: its message would include the API key.
+			return "", fmt.Errorf("steam: request failed: %v", errors.Unwrap(err))
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var body struct {
+				Result struct {
+					NextCode string `json:"nextcode"`
+				} `json:"result"`
+			}
+			err := json.NewDecoder(resp.Body).Decode(&body)
+			resp.Body.Close()
+			if err != nil {
+				return "", fmt.Errorf("steam: decoding response: %w", err)
+			}
+			if body.Result.NextCode == "n/a" {
+				return "", nil
+			}
+			return body.Result.NextCode, nil
+		case http.StatusAccepted:
+			// No match after knownCode has been recorded yet.
+			resp.Body.Close()
+			return "", nil
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			wait := retryAfter(resp, backoff)
+			resp.Body.Close()
+			if attempt >= c.MaxRetries {
+				return "", ErrRateLimited
+			}
+			c.sleep(wait)
+			backoff *= 2
+		case http.StatusForbidden:
+			resp.Body.Close()
+			return "", errors.New("steam: API key or authentication code rejected")
+		case http.StatusPreconditionFailed:
+			resp.Body.Close()
+			return "", fmt.Errorf("steam: share code %s does not belong to %s", knownCode, steamID)
+		default:
+			resp.Body.Close()
+			return "", fmt.Errorf("steam: unexpected status %s", resp.Status)
+		}
+	}
+}
+
+// retryAfter reads a Retry-After header in seconds, falling back to def.
+func retryAfter(resp *http.Response, def time.Duration) time.Duration {
+	if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	return def
+}
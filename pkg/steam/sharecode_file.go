@@ -0,0 +1,93 @@
+package steam
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// shareCodeAlphabet is the base-57 alphabet of match share codes: letters
+// and digits without I, g, l, 0 and 1.
+const shareCodeAlphabet = "ABCDEFGHJKLMNOPQRSTUVWXYZabcdefhijkmnopqrstuvwxyz23456789"
+
+// shareCodeBits is the size of the match ID, outcome ID and token a share
+// code encodes.
+const shareCodeBits = 144
+
+// ErrInvalidShareCode is wrapped by the errors of ValidateShareCode.
+var ErrInvalidShareCode = errors.New("steam: invalid share code")
+
+// ValidateShareCode checks that code is a well-formed match share code,
+// CSGO-xxxxx-xxxxx-xxxxx-xxxxx-xxxxx in the share-code alphabet, that
+// decodes to a match. It doesn't ask Steam whether the match exists.
+func ValidateShareCode(code string) error {
+	body, ok := strings.CutPrefix(code, "CSGO-")
+	if !ok {
+		return fmt.Errorf("%w %q: want the CSGO- prefix", ErrInvalidShareCode, code)
+	}
+	groups := strings.Split(body, "-")
+	if len(groups) != 5 {
+		return fmt.Errorf("%w %q: want five groups of five characters", ErrInvalidShareCode, code)
+	}
+	chars := strings.Join(groups, "")
+	for _, g := range groups {
+		if len(g) != 5 {
+			return fmt.Errorf("%w %q: want five groups of five characters", ErrInvalidShareCode, code)
+		}
+	}
+
+	// Digits are least significant first.
+	v, base := new(big.Int), big.NewInt(int64(len(shareCodeAlphabet)))
+	for i := len(chars) - 1; i >= 0; i-- {
+		d := strings.IndexByte(shareCodeAlphabet, chars[i])
+		if d < 0 {
+			return fmt.Errorf("%w %q: %q is not in the share-code alphabet", ErrInvalidShareCode, code, chars[i])
+		}
+		v.Mul(v, base).Add(v, big.NewInt(int64(d)))
+	}
+	if v.BitLen() > shareCodeBits {
+		return fmt.Errorf("%w %q: doesn't decode to a match", ErrInvalidShareCode, code)
+	}
+	return nil
+}
+
+// ShareCodeLineError is an invalid line found by ReadShareCodes.
+type ShareCodeLineError struct {
+	Line int
+	Text string
+	Err  error
+}
+
+func (e *ShareCodeLineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ShareCodeLineError) Unwrap() error { return e.Err }
+
+// ReadShareCodes reads one share code per line from r, e.g. a match-history
+// dump, for DownloadMany. Blank lines and lines starting with # are
+// skipped, surrounding whitespace is trimmed, and repeated codes are kept
+// once. Lines that fail ValidateShareCode are left out and returned in
+// invalid instead of stopping the read; err is only set when r fails.
+func ReadShareCodes(r io.Reader) (codes []string, invalid []*ShareCodeLineError, err error) {
+	seen := make(map[string]bool)
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := ValidateShareCode(line); err != nil {
+			invalid = append(invalid, &ShareCodeLineError{Line: n, Text: line, Err: err})
+			continue
+		}
+		if !seen[line] {
+			seen[line] = true
+			codes = append(codes, line)
+		}
+	}
+	return codes, invalid, sc.Err()
+}
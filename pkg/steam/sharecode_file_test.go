@@ -0,0 +1,50 @@
+package steam
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateShareCode(t *testing.T) {
+	if err := ValidateShareCode("CSGO-GADqf-jjyJ8-cSP2r-smZRo-TO2xK"); err != nil {
+		t.Errorf("valid code rejected: %v", err)
+	}
+	for _, code := range []string{
+		"",
+		"GADqf-jjyJ8-cSP2r-smZRo-TO2xK",       // no prefix
+		"CSGO-GADqf-jjyJ8-cSP2r-smZRo",        // four groups
+		"CSGO-GADqf-jjyJ8-cSP2r-smZRo-TO2x",   // short group
+		"CSGO-GADqf-jjyJ8-cSP2r-smZRo-TO2x0",  // 0 isn't in the alphabet
+		"CSGO-99999-99999-99999-99999-99999",  // more than 144 bits
+		"CSGO-GADqf-jjyJ8-cSP2r-smZRo-TO2xK ", // trailing space
+	} {
+		if err := ValidateShareCode(code); !errors.Is(err, ErrInvalidShareCode) {
+			t.Errorf("ValidateShareCode(%q) = %v, want ErrInvalidShareCode", code, err)
+		}
+	}
+}
+
+func TestReadShareCodes(t *testing.T) {
+	input := `# match history
+CSGO-GADqf-jjyJ8-cSP2r-smZRo-TO2xK
+
+  CSGO-AAAAA-AAAAA-AAAAA-AAAAA-AAAAB  
+not a code
+CSGO-GADqf-jjyJ8-cSP2r-smZRo-TO2xK
+`
+	codes, invalid, err := ReadShareCodes(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"CSGO-GADqf-jjyJ8-cSP2r-smZRo-TO2xK", "CSGO-AAAAA-AAAAA-AAAAA-AAAAA-AAAAB"}
+	if strings.Join(codes, ",") != strings.Join(want, ",") {
+		t.Errorf("codes = %v, want %v", codes, want)
+	}
+	if len(invalid) != 1 || invalid[0].Line != 5 || invalid[0].Text != "not a code" {
+		t.Fatalf("invalid = %v, want line 5", invalid)
+	}
+	if !errors.Is(invalid[0], ErrInvalidShareCode) {
+		t.Errorf("line error doesn't wrap ErrInvalidShareCode: %v", invalid[0])
+	}
+}
@@ -0,0 +1,77 @@
+package steam
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testClient(t *testing.T, h http.HandlerFunc) (*Client, *[]time.Duration) {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	var slept []time.Duration
+	c := NewClient("key")
+	c.baseURL = srv.URL
+	c.sleep = func(d time.Duration) { slept = append(slept, d) }
+	return c, &slept
+}
+
+func TestNextMatchSharingCode(t *testing.T) {
+	c, _ := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("steamid") != "7656" || q.Get("steamidkey") != "AAAA-BBBBB-CCCC" || q.Get("knowncode") != "CSGO-1" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"result":{"nextcode":"CSGO-2"}}`))
+	})
+	code, err := c.NextMatchSharingCode("7656", "AAAA-BBBBB-CCCC", "CSGO-1")
+	if err != nil || code != "CSGO-2" {
+		t.Fatalf("got %q, %v", code, err)
+	}
+}
+
+func TestNextMatchSharingCodeNoMore(t *testing.T) {
+	for name, h := range map[string]http.HandlerFunc{
+		"n/a": func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`{"result":{"nextcode":"n/a"}}`))
+		},
+		"202": func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		},
+	} {
+		c, _ := testClient(t, h)
+		if code, err := c.NextMatchSharingCode("1", "a", "k"); code != "" || err != nil {
+			t.Errorf("%s: got %q, %v; want end of history", name, code, err)
+		}
+	}
+}
+
+func TestNextMatchSharingCodeRateLimit(t *testing.T) {
+	calls := 0
+	c, slept := testClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "5")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"result":{"nextcode":"CSGO-3"}}`))
+	})
+	code, err := c.NextMatchSharingCode("1", "a", "k")
+	if err != nil || code != "CSGO-3" {
+		t.Fatalf("got %q, %v", code, err)
+	}
+	if len(*slept) != 2 || (*slept)[0] != 5*time.Second {
+		t.Errorf("slept %v, want two 5s waits", *slept)
+	}
+
+	c, _ = testClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	if _, err := c.NextMatchSharingCode("1", "a", "k"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("err = %v, want ErrRateLimited", err)
+	}
+}